@@ -11,19 +11,21 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // timezone arguments must resolve in images without a zoneinfo database
 
 	middleware "mcp-digitalocean/internal"
 	"mcp-digitalocean/internal/wslogging"
+	"mcp-digitalocean/pkg/metrics"
 	"mcp-digitalocean/pkg/registry"
+	"mcp-digitalocean/pkg/registry/common"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/server"
-	"golang.org/x/oauth2"
 )
 
 const (
 	mcpName                 = "mcp-digitalocean"
-	mcpVersion              = "1.0.30"
+	mcpVersion              = common.Version
 	wsLoggingContextTimeout = 15 * time.Second
 )
 
@@ -36,31 +38,70 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// parseLogLevel converts a level name to a slog.Level, defaulting to info.
+func parseLogLevel(name string) slog.Level {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug
+	case "info":
+		return slog.LevelInfo
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// parseDefaultCategories parses comma-separated service=category pairs, e.g. "networking=dns,apps=apps".
+func parseDefaultCategories(value string) (map[string]string, error) {
+	defaults := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		svc, category, ok := strings.Cut(pair, "=")
+		svc, category = strings.TrimSpace(svc), strings.TrimSpace(category)
+		if !ok || svc == "" || category == "" {
+			return nil, fmt.Errorf("invalid default category %q, expected service=category", pair)
+		}
+		defaults[svc] = category
+	}
+	return defaults, nil
+}
+
 func main() {
 	logLevelFlag := flag.String("log-level", getEnv("LOG_LEVEL", "info"), "Log level: debug, info, warn, error")
-	serviceFlag := flag.String("services", getEnv("SERVICES", ""), "Comma-separated list of services to activate (e.g., apps,networking,droplets)")
+	serviceFlag := flag.String("services", getEnv("SERVICES", ""), "Comma-separated list of services to activate (e.g., apps,networking,droplets). Opt-in categories are enabled as service:category (e.g., apps:alerts)")
 	tokenFlag := flag.String("digitalocean-api-token", getEnv("DIGITALOCEAN_API_TOKEN", ""), "DigitalOcean API token")
+	tokenFileFlag := flag.String("digitalocean-token-file", getEnv("DIGITALOCEAN_TOKEN_FILE", ""), "File holding the DigitalOcean API token, used when neither the token flag nor the DIGITALOCEAN_ACCESS_TOKEN or DIGITALOCEAN_TOKEN environment variables are set")
 	endpointFlag := flag.String("digitalocean-api-endpoint", getEnv("DIGITALOCEAN_API_ENDPOINT", "https://api.digitalocean.com"), "DigitalOcean API endpoint")
 	transport := flag.String("transport", getEnv("TRANSPORT", "stdio"), "The transport protocol to use (http or stdio). Default is stdio.")
 	bindAddr := flag.String("bind-addr", getEnv("BIND_ADDR", "127.0.0.1:8080"), "Bind address to bind to. Only used for http transport.")
 	wsLoggingURL := flag.String("ws-logging-url", getEnv("WS_LOGGING_URL", ""), "WebSocket URL for WebSocket logging (optional)")
 	wsLoggingToken := flag.String("ws-logging-token", getEnv("WS_LOGGING_TOKEN", ""), "Authentication token for WebSocket logging (optional)")
 	enableToolErrorLogging := flag.Bool("enable-tool-error-logging", getEnv("ENABLE_TOOL_ERROR_LOGGING", "false") == "true", "Enable logging of tool errors")
+	toolTimeoutFlag := flag.String("tool-timeout", getEnv("TOOL_TIMEOUT", registry.DefaultToolTimeout.String()), "Default timeout for a single tool call (e.g. 30s, 2m)")
+	enableDryRun := flag.Bool("enable-dry-run", getEnv("ENABLE_DRY_RUN", "true") == "true", "Add a dry_run argument to mutating tools to preview their API requests")
+	strictConfirm := flag.Bool("strict-confirm", getEnv("STRICT_CONFIRM", "false") == "true", "Require destructive tools such as db-cluster-delete to be given the confirm_token of their target, returned by a dry run, instead of a plain confirm flag. Requires --enable-dry-run")
+	httpTimeoutFlag := flag.String("http-timeout", getEnv("HTTP_TIMEOUT", "0s"), "Timeout for a single HTTP request to the DigitalOcean API (e.g. 30s), 0 disables it")
+	proxyURLFlag := flag.String("proxy-url", getEnv("PROXY_URL", ""), "Proxy URL for DigitalOcean API traffic (e.g. http://proxy.internal:3128). Defaults to the HTTPS_PROXY environment variable")
+	userAgentFlag := flag.String("user-agent", getEnv("USER_AGENT", common.DefaultUserAgent), "User agent sent to the DigitalOcean API")
+	insecureSkipVerify := flag.Bool("insecure-skip-verify", getEnv("INSECURE_SKIP_VERIFY", "false") == "true", "Disable TLS certificate verification of the DigitalOcean API, e.g. behind a TLS-intercepting proxy")
+	idempotencyWindowFlag := flag.String("idempotency-window", getEnv("IDEMPOTENCY_WINDOW", registry.DefaultIdempotencyWindow.String()), "How long a successful create is replayed instead of repeated when retried (e.g. 10m), 0 disables it")
+	bestEffort := flag.Bool("best-effort", getEnv("BEST_EFFORT", "false") == "true", "Keep serving the services that registered when others fail to register")
+	toolCallLogLevel := flag.String("tool-call-log-level", getEnv("TOOL_CALL_LOG_LEVEL", "info"), "Log level for the audit log of tool calls: debug, info, warn, error or off")
+	defaultCategoriesFlag := flag.String("default-categories", getEnv("DEFAULT_CATEGORIES", ""), "Comma-separated service=category pairs restricting a service to one category by default (e.g. networking=dns). Explicit service:category selections are still loaded")
+	authContextsFlag := flag.String("auth-contexts", getEnv("DIGITALOCEAN_AUTH_CONTEXTS", ""), "Comma-separated name=token-file pairs of extra auth contexts, one per team, that account-switch-context switches to (e.g. staging=/run/secrets/staging-token). The main token is the \"default\" context. Only used for stdio transport")
+	healthAddr := flag.String("health-addr", getEnv("HEALTH_ADDR", ""), "Address to serve health checks on, at /healthz (e.g. 127.0.0.1:8081), for liveness and readiness probes. Disabled when empty")
+	shutdownGracePeriodFlag := flag.String("shutdown-grace-period", getEnv("SHUTDOWN_GRACE_PERIOD", registry.DefaultShutdownGracePeriod.String()), "How long in-flight tool calls are given to finish on SIGTERM or SIGINT before the server exits (e.g. 25s). New calls are turned away meanwhile")
+	regionSizeRefreshFlag := flag.String("region-size-refresh-interval", getEnv("REGION_SIZE_REFRESH_INTERVAL", common.DefaultRegionSizeRefreshInterval.String()), "How often the regions and sizes served by region-list and size-list are refetched in the background (e.g. 15m), 0 disables the cache. Only used for stdio transport without auth contexts")
+	metricsAddr := flag.String("metrics-addr", getEnv("METRICS_ADDR", ""), "Address to serve Prometheus metrics of tool calls on, at /metrics (e.g. 127.0.0.1:9090). Disabled when empty")
 	flag.Parse()
 
-	var level slog.Level
-	switch strings.ToLower(*logLevelFlag) {
-	case "debug":
-		level = slog.LevelDebug
-	case "info":
-		level = slog.LevelInfo
-	case "warn", "warning":
-		level = slog.LevelWarn
-	case "error":
-		level = slog.LevelError
-	default:
-		level = slog.LevelInfo
-	}
+	level := parseLogLevel(*logLevelFlag)
 
 	// setup signal context for graceful shutdown
 	// This context is cancelled when the user presses Ctrl+C or the process receives SIGTERM/SIGINT.
@@ -115,9 +156,39 @@ func main() {
 
 	// create logger after adding service attributes
 	logger := slog.New(wsLoggingHandler)
-	token := *tokenFlag
-	if token == "" && *transport == "stdio" {
-		logger.Error("DigitalOcean API token not provided. Use --digitalocean-api-token flag or set DIGITALOCEAN_API_TOKEN environment variable")
+	toolTimeout, err := time.ParseDuration(*toolTimeoutFlag)
+	if err != nil {
+		logger.Error("Invalid tool timeout: " + err.Error())
+		os.Exit(1)
+	}
+	idempotencyWindow, err := time.ParseDuration(*idempotencyWindowFlag)
+	if err != nil {
+		logger.Error("Invalid idempotency window: " + err.Error())
+		os.Exit(1)
+	}
+	shutdownGracePeriod, err := time.ParseDuration(*shutdownGracePeriodFlag)
+	if err != nil {
+		logger.Error("Invalid shutdown grace period: " + err.Error())
+		os.Exit(1)
+	}
+	regionSizeRefreshInterval, err := time.ParseDuration(*regionSizeRefreshFlag)
+	if err != nil {
+		logger.Error("Invalid region and size refresh interval: " + err.Error())
+		os.Exit(1)
+	}
+	httpTimeout, err := time.ParseDuration(*httpTimeoutFlag)
+	if err != nil {
+		logger.Error("Invalid HTTP timeout: " + err.Error())
+		os.Exit(1)
+	}
+	clientConfig := common.ClientConfig{
+		Timeout:            httpTimeout,
+		ProxyURL:           *proxyURLFlag,
+		UserAgent:          *userAgentFlag,
+		InsecureSkipVerify: *insecureSkipVerify,
+	}
+	if err := clientConfig.Validate(); err != nil {
+		logger.Error("Invalid DigitalOcean client configuration: " + err.Error())
 		os.Exit(1)
 	}
 
@@ -129,14 +200,21 @@ func main() {
 
 	svr := server.NewMCPServer(mcpName, mcpVersion, opts...)
 
-	// by default, we create a new client per request.
+	// by default, we create a client per auth token, which the registry memoizes.
 	getClientFn := func(ctx context.Context) (*godo.Client, error) {
-		return clientFromContext(ctx, *endpointFlag)
+		return clientFromContext(ctx, *endpointFlag, clientConfig)
 	}
 
 	// if using stdio, we can re-use the client.
+	var stdioToken string
 	if *transport == "stdio" {
-		godoClient, err := newGodoClientWithTokenAndEndpoint(context.Background(), token, *endpointFlag)
+		token, tokenSource, err := common.ResolveToken(common.TokenConfig{Token: *tokenFlag, TokenFile: *tokenFileFlag})
+		if err != nil {
+			logger.Error("DigitalOcean API token not provided: " + err.Error() + ". Use the --digitalocean-api-token or --digitalocean-token-file flag, or the DIGITALOCEAN_API_TOKEN environment variable")
+			os.Exit(1)
+		}
+		logger.Debug("using DigitalOcean API token", "source", tokenSource)
+		godoClient, err := common.NewGodoClient(context.Background(), token, *endpointFlag, clientConfig)
 		if err != nil {
 			logger.Error("Failed to create DigitalOcean client: " + err.Error())
 			os.Exit(1)
@@ -144,18 +222,105 @@ func main() {
 		getClientFn = func(ctx context.Context) (*godo.Client, error) {
 			return godoClient, nil
 		}
+		stdioToken = token
+	}
+
+	// Health checks use the main token in stdio mode, even with auth contexts.
+	healthClientFn := getClientFn
+
+	shutdown := registry.NewShutdown()
+	registryOpts := []registry.Option{registry.WithTimeout(toolTimeout), registry.WithShutdown(shutdown)}
+	if *authContextsFlag != "" {
+		if *transport != "stdio" {
+			// Over HTTP each request brings its own token, which selects the team.
+			logger.Warn("auth contexts are ignored by the http transport, send the token of the team in the Authorization header instead")
+		} else {
+			tokens, err := common.ReadAuthContexts(*authContextsFlag)
+			if err != nil {
+				logger.Error("Invalid auth contexts: " + err.Error())
+				os.Exit(1)
+			}
+			tokens[common.DefaultAuthContext] = stdioToken
+			authContexts, err := common.NewAuthContexts(tokens, common.DefaultAuthContext)
+			if err != nil {
+				logger.Error("Invalid auth contexts: " + err.Error())
+				os.Exit(1)
+			}
+			// Clients are built per context token, as for the http transport.
+			getClientFn = func(ctx context.Context) (*godo.Client, error) {
+				return clientFromContext(ctx, *endpointFlag, clientConfig)
+			}
+			registryOpts = append(registryOpts, registry.WithAuthContexts(authContexts))
+		}
+	}
+	if regionSizeRefreshInterval > 0 && *transport == "stdio" && *authContextsFlag == "" {
+		// The cached data is only served to calls made with the client that fetched it, which is
+		// the single client of the stdio transport. Other setups build a client per token.
+		regionSizes := common.NewRegionSizeCache(getClientFn, regionSizeRefreshInterval)
+		registryOpts = append(registryOpts, registry.WithRegionSizeCache(regionSizes))
+		go regionSizes.Run(ctx, logger)
+	}
+	if *enableDryRun {
+		registryOpts = append(registryOpts, registry.WithDryRun())
+	}
+	if *strictConfirm {
+		registryOpts = append(registryOpts, registry.WithStrictConfirm())
+	}
+	defaultCategories, err := parseDefaultCategories(*defaultCategoriesFlag)
+	if err != nil {
+		logger.Error("Invalid default categories: " + err.Error())
+		os.Exit(1)
+	}
+	if len(defaultCategories) > 0 {
+		registryOpts = append(registryOpts, registry.WithDefaultCategories(defaultCategories))
+	}
+	if idempotencyWindow > 0 {
+		registryOpts = append(registryOpts, registry.WithIdempotency(idempotencyWindow))
+	}
+	if !strings.EqualFold(*toolCallLogLevel, "off") {
+		registryOpts = append(registryOpts, registry.WithCallLogging(parseLogLevel(*toolCallLogLevel)))
+	}
+	if *bestEffort {
+		registryOpts = append(registryOpts, registry.WithBestEffort())
+	}
+	if *metricsAddr != "" {
+		toolMetrics := metrics.NewRegistry()
+		registryOpts = append(registryOpts, registry.WithMetrics(toolMetrics))
+		go func() {
+			if err := toolMetrics.Serve(ctx, logger, *metricsAddr); err != nil {
+				logger.Error("Failed to serve metrics: " + err.Error())
+			}
+		}()
+	}
+
+	if *healthAddr != "" {
+		go func() {
+			if err := common.ServeHealth(ctx, logger, *healthAddr, healthClientFn); err != nil {
+				logger.Error("Failed to serve health checks: " + err.Error())
+			}
+		}()
 	}
 
 	// register the tools.
-	err := registry.Register(
+	err = registry.RegisterWithOptions(
 		logger,
 		svr,
 		getClientFn,
-		services...,
+		services,
+		registryOpts...,
 	)
+	if err != nil {
+		var regErr *registry.RegistrationError
+		if !errors.As(err, &regErr) {
+			logger.Error("Failed to register tools: " + err.Error())
+			os.Exit(1)
+		}
+		// In best-effort mode the failures were already logged per service.
+		logger.Warn("serving without the services that failed to register", "failed", len(regErr.Services))
+	}
 
 	// start our server.
-	err = runServer(ctx, svr, logger, *bindAddr, transport)
+	err = runServer(ctx, svr, logger, *bindAddr, transport, shutdown, shutdownGracePeriod)
 	if err != nil {
 		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 			logger.Info("shutting down mcp server")
@@ -167,7 +332,7 @@ func main() {
 	}
 }
 
-func clientFromContext(ctx context.Context, endpoint string) (*godo.Client, error) {
+func clientFromContext(ctx context.Context, endpoint string, config common.ClientConfig) (*godo.Client, error) {
 	auth, ok := ctx.Value(middleware.AuthKey{}).(string)
 	if !ok || strings.TrimSpace(auth) == "" {
 		return nil, errors.New("no auth header found")
@@ -176,7 +341,7 @@ func clientFromContext(ctx context.Context, endpoint string) (*godo.Client, erro
 	if token == "" {
 		return nil, errors.New("no bearer token found")
 	}
-	client, err := newGodoClientWithTokenAndEndpoint(ctx, token, endpoint)
+	client, err := common.NewGodoClient(ctx, token, endpoint, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create godo client: %w", err)
 	}
@@ -184,32 +349,42 @@ func clientFromContext(ctx context.Context, endpoint string) (*godo.Client, erro
 	return client, nil
 }
 
-// newGodoClientWithTokenAndEndpoint initializes a new godo client with a custom user agent and endpoint.
-func newGodoClientWithTokenAndEndpoint(ctx context.Context, token string, endpoint string) (*godo.Client, error) {
-	cleanToken := strings.Trim(strings.TrimSpace(token), "'")
-	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cleanToken})
-	oauthClient := oauth2.NewClient(ctx, ts)
-
-	retry := godo.RetryConfig{
-		RetryMax:     4,
-		RetryWaitMin: godo.PtrTo(float64(1)),
-		RetryWaitMax: godo.PtrTo(float64(30)),
+// drainToolCalls turns new tool calls away and waits up to gracePeriod for those in flight to finish.
+func drainToolCalls(shutdown *registry.Shutdown, gracePeriod time.Duration, logger *slog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+	logger.Info("draining in-flight tool calls", "in_flight", shutdown.InFlight(), "grace_period", gracePeriod.String())
+	if err := shutdown.Drain(ctx); err != nil {
+		logger.Warn("shutting down with tool calls still running: " + err.Error())
+	}
+	if dropped := shutdown.Dropped(); dropped > 0 {
+		logger.Warn("dropped scheduled tasks that had not run yet, such as the deletion of rotated Spaces keys", "dropped", dropped)
 	}
-
-	return godo.New(oauthClient,
-		godo.WithRetryAndBackoffs(retry),
-		godo.SetBaseURL(endpoint),
-		godo.SetUserAgent(fmt.Sprintf("%s/%s", mcpName, mcpVersion)))
 }
 
-func runServer(ctx context.Context, s *server.MCPServer, logger *slog.Logger, bindAddr string, transport *string) error {
+func runServer(ctx context.Context, s *server.MCPServer, logger *slog.Logger, bindAddr string, transport *string, shutdown *registry.Shutdown, gracePeriod time.Duration) error {
 	logger.Info("starting MCP server", "name", mcpName, "version", mcpVersion, "transport", *transport)
 	switch *transport {
 	case "stdio":
+		// The stdio server keeps running on a signal until the in-flight calls are drained, so that
+		// their responses are still written out.
+		listenCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		errC := make(chan error, 1)
 		logger.Info("stdio server started")
-		err := server.ServeStdio(s)
-		if err != nil {
-			return fmt.Errorf("failed to start http server: %w", err)
+		go func() {
+			errC <- server.NewStdioServer(s).Listen(listenCtx, os.Stdin, os.Stdout)
+		}()
+
+		select {
+		case <-ctx.Done():
+			logger.Info("received shutdown signal")
+			drainToolCalls(shutdown, gracePeriod, logger)
+			return nil
+		case err := <-errC:
+			if err != nil {
+				return fmt.Errorf("stdio server error: %w", err)
+			}
 		}
 	// fallback to http
 	default:
@@ -225,12 +400,13 @@ func runServer(ctx context.Context, s *server.MCPServer, logger *slog.Logger, bi
 
 		select {
 		case <-ctx.Done():
+			logger.Info("received shutdown signal")
+			drainToolCalls(shutdown, gracePeriod, logger)
 
-			// allow 15 seconds for graceful shutdown
+			// allow 15 seconds for the connections to close
 			timeoutCtx, cancelFunc := context.WithTimeout(context.Background(), time.Second*15)
 			defer cancelFunc()
 
-			logger.Info("received shutdown signal")
 			err := httpServer.Shutdown(timeoutCtx)
 			if err != nil {
 				// this happens if the clients still hold connections after the timeout.
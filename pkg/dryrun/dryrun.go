@@ -0,0 +1,119 @@
+// Package dryrun records the API requests a tool would make without sending the mutating ones.
+//
+// A Recorder is attached to the context of a tool call. HTTP clients built with Recorder.Client pass
+// read-only requests (GET and HEAD) through so tools can still look up the resources they act on,
+// while any other request is captured and fails with ErrDryRun instead of reaching the API.
+package dryrun
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrDryRun is returned for every mutating request intercepted by a Recorder.
+var ErrDryRun = errors.New("dry run: request was not sent")
+
+// Request describes a mutating API request that was intercepted.
+type Request struct {
+	Method   string `json:"method"`
+	Endpoint string `json:"endpoint"`
+	Body     any    `json:"body,omitempty"`
+}
+
+// Recorder collects the mutating requests made during a dry-run tool call.
+type Recorder struct {
+	mu           sync.Mutex
+	requests     []Request
+	confirmToken string
+}
+
+type recorderKey struct{}
+
+// WithRecorder returns a context carrying a new Recorder, and the Recorder itself.
+func WithRecorder(ctx context.Context) (context.Context, *Recorder) {
+	rec := &Recorder{}
+	return context.WithValue(ctx, recorderKey{}, rec), rec
+}
+
+// FromContext returns the Recorder attached to ctx, or nil when the call is not a dry run.
+func FromContext(ctx context.Context) *Recorder {
+	rec, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return rec
+}
+
+// Requests returns the mutating requests intercepted so far.
+func (r *Recorder) Requests() []Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Request(nil), r.requests...)
+}
+
+// SetConfirmToken records the confirmation token the previewed call would have to carry to be executed.
+func (r *Recorder) SetConfirmToken(token string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.confirmToken = token
+}
+
+// ConfirmToken returns the confirmation token recorded with SetConfirmToken, if any.
+func (r *Recorder) ConfirmToken() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.confirmToken
+}
+
+// Client returns a copy of base whose transport intercepts mutating requests. A nil base uses http.DefaultClient.
+func (r *Recorder) Client(base *http.Client) *http.Client {
+	if base == nil {
+		base = http.DefaultClient
+	}
+	next := base.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	c := *base
+	c.Transport = &transport{rec: r, next: next}
+	return &c
+}
+
+func (r *Recorder) record(req Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+}
+
+// transport forwards read-only requests and records all others.
+type transport struct {
+	rec  *Recorder
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return t.next.RoundTrip(req)
+	}
+
+	recorded := Request{Method: req.Method, Endpoint: req.URL.RequestURI()}
+	if req.Body != nil {
+		data, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			var decoded any
+			if json.Unmarshal(data, &decoded) == nil {
+				recorded.Body = decoded
+			} else {
+				recorded.Body = string(data)
+			}
+		}
+	}
+	t.rec.record(recorded)
+	return nil, ErrDryRun
+}
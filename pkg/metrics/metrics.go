@@ -0,0 +1,171 @@
+// Package metrics records tool call counts and latencies and exposes them in the Prometheus text
+// exposition format.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Outcomes of a tool call, used as the outcome label.
+const (
+	// OutcomeSuccess is a call that returned a result.
+	OutcomeSuccess = "success"
+	// OutcomeResultError is a call that returned an error result, typically caused by invalid input.
+	OutcomeResultError = "result_error"
+	// OutcomeError is a call whose handler failed, e.g. because no client could be created.
+	OutcomeError = "error"
+)
+
+// shutdownTimeout bounds the graceful shutdown of the metrics endpoint.
+const shutdownTimeout = 5 * time.Second
+
+// DefaultBuckets are the upper bounds, in seconds, of the tool call duration histogram.
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+// callKey identifies a counter series.
+type callKey struct {
+	tool    string
+	outcome string
+}
+
+// histogram holds per-bucket observation counts: counts[i] counts the observations in
+// (buckets[i-1], buckets[i]] and the last count those above every bound.
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Registry holds the tool call metrics. It is safe for concurrent use.
+type Registry struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	calls     map[callKey]uint64
+	durations map[string]*histogram
+}
+
+// NewRegistry creates an empty Registry using DefaultBuckets.
+func NewRegistry() *Registry {
+	return &Registry{
+		buckets:   DefaultBuckets,
+		calls:     map[callKey]uint64{},
+		durations: map[string]*histogram{},
+	}
+}
+
+// ObserveCall records a call of tool with the given outcome that took d.
+func (r *Registry) ObserveCall(tool, outcome string, d time.Duration) {
+	seconds := d.Seconds()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls[callKey{tool: tool, outcome: outcome}]++
+
+	h, ok := r.durations[tool]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(r.buckets)+1)}
+		r.durations[tool] = h
+	}
+	i, _ := slices.BinarySearch(r.buckets, seconds)
+	h.counts[i]++
+	h.sum += seconds
+	h.count++
+}
+
+// Calls returns the number of calls recorded for tool with the given outcome.
+func (r *Registry) Calls(tool, outcome string) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls[callKey{tool: tool, outcome: outcome}]
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format, series sorted by label values.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	r.mu.Lock()
+	keys := make([]callKey, 0, len(r.calls))
+	for k := range r.calls {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b callKey) int {
+		if c := strings.Compare(a.tool, b.tool); c != 0 {
+			return c
+		}
+		return strings.Compare(a.outcome, b.outcome)
+	})
+	b.WriteString("# HELP mcp_tool_calls_total Number of tool calls by tool and outcome.\n")
+	b.WriteString("# TYPE mcp_tool_calls_total counter\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "mcp_tool_calls_total{tool=%s,outcome=%s} %d\n", quote(k.tool), quote(k.outcome), r.calls[k])
+	}
+
+	tools := make([]string, 0, len(r.durations))
+	for tool := range r.durations {
+		tools = append(tools, tool)
+	}
+	slices.Sort(tools)
+	b.WriteString("# HELP mcp_tool_call_duration_seconds Duration of tool calls in seconds.\n")
+	b.WriteString("# TYPE mcp_tool_call_duration_seconds histogram\n")
+	for _, tool := range tools {
+		h := r.durations[tool]
+		var cumulative uint64
+		for i, bound := range r.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "mcp_tool_call_duration_seconds_bucket{tool=%s,le=\"%s\"} %d\n", quote(tool), formatFloat(bound), cumulative)
+		}
+		fmt.Fprintf(&b, "mcp_tool_call_duration_seconds_bucket{tool=%s,le=\"+Inf\"} %d\n", quote(tool), h.count)
+		fmt.Fprintf(&b, "mcp_tool_call_duration_seconds_sum{tool=%s} %s\n", quote(tool), formatFloat(h.sum))
+		fmt.Fprintf(&b, "mcp_tool_call_duration_seconds_count{tool=%s} %d\n", quote(tool), h.count)
+	}
+	r.mu.Unlock()
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = r.WriteTo(w)
+}
+
+// Serve exposes the metrics on http://addr/metrics until ctx is cancelled.
+func (r *Registry) Serve(ctx context.Context, logger *slog.Logger, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r)
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("serving metrics", "addr", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// quote returns a quoted label value, escaped as the exposition format requires.
+func quote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
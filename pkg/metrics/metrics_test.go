@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_ObserveCall(t *testing.T) {
+	r := NewRegistry()
+	r.ObserveCall("droplet-list", OutcomeSuccess, 31250*time.Microsecond)
+	r.ObserveCall("droplet-list", OutcomeSuccess, 2*time.Second)
+	r.ObserveCall("droplet-list", OutcomeResultError, 62500*time.Microsecond)
+	r.ObserveCall("droplet-create", OutcomeError, time.Second)
+
+	require.Equal(t, uint64(2), r.Calls("droplet-list", OutcomeSuccess))
+	require.Equal(t, uint64(1), r.Calls("droplet-list", OutcomeResultError))
+	require.Equal(t, uint64(0), r.Calls("droplet-list", OutcomeError))
+	require.Equal(t, uint64(1), r.Calls("droplet-create", OutcomeError))
+
+	var b strings.Builder
+	_, err := r.WriteTo(&b)
+	require.NoError(t, err)
+	out := b.String()
+	for _, line := range []string{
+		"# TYPE mcp_tool_calls_total counter",
+		`mcp_tool_calls_total{tool="droplet-create",outcome="error"} 1`,
+		`mcp_tool_calls_total{tool="droplet-list",outcome="result_error"} 1`,
+		`mcp_tool_calls_total{tool="droplet-list",outcome="success"} 2`,
+		"# TYPE mcp_tool_call_duration_seconds histogram",
+		`mcp_tool_call_duration_seconds_bucket{tool="droplet-list",le="0.05"} 1`,
+		`mcp_tool_call_duration_seconds_bucket{tool="droplet-list",le="0.1"} 2`,
+		`mcp_tool_call_duration_seconds_bucket{tool="droplet-list",le="1"} 2`,
+		`mcp_tool_call_duration_seconds_bucket{tool="droplet-list",le="2.5"} 3`,
+		`mcp_tool_call_duration_seconds_bucket{tool="droplet-list",le="+Inf"} 3`,
+		`mcp_tool_call_duration_seconds_sum{tool="droplet-list"} 2.09375`,
+		`mcp_tool_call_duration_seconds_count{tool="droplet-list"} 3`,
+	} {
+		require.Contains(t, out, line+"\n")
+	}
+	require.Less(t, strings.Index(out, `tool="droplet-create",outcome`), strings.Index(out, `tool="droplet-list",outcome`))
+}
+
+func TestRegistry_quotesLabelValues(t *testing.T) {
+	r := NewRegistry()
+	r.ObserveCall(`a"b\c`, OutcomeSuccess, 0)
+
+	var b strings.Builder
+	_, err := r.WriteTo(&b)
+	require.NoError(t, err)
+	require.Contains(t, b.String(), `mcp_tool_calls_total{tool="a\"b\\c",outcome="success"} 1`)
+}
+
+func TestRegistry_ServeHTTP(t *testing.T) {
+	r := NewRegistry()
+	r.ObserveCall("region-list", OutcomeSuccess, time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Header().Get("Content-Type"), "text/plain; version=0.0.4")
+	require.Contains(t, rec.Body.String(), `mcp_tool_calls_total{tool="region-list",outcome="success"} 1`)
+}
+
+func TestRegistry_Serve(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	r := NewRegistry()
+	r.ObserveCall("region-list", OutcomeSuccess, time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.Serve(ctx, slog.New(slog.DiscardHandler), addr) }()
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		resp, err = http.Get("http://" + addr + "/metrics")
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Contains(t, string(body), `mcp_tool_calls_total{tool="region-list",outcome="success"} 1`)
+
+	cancel()
+	require.NoError(t, <-done)
+}
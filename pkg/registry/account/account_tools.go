@@ -3,6 +3,7 @@ package account
 import (
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
 
 	"github.com/digitalocean/godo"
@@ -20,7 +21,28 @@ func NewAccountTools(client func(ctx context.Context) (*godo.Client, error)) *Ac
 	}
 }
 
+// accountSummary holds the key account fields returned by account-get-information in summary mode.
+type accountSummary struct {
+	Email        string `json:"email"`
+	Status       string `json:"status"`
+	DropletLimit int    `json:"droplet_limit"`
+	VolumeLimit  int    `json:"volume_limit"`
+	Team         string `json:"team"`
+}
+
 func (a *AccountTools) getAccountInformation(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	pretty := args.OptionalBool("Pretty", false)
+	opts := args.RenderOptions()
+	summary := args.OptionalBool("summary", false)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if pretty {
+		// Pretty predates the format argument and is kept as its shorthand.
+		opts.Format = response.FormatNamePretty
+	}
+
 	client, err := a.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
@@ -31,12 +53,21 @@ func (a *AccountTools) getAccountInformation(ctx context.Context, req mcp.CallTo
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
 
-	jsonData, err := response.CompactJSON(account)
-	if err != nil {
-		return nil, fmt.Errorf("error marshalling account: %w", err)
+	var result any = account
+	if summary {
+		sum := accountSummary{
+			Email:        account.Email,
+			Status:       account.Status,
+			DropletLimit: account.DropletLimit,
+			VolumeLimit:  account.VolumeLimit,
+		}
+		if account.Team != nil {
+			sum.Team = account.Team.Name
+		}
+		result = sum
 	}
 
-	return mcp.NewToolResultText(jsonData), nil
+	return common.RenderResult(result, opts)
 }
 
 func (a *AccountTools) Tools() []server.ServerTool {
@@ -45,6 +76,9 @@ func (a *AccountTools) Tools() []server.ServerTool {
 			Handler: a.getAccountInformation,
 			Tool: mcp.NewTool("account-get-information",
 				mcp.WithDescription("Retrieves account information for the current user"),
+				mcp.WithBoolean("Pretty", mcp.DefaultBool(false), mcp.Description("Indent the JSON output for human readability, like format pretty")),
+				common.WithRenderOptions(),
+				mcp.WithBoolean("summary", mcp.DefaultBool(false), mcp.Description("Only return the email, status, droplet_limit, volume_limit and team name")),
 			),
 		},
 	}
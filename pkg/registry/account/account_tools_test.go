@@ -2,6 +2,7 @@ package account
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -77,3 +78,96 @@ func TestAccountTools_handleGetAccountInformation(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountTools_getAccountInformationSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccount := NewMockAccountService(ctrl)
+	mockAccount.EXPECT().Get(gomock.Any()).Return(&godo.Account{
+		UUID:          "abc-123",
+		Email:         "test@example.com",
+		EmailVerified: true,
+		Status:        "active",
+		DropletLimit:  25,
+		VolumeLimit:   100,
+		Team:          &godo.TeamInfo{UUID: "team-1", Name: "Platform"},
+	}, nil, nil)
+	tool := setupAccountToolsWithMock(mockAccount)
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"summary": true}}}
+	resp, err := tool.getAccountInformation(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+	require.Equal(t, map[string]any{
+		"email":         "test@example.com",
+		"status":        "active",
+		"droplet_limit": float64(25),
+		"volume_limit":  float64(100),
+		"team":          "Platform",
+	}, out)
+}
+
+func TestAccountTools_getAccountInformationRenderOptions(t *testing.T) {
+	account := &godo.Account{
+		UUID:   "abc-123",
+		Email:  "test@example.com",
+		Status: "active",
+		Team:   &godo.TeamInfo{UUID: "team-1", Name: "Platform"},
+	}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expect      string
+		expectError string
+	}{
+		{
+			name:   "Fields",
+			args:   map[string]any{"fields": []any{"email", "team.name"}},
+			expect: `{"email":"test@example.com","team":{"name":"Platform"}}`,
+		},
+		{
+			name:   "Fields with pretty format",
+			args:   map[string]any{"fields": []any{"status"}, "format": "pretty"},
+			expect: "{\n  \"status\": \"active\"\n}",
+		},
+		{
+			name:        "Unknown field",
+			args:        map[string]any{"fields": []any{"emial"}},
+			expectError: `unknown field "emial"`,
+		},
+		{
+			name:        "Over max_bytes",
+			args:        map[string]any{"max_bytes": float64(10)},
+			expectError: "response too large",
+		},
+		{
+			name:        "Invalid format",
+			args:        map[string]any{"format": "xml"},
+			expectError: "format",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockAccount := NewMockAccountService(ctrl)
+			mockAccount.EXPECT().Get(gomock.Any()).Return(account, nil, nil).AnyTimes()
+			tool := setupAccountToolsWithMock(mockAccount)
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.getAccountInformation(context.Background(), req)
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			require.Equal(t, tc.expect, text)
+		})
+	}
+}
@@ -3,7 +3,11 @@ package account
 import (
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,16 +17,40 @@ import (
 const (
 	defaultActionsPageSize = 30
 	defaultActionsPage     = 1
+
+	defaultActionWaitTimeout      = 300 * time.Second
+	maxActionWaitTimeout          = 1800 * time.Second
+	defaultActionWaitPollInterval = 5 * time.Second
 )
 
 // ActionTools provides tool-based handlers for DigitalOcean Actions.
 type ActionTools struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	client       func(ctx context.Context) (*godo.Client, error)
+	notifyClient *http.Client
 }
 
 // NewActionTools creates a new ActionTools instance.
 func NewActionTools(client func(ctx context.Context) (*godo.Client, error)) *ActionTools {
-	return &ActionTools{client: client}
+	return &ActionTools{client: client, notifyClient: http.DefaultClient}
+}
+
+// timezoneArg reads the timezone argument, returning an error result when it isn't a known zone.
+func timezoneArg(req mcp.CallToolRequest) (*time.Location, *mcp.CallToolResult) {
+	args := common.NewArgs(req)
+	loc := args.Timezone()
+	if err := args.Err(); err != nil {
+		return nil, mcp.NewToolResultError(err.Error())
+	}
+	return loc, nil
+}
+
+// actionJSON marshals actions with their timestamps expressed in loc.
+func actionJSON(v any, loc *time.Location) (string, error) {
+	converted, err := response.ConvertTimestamps(v, loc)
+	if err != nil {
+		return "", err
+	}
+	return response.CompactJSON(converted)
 }
 
 // getAction retrieves a specific action by its ID.
@@ -31,6 +59,10 @@ func (a *ActionTools) getAction(ctx context.Context, req mcp.CallToolRequest) (*
 	if !ok {
 		return mcp.NewToolResultError("Action ID is required"), nil
 	}
+	loc, errResult := timezoneArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := a.client(ctx)
 	if err != nil {
@@ -39,9 +71,9 @@ func (a *ActionTools) getAction(ctx context.Context, req mcp.CallToolRequest) (*
 
 	action, _, err := client.Actions.Get(ctx, int(id))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(err, "action", int(id)), nil
 	}
-	jsonData, err := response.CompactJSON(action)
+	jsonData, err := actionJSON(action, loc)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
@@ -58,6 +90,10 @@ func (a *ActionTools) listActions(ctx context.Context, req mcp.CallToolRequest)
 	if !ok {
 		perPage = defaultActionsPageSize
 	}
+	loc, errResult := timezoneArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := a.client(ctx)
 	if err != nil {
@@ -68,13 +104,92 @@ func (a *ActionTools) listActions(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
-	jsonData, err := response.CompactJSON(actions)
+	jsonData, err := actionJSON(actions, loc)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
 	return mcp.NewToolResultText(jsonData), nil
 }
 
+// waitForAction polls an action until it completes or errors, the timeout elapses, or the context is cancelled.
+// When notify_url is set, the final status is POSTed to it once the action completes or errors.
+func (a *ActionTools) waitForAction(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["ID"].(float64)
+	if !ok {
+		return mcp.NewToolResultError("Action ID is required"), nil
+	}
+	notifyURL, err := common.NotifyURL(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	loc, errResult := timezoneArg(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+	timeout := defaultActionWaitTimeout
+	if v, ok := args["TimeoutSeconds"].(float64); ok && v > 0 {
+		timeout = time.Duration(v * float64(time.Second))
+	}
+	if timeout > maxActionWaitTimeout {
+		return mcp.NewToolResultError(fmt.Sprintf("TimeoutSeconds must not exceed %d", int(maxActionWaitTimeout.Seconds()))), nil
+	}
+	pollArgs := common.NewArgs(req)
+	interval := pollArgs.PollInterval(defaultActionWaitPollInterval)
+	if err := pollArgs.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var action *godo.Action
+	err = common.Poll(ctx, interval, func() (bool, error) {
+		latest, _, err := client.Actions.Get(ctx, int(id))
+		if err != nil {
+			return false, err
+		}
+		action = latest
+		return action.Status == godo.ActionCompleted || action.Status == "errored", nil
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		msg := fmt.Sprintf("stopped waiting for action %d: %v", int(id), ctx.Err())
+		if action != nil {
+			jsonData, err := actionJSON(action, loc)
+			if err != nil {
+				return nil, fmt.Errorf("marshal error: %w", err)
+			}
+			msg += ", last status: " + jsonData
+		}
+		return mcp.NewToolResultError(msg), nil
+	}
+	jsonData, err := actionJSON(action, loc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	result := mcp.NewToolResultText(jsonData)
+	if action.Status == "errored" {
+		result = mcp.NewToolResultError(fmt.Sprintf("action %d errored: %s", int(id), jsonData))
+	}
+	if notifyURL != "" {
+		result = common.NotifyAndNote(ctx, a.notifyClient, notifyURL, common.Notification{
+			Tool:       "action-wait",
+			Resource:   "action",
+			ResourceID: strconv.Itoa(action.ID),
+			Status:     action.Status,
+		}, result)
+	}
+	return result, nil
+}
+
 // Tools returns the list of server tools for actions.
 func (a *ActionTools) Tools() []server.ServerTool {
 	return []server.ServerTool{
@@ -83,6 +198,19 @@ func (a *ActionTools) Tools() []server.ServerTool {
 			Tool: mcp.NewTool("action-get",
 				mcp.WithDescription("Get a specific action by ID"),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Action ID")),
+				common.WithTimezone(),
+			),
+		},
+		{
+			Handler: a.waitForAction,
+			Tool: mcp.NewTool("action-wait",
+				mcp.WithDescription("Wait for an action to finish by polling it until its status is completed or errored. Returns the final action."),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Action ID")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(defaultActionWaitTimeout.Seconds()), mcp.Max(maxActionWaitTimeout.Seconds()), mcp.Description("Maximum time to wait in seconds")),
+				common.WithPollInterval(defaultActionWaitPollInterval),
+				mcp.WithString(common.NotifyURLArg, mcp.Description("HTTPS URL to POST a JSON status payload to when the action completes or errors")),
+				common.WithTimezone(),
 			),
 		},
 		{
@@ -91,6 +219,7 @@ func (a *ActionTools) Tools() []server.ServerTool {
 				mcp.WithDescription("List actions with pagination"),
 				mcp.WithNumber("Page", mcp.DefaultNumber(defaultActionsPage), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultActionsPageSize), mcp.Description("Items per page")),
+				common.WithTimezone(),
 			),
 		},
 	}
@@ -2,8 +2,14 @@ package account
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
+
+	"mcp-digitalocean/pkg/registry/common"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +24,7 @@ func setupActionToolsWithMock(mockActions *MockActionsService) *ActionTools {
 		}, nil
 	}
 
-	return &ActionTools{client: client}
+	return &ActionTools{client: client, notifyClient: http.DefaultClient}
 }
 
 func TestActionTools_getAction(t *testing.T) {
@@ -95,6 +101,44 @@ func TestActionTools_getAction(t *testing.T) {
 	}
 }
 
+func TestActionTools_getActionNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockActions := NewMockActionsService(ctrl)
+	notFound := &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	mockActions.EXPECT().Get(gomock.Any(), 99).Return(nil, nil, notFound)
+
+	tool := setupActionToolsWithMock(mockActions)
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(99)}}}
+	resp, err := tool.getAction(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	var nf common.NotFound
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &nf))
+	require.Equal(t, common.NotFound{Error: "resource not found", ResourceType: "action", ID: float64(99)}, nf)
+}
+
+func TestActionTools_getActionTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockActions := NewMockActionsService(ctrl)
+	started := &godo.Timestamp{Time: time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)}
+	mockActions.EXPECT().Get(gomock.Any(), 1).Return(&godo.Action{ID: 1, Status: "completed", StartedAt: started}, nil, nil)
+
+	tool := setupActionToolsWithMock(mockActions)
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(1), "timezone": "America/Los_Angeles"}}}
+	resp, err := tool.getAction(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+	require.Equal(t, "2024-07-01T05:00:00-07:00", out["started_at"])
+
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(1), "timezone": "PST8PDT/Nowhere"}}}
+	resp, err = tool.getAction(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "must be an IANA time zone name")
+}
+
 func TestActionTools_listActions(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -174,3 +218,149 @@ func TestActionTools_listActions(t *testing.T) {
 		})
 	}
 }
+
+func TestActionTools_waitForAction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockActionsService)
+		expectError string
+	}{
+		{
+			name: "Completes after polling",
+			args: map[string]any{"ID": float64(123456), "PollIntervalSeconds": float64(1)},
+			mockSetup: func(m *MockActionsService) {
+				gomock.InOrder(
+					m.EXPECT().Get(gomock.Any(), 123456).Return(&godo.Action{ID: 123456, Status: "in-progress"}, nil, nil).Times(1),
+					m.EXPECT().Get(gomock.Any(), 123456).Return(&godo.Action{ID: 123456, Status: "completed"}, nil, nil).Times(1),
+				)
+			},
+		},
+		{
+			name: "Errored action",
+			args: map[string]any{"ID": float64(123456), "PollIntervalSeconds": float64(1)},
+			mockSetup: func(m *MockActionsService) {
+				m.EXPECT().Get(gomock.Any(), 123456).Return(&godo.Action{ID: 123456, Status: "errored"}, nil, nil).Times(1)
+			},
+			expectError: "errored",
+		},
+		{
+			name: "Timeout",
+			args: map[string]any{"ID": float64(123456), "TimeoutSeconds": 0.05, "PollIntervalSeconds": float64(1)},
+			mockSetup: func(m *MockActionsService) {
+				m.EXPECT().Get(gomock.Any(), 123456).Return(&godo.Action{ID: 123456, Status: "in-progress"}, nil, nil).MinTimes(1)
+			},
+			expectError: "stopped waiting",
+		},
+		{
+			name:        "Poll interval below a second",
+			args:        map[string]any{"ID": float64(123456), "PollIntervalSeconds": float64(0)},
+			expectError: "argument 'PollIntervalSeconds' must be at least 1",
+		},
+		{
+			name:        "Timeout too large",
+			args:        map[string]any{"ID": float64(123456), "TimeoutSeconds": float64(7200)},
+			expectError: "TimeoutSeconds",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockActions := NewMockActionsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockActions)
+			}
+			tool := setupActionToolsWithMock(mockActions)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.waitForAction(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, `"status":"completed"`)
+		})
+	}
+}
+
+func TestActionTools_waitForActionCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockActions := NewMockActionsService(ctrl)
+	mockActions.EXPECT().Get(gomock.Any(), 123456).DoAndReturn(func(context.Context, int) (*godo.Action, *godo.Response, error) {
+		cancel()
+		return &godo.Action{ID: 123456, Status: "in-progress"}, nil, nil
+	}).Times(1)
+	tool := setupActionToolsWithMock(mockActions)
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(123456)}}}
+	resp, err := tool.waitForAction(ctx, req)
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "context canceled")
+}
+
+func TestActionTools_waitForActionNotify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Notifies on completion", func(t *testing.T) {
+		var received common.Notification
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodPost, r.Method)
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		}))
+		defer srv.Close()
+
+		mockActions := NewMockActionsService(ctrl)
+		mockActions.EXPECT().Get(gomock.Any(), 123456).Return(&godo.Action{ID: 123456, Status: "completed"}, nil, nil)
+		tool := setupActionToolsWithMock(mockActions)
+		tool.notifyClient = srv.Client()
+
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(123456), "notify_url": srv.URL + "/hook"}}}
+		resp, err := tool.waitForAction(context.Background(), req)
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		require.Len(t, resp.Content, 2)
+		require.Contains(t, resp.Content[1].(mcp.TextContent).Text, "notification sent to")
+		require.Equal(t, "action-wait", received.Tool)
+		require.Equal(t, "123456", received.ResourceID)
+		require.Equal(t, "completed", received.Status)
+	})
+
+	t.Run("Failed delivery does not fail the tool", func(t *testing.T) {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		mockActions := NewMockActionsService(ctrl)
+		mockActions.EXPECT().Get(gomock.Any(), 123456).Return(&godo.Action{ID: 123456, Status: "completed"}, nil, nil)
+		tool := setupActionToolsWithMock(mockActions)
+		tool.notifyClient = srv.Client()
+
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(123456), "notify_url": srv.URL}}}
+		resp, err := tool.waitForAction(context.Background(), req)
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		require.Contains(t, resp.Content[0].(mcp.TextContent).Text, `"status":"completed"`)
+		require.Contains(t, resp.Content[1].(mcp.TextContent).Text, "failed: notification endpoint returned 503")
+	})
+
+	t.Run("Rejects a non-https URL", func(t *testing.T) {
+		tool := setupActionToolsWithMock(NewMockActionsService(ctrl))
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(123456), "notify_url": "http://example.com/hook"}}}
+		resp, err := tool.waitForAction(context.Background(), req)
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+		require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "https")
+	})
+}
@@ -3,7 +3,12 @@ package account
 import (
 	"context"
 	"fmt"
+	"math"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,15 +18,103 @@ import (
 // BalanceTools provides tool-based handlers for DigitalOcean account balance.
 type BalanceTools struct {
 	client func(ctx context.Context) (*godo.Client, error)
+	now    func() time.Time
 }
 
 // NewBalanceTools creates a new BalanceTools instance.
 func NewBalanceTools(client func(ctx context.Context) (*godo.Client, error)) *BalanceTools {
-	return &BalanceTools{client: client}
+	return &BalanceTools{client: client, now: time.Now}
+}
+
+// SpendProjection extrapolates the month-to-date spend to the end of the month, in USD. The month
+// runs in UTC. ProjectedMonthly is omitted, with a Note, until a full day of the month has elapsed.
+type SpendProjection struct {
+	MonthStart       time.Time `json:"month_start"`
+	DaysElapsed      float64   `json:"days_elapsed"`
+	DaysInMonth      int       `json:"days_in_month"`
+	MonthToDateSpend float64   `json:"month_to_date_spend"`
+	ProjectedMonthly *float64  `json:"projected_monthly,omitempty"`
+	Note             string    `json:"note,omitempty"`
+}
+
+// balanceWithProjection is the balance-get output when project_monthly is set.
+type balanceWithProjection struct {
+	Balance    *godo.Balance    `json:"balance"`
+	Projection *SpendProjection `json:"projection"`
+}
+
+// monthToDateSpend sums the billing history entries of the month starting at monthStart, leaving out
+// payments. The history is listed newest first, so paging stops at the first older entry.
+func monthToDateSpend(ctx context.Context, client *godo.Client, monthStart time.Time) (float64, error) {
+	var spend float64
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		history, resp, err := client.BillingHistory.List(ctx, opt)
+		if err != nil {
+			return 0, err
+		}
+		for _, entry := range history.BillingHistory {
+			if entry.Date.Before(monthStart) {
+				return spend, nil
+			}
+			if strings.EqualFold(entry.Type, "payment") {
+				continue
+			}
+			amount, err := strconv.ParseFloat(entry.Amount, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid amount %q in billing history entry %q", entry.Amount, entry.Description)
+			}
+			spend += amount
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+	return spend, nil
+}
+
+// startOfMonth returns the start of the UTC month containing t.
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// projectSpend extrapolates spend linearly over the month containing now.
+func projectSpend(spend float64, now time.Time) *SpendProjection {
+	monthStart := startOfMonth(now)
+	daysInMonth := monthStart.AddDate(0, 1, -1).Day()
+	elapsed := now.Sub(monthStart).Hours() / 24
+
+	p := &SpendProjection{
+		MonthStart:       monthStart,
+		DaysElapsed:      math.Round(elapsed*100) / 100,
+		DaysInMonth:      daysInMonth,
+		MonthToDateSpend: math.Round(spend*100) / 100,
+	}
+	if elapsed < 1 {
+		p.Note = "less than a day of the month has elapsed, too early to project the monthly total"
+		return p
+	}
+	projected := math.Round(spend/elapsed*float64(daysInMonth)*100) / 100
+	p.ProjectedMonthly = &projected
+	return p
 }
 
 // getBalance retrieves the balance information for the user account.
 func (b *BalanceTools) getBalance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	pretty := args.OptionalBool("Pretty", false)
+	opts := args.RenderOptions()
+	project := args.OptionalBool("project_monthly", false)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if pretty {
+		// Pretty predates the format argument and is kept as its shorthand.
+		opts.Format = response.FormatNamePretty
+	}
+
 	client, err := b.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
@@ -32,12 +125,17 @@ func (b *BalanceTools) getBalance(ctx context.Context, req mcp.CallToolRequest)
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
 
-	jsonData, err := response.CompactJSON(balance)
-	if err != nil {
-		return nil, fmt.Errorf("marshal error: %w", err)
+	var result any = balance
+	if project {
+		now := b.now()
+		spend, err := monthToDateSpend(ctx, client, startOfMonth(now))
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		result = balanceWithProjection{Balance: balance, Projection: projectSpend(spend, now)}
 	}
 
-	return mcp.NewToolResultText(jsonData), nil
+	return common.RenderResult(result, opts)
 }
 
 // Tools returns the list of server tools for balance.
@@ -47,6 +145,9 @@ func (b *BalanceTools) Tools() []server.ServerTool {
 			Handler: b.getBalance,
 			Tool: mcp.NewTool("balance-get",
 				mcp.WithDescription("Get balance information for the user account"),
+				mcp.WithBoolean("Pretty", mcp.DefaultBool(false), mcp.Description("Indent the JSON output for human readability, like format pretty")),
+				common.WithRenderOptions(),
+				mcp.WithBoolean("project_monthly", mcp.DefaultBool(false), mcp.Description("Also project the end-of-month total from this month's billing history")),
 			),
 		},
 	}
@@ -2,8 +2,11 @@ package account
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,7 +21,7 @@ func setupBalanceToolsWithMock(mockBalance *MockBalanceService) *BalanceTools {
 		}, nil
 	}
 
-	return &BalanceTools{client: client}
+	return &BalanceTools{client: client, now: time.Now}
 }
 
 func TestBalanceTools_getBalance(t *testing.T) {
@@ -32,6 +35,7 @@ func TestBalanceTools_getBalance(t *testing.T) {
 	}
 	tests := []struct {
 		name        string
+		pretty      bool
 		mockSetup   func(*MockBalanceService)
 		expectError bool
 	}{
@@ -44,6 +48,16 @@ func TestBalanceTools_getBalance(t *testing.T) {
 					Times(1)
 			},
 		},
+		{
+			name:   "Pretty output",
+			pretty: true,
+			mockSetup: func(m *MockBalanceService) {
+				m.EXPECT().
+					Get(gomock.Any()).
+					Return(testBalance, nil, nil).
+					Times(1)
+			},
+		},
 		{
 			name: "API error",
 			mockSetup: func(m *MockBalanceService) {
@@ -63,7 +77,7 @@ func TestBalanceTools_getBalance(t *testing.T) {
 				tc.mockSetup(mockBalance)
 			}
 			tool := setupBalanceToolsWithMock(mockBalance)
-			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{}}}
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Pretty": tc.pretty}}}
 			resp, err := tool.getBalance(context.Background(), req)
 			if tc.expectError {
 				require.NotNil(t, resp)
@@ -74,6 +88,95 @@ func TestBalanceTools_getBalance(t *testing.T) {
 			require.NotNil(t, resp)
 			require.False(t, resp.IsError)
 			require.NotEmpty(t, resp.Content)
+			text := resp.Content[0].(mcp.TextContent).Text
+			require.Equal(t, tc.pretty, strings.Contains(text, "\n  "))
+			var out godo.Balance
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, testBalance.AccountBalance, out.AccountBalance)
+		})
+	}
+}
+
+func TestBalanceTools_getBalanceProjection(t *testing.T) {
+	testBalance := &godo.Balance{MonthToDateUsage: "20.00"}
+	history := func(entries ...godo.BillingHistoryEntry) *godo.BillingHistory {
+		return &godo.BillingHistory{BillingHistory: entries}
+	}
+	day := func(d int) time.Time { return time.Date(2025, time.June, d, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		mockSetup func(*MockBillingHistoryService)
+		expected  SpendProjection
+		projected float64
+	}{
+		{
+			name: "Projects from the month-to-date spend across pages",
+			now:  time.Date(2025, time.June, 11, 0, 0, 0, 0, time.UTC),
+			mockSetup: func(m *MockBillingHistoryService) {
+				gomock.InOrder(
+					m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return(history(
+						godo.BillingHistoryEntry{Description: "Droplet usage", Amount: "15.00", Type: "Invoice", Date: day(10)},
+						godo.BillingHistoryEntry{Description: "Payment", Amount: "-50.00", Type: "Payment", Date: day(5)},
+					), &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+					m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 200}).Return(history(
+						godo.BillingHistoryEntry{Description: "Volume usage", Amount: "5.00", Type: "Invoice", Date: day(2)},
+						godo.BillingHistoryEntry{Description: "Last month", Amount: "99.00", Type: "Invoice", Date: time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC)},
+					), &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=3", Last: "page=3"}}}, nil),
+				)
+			},
+			expected: SpendProjection{
+				MonthStart:       time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC),
+				DaysElapsed:      10,
+				DaysInMonth:      30,
+				MonthToDateSpend: 20,
+			},
+			projected: 60,
+		},
+		{
+			name: "First hours of the month",
+			now:  time.Date(2025, time.June, 1, 6, 0, 0, 0, time.UTC),
+			mockSetup: func(m *MockBillingHistoryService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(history(), nil, nil)
+			},
+			expected: SpendProjection{
+				MonthStart:  time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC),
+				DaysElapsed: 0.25,
+				DaysInMonth: 30,
+				Note:        "less than a day of the month has elapsed, too early to project the monthly total",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBalance := NewMockBalanceService(ctrl)
+			mockBalance.EXPECT().Get(gomock.Any()).Return(testBalance, nil, nil)
+			mockBilling := NewMockBillingHistoryService(ctrl)
+			tc.mockSetup(mockBilling)
+			tool := &BalanceTools{
+				client: func(ctx context.Context) (*godo.Client, error) {
+					return &godo.Client{Balance: mockBalance, BillingHistory: mockBilling}, nil
+				},
+				now: func() time.Time { return tc.now },
+			}
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"project_monthly": true}}}
+			resp, err := tool.getBalance(context.Background(), req)
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+
+			var out balanceWithProjection
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, testBalance.MonthToDateUsage, out.Balance.MonthToDateUsage)
+			if tc.expected.Note == "" {
+				require.NotNil(t, out.Projection.ProjectedMonthly)
+				require.Equal(t, tc.projected, *out.Projection.ProjectedMonthly)
+				tc.expected.ProjectedMonthly = out.Projection.ProjectedMonthly
+			}
+			require.Equal(t, tc.expected, *out.Projection)
 		})
 	}
 }
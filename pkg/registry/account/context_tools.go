@@ -0,0 +1,124 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ContextTools lists and switches the auth contexts configured on the server. Each context holds
+// the token of one team, since DigitalOcean tokens can't act on behalf of another team.
+type ContextTools struct {
+	client   func(ctx context.Context) (*godo.Client, error)
+	contexts *common.AuthContexts
+}
+
+func NewContextTools(client func(ctx context.Context) (*godo.Client, error), contexts *common.AuthContexts) *ContextTools {
+	return &ContextTools{
+		client:   client,
+		contexts: contexts,
+	}
+}
+
+// authContext describes an auth context and the team its token belongs to.
+type authContext struct {
+	Context  string `json:"context"`
+	Active   bool   `json:"active"`
+	Email    string `json:"email,omitempty"`
+	TeamUUID string `json:"team_uuid,omitempty"`
+	TeamName string `json:"team_name,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// describe fetches the account behind the token of the named context.
+func (c *ContextTools) describe(ctx context.Context, name string) (authContext, error) {
+	result := authContext{Context: name, Active: name == c.contexts.Active()}
+	ctx, err := c.contexts.WithContextToken(ctx, name)
+	if err != nil {
+		return result, err
+	}
+	client, err := c.client(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	account, _, err := client.Account.Get(ctx)
+	if err != nil {
+		return result, err
+	}
+	result.Email = account.Email
+	if account.Team != nil {
+		result.TeamUUID = account.Team.UUID
+		result.TeamName = account.Team.Name
+	}
+	return result, nil
+}
+
+func (c *ContextTools) listContexts(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	names := c.contexts.Names()
+	contexts := make([]authContext, 0, len(names))
+	for _, name := range names {
+		described, err := c.describe(ctx, name)
+		if err != nil {
+			// A revoked token shouldn't hide the other contexts.
+			described.Error = err.Error()
+		}
+		contexts = append(contexts, described)
+	}
+
+	jsonData, err := response.CompactJSON(contexts)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+func (c *ContextTools) switchContext(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	name := args.RequireString("context")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Check the token works before switching to it.
+	described, err := c.describe(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to use auth context", err), nil
+	}
+	if err := c.contexts.Use(name); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	described.Active = true
+
+	jsonData, err := response.CompactJSON(described)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+func (c *ContextTools) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: c.listContexts,
+			Tool: mcp.NewTool("account-list-contexts",
+				mcp.WithDescription("List the auth contexts configured on the server, each holding the token of one team, with the team and email of each token and which context is in use"),
+				mcp.WithReadOnlyHintAnnotation(true),
+			),
+		},
+		{
+			Handler: c.switchContext,
+			Tool: mcp.NewTool("account-switch-context",
+				mcp.WithDescription("Switch the auth context, and so the team, used by every following tool call. The context's token is checked before switching"),
+				// Only the server's own state changes, no DigitalOcean resource.
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("context", mcp.Required(), mcp.Description("Name of the auth context to use, as listed by account-list-contexts")),
+			),
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// setupContextTools returns tools whose client serves the account service of the token in the context.
+func setupContextTools(t *testing.T, accounts map[string]*MockAccountService) (*ContextTools, *common.AuthContexts) {
+	t.Helper()
+	contexts, err := common.NewAuthContexts(map[string]string{common.DefaultAuthContext: "main", "staging": "staging-token"}, common.DefaultAuthContext)
+	require.NoError(t, err)
+	client := func(ctx context.Context) (*godo.Client, error) {
+		auth, _ := ctx.Value(middleware.AuthKey{}).(string)
+		return &godo.Client{Account: accounts[auth]}, nil
+	}
+	return NewContextTools(client, contexts), contexts
+}
+
+func TestContextTools_listContexts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mainAccount := NewMockAccountService(ctrl)
+	mainAccount.EXPECT().Get(gomock.Any()).Return(&godo.Account{Email: "me@example.com", Team: &godo.TeamInfo{UUID: "team-1", Name: "Main"}}, nil, nil)
+	stagingAccount := NewMockAccountService(ctrl)
+	stagingAccount.EXPECT().Get(gomock.Any()).Return(nil, nil, errors.New("unauthorized"))
+	tool, _ := setupContextTools(t, map[string]*MockAccountService{"Bearer main": mainAccount, "Bearer staging-token": stagingAccount})
+
+	resp, err := tool.listContexts(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var contexts []authContext
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &contexts))
+	require.Equal(t, []authContext{
+		{Context: common.DefaultAuthContext, Active: true, Email: "me@example.com", TeamUUID: "team-1", TeamName: "Main"},
+		{Context: "staging", Error: "unauthorized"},
+	}, contexts)
+}
+
+func TestContextTools_switchContext(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         map[string]any
+		mockSetup    func(*MockAccountService)
+		expectError  string
+		expectActive string
+	}{
+		{
+			name: "Switches",
+			args: map[string]any{"context": "staging"},
+			mockSetup: func(m *MockAccountService) {
+				m.EXPECT().Get(gomock.Any()).Return(&godo.Account{Email: "me@example.com", Team: &godo.TeamInfo{UUID: "team-2", Name: "Staging"}}, nil, nil)
+			},
+			expectActive: "staging",
+		},
+		{
+			name: "Rejected token",
+			args: map[string]any{"context": "staging"},
+			mockSetup: func(m *MockAccountService) {
+				m.EXPECT().Get(gomock.Any()).Return(nil, nil, errors.New("unauthorized"))
+			},
+			expectError:  "unauthorized",
+			expectActive: common.DefaultAuthContext,
+		},
+		{
+			name:         "Unknown context",
+			args:         map[string]any{"context": "prod"},
+			expectError:  `unknown auth context "prod"`,
+			expectActive: common.DefaultAuthContext,
+		},
+		{
+			name:         "Missing context",
+			args:         map[string]any{},
+			expectError:  "context",
+			expectActive: common.DefaultAuthContext,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			stagingAccount := NewMockAccountService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(stagingAccount)
+			}
+			tool, contexts := setupContextTools(t, map[string]*MockAccountService{"Bearer staging-token": stagingAccount})
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.switchContext(context.Background(), req)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectActive, contexts.Active())
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+
+			var described authContext
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &described))
+			require.Equal(t, authContext{Context: "staging", Active: true, Email: "me@example.com", TeamUUID: "team-2", TeamName: "Staging"}, described)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package account
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// invoiceMonthLayout is the layout of invoice periods, e.g. 2025-01.
+	invoiceMonthLayout = "2006-01"
+	// maxSummaryMonths bounds the months invoice-summarize reads, each costing an invoice fetch.
+	maxSummaryMonths = 24
+)
+
+// invoiceMonth is the spend of one invoiced month.
+type invoiceMonth struct {
+	Month       string `json:"month"`
+	InvoiceUUID string `json:"invoice_uuid"`
+	Amount      string `json:"amount"`
+}
+
+// categorySpend is the spend on one product category over the summarized months.
+type categorySpend struct {
+	Category string `json:"category"`
+	Amount   string `json:"amount"`
+	Items    int    `json:"items"`
+}
+
+// invoiceSummary is the result of invoice-summarize. Total is the sum of the invoice amounts, which
+// include taxes and credits that the categorized line items may not.
+type invoiceSummary struct {
+	StartMonth    string          `json:"start_month"`
+	EndMonth      string          `json:"end_month"`
+	Total         string          `json:"total"`
+	Categories    []categorySpend `json:"categories"`
+	Months        []invoiceMonth  `json:"months"`
+	MissingMonths []string        `json:"missing_months,omitempty"`
+}
+
+// parseCents parses a dollar amount such as "12.34" into cents.
+func parseCents(amount string) (int64, error) {
+	if amount == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(amount), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+	return int64(math.Round(f * 100)), nil
+}
+
+// formatCents formats cents as a dollar amount such as "12.34".
+func formatCents(cents int64) string {
+	return strconv.FormatFloat(float64(cents)/100, 'f', 2, 64)
+}
+
+// monthsBetween returns the months from start to end, both included, in invoice period format.
+func monthsBetween(start, end time.Time) []string {
+	var months []string
+	for m := start; !m.After(end); m = m.AddDate(0, 1, 0) {
+		months = append(months, m.Format(invoiceMonthLayout))
+	}
+	return months
+}
+
+// invoicesByMonth lists every invoice of the account, keyed by invoice period.
+func invoicesByMonth(ctx context.Context, client *godo.Client) (map[string]godo.InvoiceListItem, error) {
+	invoices := map[string]godo.InvoiceListItem{}
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		list, resp, err := client.Invoices.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, invoice := range list.Invoices {
+			invoices[invoice.InvoicePeriod] = invoice
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+	return invoices, nil
+}
+
+// addInvoiceItems adds the line items of an invoice, read across all its pages, to the spend of
+// their product category.
+func addInvoiceItems(ctx context.Context, client *godo.Client, invoiceUUID string, categories map[string]*categorySpend, cents map[string]int64) error {
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		invoice, resp, err := client.Invoices.Get(ctx, invoiceUUID, opt)
+		if err != nil {
+			return err
+		}
+		for _, item := range invoice.InvoiceItems {
+			amount, err := parseCents(item.Amount)
+			if err != nil {
+				return fmt.Errorf("invoice %s: %w", invoiceUUID, err)
+			}
+			category := item.Product
+			if category == "" {
+				category = "Other"
+			}
+			if categories[category] == nil {
+				categories[category] = &categorySpend{Category: category}
+			}
+			categories[category].Items++
+			cents[category] += amount
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+	return nil
+}
+
+// summarizeInvoices aggregates the invoices of a month range by product category. Months without an
+// invoice, such as the current month which is only invoiced once it ends, are reported as missing.
+func (i *InvoiceTools) summarizeInvoices(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	startMonth := args.RequireString("StartMonth")
+	endMonth := args.OptionalString("EndMonth", startMonth)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	start, err := time.Parse(invoiceMonthLayout, startMonth)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("StartMonth must be a month in YYYY-MM format, got %q", startMonth)), nil
+	}
+	end, err := time.Parse(invoiceMonthLayout, endMonth)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("EndMonth must be a month in YYYY-MM format, got %q", endMonth)), nil
+	}
+	if end.Before(start) {
+		return mcp.NewToolResultError("EndMonth must not be before StartMonth"), nil
+	}
+	months := monthsBetween(start, end)
+	if len(months) > maxSummaryMonths {
+		return mcp.NewToolResultError(fmt.Sprintf("the range spans %d months, at most %d can be summarized at once", len(months), maxSummaryMonths)), nil
+	}
+
+	client, err := i.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	invoices, err := invoicesByMonth(ctx, client)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	summary := invoiceSummary{StartMonth: startMonth, EndMonth: endMonth, Categories: []categorySpend{}, Months: []invoiceMonth{}}
+	categories := map[string]*categorySpend{}
+	categoryCents := map[string]int64{}
+	var total int64
+	for _, month := range months {
+		invoice, ok := invoices[month]
+		if !ok {
+			summary.MissingMonths = append(summary.MissingMonths, month)
+			continue
+		}
+		amount, err := parseCents(invoice.Amount)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("invoice %s", invoice.InvoiceUUID), err), nil
+		}
+		total += amount
+		summary.Months = append(summary.Months, invoiceMonth{Month: month, InvoiceUUID: invoice.InvoiceUUID, Amount: formatCents(amount)})
+		if err := addInvoiceItems(ctx, client, invoice.InvoiceUUID, categories, categoryCents); err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+	}
+
+	for category, spend := range categories {
+		spend.Amount = formatCents(categoryCents[category])
+		summary.Categories = append(summary.Categories, *spend)
+	}
+	slices.SortFunc(summary.Categories, func(a, b categorySpend) int {
+		return cmp.Or(cmp.Compare(categoryCents[b.Category], categoryCents[a.Category]), cmp.Compare(a.Category, b.Category))
+	})
+	summary.Total = formatCents(total)
+
+	jsonData, err := response.CompactJSON(summary)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// SummaryTools returns the tools aggregating invoices.
+func (i *InvoiceTools) SummaryTools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: i.summarizeInvoices,
+			Tool: mcp.NewTool("invoice-summarize",
+				mcp.WithDescription(fmt.Sprintf("Summarize the invoices of a range of up to %d months: the spend per product category (e.g. Droplets, Managed Databases, Spaces) from their line items, each month's invoice amount, and the total. Months without an invoice, such as the current month, are listed as missing.", maxSummaryMonths)),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("StartMonth", mcp.Required(), mcp.Description("First month to summarize, in YYYY-MM format")),
+				mcp.WithString("EndMonth", mcp.Description("Last month to summarize, in YYYY-MM format. Defaults to StartMonth")),
+			),
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestInvoiceTools_summarizeInvoices(t *testing.T) {
+	invoices := &godo.InvoiceList{
+		Invoices: []godo.InvoiceListItem{
+			{InvoiceUUID: "inv-feb", Amount: "30.50", InvoicePeriod: "2025-02"},
+			{InvoiceUUID: "inv-jan", Amount: "20.00", InvoicePeriod: "2025-01"},
+			{InvoiceUUID: "inv-dec", Amount: "99.00", InvoicePeriod: "2024-12"},
+		},
+	}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockInvoicesService)
+		expect      invoiceSummary
+		expectError string
+	}{
+		{
+			name: "Months with and without invoices",
+			args: map[string]any{"StartMonth": "2025-01", "EndMonth": "2025-03"},
+			mockSetup: func(m *MockInvoicesService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(invoices, nil, nil)
+				m.EXPECT().Get(gomock.Any(), "inv-jan", gomock.Any()).Return(&godo.Invoice{InvoiceItems: []godo.InvoiceItem{
+					{Product: "Droplets", Amount: "12.00"},
+					{Product: "Spaces Subscription", Amount: "5.00"},
+					{Product: "Droplets", Amount: "3.00"},
+				}}, nil, nil)
+				m.EXPECT().Get(gomock.Any(), "inv-feb", gomock.Any()).Return(&godo.Invoice{InvoiceItems: []godo.InvoiceItem{
+					{Product: "Managed Databases", Amount: "15.25"},
+					{Product: "Droplets", Amount: "15.25"},
+				}}, nil, nil)
+			},
+			expect: invoiceSummary{
+				StartMonth: "2025-01",
+				EndMonth:   "2025-03",
+				Total:      "50.50",
+				Categories: []categorySpend{
+					{Category: "Droplets", Amount: "30.25", Items: 3},
+					{Category: "Managed Databases", Amount: "15.25", Items: 1},
+					{Category: "Spaces Subscription", Amount: "5.00", Items: 1},
+				},
+				Months: []invoiceMonth{
+					{Month: "2025-01", InvoiceUUID: "inv-jan", Amount: "20.00"},
+					{Month: "2025-02", InvoiceUUID: "inv-feb", Amount: "30.50"},
+				},
+				MissingMonths: []string{"2025-03"},
+			},
+		},
+		{
+			name: "No invoice in the range",
+			args: map[string]any{"StartMonth": "2023-06"},
+			mockSetup: func(m *MockInvoicesService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(invoices, nil, nil)
+			},
+			expect: invoiceSummary{
+				StartMonth:    "2023-06",
+				EndMonth:      "2023-06",
+				Total:         "0.00",
+				Categories:    []categorySpend{},
+				Months:        []invoiceMonth{},
+				MissingMonths: []string{"2023-06"},
+			},
+		},
+		{
+			name: "Invoice fetch fails",
+			args: map[string]any{"StartMonth": "2025-01"},
+			mockSetup: func(m *MockInvoicesService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(invoices, nil, nil)
+				m.EXPECT().Get(gomock.Any(), "inv-jan", gomock.Any()).Return(nil, nil, errors.New("boom"))
+			},
+			expectError: "boom",
+		},
+		{
+			name:        "Invalid month",
+			args:        map[string]any{"StartMonth": "January"},
+			expectError: "StartMonth must be a month in YYYY-MM format",
+		},
+		{
+			name:        "End before start",
+			args:        map[string]any{"StartMonth": "2025-03", "EndMonth": "2025-01"},
+			expectError: "EndMonth must not be before StartMonth",
+		},
+		{
+			name:        "Range too long",
+			args:        map[string]any{"StartMonth": "2020-01", "EndMonth": "2025-01"},
+			expectError: "at most 24 can be summarized",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockInvoices := NewMockInvoicesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockInvoices)
+			}
+			tool := setupInvoiceToolsWithMock(mockInvoices)
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.summarizeInvoices(context.Background(), req)
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out invoiceSummary
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, tc.expect, out)
+		})
+	}
+}
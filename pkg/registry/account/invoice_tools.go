@@ -2,6 +2,7 @@ package account
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"mcp-digitalocean/pkg/response"
 
@@ -87,6 +88,46 @@ func (i *InvoiceTools) getInvoice(ctx context.Context, req mcp.CallToolRequest)
 	return mcp.NewToolResultText(jsonData), nil
 }
 
+// getInvoicePDF retrieves an invoice as a base64-encoded PDF document.
+func (i *InvoiceTools) getInvoicePDF(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	invoiceUUID, ok := req.GetArguments()["InvoiceUUID"].(string)
+	if !ok || invoiceUUID == "" {
+		return mcp.NewToolResultError("missing InvoiceUUID"), nil
+	}
+
+	client, err := i.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	pdf, _, err := client.Invoices.GetPDF(ctx, invoiceUUID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	return mcp.NewToolResultText(base64.StdEncoding.EncodeToString(pdf)), nil
+}
+
+// getInvoiceCSV retrieves an invoice as CSV text.
+func (i *InvoiceTools) getInvoiceCSV(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	invoiceUUID, ok := req.GetArguments()["InvoiceUUID"].(string)
+	if !ok || invoiceUUID == "" {
+		return mcp.NewToolResultError("missing InvoiceUUID"), nil
+	}
+
+	client, err := i.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	csv, _, err := client.Invoices.GetCSV(ctx, invoiceUUID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	return mcp.NewToolResultText(string(csv)), nil
+}
+
 // Tools returns the list of server tools for invoices.
 func (i *InvoiceTools) Tools() []server.ServerTool {
 	return []server.ServerTool{
@@ -107,5 +148,19 @@ func (i *InvoiceTools) Tools() []server.ServerTool {
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultInvoicesPageSize), mcp.Description("Items per page")),
 			),
 		},
+		{
+			Handler: i.getInvoicePDF,
+			Tool: mcp.NewTool("invoice-get-pdf",
+				mcp.WithDescription("Get an invoice as a PDF document. The output is the raw PDF encoded as base64, suitable for saving to a file; use invoice-get-csv to analyze the invoice contents."),
+				mcp.WithString("InvoiceUUID", mcp.Required(), mcp.Description("The UUID of the invoice")),
+			),
+		},
+		{
+			Handler: i.getInvoiceCSV,
+			Tool: mcp.NewTool("invoice-get-csv",
+				mcp.WithDescription("Get an invoice as CSV text, with one line item per row"),
+				mcp.WithString("InvoiceUUID", mcp.Required(), mcp.Description("The UUID of the invoice")),
+			),
+		},
 	}
 }
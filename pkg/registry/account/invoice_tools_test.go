@@ -192,3 +192,89 @@ func TestInvoiceTools_getInvoice(t *testing.T) {
 		})
 	}
 }
+
+func TestInvoiceTools_getInvoicePDF(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		invoiceUUID string
+		mockSetup   func(*MockInvoicesService)
+		expectText  string
+		expectError bool
+	}{
+		{
+			name:        "Successful get",
+			invoiceUUID: "inv-123",
+			mockSetup: func(m *MockInvoicesService) {
+				m.EXPECT().
+					GetPDF(gomock.Any(), "inv-123").
+					Return([]byte("%PDF-1.4"), nil, nil).
+					Times(1)
+			},
+			expectText: "JVBERi0xLjQ=",
+		},
+		{
+			name:        "Missing UUID",
+			expectError: true,
+		},
+		{
+			name:        "API error",
+			invoiceUUID: "inv-123",
+			mockSetup: func(m *MockInvoicesService) {
+				m.EXPECT().
+					GetPDF(gomock.Any(), "inv-123").
+					Return(nil, nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockInvoices := NewMockInvoicesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockInvoices)
+			}
+			tool := setupInvoiceToolsWithMock(mockInvoices)
+			args := map[string]any{}
+			if tc.invoiceUUID != "" {
+				args["InvoiceUUID"] = tc.invoiceUUID
+			}
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+			resp, err := tool.getInvoicePDF(context.Background(), req)
+			if tc.expectError {
+				require.NotNil(t, resp)
+				require.True(t, resp.IsError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			require.False(t, resp.IsError)
+			require.Equal(t, tc.expectText, resp.Content[0].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestInvoiceTools_getInvoiceCSV(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	csv := "product,description,hours,amount\nDroplets,web-1,744,24.00\n"
+	mockInvoices := NewMockInvoicesService(ctrl)
+	mockInvoices.EXPECT().
+		GetCSV(gomock.Any(), "inv-123").
+		Return([]byte(csv), nil, nil).
+		Times(1)
+	tool := setupInvoiceToolsWithMock(mockInvoices)
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"InvoiceUUID": "inv-123"}}}
+	resp, err := tool.getInvoiceCSV(context.Background(), req)
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	require.False(t, resp.IsError)
+	require.Equal(t, csv, resp.Content[0].(mcp.TextContent).Text)
+}
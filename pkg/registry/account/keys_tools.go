@@ -3,11 +3,14 @@ package account
 import (
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"golang.org/x/crypto/ssh"
 )
 
 const (
@@ -27,10 +30,45 @@ func NewKeysTool(client func(ctx context.Context) (*godo.Client, error)) *KeysTo
 	}
 }
 
+// validatePublicKey checks that publicKey is a single OpenSSH authorized_keys line.
+func validatePublicKey(publicKey string) error {
+	_, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return fmt.Errorf("invalid public key, expected an OpenSSH authorized_keys line such as \"ssh-ed25519 AAAA... user@host\": %w", err)
+	}
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return fmt.Errorf("invalid public key, expected a single key but found more than one line")
+	}
+	return nil
+}
+
+// keyRef reads the ID or Fingerprint argument identifying an SSH key.
+func keyRef(args map[string]any) (int, string, error) {
+	id, _ := args["ID"].(float64)
+	fingerprint, _ := args["Fingerprint"].(string)
+	switch {
+	case id > 0 && fingerprint != "":
+		return 0, "", fmt.Errorf("only one of ID or Fingerprint may be set")
+	case id <= 0 && fingerprint == "":
+		return 0, "", fmt.Errorf("one of ID or Fingerprint is required")
+	}
+	return int(id), fingerprint, nil
+}
+
 func (k *KeysTool) createKey(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
-	name := args["Name"].(string)
-	publicKey := args["PublicKey"].(string)
+	name, ok := args["Name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+	publicKey, ok := args["PublicKey"].(string)
+	if !ok || publicKey == "" {
+		return mcp.NewToolResultError("PublicKey is required"), nil
+	}
+	publicKey = strings.TrimSpace(publicKey)
+	if err := validatePublicKey(publicKey); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := k.client(ctx)
 	if err != nil {
@@ -53,15 +91,57 @@ func (k *KeysTool) createKey(ctx context.Context, req mcp.CallToolRequest) (*mcp
 	return mcp.NewToolResultText(jsonKey), nil
 }
 
+func (k *KeysTool) updateKey(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, fingerprint, err := keyRef(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	name, ok := args["Name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+
+	client, err := k.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	updateReq := &godo.KeyUpdateRequest{Name: name}
+	var key *godo.Key
+	if fingerprint != "" {
+		key, _, err = client.Keys.UpdateByFingerprint(ctx, fingerprint, updateReq)
+	} else {
+		key, _, err = client.Keys.UpdateByID(ctx, id, updateReq)
+	}
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	jsonKey, err := response.CompactJSON(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(jsonKey), nil
+}
+
 func (k *KeysTool) deleteKey(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	keyID := int(req.GetArguments()["ID"].(float64))
+	id, fingerprint, err := keyRef(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := k.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.Keys.DeleteByID(ctx, keyID)
+	if fingerprint != "" {
+		_, err = client.Keys.DeleteByFingerprint(ctx, fingerprint)
+	} else {
+		_, err = client.Keys.DeleteByID(ctx, id)
+	}
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -83,7 +163,30 @@ func (k *KeysTool) getKey(ctx context.Context, req mcp.CallToolRequest) (*mcp.Ca
 
 	key, _, err := client.Keys.GetByID(ctx, int(id))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(err, "ssh_key", int(id)), nil
+	}
+	jsonData, err := response.CompactJSON(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// getKeyByFingerprint retrieves a specific SSH key by its fingerprint.
+func (k *KeysTool) getKeyByFingerprint(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	fingerprint, ok := req.GetArguments()["Fingerprint"].(string)
+	if !ok || fingerprint == "" {
+		return mcp.NewToolResultError("Fingerprint is required"), nil
+	}
+
+	client, err := k.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	key, _, err := client.Keys.GetByFingerprint(ctx, fingerprint)
+	if err != nil {
+		return common.APIErrorResult(err, "ssh_key", fingerprint), nil
 	}
 	jsonData, err := response.CompactJSON(key)
 	if err != nil {
@@ -125,16 +228,26 @@ func (k *KeysTool) Tools() []server.ServerTool {
 		{
 			Handler: k.createKey,
 			Tool: mcp.NewTool("key-create",
-				mcp.WithDescription("Create a new SSH key"),
+				mcp.WithDescription("Import an SSH public key into the account"),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the SSH key")),
-				mcp.WithString("PublicKey", mcp.Required(), mcp.Description("Public key content")),
+				mcp.WithString("PublicKey", mcp.Required(), mcp.Description("Public key as a single OpenSSH authorized_keys line (e.g. ssh-ed25519 AAAA... user@host)")),
+			),
+		},
+		{
+			Handler: k.updateKey,
+			Tool: mcp.NewTool("key-update",
+				mcp.WithDescription("Rename an SSH key identified by ID or fingerprint"),
+				mcp.WithNumber("ID", mcp.Description("ID of the SSH key to update")),
+				mcp.WithString("Fingerprint", mcp.Description("Fingerprint of the SSH key to update")),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("New name of the SSH key")),
 			),
 		},
 		{
 			Handler: k.deleteKey,
 			Tool: mcp.NewTool("key-delete",
-				mcp.WithDescription("Delete an SSH key"),
-				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the SSH key to delete")),
+				mcp.WithDescription("Delete an SSH key identified by ID or fingerprint"),
+				mcp.WithNumber("ID", mcp.Description("ID of the SSH key to delete")),
+				mcp.WithString("Fingerprint", mcp.Description("Fingerprint of the SSH key to delete")),
 			),
 		},
 		{
@@ -144,6 +257,13 @@ func (k *KeysTool) Tools() []server.ServerTool {
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the SSH key")),
 			),
 		},
+		{
+			Handler: k.getKeyByFingerprint,
+			Tool: mcp.NewTool("key-get-by-fingerprint",
+				mcp.WithDescription("Get a specific SSH key by fingerprint"),
+				mcp.WithString("Fingerprint", mcp.Required(), mcp.Description("MD5 fingerprint of the SSH key (e.g. 3b:16:bf:e4:8b:00:8b:b8:59:8c:a9:d3:f0:19:45:fa)")),
+			),
+		},
 		{
 			Handler: k.listKeys,
 			Tool: mcp.NewTool("key-list",
@@ -2,15 +2,27 @@ package account
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/ssh"
 )
 
+func generatePublicKey(t *testing.T) string {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	sshPub, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPub))) + " test@example"
+}
+
 func setupKeysToolWithMock(mockKeys *MockKeysService) *KeysTool {
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -25,10 +37,11 @@ func TestKeysTool_createKey(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	publicKey := generatePublicKey(t)
 	testKey := &godo.Key{
 		ID:        123,
 		Name:      "test-key",
-		PublicKey: "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC...",
+		PublicKey: publicKey,
 	}
 	tests := []struct {
 		name        string
@@ -40,13 +53,13 @@ func TestKeysTool_createKey(t *testing.T) {
 			name: "Successful create",
 			args: map[string]any{
 				"Name":      "test-key",
-				"PublicKey": "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC...",
+				"PublicKey": publicKey + "\n",
 			},
 			mockSetup: func(m *MockKeysService) {
 				m.EXPECT().
 					Create(gomock.Any(), &godo.KeyCreateRequest{
 						Name:      "test-key",
-						PublicKey: "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQC...",
+						PublicKey: publicKey,
 					}).
 					Return(testKey, nil, nil).
 					Times(1)
@@ -56,19 +69,35 @@ func TestKeysTool_createKey(t *testing.T) {
 			name: "API error",
 			args: map[string]any{
 				"Name":      "fail-key",
-				"PublicKey": "ssh-rsa BADKEY",
+				"PublicKey": publicKey,
 			},
 			mockSetup: func(m *MockKeysService) {
 				m.EXPECT().
 					Create(gomock.Any(), &godo.KeyCreateRequest{
 						Name:      "fail-key",
-						PublicKey: "ssh-rsa BADKEY",
+						PublicKey: publicKey,
 					}).
 					Return(nil, nil, errors.New("api error")).
 					Times(1)
 			},
 			expectError: true,
 		},
+		{
+			name: "Invalid public key",
+			args: map[string]any{
+				"Name":      "bad-key",
+				"PublicKey": "ssh-rsa BADKEY",
+			},
+			expectError: true,
+		},
+		{
+			name: "Multiple keys",
+			args: map[string]any{
+				"Name":      "two-keys",
+				"PublicKey": publicKey + "\n" + generatePublicKey(t),
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -114,6 +143,22 @@ func TestKeysTool_deleteKey(t *testing.T) {
 			},
 			expectText: "SSH key deleted successfully",
 		},
+		{
+			name: "Delete by fingerprint",
+			args: map[string]any{"Fingerprint": "3b:16:bf:e4"},
+			mockSetup: func(m *MockKeysService) {
+				m.EXPECT().
+					DeleteByFingerprint(gomock.Any(), "3b:16:bf:e4").
+					Return(nil, nil).
+					Times(1)
+			},
+			expectText: "SSH key deleted successfully",
+		},
+		{
+			name:        "Missing ID and fingerprint",
+			args:        map[string]any{},
+			expectError: true,
+		},
 		{
 			name: "API error",
 			args: map[string]any{"ID": float64(456)},
@@ -300,3 +345,85 @@ func TestKeysTool_listKeys(t *testing.T) {
 		})
 	}
 }
+
+func TestKeysTool_getKeyByFingerprint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockKeys := NewMockKeysService(ctrl)
+	mockKeys.EXPECT().
+		GetByFingerprint(gomock.Any(), "3b:16:bf:e4").
+		Return(&godo.Key{ID: 123, Name: "laptop", Fingerprint: "3b:16:bf:e4"}, nil, nil).
+		Times(1)
+	tool := setupKeysToolWithMock(mockKeys)
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Fingerprint": "3b:16:bf:e4"}}}
+	resp, err := tool.getKeyByFingerprint(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "laptop")
+}
+
+func TestKeysTool_updateKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockKeysService)
+		expectError bool
+	}{
+		{
+			name: "Update by ID",
+			args: map[string]any{"ID": float64(123), "Name": "renamed"},
+			mockSetup: func(m *MockKeysService) {
+				m.EXPECT().
+					UpdateByID(gomock.Any(), 123, &godo.KeyUpdateRequest{Name: "renamed"}).
+					Return(&godo.Key{ID: 123, Name: "renamed"}, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Update by fingerprint",
+			args: map[string]any{"Fingerprint": "3b:16:bf:e4", "Name": "renamed"},
+			mockSetup: func(m *MockKeysService) {
+				m.EXPECT().
+					UpdateByFingerprint(gomock.Any(), "3b:16:bf:e4", &godo.KeyUpdateRequest{Name: "renamed"}).
+					Return(&godo.Key{ID: 123, Name: "renamed"}, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "Both ID and fingerprint",
+			args:        map[string]any{"ID": float64(123), "Fingerprint": "3b:16:bf:e4", "Name": "renamed"},
+			expectError: true,
+		},
+		{
+			name:        "Missing name",
+			args:        map[string]any{"ID": float64(123)},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockKeys := NewMockKeysService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockKeys)
+			}
+			tool := setupKeysToolWithMock(mockKeys)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.updateKey(context.Background(), req)
+			if tc.expectError {
+				require.NotNil(t, resp)
+				require.True(t, resp.IsError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			require.False(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "renamed")
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/response"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultMetricsWindow is the time window used by apps-get-metrics when Start is omitted.
+	defaultMetricsWindow = time.Hour
+	// maxBandwidthDays caps the number of daily bandwidth reports a single apps-get-metrics call fetches.
+	maxBandwidthDays = 31
+)
+
+// appMetricPaths maps the CPU and memory metrics to their monitoring endpoints. godo doesn't wrap
+// the app metrics endpoints, so they are called through the client's generic request methods.
+var appMetricPaths = map[string]string{
+	"cpu":    "v2/monitoring/metrics/apps/cpu_percentage",
+	"memory": "v2/monitoring/metrics/apps/memory_percentage",
+}
+
+// BandwidthUsage is the bandwidth used by an app on a single day.
+type BandwidthUsage struct {
+	Date           string `json:"date"`
+	BandwidthBytes string `json:"bandwidth_bytes"`
+}
+
+// bandwidthDailyResponse is the response of the app bandwidth_daily endpoint.
+type bandwidthDailyResponse struct {
+	AppBandwidthUsage []struct {
+		AppID          string `json:"app_id"`
+		BandwidthBytes string `json:"bandwidth_bytes"`
+	} `json:"app_bandwidth_usage"`
+	Date time.Time `json:"date"`
+}
+
+// AppAlertsTool provides tools for App Platform alerts and metrics.
+type AppAlertsTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewAppAlertsTool creates a new AppAlertsTool instance.
+func NewAppAlertsTool(client func(ctx context.Context) (*godo.Client, error)) *AppAlertsTool {
+	return &AppAlertsTool{client: client}
+}
+
+// listAlerts lists the alerts configured for an app.
+func (a *AppAlertsTool) listAlerts(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appID, ok := req.GetArguments()["AppID"].(string)
+	if !ok || appID == "" {
+		return mcp.NewToolResultError("App ID is required"), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	alerts, _, err := client.Apps.ListAlerts(ctx, appID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to list alerts for app %s", appID), err), nil
+	}
+
+	jsonData, err := response.CompactJSON(alerts)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// validateEmail reports an error unless email is a bare address such as ops@example.com.
+func validateEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("invalid email address %q", email)
+	}
+	return nil
+}
+
+// validateSlackWebhookURL reports an error unless rawURL is a Slack incoming webhook URL.
+func validateSlackWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host != "hooks.slack.com" || !strings.HasPrefix(u.Path, "/services/") {
+		return fmt.Errorf("invalid Slack webhook URL %q, must start with https://hooks.slack.com/services/", rawURL)
+	}
+	return nil
+}
+
+// updateAlertDestinations replaces the email and Slack destinations of an app alert.
+func (a *AppAlertsTool) updateAlertDestinations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	appID, _ := args["AppID"].(string)
+	if appID == "" {
+		return mcp.NewToolResultError("App ID is required"), nil
+	}
+	alertID, _ := args["AlertID"].(string)
+	if alertID == "" {
+		return mcp.NewToolResultError("Alert ID is required"), nil
+	}
+
+	update := &godo.AlertDestinationUpdateRequest{Emails: []string{}, SlackWebhooks: []*godo.AppAlertSlackWebhook{}}
+	if emails, ok := args["Emails"].([]any); ok {
+		for _, v := range emails {
+			email, _ := v.(string)
+			if err := validateEmail(email); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			update.Emails = append(update.Emails, email)
+		}
+	}
+	if webhooks, ok := args["SlackWebhooks"].([]any); ok {
+		for _, v := range webhooks {
+			webhook, _ := v.(map[string]any)
+			webhookURL, _ := webhook["URL"].(string)
+			if err := validateSlackWebhookURL(webhookURL); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			channel, _ := webhook["Channel"].(string)
+			update.SlackWebhooks = append(update.SlackWebhooks, &godo.AppAlertSlackWebhook{URL: webhookURL, Channel: channel})
+		}
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	alert, _, err := client.Apps.UpdateAlertDestinations(ctx, appID, alertID, update)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to update destinations of alert %s", alertID), err), nil
+	}
+
+	jsonData, err := response.CompactJSON(alert)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// parseMetricsWindow returns the time window requested by the Start and End arguments. End defaults
+// to now and Start to defaultMetricsWindow before End.
+func parseMetricsWindow(args map[string]any) (time.Time, time.Time, error) {
+	end := time.Now().UTC()
+	if v, _ := args["End"].(string); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid End %q, must be an RFC 3339 timestamp", v)
+		}
+		end = t
+	}
+	start := end.Add(-defaultMetricsWindow)
+	if v, _ := args["Start"].(string); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid Start %q, must be an RFC 3339 timestamp", v)
+		}
+		start = t
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start must be before end")
+	}
+	return start, end, nil
+}
+
+// getMetrics returns the CPU or memory usage of an app over a time window, or its daily bandwidth
+// usage for every day in the window.
+func (a *AppAlertsTool) getMetrics(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	appID, _ := args["AppID"].(string)
+	if appID == "" {
+		return mcp.NewToolResultError("App ID is required"), nil
+	}
+	metric, _ := args["Metric"].(string)
+	if _, ok := appMetricPaths[metric]; !ok && metric != "bandwidth" {
+		return mcp.NewToolResultError("Metric must be one of: bandwidth, cpu, memory"), nil
+	}
+	start, end, err := parseMetricsWindow(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if days := bandwidthDays(start, end); metric == "bandwidth" && days > maxBandwidthDays {
+		return mcp.NewToolResultError(fmt.Sprintf("bandwidth window spans %d days, at most %d are supported", days, maxBandwidthDays)), nil
+	}
+	component, _ := args["Component"].(string)
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	var result any
+	if metric == "bandwidth" {
+		result, err = getBandwidthUsage(ctx, client, appID, start, end)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to get bandwidth usage for app %s", appID), err), nil
+		}
+	} else {
+		query := url.Values{}
+		query.Set("app_id", appID)
+		if component != "" {
+			query.Set("app_component", component)
+		}
+		query.Set("start", strconv.FormatInt(start.Unix(), 10))
+		query.Set("end", strconv.FormatInt(end.Unix(), 10))
+
+		httpReq, err := client.NewRequest(ctx, http.MethodGet, appMetricPaths[metric]+"?"+query.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build metrics request: %w", err)
+		}
+		metrics := new(godo.MetricsResponse)
+		if _, err := client.Do(ctx, httpReq, metrics); err != nil {
+			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to get %s metrics for app %s", metric, appID), err), nil
+		}
+		result = metrics
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// bandwidthDays returns the number of days, in UTC, the window between start and end touches.
+func bandwidthDays(start, end time.Time) int {
+	return int(end.Sub(start.UTC().Truncate(24*time.Hour)).Hours()/24) + 1
+}
+
+// getBandwidthUsage fetches the daily bandwidth usage of an app for every day between start and end.
+func getBandwidthUsage(ctx context.Context, client *godo.Client, appID string, start, end time.Time) ([]BandwidthUsage, error) {
+	usage := []BandwidthUsage{}
+	for day := start.UTC().Truncate(24 * time.Hour); day.Before(end); day = day.Add(24 * time.Hour) {
+		path := fmt.Sprintf("v2/apps/%s/metrics/bandwidth_daily?date=%s", url.PathEscape(appID), url.QueryEscape(day.Format(time.RFC3339)))
+		httpReq, err := client.NewRequest(ctx, http.MethodGet, path, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build bandwidth request: %w", err)
+		}
+		daily := new(bandwidthDailyResponse)
+		if _, err := client.Do(ctx, httpReq, daily); err != nil {
+			return nil, fmt.Errorf("day %s: %w", day.Format(time.DateOnly), err)
+		}
+		for _, u := range daily.AppBandwidthUsage {
+			usage = append(usage, BandwidthUsage{Date: day.Format(time.DateOnly), BandwidthBytes: u.BandwidthBytes})
+		}
+	}
+	return usage, nil
+}
+
+// Tools returns the list of server tools for App Platform alerts and metrics.
+func (a *AppAlertsTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: a.listAlerts,
+			Tool: mcp.NewTool("apps-list-alerts",
+				mcp.WithDescription("List the alerts configured for an app on DigitalOcean App Platform, with their rule, component and email and Slack destinations."),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+			),
+		},
+		{
+			Handler: a.updateAlertDestinations,
+			Tool: mcp.NewTool("apps-update-alert-destinations",
+				mcp.WithDescription("Replace the email and Slack destinations of an app alert. Destinations that are not passed are removed. Returns the updated alert."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("AlertID", mcp.Required(), mcp.Description("The alert ID, as returned by apps-list-alerts")),
+				mcp.WithArray("Emails", mcp.Description("Email addresses to notify"), mcp.Items(map[string]any{"type": "string"})),
+				mcp.WithArray("SlackWebhooks", mcp.Description("Slack incoming webhooks to notify"), mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"URL":     map[string]any{"type": "string", "description": "Webhook URL, e.g. https://hooks.slack.com/services/..."},
+						"Channel": map[string]any{"type": "string", "description": "Slack channel name, e.g. #alerts"},
+					},
+					"required": []string{"URL"},
+				})),
+			),
+		},
+		{
+			Handler: a.getMetrics,
+			Tool: mcp.NewTool("apps-get-metrics",
+				mcp.WithDescription("Get usage metrics of an app for a time window. cpu and memory return the usage percentage over time, bandwidth returns the bandwidth used on each day of the window."),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("Metric", mcp.Required(), mcp.Enum("bandwidth", "cpu", "memory"), mcp.Description("The metric to retrieve")),
+				mcp.WithString("Component", mcp.Description("Only report cpu or memory usage of this component")),
+				mcp.WithString("Start", mcp.Description("Start of the window as an RFC 3339 timestamp (default: one hour before End)")),
+				mcp.WithString("End", mcp.Description("End of the window as an RFC 3339 timestamp (default: now)")),
+			),
+		},
+	}
+}
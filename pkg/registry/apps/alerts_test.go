@@ -0,0 +1,239 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListAlerts(t *testing.T) {
+	alerts := []*godo.AppAlert{{ID: "alert-1", Emails: []string{"ops@example.com"}}}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mock        func(*MockAppsService)
+		expectError bool
+	}{
+		{
+			name: "Successful list",
+			args: map[string]any{"AppID": "app-123"},
+			mock: func(m *MockAppsService) {
+				m.EXPECT().ListAlerts(gomock.Any(), "app-123").Return(alerts, nil, nil)
+			},
+		},
+		{
+			name:        "Missing AppID",
+			args:        map[string]any{},
+			expectError: true,
+		},
+		{
+			name: "API error",
+			args: map[string]any{"AppID": "app-123"},
+			mock: func(m *MockAppsService) {
+				m.EXPECT().ListAlerts(gomock.Any(), "app-123").Return(nil, nil, errors.New("api error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, appService := setupMock(t)
+			if tc.mock != nil {
+				tc.mock(appService)
+			}
+			res, err := NewAppAlertsTool(client).listAlerts(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, res.IsError)
+				return
+			}
+			require.False(t, res.IsError)
+			equalsToolResult(t, alerts, res)
+		})
+	}
+}
+
+func TestUpdateAlertDestinations(t *testing.T) {
+	webhookURL := "https://hooks.slack.com/services/T000/B000/XXXX"
+	updated := &godo.AppAlert{
+		ID:            "alert-1",
+		Emails:        []string{"ops@example.com"},
+		SlackWebhooks: []*godo.AppAlertSlackWebhook{{URL: webhookURL, Channel: "#alerts"}},
+	}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mock        func(*MockAppsService)
+		expectError bool
+	}{
+		{
+			name: "Successful update",
+			args: map[string]any{
+				"AppID":         "app-123",
+				"AlertID":       "alert-1",
+				"Emails":        []any{"ops@example.com"},
+				"SlackWebhooks": []any{map[string]any{"URL": webhookURL, "Channel": "#alerts"}},
+			},
+			mock: func(m *MockAppsService) {
+				m.EXPECT().UpdateAlertDestinations(gomock.Any(), "app-123", "alert-1", &godo.AlertDestinationUpdateRequest{
+					Emails:        []string{"ops@example.com"},
+					SlackWebhooks: []*godo.AppAlertSlackWebhook{{URL: webhookURL, Channel: "#alerts"}},
+				}).Return(updated, nil, nil)
+			},
+		},
+		{
+			name: "Invalid email",
+			args: map[string]any{
+				"AppID":   "app-123",
+				"AlertID": "alert-1",
+				"Emails":  []any{"Ops <ops@example.com>"},
+			},
+			expectError: true,
+		},
+		{
+			name: "Invalid Slack webhook URL",
+			args: map[string]any{
+				"AppID":         "app-123",
+				"AlertID":       "alert-1",
+				"SlackWebhooks": []any{map[string]any{"URL": "http://hooks.slack.com/services/T000"}},
+			},
+			expectError: true,
+		},
+		{
+			name:        "Missing AlertID",
+			args:        map[string]any{"AppID": "app-123"},
+			expectError: true,
+		},
+		{
+			name: "API error",
+			args: map[string]any{"AppID": "app-123", "AlertID": "alert-1"},
+			mock: func(m *MockAppsService) {
+				m.EXPECT().UpdateAlertDestinations(gomock.Any(), "app-123", "alert-1", gomock.Any()).Return(nil, nil, errors.New("api error"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, appService := setupMock(t)
+			if tc.mock != nil {
+				tc.mock(appService)
+			}
+			res, err := NewAppAlertsTool(client).updateAlertDestinations(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, res.IsError)
+				return
+			}
+			require.False(t, res.IsError)
+			equalsToolResult(t, updated, res)
+		})
+	}
+}
+
+// setupMetricsTool returns an AppAlertsTool backed by a real godo client pointed at a test server,
+// along with the requests the server received.
+func setupMetricsTool(t *testing.T, handler http.HandlerFunc) (*AppAlertsTool, *[]*http.Request) {
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client := godo.NewClient(srv.Client())
+	client.BaseURL = baseURL
+
+	return NewAppAlertsTool(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	}), &requests
+}
+
+func TestGetMetrics(t *testing.T) {
+	t.Run("CPU usage for a window", func(t *testing.T) {
+		tool, requests := setupMetricsTool(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[]}}`))
+		})
+		res, err := tool.getMetrics(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+			"AppID":     "app-123",
+			"Metric":    "cpu",
+			"Component": "web",
+			"Start":     "2025-01-01T00:00:00Z",
+			"End":       "2025-01-01T01:00:00Z",
+		}}})
+		require.NoError(t, err)
+		require.False(t, res.IsError)
+		require.Len(t, *requests, 1)
+		r := (*requests)[0]
+		require.Equal(t, "/v2/monitoring/metrics/apps/cpu_percentage", r.URL.Path)
+		require.Equal(t, "app-123", r.URL.Query().Get("app_id"))
+		require.Equal(t, "web", r.URL.Query().Get("app_component"))
+		require.Equal(t, "1735689600", r.URL.Query().Get("start"))
+		require.Equal(t, "1735693200", r.URL.Query().Get("end"))
+
+		var metrics godo.MetricsResponse
+		require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &metrics))
+		require.Equal(t, "success", metrics.Status)
+	})
+
+	t.Run("Daily bandwidth for every day of the window", func(t *testing.T) {
+		tool, requests := setupMetricsTool(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"app_bandwidth_usage":[{"app_id":"app-123","bandwidth_bytes":"1024"}]}`))
+		})
+		res, err := tool.getMetrics(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+			"AppID":  "app-123",
+			"Metric": "bandwidth",
+			"Start":  "2025-01-01T12:00:00Z",
+			"End":    "2025-01-02T12:00:00Z",
+		}}})
+		require.NoError(t, err)
+		require.False(t, res.IsError)
+		require.Len(t, *requests, 2)
+		require.Equal(t, "/v2/apps/app-123/metrics/bandwidth_daily", (*requests)[0].URL.Path)
+		require.Equal(t, "2025-01-01T00:00:00Z", (*requests)[0].URL.Query().Get("date"))
+		equalsToolResult(t, []BandwidthUsage{
+			{Date: "2025-01-01", BandwidthBytes: "1024"},
+			{Date: "2025-01-02", BandwidthBytes: "1024"},
+		}, res)
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		tool, requests := setupMetricsTool(t, func(w http.ResponseWriter, r *http.Request) {})
+		for _, args := range []map[string]any{
+			{"Metric": "cpu"},
+			{"AppID": "app-123", "Metric": "disk"},
+			{"AppID": "app-123", "Metric": "cpu", "Start": "yesterday"},
+			{"AppID": "app-123", "Metric": "cpu", "Start": "2025-01-02T00:00:00Z", "End": "2025-01-01T00:00:00Z"},
+			{"AppID": "app-123", "Metric": "bandwidth", "Start": "2025-01-01T00:00:00Z", "End": "2025-03-01T00:00:00Z"},
+		} {
+			res, err := tool.getMetrics(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			require.True(t, res.IsError, args)
+		}
+		require.Empty(t, *requests)
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		tool, _ := setupMetricsTool(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"id":"not_found","message":"app not found"}`))
+		})
+		res, err := tool.getMetrics(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"AppID": "app-123", "Metric": "memory"}}})
+		require.NoError(t, err)
+		require.True(t, res.IsError)
+	})
+}
@@ -1,10 +1,14 @@
 package apps
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"mcp-digitalocean/pkg/response"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/digitalocean/godo"
@@ -67,6 +71,130 @@ func (a *AppPlatformTool) createAppFromAppSpec(ctx context.Context, req mcp.Call
 	return mcp.NewToolResultText(appJSON), nil
 }
 
+// SpecError is a problem found in one component of an app spec.
+type SpecError struct {
+	Component string `json:"component"`
+	Message   string `json:"message"`
+}
+
+// SpecValidation is the result of validating an app spec.
+type SpecValidation struct {
+	Valid    bool                     `json:"valid"`
+	Errors   []SpecError              `json:"errors,omitempty"`
+	Proposal *godo.AppProposeResponse `json:"proposal,omitempty"`
+}
+
+// specComponentKinds maps the app spec fields holding components to the kind reported in errors.
+var specComponentKinds = []struct{ field, kind string }{
+	{"services", "service"},
+	{"static_sites", "static_site"},
+	{"workers", "worker"},
+	{"jobs", "job"},
+	{"functions", "function"},
+}
+
+// specSourceFields are the component fields that set where a component is built or pulled from.
+var specSourceFields = []string{"git", "github", "gitlab", "bitbucket", "image"}
+
+// validateSpecComponents checks the app and each of its components has a name and every component has
+// a source, reporting the problems per component.
+func validateSpecComponents(spec *godo.AppSpec) []SpecError {
+	var errs []SpecError
+	if spec.Name == "" {
+		errs = append(errs, SpecError{Component: "app", Message: "name is required"})
+	}
+
+	// Components are inspected through their JSON form so every component kind is handled alike.
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return append(errs, SpecError{Component: "spec", Message: err.Error()})
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(specJSON, &fields); err != nil {
+		return append(errs, SpecError{Component: "spec", Message: err.Error()})
+	}
+
+	seen := map[string]bool{}
+	for _, k := range specComponentKinds {
+		var components []map[string]any
+		if raw, ok := fields[k.field]; ok {
+			if err := json.Unmarshal(raw, &components); err != nil {
+				errs = append(errs, SpecError{Component: k.field, Message: err.Error()})
+				continue
+			}
+		}
+		for i, c := range components {
+			name, _ := c["name"].(string)
+			label := k.kind + " " + name
+			if name == "" {
+				label = fmt.Sprintf("%s #%d", k.kind, i+1)
+				errs = append(errs, SpecError{Component: label, Message: "name is required"})
+			} else if seen[name] {
+				errs = append(errs, SpecError{Component: label, Message: "name must be unique within the app"})
+			}
+			seen[name] = true
+
+			hasSource := slices.ContainsFunc(specSourceFields, func(field string) bool {
+				return c[field] != nil
+			})
+			if !hasSource {
+				errs = append(errs, SpecError{Component: label, Message: "a source (git, github, gitlab, bitbucket or image) is required"})
+			}
+		}
+	}
+	return errs
+}
+
+// validateAppSpec checks an app spec locally and then asks App Platform to propose it, which validates
+// the spec and estimates its cost without creating an app.
+func (a *AppPlatformTool) validateAppSpec(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	specArg, ok := req.GetArguments()["spec"].(map[string]any)
+	if !ok {
+		return mcp.NewToolResultError("App spec is required"), nil
+	}
+	specBytes, err := json.Marshal(specArg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal app spec for validation: %w", err)
+	}
+
+	// Unknown fields are reported rather than dropped, they are usually misspelled settings.
+	propose := godo.AppProposeRequest{Spec: &godo.AppSpec{}}
+	propose.AppID, _ = req.GetArguments()["app_id"].(string)
+	decoder := json.NewDecoder(bytes.NewReader(specBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(propose.Spec); err != nil {
+		return validationResult(SpecValidation{Errors: []SpecError{{Component: "spec", Message: err.Error()}}})
+	}
+	if errs := validateSpecComponents(propose.Spec); len(errs) > 0 {
+		return validationResult(SpecValidation{Errors: errs})
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	proposal, _, err := client.Apps.Propose(ctx, &propose)
+	if err != nil {
+		var errResp *godo.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil &&
+			(errResp.Response.StatusCode == http.StatusBadRequest || errResp.Response.StatusCode == http.StatusUnprocessableEntity) {
+			return validationResult(SpecValidation{Errors: []SpecError{{Component: "spec", Message: errResp.Message}}})
+		}
+		return mcp.NewToolResultErrorFromErr("failed to validate app spec", err), nil
+	}
+
+	return validationResult(SpecValidation{Valid: true, Proposal: proposal})
+}
+
+func validationResult(validation SpecValidation) (*mcp.CallToolResult, error) {
+	validationJSON, err := response.CompactJSON(validation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec validation: %w", err)
+	}
+	return mcp.NewToolResultText(validationJSON), nil
+}
+
 type AppSummary struct {
 	ID        string          `json:"id"`
 	Name      string          `json:"name"`
@@ -374,6 +502,15 @@ func (a *AppPlatformTool) Tools() []server.ServerTool {
 				appCreateSchemaJSON,
 			),
 		},
+		{
+			Handler: a.validateAppSpec,
+			Tool: mcp.NewTool("apps-validate-spec",
+				mcp.WithDescription("Validates an app spec without creating an app. Returns whether the spec is valid, the problems found per component, and the estimated monthly cost. Use it to iterate on a spec before calling apps-create-app-from-spec or apps-update."),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithObject("spec", mcp.Required(), mcp.Description("The app spec to validate, in the same format as for apps-create-app-from-spec")),
+				mcp.WithString("app_id", mcp.Description("ID of an existing app, when validating a spec update for it")),
+			),
+		},
 		{
 			Handler: a.updateApp,
 			Tool: mcp.NewToolWithRawSchema(
@@ -382,6 +519,14 @@ func (a *AppPlatformTool) Tools() []server.ServerTool {
 				appUpdateSchemaJSON,
 			),
 		},
+		{
+			Handler: a.cancelDeployment,
+			Tool: mcp.NewTool("apps-cancel-deployment",
+				mcp.WithDescription("Cancel an in-progress deployment of an application on DigitalOcean App Platform, e.g. after noticing a bad spec mid-deploy. Only a deployment that is PENDING_BUILD, BUILDING, PENDING_DEPLOY or DEPLOYING can be canceled. Returns the updated deployment."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("DeploymentID", mcp.Required(), mcp.Description("The deployment ID")),
+			),
+		},
 		{
 			Handler: a.getAppLogs,
 			Tool: mcp.NewTool("apps-get-logs",
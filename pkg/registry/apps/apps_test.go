@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/digitalocean/godo"
@@ -646,3 +647,119 @@ func TestGetAppLogs(t *testing.T) {
 	}
 
 }
+
+func TestValidateAppSpec(t *testing.T) {
+	validSpec := map[string]any{
+		"name": "test-app",
+		"services": []any{
+			map[string]any{
+				"name":   "web",
+				"github": map[string]any{"repo": "owner/repo", "branch": "main"},
+			},
+		},
+	}
+	proposal := &godo.AppProposeResponse{AppNameAvailable: true, AppCost: 5}
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		mock         func(app *MockAppsService)
+		expected     SpecValidation
+		expectError  bool
+		errorMessage string
+	}{
+		{
+			name: "Valid spec",
+			args: map[string]any{"spec": validSpec},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Propose(gomock.Any(), &godo.AppProposeRequest{Spec: &godo.AppSpec{
+					Name: "test-app",
+					Services: []*godo.AppServiceSpec{
+						{Name: "web", GitHub: &godo.GitHubSourceSpec{Repo: "owner/repo", Branch: "main"}},
+					},
+				}}).Return(proposal, nil, nil).Times(1)
+			},
+			expected: SpecValidation{Valid: true, Proposal: proposal},
+		},
+		{
+			name: "Component errors are reported without calling the API",
+			args: map[string]any{"spec": map[string]any{
+				"name": "test-app",
+				"services": []any{
+					map[string]any{"name": "web", "github": map[string]any{"repo": "owner/repo"}},
+				},
+				"workers": []any{
+					map[string]any{"name": "web", "image": map[string]any{"registry_type": "DOCR", "repository": "worker"}},
+					map[string]any{},
+				},
+			}},
+			expected: SpecValidation{Errors: []SpecError{
+				{Component: "worker web", Message: "name must be unique within the app"},
+				{Component: "worker #2", Message: "name is required"},
+				{Component: "worker #2", Message: "a source (git, github, gitlab, bitbucket or image) is required"},
+			}},
+		},
+		{
+			name: "Unknown fields are rejected",
+			args: map[string]any{"spec": map[string]any{
+				"name":     "test-app",
+				"services": []any{map[string]any{"name": "web", "instnace_count": 2}},
+			}},
+			expected: SpecValidation{Errors: []SpecError{
+				{Component: "spec", Message: `json: unknown field "instnace_count"`},
+			}},
+		},
+		{
+			name: "API validation error",
+			args: map[string]any{"spec": validSpec},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Propose(gomock.Any(), gomock.Any()).Return(nil, nil, &godo.ErrorResponse{
+					Response: &http.Response{StatusCode: http.StatusBadRequest},
+					Message:  "error validating app spec field \"services.web.instance_size_slug\"",
+				}).Times(1)
+			},
+			expected: SpecValidation{Errors: []SpecError{
+				{Component: "spec", Message: "error validating app spec field \"services.web.instance_size_slug\""},
+			}},
+		},
+		{
+			name: "API failure",
+			args: map[string]any{"spec": validSpec},
+			mock: func(app *MockAppsService) {
+				app.EXPECT().Propose(gomock.Any(), gomock.Any()).Return(nil, nil, fmt.Errorf("connection reset")).Times(1)
+			},
+			expectError: true,
+		},
+		{
+			name:         "Missing spec",
+			args:         map[string]any{},
+			expectError:  true,
+			errorMessage: "App spec is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, appService := setupMock(t)
+			tool := &AppPlatformTool{client: client}
+			if tc.mock != nil {
+				tc.mock(appService)
+			}
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.validateAppSpec(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				if tc.errorMessage != "" {
+					require.Equal(t, tc.errorMessage, resp.Content[0].(mcp.TextContent).Text)
+				}
+				return
+			}
+
+			require.False(t, resp.IsError)
+			equalsToolResult(t, tc.expected, resp)
+		})
+	}
+}
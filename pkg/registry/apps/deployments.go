@@ -0,0 +1,72 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// cancelablePhases are the phases of a deployment that is still building or deploying.
+var cancelablePhases = []godo.DeploymentPhase{
+	godo.DeploymentPhase_PendingBuild,
+	godo.DeploymentPhase_Building,
+	godo.DeploymentPhase_PendingDeploy,
+	godo.DeploymentPhase_Deploying,
+}
+
+// deploymentRoot is the response of the deployment cancel endpoint.
+type deploymentRoot struct {
+	Deployment *godo.Deployment `json:"deployment"`
+}
+
+// cancelDeployment stops a deployment that is still building or deploying. godo doesn't wrap the
+// cancel endpoint, so it is called through the client's generic request methods.
+func (a *AppPlatformTool) cancelDeployment(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	appID := args.RequireString("AppID")
+	deploymentID := args.RequireString("DeploymentID")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	deployment, _, err := client.Apps.GetDeployment(ctx, appID, deploymentID)
+	if err != nil {
+		return common.APIErrorResult(err, "deployment", deploymentID), nil
+	}
+	if !slices.Contains(cancelablePhases, deployment.Phase) {
+		phases := make([]string, len(cancelablePhases))
+		for i, phase := range cancelablePhases {
+			phases[i] = string(phase)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("deployment %s is %s and can't be canceled, only %s deployments can", deploymentID, deployment.Phase, strings.Join(phases, ", "))), nil
+	}
+
+	path := fmt.Sprintf("v2/apps/%s/deployments/%s/cancel", url.PathEscape(appID), url.PathEscape(deploymentID))
+	httpReq, err := client.NewRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build cancel request: %w", err)
+	}
+	root := new(deploymentRoot)
+	if _, err := client.Do(ctx, httpReq, root); err != nil {
+		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to cancel deployment %s of app %s", deploymentID, appID), err), nil
+	}
+
+	jsonData, err := response.CompactJSON(root.Deployment)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
@@ -0,0 +1,91 @@
+package apps
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCancelDeployment(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         map[string]any
+		phase        godo.DeploymentPhase
+		getErr       error
+		expectCancel bool
+		expectError  string
+	}{
+		{
+			name:         "Building deployment is canceled",
+			args:         map[string]any{"AppID": "app-123", "DeploymentID": "dep-1"},
+			phase:        godo.DeploymentPhase_Building,
+			expectCancel: true,
+		},
+		{
+			name:        "Active deployment can't be canceled",
+			args:        map[string]any{"AppID": "app-123", "DeploymentID": "dep-1"},
+			phase:       godo.DeploymentPhase_Active,
+			expectError: "deployment dep-1 is ACTIVE and can't be canceled, only PENDING_BUILD, BUILDING, PENDING_DEPLOY, DEPLOYING deployments can",
+		},
+		{
+			name:        "Deployment not found",
+			args:        map[string]any{"AppID": "app-123", "DeploymentID": "dep-1"},
+			getErr:      &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}},
+			expectError: "resource not found",
+		},
+		{
+			name:        "Missing deployment ID",
+			args:        map[string]any{"AppID": "app-123"},
+			expectError: "argument 'DeploymentID' is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var requests []*http.Request
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"deployment":{"id":"dep-1","phase":"CANCELED"}}`))
+			}))
+			t.Cleanup(srv.Close)
+			baseURL, err := url.Parse(srv.URL + "/")
+			require.NoError(t, err)
+
+			appService := NewMockAppsService(gomock.NewController(t))
+			if tc.phase != "" || tc.getErr != nil {
+				var deployment *godo.Deployment
+				if tc.getErr == nil {
+					deployment = &godo.Deployment{ID: "dep-1", Phase: tc.phase}
+				}
+				appService.EXPECT().GetDeployment(gomock.Any(), "app-123", "dep-1").Return(deployment, nil, tc.getErr)
+			}
+			client := godo.NewClient(srv.Client())
+			client.BaseURL = baseURL
+			client.Apps = appService
+			tool, err := NewAppPlatformTool(func(ctx context.Context) (*godo.Client, error) { return client, nil })
+			require.NoError(t, err)
+
+			res, err := tool.cancelDeployment(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expectError)
+				require.Empty(t, requests)
+				return
+			}
+			require.False(t, res.IsError)
+			require.Len(t, requests, 1)
+			require.Equal(t, http.MethodPost, requests[0].Method)
+			require.Equal(t, "/v2/apps/app-123/deployments/dep-1/cancel", requests[0].URL.Path)
+			equalsToolResult(t, godo.Deployment{ID: "dep-1", Phase: godo.DeploymentPhase_Canceled}, res)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// appDomainTypes are the domain types that can be added to an app. The DEFAULT domain is the
+// ondigitalocean.app one managed by App Platform.
+var appDomainTypes = []string{string(godo.AppDomainSpecType_Primary), string(godo.AppDomainSpecType_Alias)}
+
+// hostnamePattern matches a fully qualified host name: dot-separated labels of letters, digits
+// and inner hyphens, ending with an alphabetic top-level label.
+var hostnamePattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+// pendingDomainPhase is reported for domains App Platform has not started to configure yet, which
+// have no phase or an UNKNOWN one.
+const pendingDomainPhase = "PENDING"
+
+// AppDomain is a custom domain of an app with its verification status as returned by the domain tools.
+type AppDomain struct {
+	Domain      string `json:"domain"`
+	Type        string `json:"type"`
+	Wildcard    bool   `json:"wildcard,omitempty"`
+	Zone        string `json:"zone,omitempty"`
+	Certificate string `json:"certificate,omitempty"`
+	// Phase is the verification and certificate provisioning status of the domain: PENDING,
+	// CONFIGURING, ACTIVE or ERROR.
+	Phase string `json:"phase"`
+}
+
+// appDomains returns the domains of the app's spec, each with the phase App Platform reports for it.
+func appDomains(app *godo.App) []AppDomain {
+	phases := map[string]string{}
+	for _, d := range app.Domains {
+		if d != nil && d.Spec != nil {
+			phases[d.Spec.Domain] = string(d.Phase)
+		}
+	}
+	out := []AppDomain{}
+	if app.Spec == nil {
+		return out
+	}
+	for _, d := range app.Spec.Domains {
+		domain := AppDomain{
+			Domain:      d.Domain,
+			Type:        string(d.Type),
+			Wildcard:    d.Wildcard,
+			Zone:        d.Zone,
+			Certificate: d.Certificate,
+			Phase:       phases[d.Domain],
+		}
+		if domain.Phase == "" || domain.Phase == "UNKNOWN" {
+			domain.Phase = pendingDomainPhase
+		}
+		out = append(out, domain)
+	}
+	return out
+}
+
+// validateHostname checks that domain is a host name App Platform can serve.
+func validateHostname(domain string) error {
+	if len(domain) > 253 || !hostnamePattern.MatchString(domain) {
+		return fmt.Errorf("invalid domain %q: must be a fully qualified host name such as www.example.com", domain)
+	}
+	return nil
+}
+
+// checkPrimaryDomain rejects a domain list with more than one PRIMARY domain.
+func checkPrimaryDomain(domains []*godo.AppDomainSpec) error {
+	var primaries []string
+	for _, d := range domains {
+		if d.Type == godo.AppDomainSpecType_Primary {
+			primaries = append(primaries, d.Domain)
+		}
+	}
+	if len(primaries) > 1 {
+		return fmt.Errorf("an app can have only one PRIMARY domain, the change would leave %s: add the domain as ALIAS or remove the current primary domain first", strings.Join(primaries, ", "))
+	}
+	return nil
+}
+
+// getAppWithSpec fetches an app, which must have a spec to patch.
+func getAppWithSpec(ctx context.Context, client *godo.Client, appID string) (*godo.App, *mcp.CallToolResult) {
+	app, _, err := client.Apps.Get(ctx, appID)
+	if err != nil {
+		return nil, mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to get app %s", appID), err)
+	}
+	if app.Spec == nil {
+		return nil, mcp.NewToolResultError(fmt.Sprintf("app %s has no spec", appID))
+	}
+	return app, nil
+}
+
+// appDomainsResult returns the domains of app as the tool result.
+func appDomainsResult(app *godo.App) (*mcp.CallToolResult, error) {
+	jsonData, err := response.CompactJSON(map[string]any{
+		"app_id":  app.ID,
+		"domains": appDomains(app),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// updateAppDomains submits the patched spec of app and returns its resulting domains.
+func updateAppDomains(ctx context.Context, client *godo.Client, app *godo.App) (*mcp.CallToolResult, error) {
+	updated, _, err := client.Apps.Update(ctx, app.ID, &godo.AppUpdateRequest{Spec: app.Spec})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to update app %s", app.ID), err), nil
+	}
+	if updated == nil || updated.Spec == nil {
+		updated = app
+	}
+	return appDomainsResult(updated)
+}
+
+// listDomains lists the custom domains of an app with their verification status.
+func (a *AppPlatformTool) listDomains(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	appID := args.RequireString("AppID")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	app, errResult := getAppWithSpec(ctx, client, appID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	return appDomainsResult(app)
+}
+
+// addDomain adds a custom domain to the spec of an app and updates the app. App Platform then
+// verifies the domain and provisions its certificate, unless one is given.
+func (a *AppPlatformTool) addDomain(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	appID := args.RequireString("AppID")
+	domain := strings.ToLower(strings.TrimSuffix(args.RequireString("Domain"), "."))
+	domainType := args.OptionalEnum("Type", string(godo.AppDomainSpecType_Alias), appDomainTypes...)
+	wildcard := args.OptionalBool("Wildcard", false)
+	zone := strings.ToLower(strings.TrimSuffix(args.OptionalString("Zone", ""), "."))
+	certificate := args.OptionalString("Certificate", "")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := validateHostname(domain); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if zone != "" && domain != zone && !strings.HasSuffix(domain, "."+zone) {
+		return mcp.NewToolResultError(fmt.Sprintf("domain %s is not in zone %s", domain, zone)), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	app, errResult := getAppWithSpec(ctx, client, appID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	if slices.ContainsFunc(app.Spec.Domains, func(d *godo.AppDomainSpec) bool { return strings.EqualFold(d.Domain, domain) }) {
+		return mcp.NewToolResultError(fmt.Sprintf("app %s already has domain %s", appID, domain)), nil
+	}
+	domains := append(slices.Clone(app.Spec.Domains), &godo.AppDomainSpec{
+		Domain:      domain,
+		Type:        godo.AppDomainSpecType(domainType),
+		Wildcard:    wildcard,
+		Zone:        zone,
+		Certificate: certificate,
+	})
+	if err := checkPrimaryDomain(domains); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	app.Spec.Domains = domains
+
+	return updateAppDomains(ctx, client, app)
+}
+
+// removeDomain removes a custom domain from the spec of an app and updates the app.
+func (a *AppPlatformTool) removeDomain(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	appID := args.RequireString("AppID")
+	domain := strings.TrimSuffix(args.RequireString("Domain"), ".")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	app, errResult := getAppWithSpec(ctx, client, appID)
+	if errResult != nil {
+		return errResult, nil
+	}
+	i := slices.IndexFunc(app.Spec.Domains, func(d *godo.AppDomainSpec) bool { return strings.EqualFold(d.Domain, domain) })
+	if i < 0 {
+		names := make([]string, 0, len(app.Spec.Domains))
+		for _, d := range app.Spec.Domains {
+			names = append(names, d.Domain)
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("app %s has no domain %s, its domains are: %s", appID, domain, strings.Join(names, ", "))), nil
+	}
+	if app.Spec.Domains[i].Type == godo.AppDomainSpecType_Default {
+		return mcp.NewToolResultError(fmt.Sprintf("%s is the default domain of app %s, which App Platform manages", domain, appID)), nil
+	}
+	app.Spec.Domains = slices.Delete(slices.Clone(app.Spec.Domains), i, i+1)
+
+	return updateAppDomains(ctx, client, app)
+}
+
+// DomainTools returns the tools managing the custom domains of apps.
+func (a *AppPlatformTool) DomainTools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: a.listDomains,
+			Tool: mcp.NewTool("apps-list-domains",
+				mcp.WithDescription("List the domains of an app with their type and verification status (phase)."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+			),
+		},
+		{
+			Handler: a.addDomain,
+			Tool: mcp.NewTool("apps-add-domain",
+				mcp.WithDescription("Add a custom domain to an app. This updates the app spec, which triggers a deployment, after which App Platform verifies the domain and provisions its certificate. An app has at most one PRIMARY domain. Returns the app's domains with their verification status."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("Domain", mcp.Required(), mcp.Description("Host name of the domain, e.g. www.example.com")),
+				mcp.WithString("Type", mcp.DefaultString(string(godo.AppDomainSpecType_Alias)), mcp.Enum(appDomainTypes...), mcp.Description("PRIMARY for the domain the app is served on, or ALIAS")),
+				mcp.WithBoolean("Wildcard", mcp.DefaultBool(false), mcp.Description("Also serve every subdomain of the domain")),
+				mcp.WithString("Zone", mcp.Description("DigitalOcean DNS domain holding the domain, e.g. example.com, to have App Platform manage its records")),
+				mcp.WithString("Certificate", mcp.Description("Name of a certificate to use instead of the one App Platform provisions")),
+			),
+		},
+		{
+			Handler: a.removeDomain,
+			Tool: mcp.NewTool("apps-remove-domain",
+				mcp.WithDescription("Remove a custom domain from an app. This updates the app spec, which triggers a deployment. Returns the app's remaining domains."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("Domain", mcp.Required(), mcp.Description("Host name of the domain to remove")),
+			),
+		},
+	}
+}
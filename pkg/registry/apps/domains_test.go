@@ -0,0 +1,138 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func testDomainApp() *godo.App {
+	return &godo.App{
+		ID: "app-123",
+		Spec: &godo.AppSpec{
+			Name: "shop",
+			Domains: []*godo.AppDomainSpec{
+				{Domain: "shop-abcde.ondigitalocean.app", Type: godo.AppDomainSpecType_Default},
+				{Domain: "shop.example.com", Type: godo.AppDomainSpecType_Primary, Zone: "example.com"},
+				{Domain: "www.shop.example.com", Type: godo.AppDomainSpecType_Alias},
+			},
+		},
+		Domains: []*godo.AppDomain{
+			{ID: "d-1", Spec: &godo.AppDomainSpec{Domain: "shop.example.com"}, Phase: "ACTIVE"},
+			{ID: "d-2", Spec: &godo.AppDomainSpec{Domain: "www.shop.example.com"}, Phase: "CONFIGURING"},
+		},
+	}
+}
+
+func domainsOf(t *testing.T, res *mcp.CallToolResult) []AppDomain {
+	t.Helper()
+	require.False(t, res.IsError, res.Content[0].(mcp.TextContent).Text)
+	var out struct {
+		AppID   string      `json:"app_id"`
+		Domains []AppDomain `json:"domains"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &out))
+	require.Equal(t, "app-123", out.AppID)
+	return out.Domains
+}
+
+func TestListDomains(t *testing.T) {
+	client, appService := setupMock(t)
+	tool := &AppPlatformTool{client: client}
+	appService.EXPECT().Get(gomock.Any(), "app-123").Return(testDomainApp(), nil, nil)
+
+	res := callEnvTool(t, tool.listDomains, map[string]any{"AppID": "app-123"})
+	require.Equal(t, []AppDomain{
+		{Domain: "shop-abcde.ondigitalocean.app", Type: "DEFAULT", Phase: "PENDING"},
+		{Domain: "shop.example.com", Type: "PRIMARY", Zone: "example.com", Phase: "ACTIVE"},
+		{Domain: "www.shop.example.com", Type: "ALIAS", Phase: "CONFIGURING"},
+	}, domainsOf(t, res))
+}
+
+func TestAddDomain(t *testing.T) {
+	client, appService := setupMock(t)
+	tool := &AppPlatformTool{client: client}
+	appService.EXPECT().Get(gomock.Any(), "app-123").Return(testDomainApp(), nil, nil)
+	appService.EXPECT().Update(gomock.Any(), "app-123", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, req *godo.AppUpdateRequest) (*godo.App, *godo.Response, error) {
+			require.Len(t, req.Spec.Domains, 4)
+			require.Equal(t, &godo.AppDomainSpec{Domain: "api.example.com", Type: godo.AppDomainSpecType_Alias, Certificate: "api-cert"}, req.Spec.Domains[3])
+			return &godo.App{ID: "app-123", Spec: req.Spec}, nil, nil
+		})
+
+	res := callEnvTool(t, tool.addDomain, map[string]any{"AppID": "app-123", "Domain": "API.example.com.", "Certificate": "api-cert"})
+	domains := domainsOf(t, res)
+	require.Len(t, domains, 4)
+	require.Equal(t, AppDomain{Domain: "api.example.com", Type: "ALIAS", Certificate: "api-cert", Phase: "PENDING"}, domains[3])
+}
+
+func TestAddDomainErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		fetchesApp  bool
+		expectError string
+	}{
+		{name: "Second primary", args: map[string]any{"AppID": "app-123", "Domain": "example.org", "Type": "PRIMARY"}, fetchesApp: true, expectError: "only one PRIMARY domain, the change would leave shop.example.com, example.org"},
+		{name: "Existing domain", args: map[string]any{"AppID": "app-123", "Domain": "www.shop.example.com"}, fetchesApp: true, expectError: "already has domain www.shop.example.com"},
+		{name: "Not a host name", args: map[string]any{"AppID": "app-123", "Domain": "https://example.com"}, expectError: "invalid domain"},
+		{name: "Single label", args: map[string]any{"AppID": "app-123", "Domain": "localhost"}, expectError: "invalid domain"},
+		{name: "Leading hyphen", args: map[string]any{"AppID": "app-123", "Domain": "-api.example.com"}, expectError: "invalid domain"},
+		{name: "Outside zone", args: map[string]any{"AppID": "app-123", "Domain": "api.example.org", "Zone": "example.com"}, expectError: "not in zone example.com"},
+		{name: "Invalid type", args: map[string]any{"AppID": "app-123", "Domain": "api.example.com", "Type": "DEFAULT"}, expectError: "Type"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, appService := setupMock(t)
+			tool := &AppPlatformTool{client: client}
+			if tc.fetchesApp {
+				appService.EXPECT().Get(gomock.Any(), "app-123").Return(testDomainApp(), nil, nil)
+			}
+
+			res := callEnvTool(t, tool.addDomain, tc.args)
+			require.True(t, res.IsError)
+			require.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expectError)
+		})
+	}
+}
+
+func TestRemoveDomain(t *testing.T) {
+	client, appService := setupMock(t)
+	tool := &AppPlatformTool{client: client}
+	appService.EXPECT().Get(gomock.Any(), "app-123").Return(testDomainApp(), nil, nil)
+	appService.EXPECT().Update(gomock.Any(), "app-123", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, req *godo.AppUpdateRequest) (*godo.App, *godo.Response, error) {
+			return &godo.App{ID: "app-123", Spec: req.Spec}, nil, nil
+		})
+
+	res := callEnvTool(t, tool.removeDomain, map[string]any{"AppID": "app-123", "Domain": "www.shop.example.com"})
+	domains := domainsOf(t, res)
+	require.Len(t, domains, 2)
+	require.Equal(t, "shop.example.com", domains[1].Domain)
+}
+
+func TestRemoveDomainErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		domain      string
+		expectError string
+	}{
+		"Unknown domain": {domain: "api.example.com", expectError: "its domains are: shop-abcde.ondigitalocean.app, shop.example.com, www.shop.example.com"},
+		"Default domain": {domain: "shop-abcde.ondigitalocean.app", expectError: "default domain of app app-123"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client, appService := setupMock(t)
+			tool := &AppPlatformTool{client: client}
+			appService.EXPECT().Get(gomock.Any(), "app-123").Return(testDomainApp(), nil, nil)
+
+			res := callEnvTool(t, tool.removeDomain, map[string]any{"AppID": "app-123", "Domain": tc.domain})
+			require.True(t, res.IsError)
+			require.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expectError)
+		})
+	}
+}
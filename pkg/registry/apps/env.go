@@ -0,0 +1,248 @@
+package apps
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maskedSecret replaces the value of secret environment variables in tool results.
+const maskedSecret = "********"
+
+var (
+	envTypes  = []string{string(godo.AppVariableType_General), string(godo.AppVariableType_Secret)}
+	envScopes = []string{string(godo.AppVariableScope_RunAndBuildTime), string(godo.AppVariableScope_RunTime), string(godo.AppVariableScope_BuildTime)}
+)
+
+// EnvVar is an environment variable of an app component as returned by the env tools. The value of
+// a secret is masked.
+type EnvVar struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Type  string `json:"type"`
+	Scope string `json:"scope,omitempty"`
+}
+
+// componentEnvs returns the environment variables of the named component of the spec and the names
+// of all components, the envs being nil when no component has that name.
+func componentEnvs(spec *godo.AppSpec, component string) (*[]*godo.AppVariableDefinition, []string) {
+	var (
+		envs  *[]*godo.AppVariableDefinition
+		names []string
+	)
+	match := func(name string, e *[]*godo.AppVariableDefinition) {
+		names = append(names, name)
+		if name == component {
+			envs = e
+		}
+	}
+	for _, c := range spec.Services {
+		match(c.Name, &c.Envs)
+	}
+	for _, c := range spec.StaticSites {
+		match(c.Name, &c.Envs)
+	}
+	for _, c := range spec.Workers {
+		match(c.Name, &c.Envs)
+	}
+	for _, c := range spec.Jobs {
+		match(c.Name, &c.Envs)
+	}
+	for _, c := range spec.Functions {
+		match(c.Name, &c.Envs)
+	}
+	return envs, names
+}
+
+// maskEnvs converts env definitions to EnvVars, masking secrets.
+func maskEnvs(envs []*godo.AppVariableDefinition) []EnvVar {
+	out := make([]EnvVar, 0, len(envs))
+	for _, e := range envs {
+		v := EnvVar{Key: e.Key, Value: e.Value, Type: string(e.Type), Scope: string(e.Scope)}
+		if v.Type == "" {
+			v.Type = string(godo.AppVariableType_General)
+		}
+		if e.Type == godo.AppVariableType_Secret {
+			v.Value = maskedSecret
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// getAppComponentEnvs fetches an app and the environment variables of one of its components.
+func getAppComponentEnvs(ctx context.Context, client *godo.Client, appID, component string) (*godo.App, *[]*godo.AppVariableDefinition, *mcp.CallToolResult) {
+	app, _, err := client.Apps.Get(ctx, appID)
+	if err != nil {
+		return nil, nil, mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to get app %s", appID), err)
+	}
+	if app.Spec == nil {
+		return nil, nil, mcp.NewToolResultError(fmt.Sprintf("app %s has no spec", appID))
+	}
+	envs, names := componentEnvs(app.Spec, component)
+	if envs == nil {
+		return nil, nil, mcp.NewToolResultError(fmt.Sprintf("app %s has no component %q, its components are: %s", appID, component, strings.Join(names, ", ")))
+	}
+	return app, envs, nil
+}
+
+// updateAppEnvs submits the patched spec of app and returns the component's resulting variables.
+func updateAppEnvs(ctx context.Context, client *godo.Client, app *godo.App, component string) (*mcp.CallToolResult, error) {
+	updated, _, err := client.Apps.Update(ctx, app.ID, &godo.AppUpdateRequest{Spec: app.Spec})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to update app %s", app.ID), err), nil
+	}
+	spec := app.Spec
+	if updated != nil && updated.Spec != nil {
+		spec = updated.Spec
+	}
+	var envs []*godo.AppVariableDefinition
+	if e, _ := componentEnvs(spec, component); e != nil {
+		envs = *e
+	}
+
+	jsonData, err := response.CompactJSON(map[string]any{
+		"app_id":    app.ID,
+		"component": component,
+		"envs":      maskEnvs(envs),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// getEnv lists the environment variables of an app component, masking secrets.
+func (a *AppPlatformTool) getEnv(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	appID := args.RequireString("AppID")
+	component := args.RequireString("Component")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	_, envs, errResult := getAppComponentEnvs(ctx, client, appID, component)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	jsonData, err := response.CompactJSON(maskEnvs(*envs))
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// setEnv adds or replaces an environment variable of an app component and updates the app, which
+// triggers a deployment.
+func (a *AppPlatformTool) setEnv(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	appID := args.RequireString("AppID")
+	component := args.RequireString("Component")
+	key := args.RequireString("Key")
+	value := args.OptionalString("Value", "")
+	envType := args.OptionalEnum("Type", string(godo.AppVariableType_General), envTypes...)
+	scope := args.OptionalEnum("Scope", string(godo.AppVariableScope_RunAndBuildTime), envScopes...)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	app, envs, errResult := getAppComponentEnvs(ctx, client, appID, component)
+	if errResult != nil {
+		return errResult, nil
+	}
+	env := &godo.AppVariableDefinition{
+		Key:   key,
+		Value: value,
+		Type:  godo.AppVariableType(envType),
+		Scope: godo.AppVariableScope(scope),
+	}
+	if i := slices.IndexFunc(*envs, func(e *godo.AppVariableDefinition) bool { return e.Key == key }); i >= 0 {
+		(*envs)[i] = env
+	} else {
+		*envs = append(*envs, env)
+	}
+
+	return updateAppEnvs(ctx, client, app, component)
+}
+
+// deleteEnv removes an environment variable from an app component and updates the app, which
+// triggers a deployment.
+func (a *AppPlatformTool) deleteEnv(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	appID := args.RequireString("AppID")
+	component := args.RequireString("Component")
+	key := args.RequireString("Key")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	app, envs, errResult := getAppComponentEnvs(ctx, client, appID, component)
+	if errResult != nil {
+		return errResult, nil
+	}
+	i := slices.IndexFunc(*envs, func(e *godo.AppVariableDefinition) bool { return e.Key == key })
+	if i < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("component %q has no environment variable %q", component, key)), nil
+	}
+	*envs = slices.Delete(*envs, i, i+1)
+
+	return updateAppEnvs(ctx, client, app, component)
+}
+
+// EnvTools returns the tools managing the environment variables of app components.
+func (a *AppPlatformTool) EnvTools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: a.getEnv,
+			Tool: mcp.NewTool("apps-get-env",
+				mcp.WithDescription("List the environment variables of an app component. Secret values are masked."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("Component", mcp.Required(), mcp.Description("Name of the service, worker, job, static site or function")),
+			),
+		},
+		{
+			Handler: a.setEnv,
+			Tool: mcp.NewTool("apps-set-env",
+				mcp.WithDescription("Add or replace an environment variable of an app component. This updates the app spec, which triggers a deployment. Returns the component's variables with secret values masked."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("Component", mcp.Required(), mcp.Description("Name of the service, worker, job, static site or function")),
+				mcp.WithString("Key", mcp.Required(), mcp.Description("Name of the variable")),
+				mcp.WithString("Value", mcp.Required(), mcp.Description("Value of the variable")),
+				mcp.WithString("Type", mcp.DefaultString(string(godo.AppVariableType_General)), mcp.Enum(envTypes...), mcp.Description("GENERAL, or SECRET to store the value encrypted")),
+				mcp.WithString("Scope", mcp.DefaultString(string(godo.AppVariableScope_RunAndBuildTime)), mcp.Enum(envScopes...), mcp.Description("When the variable is available")),
+			),
+		},
+		{
+			Handler: a.deleteEnv,
+			Tool: mcp.NewTool("apps-delete-env",
+				mcp.WithDescription("Remove an environment variable from an app component. This updates the app spec, which triggers a deployment."),
+				mcp.WithString("AppID", mcp.Required(), mcp.Description("The application ID")),
+				mcp.WithString("Component", mcp.Required(), mcp.Description("Name of the service, worker, job, static site or function")),
+				mcp.WithString("Key", mcp.Required(), mcp.Description("Name of the variable to remove")),
+			),
+		},
+	}
+}
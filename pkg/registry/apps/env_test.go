@@ -0,0 +1,155 @@
+package apps
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func testEnvApp() *godo.App {
+	return &godo.App{
+		ID: "app-123",
+		Spec: &godo.AppSpec{
+			Name: "shop",
+			Services: []*godo.AppServiceSpec{{
+				Name: "web",
+				Envs: []*godo.AppVariableDefinition{
+					{Key: "LOG_LEVEL", Value: "info", Type: godo.AppVariableType_General, Scope: godo.AppVariableScope_RunTime},
+					{Key: "DB_PASSWORD", Value: "EV[1:abc:def]", Type: godo.AppVariableType_Secret},
+				},
+			}},
+			Workers: []*godo.AppWorkerSpec{{Name: "queue"}},
+		},
+	}
+}
+
+func callEnvTool(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	res, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	return res
+}
+
+func TestGetEnv(t *testing.T) {
+	client, appService := setupMock(t)
+	tool := &AppPlatformTool{client: client}
+	appService.EXPECT().Get(gomock.Any(), "app-123").Return(testEnvApp(), nil, nil)
+
+	res := callEnvTool(t, tool.getEnv, map[string]any{"AppID": "app-123", "Component": "web"})
+	require.False(t, res.IsError)
+	var envs []EnvVar
+	require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &envs))
+	require.Equal(t, []EnvVar{
+		{Key: "LOG_LEVEL", Value: "info", Type: "GENERAL", Scope: "RUN_TIME"},
+		{Key: "DB_PASSWORD", Value: maskedSecret, Type: "SECRET"},
+	}, envs)
+	require.NotContains(t, res.Content[0].(mcp.TextContent).Text, "EV[1:abc:def]")
+}
+
+func TestGetEnvUnknownComponent(t *testing.T) {
+	client, appService := setupMock(t)
+	tool := &AppPlatformTool{client: client}
+	appService.EXPECT().Get(gomock.Any(), "app-123").Return(testEnvApp(), nil, nil)
+
+	res := callEnvTool(t, tool.getEnv, map[string]any{"AppID": "app-123", "Component": "api"})
+	require.True(t, res.IsError)
+	require.Contains(t, res.Content[0].(mcp.TextContent).Text, "its components are: web, queue")
+}
+
+func TestSetEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     map[string]any
+		expected []*godo.AppVariableDefinition
+	}{
+		{
+			name: "Replace variable",
+			args: map[string]any{"AppID": "app-123", "Component": "web", "Key": "LOG_LEVEL", "Value": "debug"},
+			expected: []*godo.AppVariableDefinition{
+				{Key: "LOG_LEVEL", Value: "debug", Type: godo.AppVariableType_General, Scope: godo.AppVariableScope_RunAndBuildTime},
+				{Key: "DB_PASSWORD", Value: "EV[1:abc:def]", Type: godo.AppVariableType_Secret},
+			},
+		},
+		{
+			name: "Add secret",
+			args: map[string]any{"AppID": "app-123", "Component": "web", "Key": "API_KEY", "Value": "s3cr3t", "Type": "SECRET", "Scope": "RUN_TIME"},
+			expected: []*godo.AppVariableDefinition{
+				{Key: "LOG_LEVEL", Value: "info", Type: godo.AppVariableType_General, Scope: godo.AppVariableScope_RunTime},
+				{Key: "DB_PASSWORD", Value: "EV[1:abc:def]", Type: godo.AppVariableType_Secret},
+				{Key: "API_KEY", Value: "s3cr3t", Type: godo.AppVariableType_Secret, Scope: godo.AppVariableScope_RunTime},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client, appService := setupMock(t)
+			tool := &AppPlatformTool{client: client}
+			appService.EXPECT().Get(gomock.Any(), "app-123").Return(testEnvApp(), nil, nil)
+			appService.EXPECT().Update(gomock.Any(), "app-123", gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, req *godo.AppUpdateRequest) (*godo.App, *godo.Response, error) {
+					require.Equal(t, tc.expected, req.Spec.Services[0].Envs)
+					return &godo.App{ID: "app-123", Spec: req.Spec}, nil, nil
+				})
+
+			res := callEnvTool(t, tool.setEnv, tc.args)
+			require.False(t, res.IsError)
+			text := res.Content[0].(mcp.TextContent).Text
+			require.NotContains(t, text, "s3cr3t")
+			require.NotContains(t, text, "EV[1:abc:def]")
+			require.Contains(t, text, `"component":"web"`)
+		})
+	}
+}
+
+func TestSetEnvInvalidArgs(t *testing.T) {
+	client, _ := setupMock(t)
+	tool := &AppPlatformTool{client: client}
+
+	res := callEnvTool(t, tool.setEnv, map[string]any{"AppID": "app-123", "Component": "web", "Key": "A", "Value": "b", "Type": "PLAIN"})
+	require.True(t, res.IsError)
+	res = callEnvTool(t, tool.setEnv, map[string]any{"AppID": "app-123", "Component": "web"})
+	require.True(t, res.IsError)
+}
+
+func TestDeleteEnv(t *testing.T) {
+	client, appService := setupMock(t)
+	tool := &AppPlatformTool{client: client}
+	appService.EXPECT().Get(gomock.Any(), "app-123").Return(testEnvApp(), nil, nil)
+	appService.EXPECT().Update(gomock.Any(), "app-123", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, req *godo.AppUpdateRequest) (*godo.App, *godo.Response, error) {
+			require.Len(t, req.Spec.Services[0].Envs, 1)
+			require.Equal(t, "DB_PASSWORD", req.Spec.Services[0].Envs[0].Key)
+			return &godo.App{ID: "app-123", Spec: req.Spec}, nil, nil
+		})
+
+	res := callEnvTool(t, tool.deleteEnv, map[string]any{"AppID": "app-123", "Component": "web", "Key": "LOG_LEVEL"})
+	require.False(t, res.IsError)
+}
+
+func TestDeleteEnvErrors(t *testing.T) {
+	t.Run("Missing key", func(t *testing.T) {
+		client, appService := setupMock(t)
+		tool := &AppPlatformTool{client: client}
+		appService.EXPECT().Get(gomock.Any(), "app-123").Return(testEnvApp(), nil, nil)
+
+		res := callEnvTool(t, tool.deleteEnv, map[string]any{"AppID": "app-123", "Component": "queue", "Key": "LOG_LEVEL"})
+		require.True(t, res.IsError)
+	})
+	t.Run("Update error", func(t *testing.T) {
+		client, appService := setupMock(t)
+		tool := &AppPlatformTool{client: client}
+		appService.EXPECT().Get(gomock.Any(), "app-123").Return(testEnvApp(), nil, nil)
+		appService.EXPECT().Update(gomock.Any(), "app-123", gomock.Any()).Return(nil, nil, errors.New("conflict"))
+
+		res := callEnvTool(t, tool.deleteEnv, map[string]any{"AppID": "app-123", "Component": "web", "Key": "LOG_LEVEL"})
+		require.True(t, res.IsError)
+	})
+}
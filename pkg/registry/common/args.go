@@ -0,0 +1,243 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Args reads typed tool arguments and records the first missing or invalid one, so a handler can
+// read all its arguments and check once, before any API call:
+//
+//	args := common.NewArgs(req)
+//	name := args.RequireString("Name")
+//	size := args.OptionalInt("Size", 10)
+//	if err := args.Err(); err != nil {
+//		return mcp.NewToolResultError(err.Error()), nil
+//	}
+//
+// Once an argument is invalid, the getters return zero values.
+type Args struct {
+	values map[string]any
+	err    error
+}
+
+// NewArgs returns an Args reading the arguments of req.
+func NewArgs(req mcp.CallToolRequest) *Args {
+	return &Args{values: req.GetArguments()}
+}
+
+// Err returns the error describing the first missing or invalid argument, if any.
+func (a *Args) Err() error {
+	return a.err
+}
+
+func (a *Args) fail(format string, v ...any) {
+	if a.err == nil {
+		a.err = fmt.Errorf(format, v...)
+	}
+}
+
+// lookup returns the value of an argument, treating null as absent.
+func (a *Args) lookup(name string) (any, bool) {
+	if a.err != nil {
+		return nil, false
+	}
+	v, ok := a.values[name]
+	return v, ok && v != nil
+}
+
+// RequireString returns a string argument that must be set and not blank.
+func (a *Args) RequireString(name string) string {
+	v, ok := a.lookup(name)
+	if !ok {
+		a.fail("argument '%s' is required", name)
+		return ""
+	}
+	s, isString := v.(string)
+	if !isString {
+		a.fail("argument '%s' must be a string", name)
+		return ""
+	}
+	if strings.TrimSpace(s) == "" {
+		a.fail("argument '%s' is required", name)
+		return ""
+	}
+	return s
+}
+
+// OptionalString returns a string argument, or def when it is not set.
+func (a *Args) OptionalString(name, def string) string {
+	v, ok := a.lookup(name)
+	if !ok {
+		return def
+	}
+	s, isString := v.(string)
+	if !isString {
+		a.fail("argument '%s' must be a string", name)
+		return ""
+	}
+	return s
+}
+
+// RequireEnum returns a string argument that must be one of allowed.
+func (a *Args) RequireEnum(name string, allowed ...string) string {
+	s := a.RequireString(name)
+	if a.err == nil && !slices.Contains(allowed, s) {
+		a.fail("argument '%s' must be one of: %s", name, strings.Join(allowed, ", "))
+		return ""
+	}
+	return s
+}
+
+// OptionalEnum returns a string argument that must be one of allowed when set, or def when it is not set.
+func (a *Args) OptionalEnum(name, def string, allowed ...string) string {
+	if _, ok := a.lookup(name); !ok {
+		return def
+	}
+	return a.RequireEnum(name, allowed...)
+}
+
+// toInt converts a JSON number to an int, rejecting fractions.
+func (a *Args) toInt(name string, v any) int {
+	f, isNumber := v.(float64)
+	if !isNumber {
+		a.fail("argument '%s' must be a number", name)
+		return 0
+	}
+	if f != math.Trunc(f) || math.Abs(f) > 1<<53 {
+		a.fail("argument '%s' must be a whole number", name)
+		return 0
+	}
+	return int(f)
+}
+
+// RequireInt returns a whole number argument that must be set.
+func (a *Args) RequireInt(name string) int {
+	v, ok := a.lookup(name)
+	if !ok {
+		a.fail("argument '%s' is required", name)
+		return 0
+	}
+	return a.toInt(name, v)
+}
+
+// OptionalInt returns a whole number argument, or def when it is not set.
+func (a *Args) OptionalInt(name string, def int) int {
+	v, ok := a.lookup(name)
+	if !ok {
+		return def
+	}
+	return a.toInt(name, v)
+}
+
+// OptionalBool returns a boolean argument, or def when it is not set.
+func (a *Args) OptionalBool(name string, def bool) bool {
+	v, ok := a.lookup(name)
+	if !ok {
+		return def
+	}
+	b, isBool := v.(bool)
+	if !isBool {
+		a.fail("argument '%s' must be a boolean", name)
+		return false
+	}
+	return b
+}
+
+// OptionalStrings returns a string array argument, or nil when it is not set.
+func (a *Args) OptionalStrings(name string) []string {
+	v, ok := a.lookup(name)
+	if !ok {
+		return nil
+	}
+	items, isArray := v.([]any)
+	if !isArray {
+		a.fail("argument '%s' must be an array of strings", name)
+		return nil
+	}
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		s, isString := item.(string)
+		if !isString {
+			a.fail("argument '%s' must be an array of strings", name)
+			return nil
+		}
+		values = append(values, s)
+	}
+	return values
+}
+
+// OptionalArray returns an array argument of any item type, or nil when it is not set.
+func (a *Args) OptionalArray(name string) []any {
+	v, ok := a.lookup(name)
+	if !ok {
+		return nil
+	}
+	items, isArray := v.([]any)
+	if !isArray {
+		a.fail("argument '%s' must be an array", name)
+		return nil
+	}
+	return items
+}
+
+// PreferPrivateArg is the argument of tools returning network addresses that selects the VPC-private
+// address of a resource over its public one when it has both.
+const PreferPrivateArg = "prefer_private"
+
+// WithPreferPrivate declares the prefer_private argument with a uniform description.
+func WithPreferPrivate() mcp.ToolOption {
+	return mcp.WithBoolean(PreferPrivateArg, mcp.DefaultBool(false), mcp.Description("Return the VPC-private address instead of the public one when the resource has both"))
+}
+
+// PreferPrivate returns the prefer_private argument.
+func (a *Args) PreferPrivate() bool {
+	return a.OptionalBool(PreferPrivateArg, false)
+}
+
+// PollIntervalArg is the argument of wait tools that sets the time between status checks, in seconds.
+const PollIntervalArg = "PollIntervalSeconds"
+
+// WithPollInterval declares the PollIntervalSeconds argument, defaulting to def.
+func WithPollInterval(def time.Duration) mcp.ToolOption {
+	return mcp.WithNumber(PollIntervalArg, mcp.DefaultNumber(def.Seconds()), mcp.Min(1), mcp.Description("Time between status checks in seconds, at least 1"))
+}
+
+// PollInterval returns the PollIntervalSeconds argument, or def when it is not set. Intervals below
+// one second are rejected so a wait can't hammer the API.
+func (a *Args) PollInterval(def time.Duration) time.Duration {
+	seconds := a.OptionalInt(PollIntervalArg, int(def.Seconds()))
+	if seconds < 1 {
+		a.fail("argument '%s' must be at least 1", PollIntervalArg)
+		return def
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// TimezoneArg is the argument of tools returning timestamps that selects the IANA time zone they are
+// expressed in, instead of the UTC returned by the API.
+const TimezoneArg = "timezone"
+
+// WithTimezone declares the timezone argument with a uniform description.
+func WithTimezone() mcp.ToolOption {
+	return mcp.WithString(TimezoneArg, mcp.Description("IANA time zone to express timestamps in, e.g. Europe/Paris (default: UTC as returned by the API)"))
+}
+
+// Timezone returns the location named by the timezone argument, or nil when it is not set.
+func (a *Args) Timezone() *time.Location {
+	name := a.OptionalString(TimezoneArg, "")
+	if name == "" {
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "Local" {
+		a.fail("argument '%s' must be an IANA time zone name such as Europe/Paris, got %q", TimezoneArg, name)
+		return nil
+	}
+	return loc
+}
@@ -0,0 +1,76 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestArgs(values map[string]any) *Args {
+	return NewArgs(mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: values}})
+}
+
+func TestArgs(t *testing.T) {
+	t.Run("reads valid arguments", func(t *testing.T) {
+		args := newTestArgs(map[string]any{
+			"Name":   "web-1",
+			"Count":  float64(3),
+			"Backup": true,
+			"Tags":   []any{"a", "b"},
+			"Plan":   "daily",
+			"Note":   nil,
+		})
+		require.Equal(t, "web-1", args.RequireString("Name"))
+		require.Equal(t, 3, args.RequireInt("Count"))
+		require.Equal(t, 20, args.OptionalInt("PerPage", 20))
+		require.True(t, args.OptionalBool("Backup", false))
+		require.Equal(t, []string{"a", "b"}, args.OptionalStrings("Tags"))
+		require.Equal(t, "daily", args.RequireEnum("Plan", "daily", "weekly"))
+		require.Equal(t, "weekly", args.OptionalEnum("Schedule", "weekly", "daily", "weekly"))
+		require.Equal(t, "none", args.OptionalString("Note", "none"))
+		require.NoError(t, args.Err())
+	})
+
+	t.Run("reads the timezone", func(t *testing.T) {
+		require.Nil(t, newTestArgs(map[string]any{}).Timezone())
+		args := newTestArgs(map[string]any{"timezone": "Asia/Tokyo"})
+		require.Equal(t, "Asia/Tokyo", args.Timezone().String())
+		require.NoError(t, args.Err())
+	})
+
+	t.Run("reads the poll interval", func(t *testing.T) {
+		require.Equal(t, 5*time.Second, newTestArgs(map[string]any{}).PollInterval(5*time.Second))
+		args := newTestArgs(map[string]any{"PollIntervalSeconds": float64(2)})
+		require.Equal(t, 2*time.Second, args.PollInterval(5*time.Second))
+		require.NoError(t, args.Err())
+	})
+
+	tests := []struct {
+		name   string
+		values map[string]any
+		read   func(*Args)
+		expect string
+	}{
+		{"missing string", map[string]any{}, func(a *Args) { a.RequireString("region") }, "argument 'region' is required"},
+		{"blank string", map[string]any{"region": " "}, func(a *Args) { a.RequireString("region") }, "argument 'region' is required"},
+		{"string of wrong type", map[string]any{"region": float64(1)}, func(a *Args) { a.RequireString("region") }, "argument 'region' must be a string"},
+		{"fractional int", map[string]any{"ID": 1.5}, func(a *Args) { a.RequireInt("ID") }, "argument 'ID' must be a whole number"},
+		{"int of wrong type", map[string]any{"ID": "1"}, func(a *Args) { a.OptionalInt("ID", 0) }, "argument 'ID' must be a number"},
+		{"bool of wrong type", map[string]any{"Pretty": "yes"}, func(a *Args) { a.OptionalBool("Pretty", false) }, "argument 'Pretty' must be a boolean"},
+		{"enum outside the allowed set", map[string]any{"Plan": "hourly"}, func(a *Args) { a.RequireEnum("Plan", "daily", "weekly") }, "argument 'Plan' must be one of: daily, weekly"},
+		{"array with wrong items", map[string]any{"Tags": []any{"a", float64(1)}}, func(a *Args) { a.OptionalStrings("Tags") }, "argument 'Tags' must be an array of strings"},
+		{"unknown time zone", map[string]any{"timezone": "Mars/Olympus"}, func(a *Args) { a.Timezone() }, `argument 'timezone' must be an IANA time zone name such as Europe/Paris, got "Mars/Olympus"`},
+		{"poll interval below a second", map[string]any{"PollIntervalSeconds": float64(0)}, func(a *Args) { a.PollInterval(time.Second) }, "argument 'PollIntervalSeconds' must be at least 1"},
+		{"fractional poll interval", map[string]any{"PollIntervalSeconds": 0.01}, func(a *Args) { a.PollInterval(time.Second) }, "argument 'PollIntervalSeconds' must be a whole number"},
+		{"first error wins", map[string]any{}, func(a *Args) { a.RequireString("Name"); a.RequireInt("ID") }, "argument 'Name' is required"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args := newTestArgs(tc.values)
+			tc.read(args)
+			require.EqualError(t, args.Err(), tc.expect)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	middleware "mcp-digitalocean/internal"
+)
+
+// DefaultAuthContext is the name of the auth context holding the main API token.
+const DefaultAuthContext = "default"
+
+// AuthContexts holds named API tokens, like the auth contexts of doctl, and the one in use. A
+// DigitalOcean token is bound to the team it was created in, so working with several teams means
+// switching between their tokens. It is safe for concurrent use.
+type AuthContexts struct {
+	mu     sync.RWMutex
+	tokens map[string]string
+	active string
+}
+
+// NewAuthContexts returns the auth contexts holding tokens, keyed by context name, with active in use.
+func NewAuthContexts(tokens map[string]string, active string) (*AuthContexts, error) {
+	if _, ok := tokens[active]; !ok {
+		return nil, fmt.Errorf("unknown auth context %q", active)
+	}
+	return &AuthContexts{tokens: maps.Clone(tokens), active: active}, nil
+}
+
+// Names returns the names of the auth contexts, sorted.
+func (c *AuthContexts) Names() []string {
+	return slices.Sorted(maps.Keys(c.tokens))
+}
+
+// Active returns the name of the auth context in use.
+func (c *AuthContexts) Active() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.active
+}
+
+// Use makes name the auth context of the calls that follow.
+func (c *AuthContexts) Use(name string) error {
+	if _, ok := c.tokens[name]; !ok {
+		return fmt.Errorf("unknown auth context %q, configured contexts are %s", name, strings.Join(c.Names(), ", "))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.active = name
+	return nil
+}
+
+// WithContextToken returns ctx carrying the token of the named auth context the way the HTTP
+// transport carries the Authorization header, so clients are built and memoized per context.
+func (c *AuthContexts) WithContextToken(ctx context.Context, name string) (context.Context, error) {
+	token, ok := c.tokens[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown auth context %q, configured contexts are %s", name, strings.Join(c.Names(), ", "))
+	}
+	return middleware.WithAuthKey(ctx, "Bearer "+token), nil
+}
+
+// WithActiveToken is like WithContextToken for the auth context in use.
+func (c *AuthContexts) WithActiveToken(ctx context.Context) context.Context {
+	ctx, _ = c.WithContextToken(ctx, c.Active())
+	return ctx
+}
+
+// ReadAuthContexts parses comma-separated name=path pairs, such as "staging=/run/secrets/staging",
+// and returns the token read from each file keyed by context name. Names must be unique and must not
+// be DefaultAuthContext, which is reserved for the main token.
+func ReadAuthContexts(spec string) (map[string]string, error) {
+	tokens := map[string]string{}
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, path, ok := strings.Cut(pair, "=")
+		name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+		if !ok || name == "" || path == "" {
+			return nil, fmt.Errorf("invalid auth context %q, must be name=token-file", pair)
+		}
+		if name == DefaultAuthContext {
+			return nil, fmt.Errorf("auth context name %q is reserved for the main token", name)
+		}
+		if _, dup := tokens[name]; dup {
+			return nil, fmt.Errorf("duplicate auth context %q", name)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file of auth context %q: %w", name, err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return nil, fmt.Errorf("token file %s of auth context %q is empty", path, name)
+		}
+		tokens[name] = token
+	}
+	return tokens, nil
+}
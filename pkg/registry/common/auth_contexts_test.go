@@ -0,0 +1,94 @@
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	middleware "mcp-digitalocean/internal"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthContexts(t *testing.T) {
+	contexts, err := NewAuthContexts(map[string]string{DefaultAuthContext: "main", "staging": "staging-token"}, DefaultAuthContext)
+	require.NoError(t, err)
+	require.Equal(t, []string{DefaultAuthContext, "staging"}, contexts.Names())
+	require.Equal(t, DefaultAuthContext, contexts.Active())
+	require.Equal(t, "Bearer main", contexts.WithActiveToken(context.Background()).Value(middleware.AuthKey{}))
+
+	require.NoError(t, contexts.Use("staging"))
+	require.Equal(t, "staging", contexts.Active())
+	require.Equal(t, "Bearer staging-token", contexts.WithActiveToken(context.Background()).Value(middleware.AuthKey{}))
+
+	err = contexts.Use("prod")
+	require.ErrorContains(t, err, `unknown auth context "prod", configured contexts are default, staging`)
+	require.Equal(t, "staging", contexts.Active())
+
+	_, err = contexts.WithContextToken(context.Background(), "prod")
+	require.Error(t, err)
+
+	_, err = NewAuthContexts(map[string]string{"staging": "staging-token"}, DefaultAuthContext)
+	require.Error(t, err)
+}
+
+func TestReadAuthContexts(t *testing.T) {
+	dir := t.TempDir()
+	staging := filepath.Join(dir, "staging")
+	require.NoError(t, os.WriteFile(staging, []byte("staging-token\n"), 0o600))
+	prod := filepath.Join(dir, "prod")
+	require.NoError(t, os.WriteFile(prod, []byte("prod-token"), 0o600))
+	empty := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(empty, []byte(" \n"), 0o600))
+
+	tests := []struct {
+		name         string
+		spec         string
+		expectTokens map[string]string
+		expectError  string
+	}{
+		{
+			name:         "Pairs",
+			spec:         " staging = " + staging + ", prod=" + prod + ",",
+			expectTokens: map[string]string{"staging": "staging-token", "prod": "prod-token"},
+		},
+		{
+			name:        "Missing file",
+			spec:        "staging",
+			expectError: `invalid auth context "staging", must be name=token-file`,
+		},
+		{
+			name:        "Reserved name",
+			spec:        DefaultAuthContext + "=" + staging,
+			expectError: "reserved for the main token",
+		},
+		{
+			name:        "Duplicate name",
+			spec:        "staging=" + staging + ",staging=" + prod,
+			expectError: `duplicate auth context "staging"`,
+		},
+		{
+			name:        "Unreadable file",
+			spec:        "staging=" + filepath.Join(dir, "missing"),
+			expectError: `failed to read token file of auth context "staging"`,
+		},
+		{
+			name:        "Empty file",
+			spec:        "staging=" + empty,
+			expectError: "is empty",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tokens, err := ReadAuthContexts(tc.spec)
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectTokens, tokens)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolInfo describes a tool registered with the MCP server.
+type ToolInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Service     string `json:"service"`
+	Category    string `json:"category"`
+}
+
+// CatalogTool provides a tool listing the tools enabled on this server.
+type CatalogTool struct {
+	tools func() []ToolInfo
+}
+
+// NewCatalogTool creates a new CatalogTool reporting the tools returned by tools.
+func NewCatalogTool(tools func() []ToolInfo) *CatalogTool {
+	return &CatalogTool{tools: tools}
+}
+
+// listEnabledTools lists the registered tools, optionally filtered by service and category.
+func (c *CatalogTool) listEnabledTools(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	service, _ := req.GetArguments()["Service"].(string)
+	category, _ := req.GetArguments()["Category"].(string)
+
+	tools := []ToolInfo{}
+	for _, tool := range c.tools() {
+		if (service == "" || tool.Service == service) && (category == "" || tool.Category == category) {
+			tools = append(tools, tool)
+		}
+	}
+	slices.SortFunc(tools, func(a, b ToolInfo) int {
+		return cmp.Or(cmp.Compare(a.Service, b.Service), cmp.Compare(a.Category, b.Category), cmp.Compare(a.Name, b.Name))
+	})
+
+	jsonData, err := response.CompactJSON(tools)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// Tools returns the list of server tools for the tool catalog.
+func (c *CatalogTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: c.listEnabledTools,
+			Tool: mcp.NewTool(
+				"list-enabled-tools",
+				mcp.WithDescription("List the tools enabled on this server with the service and category each belongs to. Use it to discover what is available under the current service selection."),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("Service", mcp.Description("Only list tools of this service (e.g., droplets)")),
+				mcp.WithString("Category", mcp.Description("Only list tools of this category (e.g., actions)")),
+			),
+		},
+	}
+}
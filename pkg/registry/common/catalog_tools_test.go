@@ -0,0 +1,64 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogTool_listEnabledTools(t *testing.T) {
+	enabled := []ToolInfo{
+		{Name: "droplet-reboot", Description: "Reboot", Service: "droplets", Category: "actions"},
+		{Name: "droplet-create", Description: "Create", Service: "droplets", Category: "basic"},
+		{Name: "action-get", Description: "Get action", Service: "accounts", Category: "actions"},
+		{Name: "region-list", Description: "List regions", Service: "common", Category: "regions"},
+	}
+	tool := NewCatalogTool(func() []ToolInfo { return enabled })
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectNames []string
+	}{
+		{
+			name:        "All tools sorted by service, category and name",
+			args:        map[string]any{},
+			expectNames: []string{"action-get", "region-list", "droplet-reboot", "droplet-create"},
+		},
+		{
+			name:        "Filter by service",
+			args:        map[string]any{"Service": "droplets"},
+			expectNames: []string{"droplet-reboot", "droplet-create"},
+		},
+		{
+			name:        "Filter by category",
+			args:        map[string]any{"Category": "actions"},
+			expectNames: []string{"action-get", "droplet-reboot"},
+		},
+		{
+			name:        "No match",
+			args:        map[string]any{"Service": "doks"},
+			expectNames: []string{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.listEnabledTools(context.Background(), req)
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+
+			var out []ToolInfo
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			names := []string{}
+			for _, info := range out {
+				names = append(names, info.Name)
+			}
+			require.Equal(t, tc.expectNames, names)
+		})
+	}
+}
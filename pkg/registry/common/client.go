@@ -0,0 +1,116 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/hashicorp/go-retryablehttp"
+	"golang.org/x/oauth2"
+)
+
+// Version is the version of the MCP server, reported in the default user agent.
+const Version = "1.0.30"
+
+// DefaultUserAgent is the user agent sent to the DigitalOcean API when ClientConfig.UserAgent is empty.
+const DefaultUserAgent = "mcp-digitalocean/" + Version
+
+// proxySchemes are the proxy URL schemes supported by net/http.
+var proxySchemes = []string{"http", "https", "socks5"}
+
+// ClientConfig tunes the HTTP client used to reach the DigitalOcean API.
+type ClientConfig struct {
+	// Timeout bounds each HTTP attempt made to the API. Zero means no timeout.
+	Timeout time.Duration
+	// ProxyURL routes API traffic through a proxy, e.g. http://proxy.internal:3128. When empty, the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables are honored.
+	ProxyURL string
+	// UserAgent is sent with every request, defaults to DefaultUserAgent.
+	UserAgent string
+	// InsecureSkipVerify disables TLS certificate verification. Only use it to debug a
+	// TLS-intercepting proxy.
+	InsecureSkipVerify bool
+}
+
+// Validate checks that the timeout is not negative and that the proxy URL, if any, is an absolute
+// URL with a supported scheme.
+func (c ClientConfig) Validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("timeout must not be negative")
+	}
+	if c.ProxyURL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.ProxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	if !slices.Contains(proxySchemes, u.Scheme) || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q, must be an absolute %s URL", c.ProxyURL, strings.Join(proxySchemes, ", "))
+	}
+	return nil
+}
+
+// HTTPClient returns an *http.Client honoring the timeout, proxy and TLS settings of the config.
+func (c ClientConfig) HTTPClient() (*http.Client, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if c.ProxyURL != "" {
+		proxyURL, _ := url.Parse(c.ProxyURL)
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if c.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	return &http.Client{Transport: transport, Timeout: c.Timeout}, nil
+}
+
+// NewGodoClient creates a godo client authenticated with token, sending requests to endpoint through an
+// HTTP client built from the config.
+func NewGodoClient(ctx context.Context, token, endpoint string, config ClientConfig) (*godo.Client, error) {
+	httpClient, err := config.HTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cleanToken := strings.Trim(strings.TrimSpace(token), "'")
+	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: cleanToken})
+	oauthClient := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, httpClient), ts)
+	oauthClient.Timeout = httpClient.Timeout
+
+	userAgent := config.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	retry := godo.RetryConfig{
+		RetryMax:     4,
+		RetryWaitMin: godo.PtrTo(float64(1)),
+		RetryWaitMax: godo.PtrTo(float64(30)),
+	}
+
+	client, err := godo.New(oauthClient,
+		godo.WithRetryAndBackoffs(retry),
+		godo.SetBaseURL(endpoint),
+		godo.SetUserAgent(userAgent))
+	if err != nil {
+		return nil, err
+	}
+	// With retries enabled, godo replaces the HTTP client with a retrying one using its own transport.
+	// Send the retried requests through the configured transport, so the proxy and TLS settings hold.
+	if t, ok := client.HTTPClient.Transport.(*oauth2.Transport); ok {
+		if rt, ok := t.Base.(*retryablehttp.RoundTripper); ok && rt.Client != nil {
+			rt.Client.HTTPClient.Transport = httpClient.Transport
+		}
+	}
+	return client, nil
+}
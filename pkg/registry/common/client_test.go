@@ -0,0 +1,97 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      ClientConfig
+		expectError string
+	}{
+		{name: "Empty config", config: ClientConfig{}},
+		{name: "HTTP proxy", config: ClientConfig{ProxyURL: "http://proxy.internal:3128", Timeout: time.Minute}},
+		{name: "SOCKS proxy", config: ClientConfig{ProxyURL: "socks5://127.0.0.1:1080"}},
+		{name: "Negative timeout", config: ClientConfig{Timeout: -time.Second}, expectError: "timeout must not be negative"},
+		{name: "Proxy without scheme", config: ClientConfig{ProxyURL: "proxy.internal:3128"}, expectError: "invalid proxy URL"},
+		{name: "Unsupported proxy scheme", config: ClientConfig{ProxyURL: "ftp://proxy.internal"}, expectError: "invalid proxy URL"},
+		{name: "Relative proxy URL", config: ClientConfig{ProxyURL: "/proxy"}, expectError: "invalid proxy URL"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClientConfig_HTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	t.Cleanup(srv.Close)
+
+	httpClient, err := ClientConfig{Timeout: 50 * time.Millisecond, InsecureSkipVerify: true}.HTTPClient()
+	require.NoError(t, err)
+	require.True(t, httpClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify)
+
+	_, err = httpClient.Get(srv.URL)
+	require.ErrorContains(t, err, "Client.Timeout exceeded")
+}
+
+func TestNewGodoClient(t *testing.T) {
+	t.Run("Routes requests through the proxy with the default user agent", func(t *testing.T) {
+		var proxied *http.Request
+		proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = r
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"account":{"email":"user@example.com"}}`))
+		}))
+		t.Cleanup(proxy.Close)
+
+		client, err := NewGodoClient(context.Background(), " 'dop_v1_token' ", "http://api.digitalocean.invalid", ClientConfig{ProxyURL: proxy.URL})
+		require.NoError(t, err)
+
+		account, _, err := client.Account.Get(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "user@example.com", account.Email)
+		require.NotNil(t, proxied)
+		require.Equal(t, "api.digitalocean.invalid", proxied.Host)
+		require.Equal(t, "Bearer dop_v1_token", proxied.Header.Get("Authorization"))
+		require.Contains(t, proxied.Header.Get("User-Agent"), DefaultUserAgent)
+	})
+
+	t.Run("Custom user agent", func(t *testing.T) {
+		var userAgent string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userAgent = r.Header.Get("User-Agent")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"account":{}}`))
+		}))
+		t.Cleanup(srv.Close)
+
+		client, err := NewGodoClient(context.Background(), "token", srv.URL, ClientConfig{UserAgent: "acme-agent/2.0"})
+		require.NoError(t, err)
+		_, _, err = client.Account.Get(context.Background())
+		require.NoError(t, err)
+		require.Contains(t, userAgent, "acme-agent/2.0")
+		require.NotContains(t, userAgent, DefaultUserAgent)
+	})
+
+	t.Run("Invalid config", func(t *testing.T) {
+		_, err := NewGodoClient(context.Background(), "token", "https://api.digitalocean.com", ClientConfig{ProxyURL: "not a url"})
+		require.ErrorContains(t, err, "invalid proxy URL")
+	})
+}
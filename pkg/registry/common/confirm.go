@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"mcp-digitalocean/pkg/dryrun"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ConfirmTokenArg is the argument carrying the confirmation token of the resource a destructive tool
+// acts on, required in strict confirmation mode.
+const ConfirmTokenArg = "confirm_token"
+
+// confirmTokenLength is the number of hex characters of a confirmation token.
+const confirmTokenLength = 12
+
+// confirmKey keys the confirmation tokens. It is drawn when the process starts, so a token can only
+// be obtained from this server and stops being valid when it restarts.
+var confirmKey = newConfirmKey()
+
+func newConfirmKey() []byte {
+	key := make([]byte, 32)
+	// crypto/rand.Read never fails, it crashes the program when no randomness is available.
+	_, _ = rand.Read(key)
+	return key
+}
+
+// ConfirmToken returns the confirmation token of a resource, a short keyed hash of its ID or name.
+func ConfirmToken(resourceID string) string {
+	mac := hmac.New(sha256.New, confirmKey)
+	mac.Write([]byte(resourceID))
+	return hex.EncodeToString(mac.Sum(nil))[:confirmTokenLength]
+}
+
+// ValidConfirmToken reports whether token is the confirmation token of a resource.
+func ValidConfirmToken(resourceID, token string) bool {
+	return hmac.Equal([]byte(token), []byte(ConfirmToken(resourceID)))
+}
+
+type strictConfirmKey struct{}
+
+// WithStrictConfirm returns a context in which destructive tools require a confirmation token.
+func WithStrictConfirm(ctx context.Context) context.Context {
+	return context.WithValue(ctx, strictConfirmKey{}, true)
+}
+
+// StrictConfirm reports whether destructive tools require a confirmation token in ctx.
+func StrictConfirm(ctx context.Context) bool {
+	strict, _ := ctx.Value(strictConfirmKey{}).(bool)
+	return strict
+}
+
+// RequireConfirmToken checks the confirmation of a destructive call on the resource with the given
+// ID or name. It returns nil when the call may go on: strict confirmation is off, the call carries
+// the resource's token, or the call is a dry run, whose preview then returns the token. Otherwise
+// it returns the error result telling how to get the token.
+func RequireConfirmToken(ctx context.Context, args map[string]any, tool, resourceID string) *mcp.CallToolResult {
+	if !StrictConfirm(ctx) {
+		return nil
+	}
+	if rec := dryrun.FromContext(ctx); rec != nil {
+		rec.SetConfirmToken(ConfirmToken(resourceID))
+		return nil
+	}
+	token, _ := args[ConfirmTokenArg].(string)
+	switch {
+	case token == "":
+		return mcp.NewToolResultError(fmt.Sprintf("%s requires the %s of %s: make the same call with dry_run set to get it", tool, ConfirmTokenArg, resourceID))
+	case !ValidConfirmToken(resourceID, token):
+		return mcp.NewToolResultError(fmt.Sprintf("%s does not match %s: make the same call with dry_run set to get its token", ConfirmTokenArg, resourceID))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"mcp-digitalocean/pkg/dryrun"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmToken(t *testing.T) {
+	token := ConfirmToken("db-1")
+	require.Len(t, token, confirmTokenLength)
+	require.Equal(t, token, ConfirmToken("db-1"))
+	require.NotEqual(t, token, ConfirmToken("db-2"))
+	require.True(t, ValidConfirmToken("db-1", token))
+	require.False(t, ValidConfirmToken("db-2", token))
+	require.False(t, ValidConfirmToken("db-1", ""))
+}
+
+func TestRequireConfirmToken(t *testing.T) {
+	strict := WithStrictConfirm(context.Background())
+	token := ConfirmToken("db-1")
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		args        map[string]any
+		expectError string
+	}{
+		{name: "Not strict", ctx: context.Background(), args: map[string]any{}},
+		{name: "Matching token", ctx: strict, args: map[string]any{ConfirmTokenArg: token}},
+		{name: "Missing token", ctx: strict, args: map[string]any{}, expectError: "db-cluster-delete requires the confirm_token of db-1"},
+		{name: "Token of another resource", ctx: strict, args: map[string]any{ConfirmTokenArg: ConfirmToken("db-2")}, expectError: "confirm_token does not match db-1"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			res := RequireConfirmToken(tc.ctx, tc.args, "db-cluster-delete", "db-1")
+			if tc.expectError == "" {
+				require.Nil(t, res)
+				return
+			}
+			require.NotNil(t, res)
+			require.True(t, res.IsError)
+			require.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expectError)
+		})
+	}
+
+	t.Run("Dry run records the token", func(t *testing.T) {
+		ctx, rec := dryrun.WithRecorder(strict)
+		require.Nil(t, RequireConfirmToken(ctx, map[string]any{}, "db-cluster-delete", "db-1"))
+		require.Equal(t, token, rec.ConfirmToken())
+	})
+}
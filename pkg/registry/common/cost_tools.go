@@ -0,0 +1,238 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// hoursPerMonth is the number of hours after which DigitalOcean stops billing hourly usage in a month.
+const hoursPerMonth = 672
+
+// costItemTypes are the resource types estimate-cost can price.
+var costItemTypes = []string{"droplet", "database", "app"}
+
+// CostLineItem is the estimated cost of one item of an estimate-cost request.
+type CostLineItem struct {
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Quantity    int     `json:"quantity"`
+	UnitMonthly float64 `json:"unit_monthly"`
+	UnitHourly  float64 `json:"unit_hourly"`
+	Monthly     float64 `json:"monthly"`
+	Hourly      float64 `json:"hourly"`
+	Priced      bool    `json:"priced"`
+	Note        string  `json:"note,omitempty"`
+}
+
+// CostEstimate is the breakdown and total returned by estimate-cost, in USD. Incomplete is set when
+// some items could not be priced and are left out of the totals.
+type CostEstimate struct {
+	Items        []CostLineItem `json:"items"`
+	TotalMonthly float64        `json:"total_monthly"`
+	TotalHourly  float64        `json:"total_hourly"`
+	Incomplete   bool           `json:"incomplete,omitempty"`
+}
+
+// costItem is an item of an estimate-cost request.
+type costItem struct {
+	Type   string        `json:"Type"`
+	Size   string        `json:"Size"`
+	Count  int           `json:"Count"`
+	Engine string        `json:"Engine"`
+	Nodes  int           `json:"Nodes"`
+	Spec   *godo.AppSpec `json:"Spec"`
+}
+
+// CostTool provides a tool estimating the cost of resources before they are created.
+type CostTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewCostTool creates a new CostTool instance.
+func NewCostTool(client func(ctx context.Context) (*godo.Client, error)) *CostTool {
+	return &CostTool{client: client}
+}
+
+// parseCostItems decodes and validates the Items argument.
+func parseCostItems(args map[string]any) ([]costItem, error) {
+	values, _ := args["Items"].([]any)
+	if len(values) == 0 {
+		return nil, fmt.Errorf("at least one item is required")
+	}
+	items := make([]costItem, len(values))
+	for i, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i+1, err)
+		}
+		if err := json.Unmarshal(data, &items[i]); err != nil {
+			return nil, fmt.Errorf("item %d: invalid definition: %w", i+1, err)
+		}
+		item := &items[i]
+		if item.Count == 0 {
+			item.Count = 1
+		}
+		switch item.Type {
+		case "droplet":
+			if item.Size == "" {
+				return nil, fmt.Errorf("item %d: Size is required for a droplet", i+1)
+			}
+		case "database":
+			if item.Size == "" || item.Engine == "" {
+				return nil, fmt.Errorf("item %d: Engine and Size are required for a database", i+1)
+			}
+			if item.Nodes == 0 {
+				item.Nodes = 1
+			}
+		case "app":
+			if item.Spec == nil {
+				return nil, fmt.Errorf("item %d: Spec is required for an app", i+1)
+			}
+		default:
+			return nil, fmt.Errorf("item %d: invalid type %q, must be one of: %s", i+1, item.Type, strings.Join(costItemTypes, ", "))
+		}
+		if item.Count < 0 || item.Nodes < 0 {
+			return nil, fmt.Errorf("item %d: Count and Nodes must be positive", i+1)
+		}
+	}
+	return items, nil
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+func roundHourly(v float64) float64 {
+	return math.Round(v*1e5) / 1e5
+}
+
+// priced fills the unit and total costs of a line item.
+func (l CostLineItem) priced(unitMonthly, unitHourly float64) CostLineItem {
+	l.Priced = true
+	l.UnitMonthly = roundCents(unitMonthly)
+	l.UnitHourly = roundHourly(unitHourly)
+	l.Monthly = roundCents(unitMonthly * float64(l.Quantity))
+	l.Hourly = roundHourly(unitHourly * float64(l.Quantity))
+	return l
+}
+
+// estimateCost prices every item and sums the totals. Droplets are priced from the size list, apps
+// from the App Platform proposal. Database sizes and layouts are validated against the database
+// options, which carry no prices, so database items are reported unpriced.
+func (c *CostTool) estimateCost(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	items, err := parseCostItems(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	var (
+		sizes     []godo.Size
+		dbOptions *godo.DatabaseOptions
+	)
+	estimate := CostEstimate{Items: []CostLineItem{}}
+	for i, item := range items {
+		line := CostLineItem{Type: item.Type, Quantity: item.Count}
+		switch item.Type {
+		case "droplet":
+			if sizes == nil {
+				if sizes, err = ListAllSizes(ctx, client); err != nil {
+					return mcp.NewToolResultErrorFromErr("api error", err), nil
+				}
+			}
+			j := slices.IndexFunc(sizes, func(s godo.Size) bool { return s.Slug == item.Size })
+			if j < 0 {
+				return mcp.NewToolResultError(fmt.Sprintf("item %d: unknown droplet size slug: %s", i+1, item.Size)), nil
+			}
+			line.Description = fmt.Sprintf("droplet %s", item.Size)
+			line = line.priced(sizes[j].PriceMonthly, sizes[j].PriceHourly)
+		case "database":
+			if dbOptions == nil {
+				if dbOptions, _, err = client.Databases.ListOptions(ctx); err != nil {
+					return mcp.NewToolResultErrorFromErr("api error", err), nil
+				}
+			}
+			if err := validateDatabaseLayout(dbOptions, item); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("item %d: %s", i+1, err)), nil
+			}
+			line.Description = fmt.Sprintf("%s database %s with %d node(s)", item.Engine, item.Size, item.Nodes)
+			line.Note = "the DigitalOcean API doesn't publish database prices, see https://www.digitalocean.com/pricing/managed-databases"
+		case "app":
+			proposal, _, err := client.Apps.Propose(ctx, &godo.AppProposeRequest{Spec: item.Spec})
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr(fmt.Sprintf("item %d: failed to estimate the app cost", i+1), err), nil
+			}
+			line.Description = fmt.Sprintf("app %s", item.Spec.Name)
+			line = line.priced(float64(proposal.AppCost), float64(proposal.AppCost)/hoursPerMonth)
+		}
+
+		if line.Priced {
+			estimate.TotalMonthly += line.Monthly
+			estimate.TotalHourly += line.Hourly
+		} else {
+			estimate.Incomplete = true
+		}
+		estimate.Items = append(estimate.Items, line)
+	}
+	estimate.TotalMonthly = roundCents(estimate.TotalMonthly)
+	estimate.TotalHourly = roundHourly(estimate.TotalHourly)
+
+	jsonData, err := response.CompactJSON(estimate)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// validateDatabaseLayout checks that the engine offers the size with the requested number of nodes.
+func validateDatabaseLayout(options *godo.DatabaseOptions, item costItem) error {
+	engine, err := databaseEngineOptions(options, item.Engine)
+	if err != nil {
+		return err
+	}
+	for _, layout := range engine.Layouts {
+		if layout.NodeNum == item.Nodes && slices.Contains(layout.Sizes, item.Size) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s doesn't offer size %s with %d node(s)", item.Engine, item.Size, item.Nodes)
+}
+
+// Tools returns the list of server tools for cost estimation.
+func (c *CostTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: c.estimateCost,
+			Tool: mcp.NewTool(
+				"estimate-cost",
+				mcp.WithDescription("Estimate the monthly and hourly cost, in USD, of droplets, databases and apps before creating them. Returns a line item per resource and the totals."),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithArray("Items", mcp.Required(), mcp.Description("Resources to price"), mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"Type":   map[string]any{"type": "string", "enum": costItemTypes, "description": "Resource type"},
+						"Size":   map[string]any{"type": "string", "description": "Size slug of a droplet (e.g. s-1vcpu-1gb) or database (e.g. db-s-1vcpu-1gb)"},
+						"Count":  map[string]any{"type": "number", "description": "Number of resources (default 1)"},
+						"Engine": map[string]any{"type": "string", "description": "Database engine (pg, mysql, redis, valkey, mongodb, kafka, opensearch)"},
+						"Nodes":  map[string]any{"type": "number", "description": "Number of database nodes (default 1)"},
+						"Spec":   map[string]any{"type": "object", "description": "App spec, in the same format as for apps-create-app-from-spec"},
+					},
+					"required": []string{"Type"},
+				})),
+			),
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCostTool(t *testing.T) *CostTool {
+	responses := map[string]string{
+		"/v2/sizes":             `{"sizes":[{"slug":"s-1vcpu-1gb","price_monthly":6,"price_hourly":0.00893},{"slug":"s-2vcpu-4gb","price_monthly":24,"price_hourly":0.03571}]}`,
+		"/v2/databases/options": `{"options":{"pg":{"layouts":[{"num_nodes":1,"sizes":["db-s-1vcpu-1gb"]},{"num_nodes":2,"sizes":["db-s-1vcpu-2gb"]}]}}}`,
+		"/v2/apps/propose":      `{"app_name_available":true,"app_cost":12}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(responses[r.URL.Path]))
+	}))
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client := godo.NewClient(srv.Client())
+	client.BaseURL = baseURL
+
+	return NewCostTool(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	})
+}
+
+func TestCostTool_estimateCost(t *testing.T) {
+	tests := []struct {
+		name        string
+		items       []any
+		expected    CostEstimate
+		expectError string
+	}{
+		{
+			name: "Droplets and app",
+			items: []any{
+				map[string]any{"Type": "droplet", "Size": "s-2vcpu-4gb", "Count": float64(3)},
+				map[string]any{"Type": "droplet", "Size": "s-1vcpu-1gb"},
+				map[string]any{"Type": "app", "Spec": map[string]any{"name": "web"}},
+			},
+			expected: CostEstimate{
+				Items: []CostLineItem{
+					{Type: "droplet", Description: "droplet s-2vcpu-4gb", Quantity: 3, UnitMonthly: 24, UnitHourly: 0.03571, Monthly: 72, Hourly: 0.10713, Priced: true},
+					{Type: "droplet", Description: "droplet s-1vcpu-1gb", Quantity: 1, UnitMonthly: 6, UnitHourly: 0.00893, Monthly: 6, Hourly: 0.00893, Priced: true},
+					{Type: "app", Description: "app web", Quantity: 1, UnitMonthly: 12, UnitHourly: 0.01786, Monthly: 12, Hourly: 0.01786, Priced: true},
+				},
+				TotalMonthly: 90,
+				TotalHourly:  0.13392,
+			},
+		},
+		{
+			name: "Database is validated but unpriced",
+			items: []any{
+				map[string]any{"Type": "droplet", "Size": "s-1vcpu-1gb"},
+				map[string]any{"Type": "database", "Engine": "pg", "Size": "db-s-1vcpu-2gb", "Nodes": float64(2)},
+			},
+			expected: CostEstimate{
+				Items: []CostLineItem{
+					{Type: "droplet", Description: "droplet s-1vcpu-1gb", Quantity: 1, UnitMonthly: 6, UnitHourly: 0.00893, Monthly: 6, Hourly: 0.00893, Priced: true},
+					{Type: "database", Description: "pg database db-s-1vcpu-2gb with 2 node(s)", Quantity: 1, Note: "the DigitalOcean API doesn't publish database prices, see https://www.digitalocean.com/pricing/managed-databases"},
+				},
+				TotalMonthly: 6,
+				TotalHourly:  0.00893,
+				Incomplete:   true,
+			},
+		},
+		{
+			name:        "Unknown droplet size",
+			items:       []any{map[string]any{"Type": "droplet", "Size": "s-64vcpu-1tb"}},
+			expectError: "unknown droplet size slug",
+		},
+		{
+			name:        "Database layout not offered",
+			items:       []any{map[string]any{"Type": "database", "Engine": "pg", "Size": "db-s-1vcpu-1gb", "Nodes": float64(3)}},
+			expectError: "doesn't offer size",
+		},
+		{
+			name:        "Unknown database engine",
+			items:       []any{map[string]any{"Type": "database", "Engine": "oracle", "Size": "db-s-1vcpu-1gb"}},
+			expectError: "unknown database engine",
+		},
+		{
+			name:        "Invalid type",
+			items:       []any{map[string]any{"Type": "bucket"}},
+			expectError: "invalid type",
+		},
+		{
+			name:        "No items",
+			items:       []any{},
+			expectError: "at least one item",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := setupCostTool(t)
+			res, err := tool.estimateCost(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Items": tc.items}}})
+			require.NoError(t, err)
+			text := res.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, res.IsError, text)
+			var estimate CostEstimate
+			require.NoError(t, json.Unmarshal([]byte(text), &estimate))
+			require.Equal(t, tc.expected, estimate)
+		})
+	}
+}
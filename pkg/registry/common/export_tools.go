@@ -0,0 +1,391 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// exportConcurrency bounds the resource types export-resources lists at the same time.
+const exportConcurrency = 3
+
+// exportTypes are the resource types export-resources can export, in document order.
+var exportTypes = []string{"droplet", "volume", "firewall", "lb", "domain"}
+
+// exportFormats are the document formats of export-resources.
+var exportFormats = []string{"json", "terraform"}
+
+// resourceExporter lists every resource of a type and renders them as Terraform resources.
+type resourceExporter struct {
+	list      func(ctx context.Context, client *godo.Client) (any, error)
+	terraform func(w *terraformWriter, resources any)
+}
+
+// ExportDocument is the JSON document returned by export-resources, keyed by resource type. Types
+// that could not be listed are reported in Errors.
+type ExportDocument struct {
+	Resources map[string]any    `json:"resources"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// listAllPages calls list for every page and returns all the items.
+func listAllPages[T any](ctx context.Context, list func(context.Context, *godo.ListOptions) ([]T, *godo.Response, error)) ([]T, error) {
+	all := []T{}
+	opt := &godo.ListOptions{Page: 1, PerPage: listAllPageSize}
+	for {
+		items, resp, err := list(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return all, nil
+		}
+		opt.Page++
+	}
+}
+
+var resourceExporters = map[string]resourceExporter{
+	"droplet": {
+		list: func(ctx context.Context, client *godo.Client) (any, error) {
+			return listAllPages(ctx, client.Droplets.List)
+		},
+		terraform: func(w *terraformWriter, resources any) {
+			for _, d := range resources.([]godo.Droplet) {
+				image := ""
+				if d.Image != nil {
+					image = d.Image.Slug
+					if image == "" {
+						image = strconv.Itoa(d.Image.ID)
+					}
+				}
+				w.resource("digitalocean_droplet", d.Name, strconv.Itoa(d.ID), func(b hclBody) {
+					b.attr("name", d.Name)
+					b.attr("region", regionSlug(d.Region))
+					b.attr("size", d.SizeSlug)
+					b.attr("image", image)
+					b.attr("vpc_uuid", d.VPCUUID)
+					b.attr("backups", slices.Contains(d.Features, "backups"))
+					b.attr("monitoring", slices.Contains(d.Features, "monitoring"))
+					b.attr("ipv6", slices.Contains(d.Features, "ipv6"))
+					b.attr("tags", d.Tags)
+				})
+			}
+		},
+	},
+	"volume": {
+		list: func(ctx context.Context, client *godo.Client) (any, error) {
+			return listAllPages(ctx, func(ctx context.Context, opt *godo.ListOptions) ([]godo.Volume, *godo.Response, error) {
+				return client.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: opt})
+			})
+		},
+		terraform: func(w *terraformWriter, resources any) {
+			for _, v := range resources.([]godo.Volume) {
+				w.resource("digitalocean_volume", v.Name, v.ID, func(b hclBody) {
+					b.attr("name", v.Name)
+					b.attr("region", regionSlug(v.Region))
+					b.attr("size", v.SizeGigaBytes)
+					b.attr("description", v.Description)
+					b.attr("initial_filesystem_type", v.FilesystemType)
+					b.attr("tags", v.Tags)
+				})
+			}
+		},
+	},
+	"firewall": {
+		list: func(ctx context.Context, client *godo.Client) (any, error) {
+			return listAllPages(ctx, client.Firewalls.List)
+		},
+		terraform: func(w *terraformWriter, resources any) {
+			for _, fw := range resources.([]godo.Firewall) {
+				w.resource("digitalocean_firewall", fw.Name, fw.ID, func(b hclBody) {
+					b.attr("name", fw.Name)
+					b.attr("droplet_ids", fw.DropletIDs)
+					b.attr("tags", fw.Tags)
+					for _, rule := range fw.InboundRules {
+						b.block("inbound_rule", func(b hclBody) {
+							b.attr("protocol", rule.Protocol)
+							if rule.Protocol != "icmp" {
+								b.attr("port_range", rule.PortRange)
+							}
+							if s := rule.Sources; s != nil {
+								b.attr("source_addresses", s.Addresses)
+								b.attr("source_droplet_ids", s.DropletIDs)
+								b.attr("source_tags", s.Tags)
+								b.attr("source_load_balancer_uids", s.LoadBalancerUIDs)
+							}
+						})
+					}
+					for _, rule := range fw.OutboundRules {
+						b.block("outbound_rule", func(b hclBody) {
+							b.attr("protocol", rule.Protocol)
+							if rule.Protocol != "icmp" {
+								b.attr("port_range", rule.PortRange)
+							}
+							if d := rule.Destinations; d != nil {
+								b.attr("destination_addresses", d.Addresses)
+								b.attr("destination_droplet_ids", d.DropletIDs)
+								b.attr("destination_tags", d.Tags)
+								b.attr("destination_load_balancer_uids", d.LoadBalancerUIDs)
+							}
+						})
+					}
+				})
+			}
+		},
+	},
+	"lb": {
+		list: func(ctx context.Context, client *godo.Client) (any, error) {
+			return listAllPages(ctx, client.LoadBalancers.List)
+		},
+		terraform: func(w *terraformWriter, resources any) {
+			for _, lb := range resources.([]godo.LoadBalancer) {
+				w.resource("digitalocean_loadbalancer", lb.Name, lb.ID, func(b hclBody) {
+					b.attr("name", lb.Name)
+					b.attr("region", regionSlug(lb.Region))
+					b.attr("size", lb.SizeSlug)
+					b.attr("vpc_uuid", lb.VPCUUID)
+					b.attr("droplet_ids", lb.DropletIDs)
+					b.attr("droplet_tag", lb.Tag)
+					for _, rule := range lb.ForwardingRules {
+						b.block("forwarding_rule", func(b hclBody) {
+							b.attr("entry_protocol", rule.EntryProtocol)
+							b.attr("entry_port", rule.EntryPort)
+							b.attr("target_protocol", rule.TargetProtocol)
+							b.attr("target_port", rule.TargetPort)
+							b.attr("certificate_id", rule.CertificateID)
+							b.attr("tls_passthrough", rule.TlsPassthrough)
+						})
+					}
+					if hc := lb.HealthCheck; hc != nil {
+						b.block("healthcheck", func(b hclBody) {
+							b.attr("protocol", hc.Protocol)
+							b.attr("port", hc.Port)
+							b.attr("path", hc.Path)
+							b.attr("check_interval_seconds", hc.CheckIntervalSeconds)
+							b.attr("response_timeout_seconds", hc.ResponseTimeoutSeconds)
+							b.attr("healthy_threshold", hc.HealthyThreshold)
+							b.attr("unhealthy_threshold", hc.UnhealthyThreshold)
+						})
+					}
+				})
+			}
+		},
+	},
+	"domain": {
+		list: func(ctx context.Context, client *godo.Client) (any, error) {
+			return listAllPages(ctx, client.Domains.List)
+		},
+		terraform: func(w *terraformWriter, resources any) {
+			for _, d := range resources.([]godo.Domain) {
+				w.resource("digitalocean_domain", d.Name, d.Name, func(b hclBody) {
+					b.attr("name", d.Name)
+				})
+			}
+		},
+	},
+}
+
+// terraformWriter renders resources as Terraform configuration, each followed by an import block
+// tying it to the existing resource.
+type terraformWriter struct {
+	b      strings.Builder
+	labels map[string]int
+}
+
+// hclBody writes the attributes and nested blocks of a block at an indentation level.
+type hclBody struct {
+	b      *strings.Builder
+	indent string
+}
+
+// resource writes a resource block and its import block. The label is derived from the resource
+// name, made unique per resource type.
+func (w *terraformWriter) resource(resourceType, name, id string, body func(hclBody)) {
+	label := terraformLabel(name)
+	key := resourceType + "." + label
+	if n := w.labels[key]; n > 0 {
+		w.labels[key] = n + 1
+		label = fmt.Sprintf("%s_%d", label, n+1)
+	} else {
+		w.labels[key] = 1
+	}
+
+	fmt.Fprintf(&w.b, "resource %q %q {\n", resourceType, label)
+	body(hclBody{b: &w.b, indent: "  "})
+	fmt.Fprintf(&w.b, "}\n\nimport {\n  to = %s.%s\n  id = %s\n}\n\n", resourceType, label, hclString(id))
+}
+
+// block writes a nested block.
+func (h hclBody) block(name string, body func(hclBody)) {
+	fmt.Fprintf(h.b, "%s%s {\n", h.indent, name)
+	body(hclBody{b: h.b, indent: h.indent + "  "})
+	fmt.Fprintf(h.b, "%s}\n", h.indent)
+}
+
+// attr writes an attribute. Empty strings and lists are left out, as Terraform then uses the
+// provider default.
+func (h hclBody) attr(name string, value any) {
+	var rendered string
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return
+		}
+		rendered = hclString(v)
+	case []string:
+		if len(v) == 0 {
+			return
+		}
+		items := make([]string, len(v))
+		for i, s := range v {
+			items[i] = hclString(s)
+		}
+		rendered = "[" + strings.Join(items, ", ") + "]"
+	case []int:
+		if len(v) == 0 {
+			return
+		}
+		items := make([]string, len(v))
+		for i, n := range v {
+			items[i] = strconv.Itoa(n)
+		}
+		rendered = "[" + strings.Join(items, ", ") + "]"
+	default:
+		rendered = fmt.Sprint(v)
+	}
+	fmt.Fprintf(h.b, "%s%s = %s\n", h.indent, name, rendered)
+}
+
+// hclString quotes s as an HCL string, escaping template sequences.
+func hclString(s string) string {
+	s = strconv.Quote(s)
+	s = strings.ReplaceAll(s, "${", "$${")
+	return strings.ReplaceAll(s, "%{", "%%{")
+}
+
+// terraformLabel turns a resource name into a Terraform identifier.
+func terraformLabel(name string) string {
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		default:
+			return '_'
+		}
+	}, name)
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "r_" + label
+	}
+	return label
+}
+
+// ExportTool provides a tool exporting the resources of the account as JSON or Terraform.
+type ExportTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewExportTool creates a new ExportTool instance.
+func NewExportTool(client func(ctx context.Context) (*godo.Client, error)) *ExportTool {
+	return &ExportTool{client: client}
+}
+
+// exportResources lists every resource of the requested types, at most exportConcurrency types at
+// a time, and renders them in the requested format. A type that can't be listed is reported
+// without failing the export.
+func (e *ExportTool) exportResources(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := NewArgs(req)
+	format := args.OptionalEnum("Format", "json", exportFormats...)
+	types := args.OptionalStrings("Types")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(types) == 0 {
+		types = exportTypes
+	}
+	for _, t := range types {
+		if !slices.Contains(exportTypes, t) {
+			return mcp.NewToolResultError(fmt.Sprintf("invalid type %q, must be one of: %s", t, strings.Join(exportTypes, ", "))), nil
+		}
+	}
+	// Keep the document order independent of the argument order.
+	types = slices.DeleteFunc(slices.Clone(exportTypes), func(t string) bool { return !slices.Contains(types, t) })
+
+	client, err := e.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		pool      = make(chan struct{}, exportConcurrency)
+		resources = make([]any, len(types))
+		errs      = make([]error, len(types))
+	)
+	for i, t := range types {
+		wg.Go(func() {
+			pool <- struct{}{}
+			defer func() { <-pool }()
+			resources[i], errs[i] = resourceExporters[t].list(ctx, client)
+		})
+	}
+	wg.Wait()
+
+	doc := ExportDocument{Resources: map[string]any{}}
+	for i, t := range types {
+		if errs[i] != nil {
+			if doc.Errors == nil {
+				doc.Errors = map[string]string{}
+			}
+			doc.Errors[t] = errs[i].Error()
+			continue
+		}
+		doc.Resources[t] = resources[i]
+	}
+
+	if format == "json" {
+		jsonData, err := response.CompactJSON(doc)
+		if err != nil {
+			return nil, fmt.Errorf("marshal error: %w", err)
+		}
+		return mcp.NewToolResultText(jsonData), nil
+	}
+
+	w := &terraformWriter{labels: map[string]int{}}
+	w.b.WriteString("# Generated by export-resources as a starting point: review every resource before applying.\n")
+	w.b.WriteString("# The import blocks need Terraform 1.5 or later.\n\n")
+	for i, t := range types {
+		if errs[i] != nil {
+			fmt.Fprintf(&w.b, "# %s resources were not exported: %s\n\n", t, strings.ReplaceAll(errs[i].Error(), "\n", " "))
+			continue
+		}
+		resourceExporters[t].terraform(w, resources[i])
+	}
+	return mcp.NewToolResultText(strings.TrimRight(w.b.String(), "\n") + "\n"), nil
+}
+
+// Tools returns the list of server tools for resource export.
+func (e *ExportTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: e.exportResources,
+			Tool: mcp.NewTool(
+				"export-resources",
+				mcp.WithDescription("Export every droplet, volume, firewall, load balancer and domain of the account as a JSON document or as a best-effort Terraform configuration with import blocks. Types that cannot be listed are reported without failing the export."),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithArray("Types", mcp.Description("Resource types to export (droplet, volume, firewall, lb, domain). Exports all types if omitted."), mcp.Items(map[string]any{"type": "string", "enum": exportTypes})),
+				mcp.WithString("Format", mcp.DefaultString("json"), mcp.Enum(exportFormats...), mcp.Description("Document format: json or terraform")),
+			),
+		},
+	}
+}
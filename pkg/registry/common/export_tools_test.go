@@ -0,0 +1,170 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+var exportResponses = map[string]string{
+	"/v2/droplets":       `{"droplets":[{"id":1,"name":"web-1","region":{"slug":"nyc3"},"size_slug":"s-1vcpu-1gb","image":{"id":7,"slug":"ubuntu-24-04-x64"},"features":["monitoring"],"tags":["web"]},{"id":2,"name":"web_1","region":{"slug":"nyc3"},"size_slug":"s-1vcpu-1gb","image":{"id":42}}]}`,
+	"/v2/volumes":        `{"volumes":[{"id":"vol-1","name":"data","region":{"slug":"nyc3"},"size_gigabytes":100}]}`,
+	"/v2/firewalls":      `{"firewalls":[{"id":"fw-1","name":"web","droplet_ids":[1],"inbound_rules":[{"protocol":"tcp","ports":"443","sources":{"addresses":["0.0.0.0/0"]}}],"outbound_rules":[{"protocol":"icmp","ports":"0","destinations":{"addresses":["0.0.0.0/0"]}}]}]}`,
+	"/v2/load_balancers": `{"load_balancers":[{"id":"lb-1","name":"lb","region":{"slug":"nyc3"},"droplet_ids":[1,2],"forwarding_rules":[{"entry_protocol":"http","entry_port":80,"target_protocol":"http","target_port":8080}]}]}`,
+	"/v2/domains":        `{"domains":[{"name":"example.com"}]}`,
+}
+
+func setupExportTool(t *testing.T, handler http.HandlerFunc) *ExportTool {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client := godo.NewClient(srv.Client())
+	client.BaseURL = baseURL
+	return NewExportTool(func(ctx context.Context) (*godo.Client, error) { return client, nil })
+}
+
+func callExport(t *testing.T, tool *ExportTool, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	res, err := tool.exportResources(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	return res
+}
+
+func TestExportTool_exportResources(t *testing.T) {
+	t.Run("JSON document of every type across pages", func(t *testing.T) {
+		tool := setupExportTool(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2/domains" && r.URL.Query().Get("page") == "1" {
+				_, _ = w.Write([]byte(`{"domains":[{"name":"first.com"}],"links":{"pages":{"last":"https://api.digitalocean.com/v2/domains?page=2","next":"https://api.digitalocean.com/v2/domains?page=2"}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(exportResponses[r.URL.Path]))
+		})
+		res := callExport(t, tool, map[string]any{})
+		require.False(t, res.IsError)
+
+		var doc struct {
+			Resources struct {
+				Droplets  []godo.Droplet      `json:"droplet"`
+				Volumes   []godo.Volume       `json:"volume"`
+				Firewalls []godo.Firewall     `json:"firewall"`
+				LBs       []godo.LoadBalancer `json:"lb"`
+				Domains   []godo.Domain       `json:"domain"`
+			} `json:"resources"`
+			Errors map[string]string `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &doc))
+		require.Empty(t, doc.Errors)
+		require.Len(t, doc.Resources.Droplets, 2)
+		require.Len(t, doc.Resources.Volumes, 1)
+		require.Len(t, doc.Resources.Firewalls, 1)
+		require.Len(t, doc.Resources.LBs, 1)
+		require.Equal(t, []godo.Domain{{Name: "first.com"}, {Name: "example.com"}}, doc.Resources.Domains)
+	})
+
+	t.Run("Terraform configuration with import blocks", func(t *testing.T) {
+		tool := setupExportTool(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2/volumes" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"id":"forbidden","message":"not allowed"}`))
+				return
+			}
+			_, _ = w.Write([]byte(exportResponses[r.URL.Path]))
+		})
+		res := callExport(t, tool, map[string]any{"Format": "terraform", "Types": []any{"firewall", "droplet", "volume"}})
+		require.False(t, res.IsError)
+		hcl := res.Content[0].(mcp.TextContent).Text
+
+		require.Contains(t, hcl, `resource "digitalocean_droplet" "web_1" {
+  name = "web-1"
+  region = "nyc3"
+  size = "s-1vcpu-1gb"
+  image = "ubuntu-24-04-x64"
+  backups = false
+  monitoring = true
+  ipv6 = false
+  tags = ["web"]
+}
+
+import {
+  to = digitalocean_droplet.web_1
+  id = "1"
+}
+`)
+		require.Contains(t, hcl, `resource "digitalocean_droplet" "web_1_2" {`)
+		require.Contains(t, hcl, `  image = "42"`)
+		require.Contains(t, hcl, `  inbound_rule {
+    protocol = "tcp"
+    port_range = "443"
+    source_addresses = ["0.0.0.0/0"]
+  }
+  outbound_rule {
+    protocol = "icmp"
+    destination_addresses = ["0.0.0.0/0"]
+  }
+`)
+		require.Contains(t, hcl, "# volume resources were not exported: ")
+		require.NotContains(t, hcl, "digitalocean_volume")
+		require.NotContains(t, hcl, "digitalocean_domain")
+		require.Less(t, strings.Index(hcl, "digitalocean_droplet"), strings.Index(hcl, "digitalocean_firewall"))
+	})
+
+	t.Run("Lists at most exportConcurrency types at once", func(t *testing.T) {
+		var (
+			mu              sync.Mutex
+			active, maxSeen int
+			requests        atomic.Int32
+		)
+		tool := setupExportTool(t, func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			mu.Lock()
+			active++
+			maxSeen = max(maxSeen, active)
+			mu.Unlock()
+			time.Sleep(20 * time.Millisecond)
+			mu.Lock()
+			active--
+			mu.Unlock()
+			_, _ = w.Write([]byte(exportResponses[r.URL.Path]))
+		})
+		res := callExport(t, tool, map[string]any{})
+		require.False(t, res.IsError)
+		require.Equal(t, int32(len(exportTypes)), requests.Load())
+		require.LessOrEqual(t, maxSeen, exportConcurrency)
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		tool := setupExportTool(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		})
+		res := callExport(t, tool, map[string]any{"Types": []any{"database"}})
+		require.True(t, res.IsError)
+		require.Contains(t, res.Content[0].(mcp.TextContent).Text, `invalid type "database"`)
+
+		res = callExport(t, tool, map[string]any{"Format": "yaml"})
+		require.True(t, res.IsError)
+		require.Contains(t, res.Content[0].(mcp.TextContent).Text, "argument 'Format' must be one of: json, terraform")
+	})
+}
+
+func TestTerraformLabel(t *testing.T) {
+	require.Equal(t, "web_prod_1", terraformLabel("Web-Prod.1"))
+	require.Equal(t, "r_1st", terraformLabel("1st"))
+	require.Equal(t, "r_", terraformLabel(""))
+	require.Equal(t, `"a$${b}%%{c}\n"`, hclString("a${b}%{c}\n"))
+}
@@ -0,0 +1,137 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/pkg/response"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Health statuses of the server.
+const (
+	HealthOK        = "ok"
+	HealthDegraded  = "degraded"
+	HealthUnhealthy = "unhealthy"
+)
+
+// lowRateRemaining is the share of the API rate limit under which the server reports it is degraded.
+const lowRateRemaining = 0.1
+
+// Health is the result of a health check: whether the DigitalOcean API is reachable with the
+// server's token, the status of the account, and how many API requests remain in the rate limit.
+type Health struct {
+	Status        string    `json:"status"`
+	AccountStatus string    `json:"account_status,omitempty"`
+	RateLimit     int       `json:"rate_limit,omitempty"`
+	RateRemaining int       `json:"rate_remaining,omitempty"`
+	RateReset     time.Time `json:"rate_reset,omitzero"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// CheckHealth checks the server can use the DigitalOcean API by fetching the account. A rejected
+// token or an unreachable API is unhealthy; a working token is degraded when the account is not
+// active or less than a tenth of the rate limit remains.
+func CheckHealth(ctx context.Context, client func(ctx context.Context) (*godo.Client, error)) Health {
+	c, err := client(ctx)
+	if err != nil {
+		return Health{Status: HealthUnhealthy, Error: fmt.Sprintf("failed to get DigitalOcean client: %v", err)}
+	}
+
+	account, resp, err := c.Account.Get(ctx)
+	if err != nil {
+		var errResp *godo.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusUnauthorized {
+			return Health{Status: HealthUnhealthy, AccountStatus: "invalid_token", Error: "the API token was rejected"}
+		}
+		return Health{Status: HealthUnhealthy, Error: fmt.Sprintf("DigitalOcean API unreachable: %v", err)}
+	}
+
+	health := Health{Status: HealthOK, AccountStatus: account.Status}
+	if resp != nil {
+		health.RateLimit = resp.Rate.Limit
+		health.RateRemaining = resp.Rate.Remaining
+		health.RateReset = resp.Rate.Reset.Time
+	}
+	if account.Status != "active" {
+		health.Status = HealthDegraded
+	}
+	if health.RateLimit > 0 && float64(health.RateRemaining) < lowRateRemaining*float64(health.RateLimit) {
+		health.Status = HealthDegraded
+	}
+	return health
+}
+
+// HealthHandler returns an HTTP handler serving the result of CheckHealth as JSON, for liveness and
+// readiness probes. It answers 503 when the server is unhealthy. The Authorization header of the
+// probe is passed on as for tool calls, so it must carry a token under the http transport.
+func HealthHandler(client func(ctx context.Context) (*godo.Client, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health := CheckHealth(middleware.AuthFromRequest(r.Context(), r), client)
+		w.Header().Set("Content-Type", "application/json")
+		if health.Status == HealthUnhealthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(health)
+	})
+}
+
+// ServeHealth exposes HealthHandler on http://addr/healthz until ctx is cancelled.
+func ServeHealth(ctx context.Context, logger *slog.Logger, addr string, client func(ctx context.Context) (*godo.Client, error)) error {
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", HealthHandler(client))
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("serving health checks", "addr", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// HealthTool reports whether the server can use the DigitalOcean API.
+type HealthTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewHealthTool creates a new HealthTool instance.
+func NewHealthTool(client func(ctx context.Context) (*godo.Client, error)) *HealthTool {
+	return &HealthTool{client: client}
+}
+
+func (h *HealthTool) checkHealth(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	jsonData, err := response.CompactJSON(CheckHealth(ctx, h.client))
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// Tools returns the health tools.
+func (h *HealthTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: h.checkHealth,
+			Tool: mcp.NewTool("server-health",
+				mcp.WithDescription("Check the server can use the DigitalOcean API: whether the API is reachable and the token accepted, the account status, and how many API requests remain in the rate limit. Status is ok, degraded (account not active or rate limit nearly used up) or unhealthy. Use it to confirm the server works before real work."),
+				mcp.WithReadOnlyHintAnnotation(true),
+			),
+		},
+	}
+}
@@ -0,0 +1,148 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// healthClient returns a client getter for a godo client pointed at a test server answering the
+// account endpoint with handler.
+func healthClient(t *testing.T, handler http.HandlerFunc) func(ctx context.Context) (*godo.Client, error) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client := godo.NewClient(srv.Client())
+	client.BaseURL = baseURL
+	return func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	}
+}
+
+// accountHandler answers the account endpoint with the given account status and rate limit headers.
+func accountHandler(status, limit, remaining string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("RateLimit-Limit", limit)
+		w.Header().Set("RateLimit-Remaining", remaining)
+		w.Header().Set("RateLimit-Reset", "1700000000")
+		_, _ = w.Write([]byte(`{"account":{"email":"ops@example.com","status":"` + status + `"}}`))
+	}
+}
+
+func TestCheckHealth(t *testing.T) {
+	tests := []struct {
+		name          string
+		client        func(t *testing.T) func(ctx context.Context) (*godo.Client, error)
+		expectStatus  string
+		expectAccount string
+		expectRemain  int
+		expectError   string
+	}{
+		{
+			name: "Healthy",
+			client: func(t *testing.T) func(ctx context.Context) (*godo.Client, error) {
+				return healthClient(t, accountHandler("active", "5000", "4990"))
+			},
+			expectStatus:  HealthOK,
+			expectAccount: "active",
+			expectRemain:  4990,
+		},
+		{
+			name: "Rate limit nearly used up",
+			client: func(t *testing.T) func(ctx context.Context) (*godo.Client, error) {
+				return healthClient(t, accountHandler("active", "5000", "20"))
+			},
+			expectStatus:  HealthDegraded,
+			expectAccount: "active",
+			expectRemain:  20,
+		},
+		{
+			name: "Account locked",
+			client: func(t *testing.T) func(ctx context.Context) (*godo.Client, error) {
+				return healthClient(t, accountHandler("locked", "5000", "4990"))
+			},
+			expectStatus:  HealthDegraded,
+			expectAccount: "locked",
+			expectRemain:  4990,
+		},
+		{
+			name: "Token rejected",
+			client: func(t *testing.T) func(ctx context.Context) (*godo.Client, error) {
+				return healthClient(t, func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(http.StatusUnauthorized)
+					_, _ = w.Write([]byte(`{"id":"unauthorized","message":"Unable to authenticate you"}`))
+				})
+			},
+			expectStatus:  HealthUnhealthy,
+			expectAccount: "invalid_token",
+			expectError:   "the API token was rejected",
+		},
+		{
+			name: "No client",
+			client: func(t *testing.T) func(ctx context.Context) (*godo.Client, error) {
+				return func(ctx context.Context) (*godo.Client, error) {
+					return nil, errors.New("no auth header found")
+				}
+			},
+			expectStatus: HealthUnhealthy,
+			expectError:  "no auth header found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			health := CheckHealth(context.Background(), tc.client(t))
+			require.Equal(t, tc.expectStatus, health.Status)
+			require.Equal(t, tc.expectAccount, health.AccountStatus)
+			require.Equal(t, tc.expectRemain, health.RateRemaining)
+			if tc.expectError == "" {
+				require.Empty(t, health.Error)
+				return
+			}
+			require.Contains(t, health.Error, tc.expectError)
+		})
+	}
+}
+
+func TestHealthHandler(t *testing.T) {
+	t.Run("Healthy", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		HealthHandler(healthClient(t, accountHandler("active", "5000", "4990"))).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		var health Health
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &health))
+		require.Equal(t, HealthOK, health.Status)
+		require.Equal(t, 5000, health.RateLimit)
+	})
+
+	t.Run("Unhealthy answers 503", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		client := func(ctx context.Context) (*godo.Client, error) {
+			return nil, errors.New("no auth header found")
+		}
+		HealthHandler(client).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
+
+func TestHealthTool_checkHealth(t *testing.T) {
+	tool := NewHealthTool(healthClient(t, accountHandler("active", "5000", "4990")))
+	res, err := tool.checkHealth(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+	var health Health
+	require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &health))
+	require.Equal(t, HealthOK, health.Status)
+	require.Equal(t, "active", health.AccountStatus)
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"sync"
+
+	middleware "mcp-digitalocean/internal"
+
+	"github.com/digitalocean/godo"
+)
+
+// clientEntry is a memoized client, built by the first call made with its token.
+type clientEntry struct {
+	once   sync.Once
+	client *godo.Client
+	err    error
+}
+
+// MemoizeClient wraps fn so that a client is built once per auth token, read from the context as set
+// by middleware.WithAuthKey, and shared by the later calls made with the same token. It is safe for
+// concurrent use: concurrent first calls wait for a single build. A failed build is not cached, and a
+// client is dropped once the API answers 401 Unauthorized to one of its requests, so the next call
+// builds a new one.
+func MemoizeClient(fn func(ctx context.Context) (*godo.Client, error)) func(ctx context.Context) (*godo.Client, error) {
+	var clients sync.Map // token digest -> *clientEntry
+	return func(ctx context.Context) (*godo.Client, error) {
+		key := authDigest(ctx)
+		v, _ := clients.LoadOrStore(key, &clientEntry{})
+		entry := v.(*clientEntry)
+		entry.once.Do(func() {
+			entry.client, entry.err = fn(ctx)
+			if entry.err == nil {
+				dropOnUnauthorized(entry.client, func() { clients.Delete(key) })
+			}
+		})
+		if entry.err != nil {
+			clients.CompareAndDelete(key, entry)
+			return nil, entry.err
+		}
+		return entry.client, nil
+	}
+}
+
+// authDigest returns the digest of the auth token of ctx, so tokens aren't kept in memory as map keys.
+func authDigest(ctx context.Context) [sha256.Size]byte {
+	auth, _ := ctx.Value(middleware.AuthKey{}).(string)
+	return sha256.Sum256([]byte(auth))
+}
+
+// unauthorizedHook is a transport calling drop when a response has status 401.
+type unauthorizedHook struct {
+	next http.RoundTripper
+	drop func()
+}
+
+func (h *unauthorizedHook) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := h.next.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		h.drop()
+	}
+	return resp, err
+}
+
+// dropOnUnauthorized makes client call drop whenever the API rejects its credentials. A client
+// already hooked, e.g. a client fn returns on every call, is left as is.
+func dropOnUnauthorized(client *godo.Client, drop func()) {
+	if client == nil || client.HTTPClient == nil {
+		return
+	}
+	if _, ok := client.HTTPClient.Transport.(*unauthorizedHook); ok {
+		return
+	}
+	next := client.HTTPClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	client.HTTPClient.Transport = &unauthorizedHook{next: next, drop: drop}
+}
@@ -0,0 +1,95 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	middleware "mcp-digitalocean/internal"
+
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoizeClient(t *testing.T) {
+	t.Run("builds one client per token", func(t *testing.T) {
+		var calls atomic.Int32
+		getClient := MemoizeClient(func(ctx context.Context) (*godo.Client, error) {
+			calls.Add(1)
+			return godo.NewFromToken("token"), nil
+		})
+
+		ctx := middleware.WithAuthKey(context.Background(), "Bearer one")
+		first, err := getClient(ctx)
+		require.NoError(t, err)
+		var wg sync.WaitGroup
+		for range 50 {
+			wg.Go(func() {
+				client, err := getClient(ctx)
+				require.NoError(t, err)
+				require.Same(t, first, client)
+			})
+		}
+		wg.Wait()
+		require.EqualValues(t, 1, calls.Load())
+
+		other, err := getClient(middleware.WithAuthKey(context.Background(), "Bearer two"))
+		require.NoError(t, err)
+		require.NotSame(t, first, other)
+		require.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("does not cache failures", func(t *testing.T) {
+		var calls atomic.Int32
+		getClient := MemoizeClient(func(ctx context.Context) (*godo.Client, error) {
+			if calls.Add(1) == 1 {
+				return nil, errors.New("no auth header found")
+			}
+			return godo.NewFromToken("token"), nil
+		})
+
+		_, err := getClient(context.Background())
+		require.Error(t, err)
+		client, err := getClient(context.Background())
+		require.NoError(t, err)
+		require.NotNil(t, client)
+		require.EqualValues(t, 2, calls.Load())
+	})
+
+	t.Run("rebuilds after an auth error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"id":"Unauthorized","message":"Unable to authenticate you"}`))
+		}))
+		defer srv.Close()
+		baseURL, err := url.Parse(srv.URL + "/")
+		require.NoError(t, err)
+
+		var calls atomic.Int32
+		getClient := MemoizeClient(func(ctx context.Context) (*godo.Client, error) {
+			calls.Add(1)
+			client := godo.NewClient(&http.Client{})
+			client.BaseURL = baseURL
+			return client, nil
+		})
+
+		client, err := getClient(context.Background())
+		require.NoError(t, err)
+		again, err := getClient(context.Background())
+		require.NoError(t, err)
+		require.Same(t, client, again)
+
+		_, _, err = client.Account.Get(context.Background())
+		require.Error(t, err)
+		rebuilt, err := getClient(context.Background())
+		require.NoError(t, err)
+		require.NotSame(t, client, rebuilt)
+		require.EqualValues(t, 2, calls.Load())
+	})
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/digitalocean/godo (interfaces: RegionsService)
+// Source: github.com/digitalocean/godo (interfaces: RegionsService,SizesService)
 //
 // Generated by this command:
 //
-//	mockgen -destination=./mocks.go -package common github.com/digitalocean/godo RegionsService
+//	mockgen -destination=./mocks.go -package common github.com/digitalocean/godo RegionsService,SizesService
 //
 
 // Package common is a generated GoMock package.
@@ -56,3 +56,43 @@ func (mr *MockRegionsServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRegionsService)(nil).List), arg0, arg1)
 }
+
+// MockSizesService is a mock of SizesService interface.
+type MockSizesService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSizesServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockSizesServiceMockRecorder is the mock recorder for MockSizesService.
+type MockSizesServiceMockRecorder struct {
+	mock *MockSizesService
+}
+
+// NewMockSizesService creates a new mock instance.
+func NewMockSizesService(ctrl *gomock.Controller) *MockSizesService {
+	mock := &MockSizesService{ctrl: ctrl}
+	mock.recorder = &MockSizesServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSizesService) EXPECT() *MockSizesServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockSizesService) List(arg0 context.Context, arg1 *godo.ListOptions) ([]godo.Size, *godo.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]godo.Size)
+	ret1, _ := ret[1].(*godo.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockSizesServiceMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockSizesService)(nil).List), arg0, arg1)
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// NotFound is the result of a tool asked for a resource that does not exist. It tells the model the
+// request was understood and the id is wrong, rather than that the API failed.
+type NotFound struct {
+	Error        string `json:"error"`
+	ResourceType string `json:"resource_type"`
+	ID           any    `json:"id"`
+}
+
+// IsNotFound reports whether err is an API error response with status 404.
+func IsNotFound(err error) bool {
+	var errResp *godo.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusNotFound
+}
+
+// NotFoundResult returns the error result reporting that the resourceType with the given id does not
+// exist, e.g. {"error":"resource not found","resource_type":"droplet","id":42}.
+func NotFoundResult(resourceType string, id any) *mcp.CallToolResult {
+	data, err := json.Marshal(NotFound{Error: "resource not found", ResourceType: resourceType, ID: id})
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("%s %v not found", resourceType, id))
+	}
+	return mcp.NewToolResultError(string(data))
+}
+
+// APIErrorResult returns the result of a failed API call for the resourceType with the given id: a
+// NotFoundResult when the API answered 404, the generic api error result otherwise.
+func APIErrorResult(err error, resourceType string, id any) *mcp.CallToolResult {
+	if IsNotFound(err) {
+		return NotFoundResult(resourceType, id)
+	}
+	return mcp.NewToolResultErrorFromErr("api error", err)
+}
@@ -0,0 +1,46 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func apiError(status int) error {
+	return &godo.ErrorResponse{
+		Response: &http.Response{
+			StatusCode: status,
+			Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "api.digitalocean.com", Path: "/v2/droplets/42"}},
+		},
+		Message: "The resource you were accessing could not be found.",
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	require.True(t, IsNotFound(apiError(http.StatusNotFound)))
+	require.True(t, IsNotFound(fmt.Errorf("get droplet: %w", apiError(http.StatusNotFound))))
+	require.False(t, IsNotFound(apiError(http.StatusInternalServerError)))
+	require.False(t, IsNotFound(errors.New("connection refused")))
+	require.False(t, IsNotFound(&godo.ErrorResponse{}))
+	require.False(t, IsNotFound(nil))
+}
+
+func TestAPIErrorResult(t *testing.T) {
+	res := APIErrorResult(apiError(http.StatusNotFound), "droplet", 42)
+	require.True(t, res.IsError)
+	require.JSONEq(t, `{"error":"resource not found","resource_type":"droplet","id":42}`, res.Content[0].(mcp.TextContent).Text)
+
+	res = APIErrorResult(apiError(http.StatusNotFound), "database_cluster", "abc")
+	require.JSONEq(t, `{"error":"resource not found","resource_type":"database_cluster","id":"abc"}`, res.Content[0].(mcp.TextContent).Text)
+
+	res = APIErrorResult(apiError(http.StatusInternalServerError), "droplet", 42)
+	require.True(t, res.IsError)
+	require.Contains(t, res.Content[0].(mcp.TextContent).Text, "api error")
+	require.NotContains(t, res.Content[0].(mcp.TextContent).Text, "resource not found")
+}
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"mcp-digitalocean/pkg/dryrun"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// NotifyURLArg is the optional argument of long-running tools naming the URL notified on completion.
+	NotifyURLArg = "notify_url"
+
+	// notifyTimeout bounds the delivery of a single notification.
+	notifyTimeout = 10 * time.Second
+)
+
+// Notification is the JSON payload POSTed to a notify_url when a long-running operation finishes.
+type Notification struct {
+	Tool       string    `json:"tool"`
+	Resource   string    `json:"resource"`
+	ResourceID string    `json:"resource_id"`
+	Status     string    `json:"status"`
+	Time       time.Time `json:"time"`
+}
+
+// NotifyURL reads and validates the notify_url argument. It returns an empty string when the
+// argument is not set.
+func NotifyURL(args map[string]any) (string, error) {
+	raw, _ := args[NotifyURLArg].(string)
+	if raw == "" {
+		return "", nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("%s must be an absolute https URL", NotifyURLArg)
+	}
+	return raw, nil
+}
+
+// Notify POSTs the notification to notifyURL with httpClient, or http.DefaultClient when nil, giving
+// up after notifyTimeout. The
+// notification is delivered even if ctx is already cancelled, as the operation it reports finished.
+// During a dry run the request is recorded instead of sent.
+func Notify(ctx context.Context, httpClient *http.Client, notifyURL string, n Notification) error {
+	if n.Time.IsZero() {
+		n.Time = time.Now().UTC()
+	}
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fmt.Errorf("marshal error: %w", err)
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if rec := dryrun.FromContext(ctx); rec != nil {
+		httpClient = rec.Client(httpClient)
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notifyURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", DefaultUserAgent)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("notification endpoint returned %s", resp.Status)
+	}
+	return nil
+}
+
+// NotifyAndNote delivers the notification and appends the outcome to the tool result as an extra
+// text content. A failed delivery is reported there without failing the tool.
+func NotifyAndNote(ctx context.Context, httpClient *http.Client, notifyURL string, n Notification, result *mcp.CallToolResult) *mcp.CallToolResult {
+	note := fmt.Sprintf("notification sent to %s", notifyURL)
+	if err := Notify(ctx, httpClient, notifyURL, n); err != nil {
+		note = fmt.Sprintf("notification to %s failed: %v", notifyURL, err)
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(note))
+	return result
+}
@@ -0,0 +1,129 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// placementCacheTTL is how long the sizes and database options fetched to validate a placement are
+// reused, sparing the API a lookup on every create.
+const placementCacheTTL = time.Minute
+
+// ClientCache holds a value fetched with a client for a limited time, per client. Clients are
+// memoized per token, so a cached value is only seen by the token that fetched it.
+type ClientCache[T any] struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[*godo.Client]clientCacheEntry[T]
+}
+
+type clientCacheEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// NewClientCache creates a ClientCache keeping values for ttl.
+func NewClientCache[T any](ttl time.Duration) *ClientCache[T] {
+	return &ClientCache[T]{ttl: ttl, now: time.Now, entries: map[*godo.Client]clientCacheEntry[T]{}}
+}
+
+// Get returns the value cached for client, calling fetch when there is none or it expired. Errors
+// are not cached.
+func (c *ClientCache[T]) Get(ctx context.Context, client *godo.Client, fetch func(context.Context, *godo.Client) (T, error)) (T, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[client]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expires) {
+		return entry.value, nil
+	}
+
+	value, err := fetch(ctx, client)
+	if err != nil {
+		return value, err
+	}
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[client] = clientCacheEntry[T]{value: value, expires: now.Add(c.ttl)}
+	return value, nil
+}
+
+var (
+	placementSizes     = NewClientCache[[]godo.Size](placementCacheTTL)
+	placementDBOptions = NewClientCache[*godo.DatabaseOptions](placementCacheTTL)
+)
+
+// ValidateSizeInRegion checks that a droplet size can be created in region. When it can't, the
+// error lists the regions where the size is available.
+func ValidateSizeInRegion(ctx context.Context, client *godo.Client, size, region string) error {
+	sizes, err := placementSizes.Get(ctx, client, ListAllSizes)
+	if err != nil {
+		return fmt.Errorf("failed to list sizes: %w", err)
+	}
+	i := slices.IndexFunc(sizes, func(s godo.Size) bool { return s.Slug == size })
+	if i < 0 {
+		return fmt.Errorf("unknown size %s, use size-list to find one", size)
+	}
+	if !sizes[i].Available || len(sizes[i].Regions) == 0 {
+		return fmt.Errorf("size %s is not available in any region", size)
+	}
+	if slices.Contains(sizes[i].Regions, region) {
+		return nil
+	}
+	return fmt.Errorf("size %s is not available in region %s, it is available in: %s", size, region, strings.Join(slices.Sorted(slices.Values(sizes[i].Regions)), ", "))
+}
+
+// ValidateDatabaseSizeInRegion checks that a database engine offers size and can be created in
+// region. When the region is the problem, the error lists the regions the engine is available in.
+func ValidateDatabaseSizeInRegion(ctx context.Context, client *godo.Client, engine, size, region string) error {
+	options, err := placementDBOptions.Get(ctx, client, func(ctx context.Context, client *godo.Client) (*godo.DatabaseOptions, error) {
+		options, _, err := client.Databases.ListOptions(ctx)
+		return options, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list database options: %w", err)
+	}
+	engineOptions, err := databaseEngineOptions(options, engine)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(engineOptions.Layouts, func(l godo.DatabaseLayout) bool { return slices.Contains(l.Sizes, size) }) {
+		return fmt.Errorf("%s doesn't offer size %s, use db-list-options to find one", engine, size)
+	}
+	if !slices.Contains(engineOptions.Regions, region) {
+		return fmt.Errorf("%s is not available in region %s, it is available in: %s", engine, region, strings.Join(slices.Sorted(slices.Values(engineOptions.Regions)), ", "))
+	}
+	return nil
+}
+
+// databaseEngineOptions returns the options of an engine.
+func databaseEngineOptions(options *godo.DatabaseOptions, engine string) (godo.DatabaseEngineOptions, error) {
+	// The options are keyed by engine slug in their JSON form, e.g. "pg" or "mysql".
+	data, err := json.Marshal(options)
+	if err != nil {
+		return godo.DatabaseEngineOptions{}, err
+	}
+	var engines map[string]godo.DatabaseEngineOptions
+	if err := json.Unmarshal(data, &engines); err != nil {
+		return godo.DatabaseEngineOptions{}, err
+	}
+	engineOptions, ok := engines[engine]
+	if !ok {
+		return godo.DatabaseEngineOptions{}, fmt.Errorf("unknown database engine %q", engine)
+	}
+	return engineOptions, nil
+}
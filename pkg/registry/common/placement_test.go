@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestValidateSizeInRegion(t *testing.T) {
+	sizes := []godo.Size{
+		{Slug: "s-1vcpu-1gb", Available: true, Regions: []string{"sfo3", "nyc1", "ams3"}},
+		{Slug: "s-old", Available: false},
+	}
+	tests := []struct {
+		name   string
+		size   string
+		region string
+		expect string
+	}{
+		{name: "Available", size: "s-1vcpu-1gb", region: "nyc1"},
+		{name: "Other region", size: "s-1vcpu-1gb", region: "blr1", expect: "size s-1vcpu-1gb is not available in region blr1, it is available in: ams3, nyc1, sfo3"},
+		{name: "Unavailable size", size: "s-old", region: "nyc1", expect: "size s-old is not available in any region"},
+		{name: "Unknown size", size: "s-huge", region: "nyc1", expect: "unknown size s-huge, use size-list to find one"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockSizes := NewMockSizesService(ctrl)
+			mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, nil, nil)
+
+			err := ValidateSizeInRegion(context.Background(), &godo.Client{Sizes: mockSizes}, tc.size, tc.region)
+			if tc.expect == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.expect)
+		})
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	cache := NewClientCache[int](time.Minute)
+	current := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return current }
+
+	calls := 0
+	fetch := func(context.Context, *godo.Client) (int, error) {
+		calls++
+		return calls, nil
+	}
+	client, other := &godo.Client{}, &godo.Client{}
+
+	v, err := cache.Get(context.Background(), client, fetch)
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+	v, _ = cache.Get(context.Background(), client, fetch)
+	require.Equal(t, 1, v, "cached for the same client")
+	v, _ = cache.Get(context.Background(), other, fetch)
+	require.Equal(t, 2, v, "each client has its own entry")
+
+	current = current.Add(time.Minute)
+	v, _ = cache.Get(context.Background(), client, fetch)
+	require.Equal(t, 3, v, "refetched once expired")
+	require.Len(t, cache.entries, 1, "expired entries are dropped")
+
+	failing := NewClientCache[int](time.Minute)
+	_, err = failing.Get(context.Background(), client, func(context.Context, *godo.Client) (int, error) {
+		return 0, errors.New("boom")
+	})
+	require.EqualError(t, err, "boom")
+	require.Empty(t, failing.entries, "errors are not cached")
+}
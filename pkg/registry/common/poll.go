@@ -0,0 +1,25 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Poll calls check every interval until it reports done or fails, or ctx is done. The first check
+// runs right away.
+func Poll(ctx context.Context, interval time.Duration, check func() (bool, error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		done, err := check()
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped waiting: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
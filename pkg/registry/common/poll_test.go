@@ -0,0 +1,37 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoll(t *testing.T) {
+	t.Run("stops once done", func(t *testing.T) {
+		calls := 0
+		err := Poll(context.Background(), time.Millisecond, func() (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("returns the error of a check", func(t *testing.T) {
+		err := Poll(context.Background(), time.Millisecond, func() (bool, error) {
+			return false, errors.New("boom")
+		})
+		require.EqualError(t, err, "boom")
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := Poll(ctx, time.Millisecond, func() (bool, error) { return false, nil })
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorContains(t, err, "stopped waiting")
+	})
+}
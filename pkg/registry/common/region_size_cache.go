@@ -0,0 +1,106 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+)
+
+// DefaultRegionSizeRefreshInterval is how often a RegionSizeCache refetches the regions and sizes.
+// They only change when DigitalOcean opens a region or launches a size.
+const DefaultRegionSizeRefreshInterval = 15 * time.Minute
+
+// RegionSizeCache keeps every region and droplet size in memory, refreshed in the background, so
+// that region-list and size-list answer without calling the API. The data is fetched with a single
+// client and only served to calls made with that client, since the sizes an account can create
+// depend on the account.
+type RegionSizeCache struct {
+	client   func(ctx context.Context) (*godo.Client, error)
+	interval time.Duration
+
+	mu      sync.RWMutex
+	fetched *godo.Client
+	regions []godo.Region
+	sizes   []godo.Size
+}
+
+// NewRegionSizeCache creates a RegionSizeCache fetching with client every interval once Run is
+// called.
+func NewRegionSizeCache(client func(ctx context.Context) (*godo.Client, error), interval time.Duration) *RegionSizeCache {
+	return &RegionSizeCache{client: client, interval: interval}
+}
+
+// Refresh fetches every region and size and replaces the cached ones. On error, the cached data is
+// kept.
+func (c *RegionSizeCache) Refresh(ctx context.Context) error {
+	client, err := c.client(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	regions, err := ListAllRegions(ctx, client)
+	if err != nil {
+		return fmt.Errorf("failed to list regions: %w", err)
+	}
+	sizes, err := ListAllSizes(ctx, client)
+	if err != nil {
+		return fmt.Errorf("failed to list sizes: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fetched, c.regions, c.sizes = client, regions, sizes
+	return nil
+}
+
+// Run refreshes the cache right away, then every interval until ctx is cancelled. Failed refreshes
+// are logged, and the calls are served by the API until one succeeds.
+func (c *RegionSizeCache) Run(ctx context.Context, logger *slog.Logger) {
+	refresh := func() {
+		if err := c.Refresh(ctx); err != nil && ctx.Err() == nil {
+			logger.Warn("failed to refresh the cached regions and sizes: " + err.Error())
+		}
+	}
+	refresh()
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
+
+// Regions returns the cached regions, or false when there are none for client. A nil cache has
+// none.
+func (c *RegionSizeCache) Regions(client *godo.Client) ([]godo.Region, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.fetched == nil || c.fetched != client {
+		return nil, false
+	}
+	return c.regions, true
+}
+
+// Sizes returns the cached droplet sizes, or false when there are none for client. A nil cache has
+// none.
+func (c *RegionSizeCache) Sizes(client *godo.Client) ([]godo.Size, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.fetched == nil || c.fetched != client {
+		return nil, false
+	}
+	return c.sizes, true
+}
@@ -0,0 +1,133 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRegionSizeCache_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRegions := NewMockRegionsService(ctrl)
+	mockSizes := NewMockSizesService(ctrl)
+	client := &godo.Client{Regions: mockRegions, Sizes: mockSizes}
+	cache := NewRegionSizeCache(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	}, time.Minute)
+
+	_, ok := cache.Regions(client)
+	require.False(t, ok, "nothing is cached before the first refresh")
+
+	regions := []godo.Region{{Slug: "nyc3"}, {Slug: "fra1"}}
+	sizes := []godo.Size{{Slug: "s-1vcpu-1gb"}}
+	mockRegions.EXPECT().List(gomock.Any(), gomock.Any()).Return(regions, &godo.Response{}, nil)
+	mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, &godo.Response{}, nil)
+	require.NoError(t, cache.Refresh(context.Background()))
+
+	gotRegions, ok := cache.Regions(client)
+	require.True(t, ok)
+	require.Equal(t, regions, gotRegions)
+	gotSizes, ok := cache.Sizes(client)
+	require.True(t, ok)
+	require.Equal(t, sizes, gotSizes)
+
+	_, ok = cache.Sizes(&godo.Client{})
+	require.False(t, ok, "the cached data is only served to the client that fetched it")
+
+	// A failed refresh keeps the data of the last one.
+	mockRegions.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api down"))
+	require.ErrorContains(t, cache.Refresh(context.Background()), "failed to list regions: api down")
+	gotRegions, ok = cache.Regions(client)
+	require.True(t, ok)
+	require.Equal(t, regions, gotRegions)
+
+	var nilCache *RegionSizeCache
+	_, ok = nilCache.Regions(client)
+	require.False(t, ok)
+}
+
+func TestRegionSizeCache_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRegions := NewMockRegionsService(ctrl)
+	mockSizes := NewMockSizesService(ctrl)
+	client := &godo.Client{Regions: mockRegions, Sizes: mockSizes}
+
+	var refreshes atomic.Int32
+	mockRegions.EXPECT().List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, *godo.ListOptions) ([]godo.Region, *godo.Response, error) {
+			n := refreshes.Add(1)
+			return []godo.Region{{Slug: "nyc3", Sizes: make([]string, n)}}, &godo.Response{}, nil
+		}).
+		AnyTimes()
+	mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Size{{Slug: "s-1vcpu-1gb"}}, &godo.Response{}, nil).AnyTimes()
+
+	cache := NewRegionSizeCache(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	}, 5*time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		cache.Run(ctx, slog.New(slog.DiscardHandler))
+		close(done)
+	}()
+
+	// The data is fetched on start, then refreshed on every tick.
+	require.Eventually(t, func() bool {
+		regions, ok := cache.Regions(client)
+		return ok && len(regions[0].Sizes) >= 3
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return once its context was cancelled")
+	}
+	stopped := refreshes.Load()
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, stopped, refreshes.Load(), "no refresh may run after Run returned")
+}
+
+func TestRegionTools_listRegionsCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRegions := NewMockRegionsService(ctrl)
+	mockSizes := NewMockSizesService(ctrl)
+	client := &godo.Client{Regions: mockRegions, Sizes: mockSizes}
+	getClient := func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	}
+
+	cache := NewRegionSizeCache(getClient, time.Minute)
+	cached := []godo.Region{{Slug: "nyc1"}, {Slug: "nyc3"}, {Slug: "sfo3"}}
+	mockRegions.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: listAllPageSize}).Return(cached, &godo.Response{}, nil)
+	mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, &godo.Response{}, nil)
+	require.NoError(t, cache.Refresh(context.Background()))
+	tool := NewRegionTools(getClient, cache)
+
+	list := func(args map[string]any) []godo.Region {
+		resp, err := tool.listRegions(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		var regions []godo.Region
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &regions))
+		return regions
+	}
+
+	// Pages are taken from the cache without calling the API.
+	require.Equal(t, cached[2:], list(map[string]any{"Page": float64(2), "PerPage": float64(2)}))
+	require.Equal(t, cached, list(map[string]any{}))
+
+	// fresh goes to the API.
+	live := []godo.Region{{Slug: "ams3"}}
+	mockRegions.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 2}).Return(live, &godo.Response{}, nil)
+	require.Equal(t, live, list(map[string]any{"Page": float64(1), "PerPage": float64(2), "fresh": true}))
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"mcp-digitalocean/pkg/response"
+	"slices"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,19 +15,37 @@ import (
 const (
 	defaultRegionsPageSize = 50
 	defaultRegionsPage     = 1
+
+	// listAllPageSize is the page size used when fetching every region or size.
+	listAllPageSize = 200
 )
 
 // RegionTools provides tool-based handlers for DigitalOcean regions.
 type RegionTools struct {
 	client func(ctx context.Context) (*godo.Client, error)
+	cache  *RegionSizeCache
+}
+
+// NewRegionTools creates a new RegionTools instance. region-list serves the regions held by cache
+// when it has them, cache may be nil.
+func NewRegionTools(client func(ctx context.Context) (*godo.Client, error), cache *RegionSizeCache) *RegionTools {
+	return &RegionTools{client: client, cache: cache}
 }
 
-// NewRegionTools creates a new RegionTools instance.
-func NewRegionTools(client func(ctx context.Context) (*godo.Client, error)) *RegionTools {
-	return &RegionTools{client: client}
+// Paginate returns the page of items selected by opt, pages starting at 1.
+func Paginate[T any](items []T, opt *godo.ListOptions) []T {
+	if opt.Page < 1 || opt.PerPage < 1 {
+		return items
+	}
+	start := (opt.Page - 1) * opt.PerPage
+	if start >= len(items) {
+		return []T{}
+	}
+	return items[start:min(start+opt.PerPage, len(items))]
 }
 
-// listRegions lists all available regions with pagination support.
+// listRegions lists all available regions with pagination support. The page is taken from the
+// cached regions unless fresh is set.
 func (r *RegionTools) listRegions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	page, ok := req.GetArguments()["Page"].(float64)
 	if !ok {
@@ -35,6 +55,7 @@ func (r *RegionTools) listRegions(ctx context.Context, req mcp.CallToolRequest)
 	if !ok {
 		perPage = defaultRegionsPageSize
 	}
+	fresh, _ := req.GetArguments()["fresh"].(bool)
 
 	opt := &godo.ListOptions{
 		Page:    int(page),
@@ -46,9 +67,14 @@ func (r *RegionTools) listRegions(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	regions, _, err := client.Regions.List(ctx, opt)
-	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	regions, cached := r.cache.Regions(client)
+	if cached && !fresh {
+		regions = Paginate(regions, opt)
+	} else {
+		regions, _, err = client.Regions.List(ctx, opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
 	}
 
 	jsonData, err := json.MarshalIndent(regions, "", "  ")
@@ -59,6 +85,89 @@ func (r *RegionTools) listRegions(ctx context.Context, req mcp.CallToolRequest)
 	return mcp.NewToolResultText(string(jsonData)), nil
 }
 
+// ListAllRegions returns every region, following pagination.
+func ListAllRegions(ctx context.Context, client *godo.Client) ([]godo.Region, error) {
+	var all []godo.Region
+	opt := &godo.ListOptions{Page: 1, PerPage: listAllPageSize}
+	for {
+		regions, resp, err := client.Regions.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, regions...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return all, nil
+		}
+		opt.Page++
+	}
+}
+
+// ListAllSizes returns every droplet size, following pagination.
+func ListAllSizes(ctx context.Context, client *godo.Client) ([]godo.Size, error) {
+	var all []godo.Size
+	opt := &godo.ListOptions{Page: 1, PerPage: listAllPageSize}
+	for {
+		sizes, resp, err := client.Sizes.List(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, sizes...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return all, nil
+		}
+		opt.Page++
+	}
+}
+
+// listRegionsForSize lists the available regions in which a droplet size can be created.
+func (r *RegionTools) listRegionsForSize(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slug, _ := req.GetArguments()["Size"].(string)
+	if slug == "" {
+		return mcp.NewToolResultError("Size is required"), nil
+	}
+
+	client, err := r.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	sizes, err := ListAllSizes(ctx, client)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	i := slices.IndexFunc(sizes, func(s godo.Size) bool { return s.Slug == slug })
+	if i < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("unknown size slug: %s", slug)), nil
+	}
+	size := sizes[i]
+
+	regions, err := ListAllRegions(ctx, client)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	filteredRegions := []map[string]any{}
+	for _, region := range regions {
+		if !region.Available || !size.Available {
+			continue
+		}
+		if slices.Contains(region.Sizes, slug) || slices.Contains(size.Regions, region.Slug) {
+			filteredRegions = append(filteredRegions, map[string]any{
+				"slug":     region.Slug,
+				"name":     region.Name,
+				"features": region.Features,
+			})
+		}
+	}
+
+	jsonData, err := response.CompactJSON(filteredRegions)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(jsonData), nil
+}
+
 // Tools returns the list of server tools for regions.
 func (r *RegionTools) Tools() []server.ServerTool {
 	return []server.ServerTool{
@@ -69,6 +178,15 @@ func (r *RegionTools) Tools() []server.ServerTool {
 				mcp.WithDescription("List all available regions with features and droplet size availability. Supports pagination."),
 				mcp.WithNumber("Page", mcp.DefaultNumber(defaultRegionsPage), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultRegionsPageSize), mcp.Description("Items per page")),
+				mcp.WithBoolean("fresh", mcp.DefaultBool(false), mcp.Description("Fetch the regions from the API instead of the server's cache, which is refreshed every few minutes")),
+			),
+		},
+		{
+			Handler: r.listRegionsForSize,
+			Tool: mcp.NewTool(
+				"region-list-for-size",
+				mcp.WithDescription("List the available regions in which droplets of the given size can be created"),
+				mcp.WithString("Size", mcp.Required(), mcp.Description("Slug of the droplet size (e.g., s-1vcpu-1gb)")),
 			),
 		},
 	}
@@ -13,13 +13,18 @@ import (
 )
 
 func setupRegionToolsWithMock(mockRegions *MockRegionsService) *RegionTools {
+	return setupRegionToolsWithMocks(mockRegions, nil)
+}
+
+func setupRegionToolsWithMocks(mockRegions *MockRegionsService, mockSizes *MockSizesService) *RegionTools {
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
 			Regions: mockRegions,
+			Sizes:   mockSizes,
 		}, nil
 	}
 
-	return NewRegionTools(client)
+	return NewRegionTools(client, nil)
 }
 
 func TestRegionTools_listRegions(t *testing.T) {
@@ -116,3 +121,110 @@ func TestRegionTools_listRegions(t *testing.T) {
 		})
 	}
 }
+
+func TestRegionTools_listRegionsForSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sizes := []godo.Size{
+		{Slug: "s-1vcpu-1gb", Available: true, Regions: []string{"nyc1", "sfo2"}},
+		{Slug: "gpu-h100x1-80gb", Available: true, Regions: []string{"tor1"}},
+		{Slug: "s-retired", Available: false, Regions: []string{"nyc1"}},
+	}
+	regionsPage1 := []godo.Region{
+		{Slug: "nyc1", Name: "New York 1", Available: true, Sizes: []string{"s-1vcpu-1gb"}},
+		{Slug: "sfo2", Name: "San Francisco 2", Available: false, Sizes: []string{"s-1vcpu-1gb"}},
+	}
+	regionsPage2 := []godo.Region{
+		{Slug: "tor1", Name: "Toronto 1", Available: true, Sizes: []string{"gpu-h100x1-80gb"}},
+	}
+	nextPage := &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "https://api.digitalocean.com/v2/regions?page=2"}}}
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		mockSetup     func(*MockRegionsService, *MockSizesService)
+		expectError   string
+		expectRegions []string
+	}{
+		{
+			name: "Regions offering the size across pages",
+			args: map[string]any{"Size": "gpu-h100x1-80gb"},
+			mockSetup: func(r *MockRegionsService, s *MockSizesService) {
+				s.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return(sizes, &godo.Response{}, nil)
+				r.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return(regionsPage1, nextPage, nil)
+				r.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 200}).Return(regionsPage2, &godo.Response{}, nil)
+			},
+			expectRegions: []string{"tor1"},
+		},
+		{
+			name: "Unavailable regions are skipped",
+			args: map[string]any{"Size": "s-1vcpu-1gb"},
+			mockSetup: func(r *MockRegionsService, s *MockSizesService) {
+				s.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, &godo.Response{}, nil)
+				r.EXPECT().List(gomock.Any(), gomock.Any()).Return(regionsPage1, &godo.Response{}, nil)
+			},
+			expectRegions: []string{"nyc1"},
+		},
+		{
+			name: "Unavailable size",
+			args: map[string]any{"Size": "s-retired"},
+			mockSetup: func(r *MockRegionsService, s *MockSizesService) {
+				s.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, &godo.Response{}, nil)
+				r.EXPECT().List(gomock.Any(), gomock.Any()).Return(regionsPage1, &godo.Response{}, nil)
+			},
+			expectRegions: []string{},
+		},
+		{
+			name: "Unknown size",
+			args: map[string]any{"Size": "s-unknown"},
+			mockSetup: func(r *MockRegionsService, s *MockSizesService) {
+				s.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, &godo.Response{}, nil)
+			},
+			expectError: "unknown size slug: s-unknown",
+		},
+		{
+			name:        "Missing size",
+			args:        map[string]any{},
+			expectError: "Size is required",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"Size": "s-1vcpu-1gb"},
+			mockSetup: func(r *MockRegionsService, s *MockSizesService) {
+				s.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("boom"))
+			},
+			expectError: "boom",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRegionsSvc := NewMockRegionsService(ctrl)
+			mockSizesSvc := NewMockSizesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockRegionsSvc, mockSizesSvc)
+			}
+			tool := setupRegionToolsWithMocks(mockRegionsSvc, mockSizesSvc)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.listRegionsForSize(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var regionsOut []struct {
+				Slug string `json:"slug"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &regionsOut))
+			slugs := []string{}
+			for _, r := range regionsOut {
+				slugs = append(slugs, r.Slug)
+			}
+			require.Equal(t, tc.expectRegions, slugs)
+		})
+	}
+}
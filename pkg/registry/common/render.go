@@ -0,0 +1,65 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"mcp-digitalocean/pkg/response"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Standard output arguments of tools rendering through response.Render, besides TimezoneArg.
+const (
+	FormatArg   = "format"
+	FieldsArg   = "fields"
+	MaxBytesArg = "max_bytes"
+)
+
+// WithRenderOptions declares the format, fields, timezone and max_bytes arguments read by
+// RenderOptions, with uniform descriptions.
+func WithRenderOptions() mcp.ToolOption {
+	options := []mcp.ToolOption{
+		mcp.WithString(FormatArg, mcp.Enum(response.Formats...), mcp.Description("Output format: json (compact, default) or pretty (indented)")),
+		mcp.WithArray(FieldsArg, mcp.Items(map[string]any{"type": "string"}), mcp.Description("Only return these fields, as keys or dotted paths such as team.name. Applies to each item of a list")),
+		WithTimezone(),
+		mcp.WithNumber(MaxBytesArg, mcp.Min(1), mcp.Description("Cap the response at this many bytes. Lists are cut short and marked truncated")),
+	}
+	return func(t *mcp.Tool) {
+		for _, option := range options {
+			option(t)
+		}
+	}
+}
+
+// RenderOptions returns the output options set by the format, fields, timezone and max_bytes arguments.
+func (a *Args) RenderOptions() response.RenderOptions {
+	opts := response.RenderOptions{
+		Format:   a.OptionalEnum(FormatArg, response.FormatNameJSON, response.Formats...),
+		Fields:   a.OptionalStrings(FieldsArg),
+		Location: a.Timezone(),
+		MaxBytes: a.OptionalInt(MaxBytesArg, 0),
+	}
+	for _, field := range opts.Fields {
+		if strings.TrimSpace(field) == "" {
+			a.fail("argument '%s' must not contain empty fields", FieldsArg)
+		}
+	}
+	if opts.MaxBytes < 0 {
+		a.fail("argument '%s' must be positive", MaxBytesArg)
+	}
+	return opts
+}
+
+// RenderResult renders v with response.Render into a tool result. Output options that can't be
+// honoured, such as an unknown field, are reported as a tool error.
+func RenderResult(v any, opts response.RenderOptions) (*mcp.CallToolResult, error) {
+	text, err := response.Render(v, opts)
+	if errors.Is(err, response.ErrTooLarge) || errors.Is(err, response.ErrUnknownField) {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(text), nil
+}
@@ -0,0 +1,222 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// searchPageSize is the page size used when listing resources to search.
+	searchPageSize = 200
+	// maxSearchAPICalls caps the list requests a single search makes across all resource types.
+	maxSearchAPICalls = 25
+)
+
+// SearchHit is a resource whose name or tags match a search query.
+type SearchHit struct {
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Region string `json:"region,omitempty"`
+}
+
+// searchResult is returned by search-resources. Types listed in Truncated hit the API call cap
+// before all their pages were searched; types in Errors could not be listed.
+type searchResult struct {
+	Hits      []SearchHit       `json:"hits"`
+	Truncated []string          `json:"truncated,omitempty"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// searchCandidate is a listed resource together with the tags it can be matched on.
+type searchCandidate struct {
+	hit  SearchHit
+	tags []string
+}
+
+// searchLister lists one page of resources of a type.
+type searchLister func(ctx context.Context, client *godo.Client, opt *godo.ListOptions) ([]searchCandidate, *godo.Response, error)
+
+// searchTypes are the resource types search-resources can search, in result order.
+var searchTypes = []string{"droplet", "volume", "database", "lb", "domain"}
+
+var searchListers = map[string]searchLister{
+	"droplet": func(ctx context.Context, client *godo.Client, opt *godo.ListOptions) ([]searchCandidate, *godo.Response, error) {
+		droplets, resp, err := client.Droplets.List(ctx, opt)
+		candidates := make([]searchCandidate, len(droplets))
+		for i, d := range droplets {
+			candidates[i] = searchCandidate{hit: SearchHit{ID: strconv.Itoa(d.ID), Name: d.Name, Region: regionSlug(d.Region)}, tags: d.Tags}
+		}
+		return candidates, resp, err
+	},
+	"volume": func(ctx context.Context, client *godo.Client, opt *godo.ListOptions) ([]searchCandidate, *godo.Response, error) {
+		volumes, resp, err := client.Storage.ListVolumes(ctx, &godo.ListVolumeParams{ListOptions: opt})
+		candidates := make([]searchCandidate, len(volumes))
+		for i, v := range volumes {
+			candidates[i] = searchCandidate{hit: SearchHit{ID: v.ID, Name: v.Name, Region: regionSlug(v.Region)}, tags: v.Tags}
+		}
+		return candidates, resp, err
+	},
+	"database": func(ctx context.Context, client *godo.Client, opt *godo.ListOptions) ([]searchCandidate, *godo.Response, error) {
+		databases, resp, err := client.Databases.List(ctx, opt)
+		candidates := make([]searchCandidate, len(databases))
+		for i, db := range databases {
+			candidates[i] = searchCandidate{hit: SearchHit{ID: db.ID, Name: db.Name, Region: db.RegionSlug}, tags: db.Tags}
+		}
+		return candidates, resp, err
+	},
+	"lb": func(ctx context.Context, client *godo.Client, opt *godo.ListOptions) ([]searchCandidate, *godo.Response, error) {
+		lbs, resp, err := client.LoadBalancers.List(ctx, opt)
+		candidates := make([]searchCandidate, len(lbs))
+		for i, lb := range lbs {
+			candidates[i] = searchCandidate{hit: SearchHit{ID: lb.ID, Name: lb.Name, Region: regionSlug(lb.Region)}, tags: lb.Tags}
+		}
+		return candidates, resp, err
+	},
+	"domain": func(ctx context.Context, client *godo.Client, opt *godo.ListOptions) ([]searchCandidate, *godo.Response, error) {
+		domains, resp, err := client.Domains.List(ctx, opt)
+		candidates := make([]searchCandidate, len(domains))
+		for i, d := range domains {
+			candidates[i] = searchCandidate{hit: SearchHit{ID: d.Name, Name: d.Name}}
+		}
+		return candidates, resp, err
+	},
+}
+
+func regionSlug(region *godo.Region) string {
+	if region == nil {
+		return ""
+	}
+	return region.Slug
+}
+
+// matches reports whether the candidate's name or one of its tags contains the lower-cased query.
+func (c searchCandidate) matches(query string) bool {
+	if strings.Contains(strings.ToLower(c.hit.Name), query) {
+		return true
+	}
+	return slices.ContainsFunc(c.tags, func(tag string) bool {
+		return strings.Contains(strings.ToLower(tag), query)
+	})
+}
+
+// SearchTool provides a tool searching resources of several types by name and tag.
+type SearchTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewSearchTool creates a new SearchTool instance.
+func NewSearchTool(client func(ctx context.Context) (*godo.Client, error)) *SearchTool {
+	return &SearchTool{client: client}
+}
+
+// searchResources lists every requested resource type concurrently and returns those whose name or
+// tags contain the query. The number of list requests is capped by maxSearchAPICalls.
+func (s *SearchTool) searchResources(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	query, _ := req.GetArguments()["Query"].(string)
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return mcp.NewToolResultError("Query is required"), nil
+	}
+
+	types := searchTypes
+	if typeArgs, ok := req.GetArguments()["Types"].([]any); ok && len(typeArgs) > 0 {
+		types = nil
+		for _, v := range typeArgs {
+			t, _ := v.(string)
+			if !slices.Contains(searchTypes, t) {
+				return mcp.NewToolResultError(fmt.Sprintf("invalid type %q, must be one of: %s", t, strings.Join(searchTypes, ", "))), nil
+			}
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	var (
+		calls     atomic.Int32
+		wg        sync.WaitGroup
+		hits      = make([][]SearchHit, len(types))
+		truncated = make([]bool, len(types))
+		errs      = make([]error, len(types))
+	)
+	for i, t := range types {
+		wg.Go(func() {
+			list := searchListers[t]
+			opt := &godo.ListOptions{Page: 1, PerPage: searchPageSize}
+			for {
+				if calls.Add(1) > maxSearchAPICalls {
+					truncated[i] = true
+					return
+				}
+				candidates, resp, err := list(ctx, client, opt)
+				if err != nil {
+					errs[i] = err
+					return
+				}
+				for _, c := range candidates {
+					if c.matches(query) {
+						c.hit.Type = t
+						hits[i] = append(hits[i], c.hit)
+					}
+				}
+				if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+					return
+				}
+				opt.Page++
+			}
+		})
+	}
+	wg.Wait()
+
+	result := searchResult{Hits: []SearchHit{}}
+	for i, t := range types {
+		result.Hits = append(result.Hits, hits[i]...)
+		if truncated[i] {
+			result.Truncated = append(result.Truncated, t)
+		}
+		if errs[i] != nil {
+			if result.Errors == nil {
+				result.Errors = map[string]string{}
+			}
+			result.Errors[t] = errs[i].Error()
+		}
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// Tools returns the list of server tools for resource search.
+func (s *SearchTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: s.searchResources,
+			Tool: mcp.NewTool(
+				"search-resources",
+				mcp.WithDescription("Find resources by name or tag across droplets, volumes, databases, load balancers and domains. Matching is case-insensitive and returns the type, ID, name and region of each hit."),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("Query", mcp.Required(), mcp.Description("Text to look for in resource names and tags")),
+				mcp.WithArray("Types", mcp.Description("Resource types to search (droplet, volume, database, lb, domain). Searches all types if omitted."), mcp.Items(map[string]any{"type": "string", "enum": searchTypes})),
+			),
+		},
+	}
+}
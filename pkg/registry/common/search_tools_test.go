@@ -0,0 +1,130 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSearchTool returns a SearchTool backed by a real godo client pointed at a test server serving
+// the given path responses, and the number of requests the server received.
+func setupSearchTool(t *testing.T, handler http.HandlerFunc) (*SearchTool, *atomic.Int32) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client := godo.NewClient(srv.Client())
+	client.BaseURL = baseURL
+
+	return NewSearchTool(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	}), &requests
+}
+
+func callSearch(t *testing.T, tool *SearchTool, args map[string]any) (*mcp.CallToolResult, searchResult) {
+	t.Helper()
+	res, err := tool.searchResources(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	var out searchResult
+	if !res.IsError {
+		require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &out))
+	}
+	return res, out
+}
+
+func TestSearchTool_searchResources(t *testing.T) {
+	responses := map[string]string{
+		"/v2/droplets":       `{"droplets":[{"id":1,"name":"web-prod-1","region":{"slug":"nyc3"}},{"id":2,"name":"worker","tags":["Prod"],"region":{"slug":"ams3"}},{"id":3,"name":"dev"}]}`,
+		"/v2/volumes":        `{"volumes":[{"id":"vol-1","name":"prod-data","region":{"slug":"nyc3"}}]}`,
+		"/v2/databases":      `{"databases":[{"id":"db-1","name":"staging-pg","region":"fra1"}]}`,
+		"/v2/load_balancers": `{"load_balancers":[{"id":"lb-1","name":"prod-lb","region":{"slug":"nyc3"}}]}`,
+		"/v2/domains":        `{"domains":[{"name":"prod.example.com"}]}`,
+	}
+
+	t.Run("Matches names and tags across types", func(t *testing.T) {
+		tool, _ := setupSearchTool(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(responses[r.URL.Path]))
+		})
+		res, out := callSearch(t, tool, map[string]any{"Query": "PROD"})
+		require.False(t, res.IsError)
+		require.Equal(t, []SearchHit{
+			{Type: "droplet", ID: "1", Name: "web-prod-1", Region: "nyc3"},
+			{Type: "droplet", ID: "2", Name: "worker", Region: "ams3"},
+			{Type: "volume", ID: "vol-1", Name: "prod-data", Region: "nyc3"},
+			{Type: "lb", ID: "lb-1", Name: "prod-lb", Region: "nyc3"},
+			{Type: "domain", ID: "prod.example.com", Name: "prod.example.com"},
+		}, out.Hits)
+		require.Empty(t, out.Errors)
+	})
+
+	t.Run("Types filter limits the listed endpoints", func(t *testing.T) {
+		tool, requests := setupSearchTool(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(responses[r.URL.Path]))
+		})
+		res, out := callSearch(t, tool, map[string]any{"Query": "staging", "Types": []any{"database"}})
+		require.False(t, res.IsError)
+		require.Equal(t, []SearchHit{{Type: "database", ID: "db-1", Name: "staging-pg", Region: "fra1"}}, out.Hits)
+		require.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("Follows pages", func(t *testing.T) {
+		tool, _ := setupSearchTool(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("page") == "1" {
+				_, _ = w.Write([]byte(`{"domains":[{"name":"a.example.com"}],"links":{"pages":{"next":"http://example.com/v2/domains?page=2","last":"http://example.com/v2/domains?page=2"}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"domains":[{"name":"b.example.com"}]}`))
+		})
+		res, out := callSearch(t, tool, map[string]any{"Query": "example", "Types": []any{"domain"}})
+		require.False(t, res.IsError)
+		require.Len(t, out.Hits, 2)
+	})
+
+	t.Run("API calls are capped", func(t *testing.T) {
+		tool, requests := setupSearchTool(t, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"domains":[],"links":{"pages":{"next":"http://example.com/v2/domains?page=1000","last":"http://example.com/v2/domains?page=1000"}}}`))
+		})
+		res, out := callSearch(t, tool, map[string]any{"Query": "example", "Types": []any{"domain"}})
+		require.False(t, res.IsError)
+		require.Equal(t, []string{"domain"}, out.Truncated)
+		require.Equal(t, int32(maxSearchAPICalls), requests.Load())
+	})
+
+	t.Run("Failing type is reported without failing the search", func(t *testing.T) {
+		tool, _ := setupSearchTool(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/v2/databases" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"id":"forbidden","message":"not allowed"}`))
+				return
+			}
+			_, _ = w.Write([]byte(responses[r.URL.Path]))
+		})
+		res, out := callSearch(t, tool, map[string]any{"Query": "prod", "Types": []any{"database", "volume"}})
+		require.False(t, res.IsError)
+		require.Equal(t, []SearchHit{{Type: "volume", ID: "vol-1", Name: "prod-data", Region: "nyc3"}}, out.Hits)
+		require.Contains(t, out.Errors["database"], "not allowed")
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		tool, requests := setupSearchTool(t, func(w http.ResponseWriter, r *http.Request) {})
+		res, _ := callSearch(t, tool, map[string]any{"Query": " "})
+		require.True(t, res.IsError)
+		res, _ = callSearch(t, tool, map[string]any{"Query": "prod", "Types": []any{"bucket"}})
+		require.True(t, res.IsError)
+		require.Equal(t, int32(0), requests.Load())
+	})
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables read by ResolveToken, after the explicit configuration.
+const (
+	AccessTokenEnv = "DIGITALOCEAN_ACCESS_TOKEN"
+	TokenEnv       = "DIGITALOCEAN_TOKEN"
+)
+
+// TokenConfig is where ResolveToken looks for the DigitalOcean API token.
+type TokenConfig struct {
+	// Token is an explicitly configured token, e.g. from a flag.
+	Token string
+	// TokenFile is the path of a file holding the token, e.g. a mounted secret.
+	TokenFile string
+}
+
+// ErrNoToken is returned by ResolveToken when no source provides a token.
+var ErrNoToken = errors.New("no DigitalOcean API token found")
+
+// ResolveToken returns the API token and a description of its source, trying in order the explicit
+// Token, the DIGITALOCEAN_ACCESS_TOKEN and DIGITALOCEAN_TOKEN environment variables and the TokenFile.
+// Surrounding whitespace is trimmed and a blank value counts as unset. A configured token file that
+// can't be read or is empty is an error, as is finding no token at all.
+func ResolveToken(cfg TokenConfig) (token, source string, err error) {
+	if token := strings.TrimSpace(cfg.Token); token != "" {
+		return token, "config", nil
+	}
+	for _, env := range []string{AccessTokenEnv, TokenEnv} {
+		if token := strings.TrimSpace(os.Getenv(env)); token != "" {
+			return token, "env:" + env, nil
+		}
+	}
+	if cfg.TokenFile != "" {
+		data, err := os.ReadFile(cfg.TokenFile)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read token file: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return "", "", fmt.Errorf("token file %s is empty", cfg.TokenFile)
+		}
+		return token, "file:" + cfg.TokenFile, nil
+	}
+	return "", "", fmt.Errorf("%w: set it with the explicit configuration, the %s or %s environment variable, or a token file", ErrNoToken, AccessTokenEnv, TokenEnv)
+}
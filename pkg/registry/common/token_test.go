@@ -0,0 +1,84 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveToken(t *testing.T) {
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("file-token\n"), 0o600))
+	emptyFile := filepath.Join(dir, "empty")
+	require.NoError(t, os.WriteFile(emptyFile, []byte(" \n"), 0o600))
+
+	tests := []struct {
+		name         string
+		cfg          TokenConfig
+		env          map[string]string
+		expectToken  string
+		expectSource string
+		expectError  string
+	}{
+		{
+			name:         "Explicit token wins",
+			cfg:          TokenConfig{Token: " config-token ", TokenFile: tokenFile},
+			env:          map[string]string{AccessTokenEnv: "access-token", TokenEnv: "token"},
+			expectToken:  "config-token",
+			expectSource: "config",
+		},
+		{
+			name:         "Access token variable before token variable",
+			cfg:          TokenConfig{TokenFile: tokenFile},
+			env:          map[string]string{AccessTokenEnv: "access-token", TokenEnv: "token"},
+			expectToken:  "access-token",
+			expectSource: "env:" + AccessTokenEnv,
+		},
+		{
+			name:         "Token variable before file",
+			cfg:          TokenConfig{Token: "  ", TokenFile: tokenFile},
+			env:          map[string]string{AccessTokenEnv: " ", TokenEnv: "token"},
+			expectToken:  "token",
+			expectSource: "env:" + TokenEnv,
+		},
+		{
+			name:         "Token file",
+			cfg:          TokenConfig{TokenFile: tokenFile},
+			expectToken:  "file-token",
+			expectSource: "file:" + tokenFile,
+		},
+		{
+			name:        "Missing token file",
+			cfg:         TokenConfig{TokenFile: filepath.Join(dir, "missing")},
+			expectError: "failed to read token file",
+		},
+		{
+			name:        "Empty token file",
+			cfg:         TokenConfig{TokenFile: emptyFile},
+			expectError: "is empty",
+		},
+		{
+			name:        "No token",
+			expectError: "no DigitalOcean API token found",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(AccessTokenEnv, tc.env[AccessTokenEnv])
+			t.Setenv(TokenEnv, tc.env[TokenEnv])
+
+			token, source, err := ResolveToken(tc.cfg)
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectToken, token)
+			require.Equal(t, tc.expectSource, source)
+		})
+	}
+}
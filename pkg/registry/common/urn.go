@@ -0,0 +1,93 @@
+package common
+
+import (
+	"fmt"
+	"maps"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// urnPrefix starts every DigitalOcean uniform resource name, such as do:droplet:123.
+const urnPrefix = "do"
+
+// URNResourceTypes are the resource types of the URNs accepted by the projects and tags APIs.
+var URNResourceTypes = []string{
+	"app", "dbaas", "domain", "droplet", "floatingip", "kubernetes", "loadbalancer", "reservedip", "space", "volume", "vpc",
+}
+
+// urnTypeAliases maps the friendlier names of resource types, as used by the tools, to their URN type.
+var urnTypeAliases = map[string]string{
+	"database":      "dbaas",
+	"load-balancer": "loadbalancer",
+	"floating-ip":   "floatingip",
+	"reserved-ip":   "reservedip",
+	"bucket":        "space",
+	"doks":          "kubernetes",
+}
+
+// URNTypeAliases returns the accepted aliases of URN resource types, sorted.
+func URNTypeAliases() []string {
+	return slices.Sorted(maps.Keys(urnTypeAliases))
+}
+
+// urnType returns the URN type of a resource type or of one of its aliases.
+func urnType(resourceType string) (string, error) {
+	t := strings.ToLower(strings.TrimSpace(resourceType))
+	if alias, ok := urnTypeAliases[t]; ok {
+		t = alias
+	}
+	if !slices.Contains(URNResourceTypes, t) {
+		return "", fmt.Errorf("unknown resource type %q, must be one of: %s", resourceType, strings.Join(URNResourceTypes, ", "))
+	}
+	return t, nil
+}
+
+// validateURNID checks that id is a valid identifier for a resource of the URN type t: a number for
+// droplets, an IP address for reserved and floating IPs, and otherwise any identifier without spaces
+// or colons.
+func validateURNID(t, id string) error {
+	switch {
+	case id == "":
+		return fmt.Errorf("resource id is required")
+	case strings.ContainsAny(id, ": \t\n"):
+		return fmt.Errorf("resource id %q must not contain colons or spaces", id)
+	case t == "droplet" && strings.Trim(id, "0123456789") != "":
+		return fmt.Errorf("droplet id must be a number, got %q", id)
+	case t == "reservedip" || t == "floatingip":
+		if _, err := netip.ParseAddr(id); err != nil {
+			return fmt.Errorf("%s id must be an IP address, got %q", t, id)
+		}
+	}
+	return nil
+}
+
+// BuildURN returns the URN of a resource, e.g. do:droplet:123 for BuildURN("droplet", "123"). The
+// type may be one of URNResourceTypes or an alias such as "database".
+func BuildURN(resourceType, id string) (string, error) {
+	t, err := urnType(resourceType)
+	if err != nil {
+		return "", err
+	}
+	id = strings.TrimSpace(id)
+	if err := validateURNID(t, id); err != nil {
+		return "", err
+	}
+	return urnPrefix + ":" + t + ":" + id, nil
+}
+
+// ParseURN splits a URN such as do:droplet:123 into its resource type and id, validating both.
+func ParseURN(urn string) (resourceType, id string, err error) {
+	parts := strings.SplitN(strings.TrimSpace(urn), ":", 3)
+	if len(parts) != 3 || parts[0] != urnPrefix {
+		return "", "", fmt.Errorf("invalid URN %q, must look like do:<type>:<id>, e.g. do:droplet:123", urn)
+	}
+	t, err := urnType(parts[1])
+	if err != nil {
+		return "", "", err
+	}
+	if err := validateURNID(t, parts[2]); err != nil {
+		return "", "", err
+	}
+	return t, parts[2], nil
+}
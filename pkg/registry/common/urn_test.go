@@ -0,0 +1,129 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildURN(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		id           string
+		expectURN    string
+		expectError  string
+	}{
+		{name: "Droplet", resourceType: "droplet", id: "123", expectURN: "do:droplet:123"},
+		{name: "Alias", resourceType: "database", id: "9cc10173-e9ea-4176-9dbc-a4cee4c4ff30", expectURN: "do:dbaas:9cc10173-e9ea-4176-9dbc-a4cee4c4ff30"},
+		{name: "Type is case-insensitive", resourceType: "Load-Balancer", id: "4de7ac8b", expectURN: "do:loadbalancer:4de7ac8b"},
+		{name: "Reserved IP", resourceType: "reserved-ip", id: "45.55.96.47", expectURN: "do:reservedip:45.55.96.47"},
+		{name: "Domain", resourceType: "domain", id: "example.com", expectURN: "do:domain:example.com"},
+		{name: "Unknown type", resourceType: "server", id: "1", expectError: `unknown resource type "server"`},
+		{name: "Missing id", resourceType: "volume", id: " ", expectError: "resource id is required"},
+		{name: "Droplet id not a number", resourceType: "droplet", id: "web-1", expectError: "droplet id must be a number"},
+		{name: "Reserved IP id not an IP", resourceType: "reservedip", id: "abc", expectError: "reservedip id must be an IP address"},
+		{name: "Id with colon", resourceType: "volume", id: "do:volume:1", expectError: "must not contain colons or spaces"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			urn, err := BuildURN(tc.resourceType, tc.id)
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectURN, urn)
+		})
+	}
+}
+
+func TestParseURN(t *testing.T) {
+	tests := []struct {
+		name        string
+		urn         string
+		expectType  string
+		expectID    string
+		expectError string
+	}{
+		{name: "Droplet", urn: "do:droplet:123", expectType: "droplet", expectID: "123"},
+		{name: "Space", urn: "do:space:my-bucket", expectType: "space", expectID: "my-bucket"},
+		{name: "Alias type is normalized", urn: "do:database:abc", expectType: "dbaas", expectID: "abc"},
+		{name: "Missing prefix", urn: "droplet:123", expectError: "invalid URN"},
+		{name: "Missing id", urn: "do:droplet", expectError: "invalid URN"},
+		{name: "Wrong scheme", urn: "aws:droplet:123", expectError: "invalid URN"},
+		{name: "Unknown type", urn: "do:server:123", expectError: "unknown resource type"},
+		{name: "Invalid id", urn: "do:droplet:abc", expectError: "droplet id must be a number"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resourceType, id, err := ParseURN(tc.urn)
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectType, resourceType)
+			require.Equal(t, tc.expectID, id)
+		})
+	}
+}
+
+func TestURNTool_convertURN(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expect      resourceURN
+		expectError string
+	}{
+		{
+			name:   "Parse",
+			args:   map[string]any{"URN": "do:volume:506f78a4"},
+			expect: resourceURN{URN: "do:volume:506f78a4", Type: "volume", ID: "506f78a4"},
+		},
+		{
+			name:   "Build",
+			args:   map[string]any{"Type": "bucket", "ID": "assets"},
+			expect: resourceURN{URN: "do:space:assets", Type: "space", ID: "assets"},
+		},
+		{
+			name:        "Both",
+			args:        map[string]any{"URN": "do:droplet:1", "Type": "droplet", "ID": "1"},
+			expectError: "not both",
+		},
+		{
+			name:        "Neither",
+			args:        map[string]any{},
+			expectError: "pass either URN to parse",
+		},
+		{
+			name:        "Invalid URN",
+			args:        map[string]any{"URN": "droplet-123"},
+			expectError: "invalid URN",
+		},
+	}
+
+	tool := NewURNTool()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.convertURN(context.Background(), req)
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out resourceURN
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, tc.expect, out)
+		})
+	}
+}
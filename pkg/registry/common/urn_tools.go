@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/response"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// URNTool converts between DigitalOcean URNs and resource types and ids.
+type URNTool struct{}
+
+// NewURNTool creates a new URNTool instance.
+func NewURNTool() *URNTool {
+	return &URNTool{}
+}
+
+// resourceURN is the result of resource-urn.
+type resourceURN struct {
+	URN  string `json:"urn"`
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// convertURN parses the urn argument when it is set, and otherwise builds the URN of type and id.
+func (u *URNTool) convertURN(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := NewArgs(req)
+	urn := args.OptionalString("URN", "")
+	resourceType := args.OptionalString("Type", "")
+	id := args.OptionalString("ID", "")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var result resourceURN
+	switch {
+	case urn != "" && (resourceType != "" || id != ""):
+		return mcp.NewToolResultError("pass either URN, or Type and ID, not both"), nil
+	case urn != "":
+		t, parsedID, err := ParseURN(urn)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result = resourceURN{URN: strings.TrimSpace(urn), Type: t, ID: parsedID}
+	case resourceType != "":
+		built, err := BuildURN(resourceType, id)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		t, builtID, _ := ParseURN(built)
+		result = resourceURN{URN: built, Type: t, ID: builtID}
+	default:
+		return mcp.NewToolResultError("pass either URN to parse, or Type and ID to build a URN"), nil
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// Tools returns the URN tools.
+func (u *URNTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: u.convertURN,
+			Tool: mcp.NewTool("resource-urn",
+				mcp.WithDescription("Convert between a DigitalOcean URN, such as do:droplet:123, and its resource type and id, as required by the projects and tags APIs. Pass URN to parse it, or Type and ID to build it"),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("URN", mcp.Description("URN to parse, e.g. do:droplet:123")),
+				mcp.WithString("Type", mcp.Description(fmt.Sprintf("Resource type: %s, or an alias: %s", strings.Join(URNResourceTypes, ", "), strings.Join(URNTypeAliases(), ", ")))),
+				mcp.WithString("ID", mcp.Description("Resource id: a number for droplets, an IP address for reserved IPs, a name for domains and spaces, otherwise a UUID")),
+			),
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package registry
+
+import (
+	"context"
+
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// confirmTokenTools are the destructive tools that, in strict confirmation mode, only act on a
+// resource when given its confirm_token.
+var confirmTokenTools = map[string]struct{}{
+	"droplet-delete-by-tag": {},
+	"db-cluster-delete":     {},
+}
+
+// WithStrictConfirm makes the tools in confirmTokenTools require a confirm_token argument equal to
+// the confirmation token of the resource they act on, rather than trusting a plain confirm flag.
+// The token is returned by a dry run of the same call, so WithDryRun must be given as well.
+func WithStrictConfirm() Option {
+	return func(o *options) {
+		o.strictConfirm = true
+		o.decorators = append(o.decorators, strictConfirmDecorator)
+	}
+}
+
+func strictConfirmDecorator(tool server.ServerTool) server.ServerTool {
+	if _, ok := confirmTokenTools[tool.Tool.Name]; !ok {
+		return tool
+	}
+	addProperty(&tool.Tool, common.ConfirmTokenArg, map[string]any{
+		"type":        "string",
+		"description": "Confirmation token of the target resource, returned by the same call with dry_run set. Required to execute the call",
+	})
+
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return next(common.WithStrictConfirm(ctx), req)
+	}
+	return tool
+}
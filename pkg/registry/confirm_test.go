@@ -0,0 +1,81 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictConfirmDecorator(t *testing.T) {
+	setup := func(t *testing.T) (server.ServerTool, func() []string) {
+		tools, sent := setupDryRunDropletTools(t)
+		for i := range tools {
+			tools[i] = strictConfirmDecorator(tools[i])
+		}
+		require.NotContains(t, findTool(t, tools, "droplet-delete").Tool.InputSchema.Properties, common.ConfirmTokenArg)
+		return findTool(t, tools, "droplet-delete-by-tag"), sent
+	}
+	token := common.ConfirmToken("tag:fleet")
+
+	t.Run("dry run returns the token", func(t *testing.T) {
+		tool, sent := setup(t)
+		require.Contains(t, tool.Tool.InputSchema.Properties, common.ConfirmTokenArg)
+
+		res := callTool(context.Background(), t, tool, map[string]any{"Tag": "fleet", "Confirm": true, "dry_run": true})
+		require.False(t, res.IsError)
+		require.Empty(t, sent())
+
+		var preview dryRunResult
+		require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &preview))
+		require.Equal(t, token, preview.ConfirmToken)
+		require.Len(t, preview.Requests, 1)
+		require.Equal(t, http.MethodDelete, preview.Requests[0].Method)
+	})
+
+	t.Run("missing token is rejected", func(t *testing.T) {
+		tool, sent := setup(t)
+
+		res := callTool(context.Background(), t, tool, map[string]any{"Tag": "fleet", "Confirm": true})
+		require.True(t, res.IsError)
+		require.Contains(t, res.Content[0].(mcp.TextContent).Text, "requires the confirm_token of tag:fleet")
+		require.Empty(t, sent())
+	})
+
+	t.Run("token of another tag is rejected", func(t *testing.T) {
+		tool, sent := setup(t)
+
+		res := callTool(context.Background(), t, tool, map[string]any{"Tag": "fleet", "Confirm": true, "confirm_token": common.ConfirmToken("tag:other")})
+		require.True(t, res.IsError)
+		require.Contains(t, res.Content[0].(mcp.TextContent).Text, "confirm_token does not match tag:fleet")
+		require.Empty(t, sent())
+	})
+
+	t.Run("matching token deletes", func(t *testing.T) {
+		tool, sent := setup(t)
+
+		res := callTool(context.Background(), t, tool, map[string]any{"Tag": "fleet", "Confirm": true, "confirm_token": token})
+		require.False(t, res.IsError)
+		require.Equal(t, []string{http.MethodDelete}, sent())
+	})
+}
+
+func TestRegisterWithOptions_strictConfirm(t *testing.T) {
+	getClient := func(ctx context.Context) (*godo.Client, error) { return godo.NewFromToken("token"), nil }
+
+	s := server.NewMCPServer("test", "0.0.0")
+	err := RegisterWithOptions(slog.New(slog.DiscardHandler), s, getClient, []string{"droplets"}, WithStrictConfirm())
+	require.ErrorContains(t, err, "strict confirmation requires dry run")
+
+	s = server.NewMCPServer("test", "0.0.0")
+	require.NoError(t, RegisterWithOptions(slog.New(slog.DiscardHandler), s, getClient, []string{"droplets"}, WithStrictConfirm(), WithDryRun()))
+	require.Contains(t, s.ListTools()["droplet-delete-by-tag"].Tool.InputSchema.Properties, common.ConfirmTokenArg)
+}
@@ -0,0 +1,27 @@
+package registry
+
+import (
+	"context"
+
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+)
+
+// WithAuthContexts makes every tool call use the token of the auth context in use in contexts, and
+// registers the account tools listing and switching the contexts. The client function passed to
+// RegisterWithOptions must then build its client from the token in the context, as for the HTTP
+// transport.
+func WithAuthContexts(contexts *common.AuthContexts) Option {
+	return func(o *options) {
+		o.authContexts = contexts
+	}
+}
+
+// authContextClient wraps getClient so that clients are built, and memoized, for the token of the
+// auth context in use at the time of the call.
+func authContextClient(getClient getClientFn, contexts *common.AuthContexts) getClientFn {
+	return func(ctx context.Context) (*godo.Client, error) {
+		return getClient(contexts.WithActiveToken(ctx))
+	}
+}
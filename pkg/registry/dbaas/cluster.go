@@ -4,22 +4,42 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"mcp-digitalocean/pkg/dryrun"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+const (
+	// resizePollInterval is how often a resize with a notify_url is polled for completion.
+	resizePollInterval = 15 * time.Second
+	// resizeWatchTimeout bounds how long a resize is watched before a timed_out notification is sent.
+	resizeWatchTimeout = 2 * time.Hour
+	// resizeStartGrace is how long a cluster may stay online after a resize request before the
+	// resize is assumed to have completed without passing through the resizing status.
+	resizeStartGrace = 2 * time.Minute
+)
+
 type ClusterTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	client       func(ctx context.Context) (*godo.Client, error)
+	notifyClient *http.Client
+	pollInterval time.Duration
 }
 
 func NewClusterTool(client func(ctx context.Context) (*godo.Client, error)) *ClusterTool {
 	return &ClusterTool{
-		client: client,
+		client:       client,
+		notifyClient: http.DefaultClient,
+		pollInterval: resizePollInterval,
 	}
 }
 
@@ -48,11 +68,11 @@ func (s *ClusterTool) listCluster(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	clusters, _, err := client.Databases.List(ctx, opts)
+	clusters, resp, err := client.Databases.List(ctx, opts)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
-	jsonClusters, err := response.CompactJSON(clusters)
+	jsonClusters, err := response.CompactJSON(response.ListFromResponse(clusters, resp))
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
@@ -72,7 +92,7 @@ func (s *ClusterTool) getCluster(ctx context.Context, req mcp.CallToolRequest) (
 
 	cluster, _, err := client.Databases.Get(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(err, "database_cluster", id), nil
 	}
 	jsonCluster, err := response.CompactJSON(cluster)
 	if err != nil {
@@ -115,6 +135,12 @@ func (s *ClusterTool) createCluster(ctx context.Context, req mcp.CallToolRequest
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	// Database sizes aren't droplet sizes: the engine options tell where the engine runs and in which sizes.
+	if engine != "" && size != "" && region != "" {
+		if err := common.ValidateDatabaseSizeInRegion(ctx, client, engine, size, region); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
 
 	cluster, _, err := client.Databases.Create(ctx, createReq)
 	if err != nil {
@@ -132,6 +158,9 @@ func (s *ClusterTool) deleteCluster(ctx context.Context, req mcp.CallToolRequest
 	if !ok || id == "" {
 		return mcp.NewToolResultError("Cluster id is required"), nil
 	}
+	if errResult := common.RequireConfirmToken(ctx, req.GetArguments(), "db-cluster-delete", id); errResult != nil {
+		return errResult, nil
+	}
 	client, err := s.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
@@ -170,6 +199,10 @@ func (s *ClusterTool) resizeCluster(ctx context.Context, req mcp.CallToolRequest
 	if storageSizeMib > 0 {
 		resizeReq.StorageSizeMib = storageSizeMib
 	}
+	notifyURL, err := common.NotifyURL(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := s.client(ctx)
 	if err != nil {
@@ -179,7 +212,61 @@ func (s *ClusterTool) resizeCluster(ctx context.Context, req mcp.CallToolRequest
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
-	return mcp.NewToolResultText("Cluster resize initiated successfully"), nil
+	if notifyURL == "" {
+		return mcp.NewToolResultText("Cluster resize initiated successfully"), nil
+	}
+
+	// The resize outlives the tool call, so it is watched in the background. A dry run started no
+	// resize, so there is nothing to watch.
+	if dryrun.FromContext(ctx) == nil {
+		go s.watchResize(context.WithoutCancel(ctx), client, id, notifyURL)
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Cluster resize initiated successfully, a notification will be sent to %s when it completes", notifyURL)), nil
+}
+
+// watchResize polls the cluster until the resize completes, i.e. the cluster is online again after
+// leaving the online status, and then notifies notifyURL. A cluster still online after
+// resizeStartGrace is considered resized, and after resizeWatchTimeout a timed_out status is sent.
+func (s *ClusterTool) watchResize(ctx context.Context, client *godo.Client, id, notifyURL string) {
+	interval := s.pollInterval
+	if interval <= 0 {
+		interval = resizePollInterval
+	}
+	ctx, cancel := context.WithTimeout(ctx, resizeWatchTimeout)
+	defer cancel()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	started := time.Now()
+	status, left := "", false
+	for {
+		select {
+		case <-ctx.Done():
+			status = "timed_out"
+		case <-ticker.C:
+			// Transient errors are retried until the watch times out.
+			db, _, err := client.Databases.Get(ctx, id)
+			if err != nil {
+				continue
+			}
+			status = db.Status
+			if status != "online" {
+				left = true
+				continue
+			}
+			if !left && time.Since(started) < resizeStartGrace {
+				continue
+			}
+		}
+		// A delivery failure can't be reported back, as the tool call has already returned.
+		_ = common.Notify(ctx, s.notifyClient, notifyURL, common.Notification{
+			Tool:       "db-cluster-resize",
+			Resource:   "database",
+			ResourceID: id,
+			Status:     status,
+		})
+		return
+	}
 }
 
 func (s *ClusterTool) getCA(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -241,7 +328,117 @@ func (s *ClusterTool) listBackups(ctx context.Context, req mcp.CallToolRequest)
 	return mcp.NewToolResultText(jsonBackups), nil
 }
 
+func (s *ClusterTool) createFromBackup(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+
+	sourceID, ok := args["cluster_id"].(string)
+	if !ok || sourceID == "" {
+		return mcp.NewToolResultError("Source cluster_id is required"), nil
+	}
+	backupCreatedAtStr, ok := args["backup_created_at"].(string)
+	if !ok || backupCreatedAtStr == "" {
+		return mcp.NewToolResultError("backup_created_at is required (RFC3339 timestamp)"), nil
+	}
+	backupCreatedAt, err := time.Parse(time.RFC3339, backupCreatedAtStr)
+	if err != nil {
+		return mcp.NewToolResultError("Invalid backup_created_at, expected RFC3339 timestamp: " + err.Error()), nil
+	}
+
+	name, _ := args["name"].(string)
+	engine, _ := args["engine"].(string)
+	version, _ := args["version"].(string)
+	region, _ := args["region"].(string)
+	size, _ := args["size"].(string)
+	numNodes, _ := args["num_nodes"].(float64) // JSON numbers are float64
+	if name == "" || engine == "" || region == "" || size == "" || numNodes < 1 {
+		return mcp.NewToolResultError("name, engine, region, size and num_nodes are required for the new cluster"), nil
+	}
+
+	tags := []string{}
+	if tagsRaw, ok := args["tags"].(string); ok && tagsRaw != "" {
+		for _, t := range strings.Split(tagsRaw, ",") {
+			t = strings.TrimSpace(t)
+			if t != "" {
+				tags = append(tags, t)
+			}
+		}
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	// The restore key is the name of the source cluster; default to looking it up.
+	databaseName, _ := args["database_name"].(string)
+	if databaseName == "" {
+		source, _, err := client.Databases.Get(ctx, sourceID)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		databaseName = source.Name
+	}
+
+	// The oldest backup bounds the retention window, so every page has to be read.
+	backups := []godo.DatabaseBackup{}
+	opts := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		page, resp, err := client.Databases.ListBackups(ctx, sourceID, opts)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		backups = append(backups, page...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opts.Page++
+	}
+	if len(backups) == 0 {
+		return mcp.NewToolResultError("Source cluster has no backups to restore from"), nil
+	}
+	oldest := backups[0].CreatedAt
+	for _, b := range backups[1:] {
+		if b.CreatedAt.Before(oldest) {
+			oldest = b.CreatedAt
+		}
+	}
+	if backupCreatedAt.Before(oldest) || backupCreatedAt.After(time.Now()) {
+		return mcp.NewToolResultError(fmt.Sprintf("backup_created_at %s is outside the retention window (%s to now)", backupCreatedAt.Format(time.RFC3339), oldest.Format(time.RFC3339))), nil
+	}
+
+	createReq := &godo.DatabaseCreateRequest{
+		Name:       name,
+		EngineSlug: engine,
+		Version:    version,
+		Region:     region,
+		SizeSlug:   size,
+		NumNodes:   int(numNodes),
+		Tags:       tags,
+		BackupRestore: &godo.DatabaseBackupRestore{
+			DatabaseName:    databaseName,
+			BackupCreatedAt: backupCreatedAt.UTC().Format(time.RFC3339),
+		},
+	}
+	cluster, _, err := client.Databases.Create(ctx, createReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonCluster, err := response.CompactJSON(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonCluster), nil
+}
+
+// dbEngines are the engine slugs the database options are keyed by.
+var dbEngines = []string{"pg", "mysql", "mongodb", "redis", "valkey", "kafka", "opensearch"}
+
 func (s *ClusterTool) listOptions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	engine, _ := req.GetArguments()["engine"].(string)
+	if engine != "" && !slices.Contains(dbEngines, engine) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid engine %q, must be one of: %s", engine, strings.Join(dbEngines, ", "))), nil
+	}
+
 	client, err := s.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
@@ -250,7 +447,21 @@ func (s *ClusterTool) listOptions(ctx context.Context, req mcp.CallToolRequest)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
-	jsonOptions, err := response.CompactJSON(options)
+
+	var result any = options
+	if engine != "" {
+		// The options are keyed by engine slug in their JSON form, so filter them in that form.
+		data, err := json.Marshal(options)
+		if err != nil {
+			return nil, fmt.Errorf("marshal error: %w", err)
+		}
+		var engines map[string]json.RawMessage
+		if err := json.Unmarshal(data, &engines); err != nil {
+			return nil, fmt.Errorf("unmarshal error: %w", err)
+		}
+		result = map[string]json.RawMessage{engine: engines[engine]}
+	}
+	jsonOptions, err := response.CompactJSON(result)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
@@ -377,13 +588,152 @@ func (s *ClusterTool) getOnlineMigrationStatus(ctx context.Context, req mcp.Call
 	return mcp.NewToolResultText(jsonStatus), nil
 }
 
+// redactedPassword replaces the password in generated connection strings unless include_password is set.
+const redactedPassword = "REDACTED"
+
+// selectPool picks the connection pool to connect through: the pool named poolName if given,
+// otherwise the only pool matching the database and user filters.
+func selectPool(pools []godo.DatabasePool, poolName, dbName, user string) (*godo.DatabasePool, error) {
+	var matches []godo.DatabasePool
+	for _, pool := range pools {
+		switch {
+		case poolName != "" && pool.Name != poolName:
+		case dbName != "" && pool.Database != dbName:
+		case user != "" && pool.User != user:
+		default:
+			matches = append(matches, pool)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return &matches[0], nil
+	case 0:
+		return nil, fmt.Errorf("no connection pool matches the given pool_name, db_name and user")
+	default:
+		names := make([]string, len(matches))
+		for i, pool := range matches {
+			names[i] = pool.Name
+		}
+		return nil, fmt.Errorf("several connection pools match, set pool_name to one of: %s", strings.Join(names, ", "))
+	}
+}
+
+// connectionURI builds a connection string from a cluster or pool connection, overriding the database and
+// user when set, and making sure the SSL requirement is part of the URI.
+func connectionURI(conn *godo.DatabaseConnection, dbName, user, password string) (string, error) {
+	u, err := url.Parse(conn.URI)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("cluster returned an invalid connection URI")
+	}
+	if user == "" {
+		user = conn.User
+	}
+	if user != "" {
+		u.User = url.UserPassword(user, password)
+	}
+	if dbName != "" {
+		u.Path = "/" + dbName
+	}
+
+	if conn.SSL {
+		query := u.Query()
+		switch {
+		case strings.HasPrefix(u.Scheme, "postgres"):
+			if query.Get("sslmode") == "" {
+				query.Set("sslmode", "require")
+			}
+		case u.Scheme == "mysql":
+			if query.Get("ssl-mode") == "" {
+				query.Set("ssl-mode", "REQUIRED")
+			}
+		case strings.HasPrefix(u.Scheme, "mongodb"):
+			if query.Get("tls") == "" && query.Get("ssl") == "" {
+				query.Set("tls", "true")
+			}
+		case u.Scheme == "redis":
+			u.Scheme = "rediss"
+		}
+		u.RawQuery = query.Encode()
+	}
+	return u.String(), nil
+}
+
+func (s *ClusterTool) getConnectionString(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	dbName, _ := args["db_name"].(string)
+	user, _ := args["user"].(string)
+	poolName, _ := args["pool_name"].(string)
+	pooled, _ := args["pooled"].(bool)
+	preferPrivate, _ := args[common.PreferPrivateArg].(bool)
+	includePassword, _ := args["include_password"].(bool)
+	if poolName != "" {
+		pooled = true
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	cluster, _, err := client.Databases.Get(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	conn, privateConn := cluster.Connection, cluster.PrivateConnection
+	if pooled {
+		if cluster.EngineSlug != "pg" {
+			return mcp.NewToolResultError("Connection pools are only available for PostgreSQL clusters"), nil
+		}
+		pools, _, err := client.Databases.ListPools(ctx, id, nil)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		pool, err := selectPool(pools, poolName, dbName, user)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		// The pool determines the database and user to connect as.
+		conn, privateConn = pool.Connection, pool.PrivateConnection
+		dbName, user = "", ""
+	}
+	if preferPrivate && privateConn != nil {
+		conn = privateConn
+	}
+	if conn == nil {
+		return mcp.NewToolResultError("Cluster has no connection details yet, it may still be provisioning"), nil
+	}
+
+	password := redactedPassword
+	if includePassword {
+		password = conn.Password
+		if user != "" && user != conn.User {
+			dbUser, _, err := client.Databases.GetUser(ctx, id, user)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("api error", err), nil
+			}
+			password = dbUser.Password
+		}
+	}
+
+	uri, err := connectionURI(conn, dbName, user, password)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(uri), nil
+}
+
 func (s *ClusterTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 
 		{
 			Handler: s.listCluster,
 			Tool: mcp.NewTool("db-cluster-list",
-				mcp.WithDescription("Get list of  Cluster"),
+				mcp.WithDescription("Get list of  Cluster. The clusters are returned under items, with the total count, has_more and the next_page to fetch."),
 				mcp.WithString("page", mcp.Description("Page number for pagination (optional, integer as string)")),
 				mcp.WithNumber("per_page", mcp.Description("Number of results per page (optional, integer)")),
 			),
@@ -395,6 +745,22 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster to retrieve")),
 			),
 		},
+		{
+			Handler: s.getMaintenance,
+			Tool: mcp.NewTool("db-get-maintenance",
+				mcp.WithDescription("Get the maintenance window of a cluster, and whether maintenance is pending with the updates it will install"),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster")),
+			),
+		},
+		{
+			Handler: s.updateMaintenance,
+			Tool: mcp.NewTool("db-update-maintenance",
+				mcp.WithDescription("Set the day and hour the maintenance window of a cluster starts at. Returns the updated window."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster")),
+				mcp.WithString("day", mcp.Required(), mcp.Enum(maintenanceDays...), mcp.Description("Lowercase day of the week the window starts on")),
+				mcp.WithString("hour", mcp.Required(), mcp.Description("Time the window starts at, in 24-hour HH:MM format (UTC), e.g. 03:00")),
+			),
+		},
 		{
 			Handler: s.getCA,
 			Tool: mcp.NewTool("db-cluster-get-ca",
@@ -402,6 +768,19 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster to retrieve the CA for")),
 			),
 		},
+		{
+			Handler: s.getConnectionString,
+			Tool: mcp.NewTool("db-get-connection-string",
+				mcp.WithDescription("Build a connection URI for a cluster, including the SSL mode. The password is redacted unless include_password is set."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster")),
+				mcp.WithString("db_name", mcp.Description("Database to connect to (defaults to the cluster's default database)")),
+				mcp.WithString("user", mcp.Description("User to connect as (defaults to the cluster's admin user)")),
+				mcp.WithBoolean("pooled", mcp.DefaultBool(false), mcp.Description("Connect through a PostgreSQL connection pool matching db_name and user")),
+				mcp.WithString("pool_name", mcp.Description("Name of the connection pool to use, implies pooled")),
+				common.WithPreferPrivate(),
+				mcp.WithBoolean("include_password", mcp.DefaultBool(false), mcp.Description("Include the real password in the URI instead of a placeholder")),
+			),
+		},
 		{
 			Handler: s.createCluster,
 			Tool: mcp.NewTool("db-cluster-create",
@@ -430,21 +809,14 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 				mcp.WithString("size", mcp.Description("The new size slug (e.g., db-s-2vcpu-4gb)")),
 				mcp.WithNumber("num_nodes", mcp.Description("The new number of nodes")),
 				mcp.WithNumber("storage_size_mib", mcp.Description("The new storage size in MiB")),
-			),
-		},
-		{
-			Handler: s.listBackups,
-			Tool: mcp.NewTool("db-cluster-list-backups",
-				mcp.WithDescription("List backups for a database cluster by its id"),
-				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster to list backups for")),
-				mcp.WithString("page", mcp.Description("Page number for pagination (optional, integer as string)")),
-				mcp.WithNumber("per_page", mcp.Description("Number of results per page (optional, integer)")),
+				mcp.WithString(common.NotifyURLArg, mcp.Description("Optional https URL to POST a JSON status payload to when the resize completes")),
 			),
 		},
 		{
 			Handler: s.listOptions,
-			Tool: mcp.NewTool("db-cluster-list-options",
-				mcp.WithDescription("List available database options (engines, versions, sizes, regions, etc) for DigitalOcean managed databases."),
+			Tool: mcp.NewTool("db-list-options",
+				mcp.WithDescription("List available database options (engines, versions, sizes, regions, layouts) for DigitalOcean managed databases. Use it to validate the engine, version, size, region and number of nodes before creating a cluster."),
+				mcp.WithString("engine", mcp.Enum(dbEngines...), mcp.Description("Only return the options of this engine")),
 			),
 		},
 		{
@@ -507,3 +879,35 @@ func (s *ClusterTool) Tools() []server.ServerTool {
 		},
 	}
 }
+
+// BackupTools returns the tools listing the backups of a cluster and restoring one into a new
+// cluster.
+func (s *ClusterTool) BackupTools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: s.listBackups,
+			Tool: mcp.NewTool("db-backups-list",
+				mcp.WithDescription("List backups for a database cluster by its id. The oldest backup marks the start of the point-in-time restore window."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The id of the cluster to list backups for")),
+				mcp.WithString("page", mcp.Description("Page number for pagination (optional, integer as string)")),
+				mcp.WithNumber("per_page", mcp.Description("Number of results per page (optional, integer)")),
+			),
+		},
+		{
+			Handler: s.createFromBackup,
+			Tool: mcp.NewTool("db-create-from-backup",
+				mcp.WithDescription("Create a new database cluster restored from a backup of an existing cluster at a given point in time. The timestamp must fall within the retention window returned by db-backups-list."),
+				mcp.WithString("cluster_id", mcp.Required(), mcp.Description("The UUID of the source cluster to restore from")),
+				mcp.WithString("backup_created_at", mcp.Required(), mcp.Description("The point in time to restore to (RFC3339, e.g., 2025-01-02T15:04:05Z)")),
+				mcp.WithString("database_name", mcp.Description("The backup restore key, i.e. the name of the source cluster (optional, looked up from cluster_id when omitted)")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The name of the new cluster")),
+				mcp.WithString("engine", mcp.Required(), mcp.Description("The engine slug, must match the source cluster (e.g., pg, mysql)")),
+				mcp.WithString("version", mcp.Description("The version of the engine")),
+				mcp.WithString("region", mcp.Required(), mcp.Description("The region slug (e.g., nyc1)")),
+				mcp.WithString("size", mcp.Required(), mcp.Description("The size slug (e.g., db-s-2vcpu-4gb)")),
+				mcp.WithNumber("num_nodes", mcp.Required(), mcp.Description("The number of nodes")),
+				mcp.WithString("tags", mcp.Description("Comma-separated tags to apply to the new cluster")),
+			),
+		},
+	}
+}
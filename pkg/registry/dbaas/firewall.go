@@ -11,6 +11,15 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// firewallRuleTypes are the source types accepted in a database firewall rule.
+var firewallRuleTypes = map[string]struct{}{
+	"ip_addr": {},
+	"droplet": {},
+	"k8s":     {},
+	"tag":     {},
+	"app":     {},
+}
+
 type FirewallTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
 }
@@ -91,6 +100,110 @@ func (s *FirewallTool) updateFirewallRules(ctx context.Context, req mcp.CallTool
 	return mcp.NewToolResultText("Firewall rules updated successfully"), nil
 }
 
+// ruleFromArgs reads and validates the type and value of a single firewall rule.
+func ruleFromArgs(args map[string]any) (string, string, error) {
+	ruleType, _ := args["type"].(string)
+	if _, ok := firewallRuleTypes[ruleType]; !ok {
+		return "", "", fmt.Errorf("rule type must be one of ip_addr, droplet, k8s, tag or app")
+	}
+	value, _ := args["value"].(string)
+	if value == "" {
+		return "", "", fmt.Errorf("rule value is required")
+	}
+	return ruleType, value, nil
+}
+
+// replaceFirewallRules applies the new rule set and returns the resulting full rule list.
+func replaceFirewallRules(ctx context.Context, client *godo.Client, id string, rules []*godo.DatabaseFirewallRule) (*mcp.CallToolResult, error) {
+	_, err := client.Databases.UpdateFirewallRules(ctx, id, &godo.DatabaseUpdateFirewallRulesRequest{Rules: rules})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	updated, _, err := client.Databases.GetFirewallRules(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonRules, err := response.CompactJSON(updated)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonRules), nil
+}
+
+func (s *FirewallTool) addFirewallRule(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	ruleType, value, err := ruleFromArgs(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	current, _, err := client.Databases.GetFirewallRules(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	rules := make([]*godo.DatabaseFirewallRule, 0, len(current)+1)
+	for i := range current {
+		if current[i].Type == ruleType && current[i].Value == value {
+			return mcp.NewToolResultError(fmt.Sprintf("A %s rule for %s already exists", ruleType, value)), nil
+		}
+		rules = append(rules, &current[i])
+	}
+	rules = append(rules, &godo.DatabaseFirewallRule{Type: ruleType, Value: value})
+
+	return replaceFirewallRules(ctx, client, id, rules)
+}
+
+func (s *FirewallTool) removeFirewallRule(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	ruleUUID, _ := args["uuid"].(string)
+	var ruleType, value string
+	if ruleUUID == "" {
+		var err error
+		ruleType, value, err = ruleFromArgs(args)
+		if err != nil {
+			return mcp.NewToolResultError("Either uuid or a valid type and value are required: " + err.Error()), nil
+		}
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	current, _, err := client.Databases.GetFirewallRules(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	rules := make([]*godo.DatabaseFirewallRule, 0, len(current))
+	for i := range current {
+		matches := current[i].UUID == ruleUUID
+		if ruleUUID == "" {
+			matches = current[i].Type == ruleType && current[i].Value == value
+		}
+		if !matches {
+			rules = append(rules, &current[i])
+		}
+	}
+	if len(rules) == len(current) {
+		return mcp.NewToolResultError("No matching firewall rule found"), nil
+	}
+
+	return replaceFirewallRules(ctx, client, id, rules)
+}
+
 func (s *FirewallTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
@@ -130,5 +243,24 @@ func (s *FirewallTool) Tools() []server.ServerTool {
 				),
 			),
 		},
+		{
+			Handler: s.addFirewallRule,
+			Tool: mcp.NewTool("db-firewall-add-rule",
+				mcp.WithDescription("Add a single firewall rule to a database cluster, keeping the existing rules. Returns the resulting full rule list."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("type", mcp.Required(), mcp.Enum("ip_addr", "droplet", "k8s", "tag", "app"), mcp.Description("Type of the rule")),
+				mcp.WithString("value", mcp.Required(), mcp.Description("Value for the rule (e.g., IP address, droplet ID, cluster UUID or tag name)")),
+			),
+		},
+		{
+			Handler: s.removeFirewallRule,
+			Tool: mcp.NewTool("db-firewall-remove-rule",
+				mcp.WithDescription("Remove a single firewall rule from a database cluster, identified by uuid or by type and value, keeping the other rules. Returns the resulting full rule list."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("uuid", mcp.Description("The rule UUID (optional if type and value are provided)")),
+				mcp.WithString("type", mcp.Enum("ip_addr", "droplet", "k8s", "tag", "app"), mcp.Description("Type of the rule")),
+				mcp.WithString("value", mcp.Description("Value for the rule")),
+			),
+		},
 	}
 }
@@ -64,3 +64,80 @@ func TestFirewallTool_updateFirewallRules(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Missing or invalid 'rules' array object")
 }
+
+func TestFirewallTool_addFirewallRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	existing := []godo.DatabaseFirewallRule{{UUID: "rule1", Type: "ip_addr", Value: "1.2.3.4"}}
+	gomock.InOrder(
+		mockDB.EXPECT().GetFirewallRules(gomock.Any(), "cid").Return(existing, nil, nil),
+		mockDB.EXPECT().UpdateFirewallRules(gomock.Any(), "cid", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, r *godo.DatabaseUpdateFirewallRulesRequest) (*godo.Response, error) {
+			assert.Len(t, r.Rules, 2)
+			assert.Equal(t, "rule1", r.Rules[0].UUID)
+			assert.Equal(t, "tag", r.Rules[1].Type)
+			return nil, nil
+		}),
+		mockDB.EXPECT().GetFirewallRules(gomock.Any(), "cid").Return(append(existing, godo.DatabaseFirewallRule{UUID: "rule2", Type: "tag", Value: "web"}), nil, nil),
+	)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	ft := &FirewallTool{client: client}
+	args := map[string]interface{}{"id": "cid", "type": "tag", "value": "web"}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err := ft.addFirewallRule(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "rule1")
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "rule2")
+	// Error case: invalid type (should not expect any call)
+	args = map[string]interface{}{"id": "cid", "type": "vpc", "value": "x"}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = ft.addFirewallRule(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "rule type must be one of")
+}
+
+func TestFirewallTool_removeFirewallRule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	existing := []godo.DatabaseFirewallRule{
+		{UUID: "rule1", Type: "ip_addr", Value: "1.2.3.4"},
+		{UUID: "rule2", Type: "tag", Value: "web"},
+	}
+	gomock.InOrder(
+		mockDB.EXPECT().GetFirewallRules(gomock.Any(), "cid").Return(existing, nil, nil),
+		mockDB.EXPECT().UpdateFirewallRules(gomock.Any(), "cid", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, r *godo.DatabaseUpdateFirewallRulesRequest) (*godo.Response, error) {
+			assert.Len(t, r.Rules, 1)
+			assert.Equal(t, "rule1", r.Rules[0].UUID)
+			return nil, nil
+		}),
+		mockDB.EXPECT().GetFirewallRules(gomock.Any(), "cid").Return(existing[:1], nil, nil),
+		mockDB.EXPECT().GetFirewallRules(gomock.Any(), "cid").Return(existing[:1], nil, nil),
+	)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	ft := &FirewallTool{client: client}
+	args := map[string]interface{}{"id": "cid", "type": "tag", "value": "web"}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err := ft.removeFirewallRule(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "rule1")
+	assert.NotContains(t, res.Content[0].(mcp.TextContent).Text, "rule2")
+	// Error case: rule not found
+	args = map[string]interface{}{"id": "cid", "uuid": "rule9"}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = ft.removeFirewallRule(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "No matching firewall rule found")
+}
@@ -21,6 +21,51 @@ func NewKafkaTool(client func(ctx context.Context) (*godo.Client, error)) *Kafka
 	return &KafkaTool{client: client}
 }
 
+// parseTopicCount reads an optional partition count or replication factor argument, given either as a
+// string or a number, and checks that it is at least 1.
+func parseTopicCount(args map[string]any, key string) (*uint32, error) {
+	var n uint64
+	switch v := args[key].(type) {
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		parsed, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a positive integer", key)
+		}
+		n = parsed
+	case float64:
+		if v < 0 || v != float64(uint32(v)) {
+			return nil, fmt.Errorf("%s must be a positive integer", key)
+		}
+		n = uint64(v)
+	default:
+		return nil, nil
+	}
+	if n < 1 {
+		return nil, fmt.Errorf("%s must be >= 1", key)
+	}
+	n32 := uint32(n)
+	return &n32, nil
+}
+
+// validateReplicationFactor checks that the replication factor does not exceed the number of nodes in the cluster.
+// It returns a tool result when validation fails, or nil when the request can proceed.
+func validateReplicationFactor(ctx context.Context, client *godo.Client, id string, replicationFactor *uint32) *mcp.CallToolResult {
+	if replicationFactor == nil {
+		return nil
+	}
+	cluster, _, err := client.Databases.Get(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err)
+	}
+	if int(*replicationFactor) > cluster.NumNodes {
+		return mcp.NewToolResultError(fmt.Sprintf("replication_factor (%d) must be <= the cluster node count (%d)", *replicationFactor, cluster.NumNodes))
+	}
+	return nil
+}
+
 func (s *KafkaTool) getKafkaConfig(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 	id, ok := args["id"].(string)
@@ -138,19 +183,13 @@ func (s *KafkaTool) createTopic(ctx context.Context, req mcp.CallToolRequest) (*
 		return mcp.NewToolResultError("Topic name is required"), nil
 	}
 
-	var partitionCount *uint32
-	if pcStr, ok := args["partition_count"].(string); ok && pcStr != "" {
-		if pc, err := strconv.ParseUint(pcStr, 10, 32); err == nil {
-			pc32 := uint32(pc)
-			partitionCount = &pc32
-		}
+	partitionCount, err := parseTopicCount(args, "partition_count")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-	var replicationFactor *uint32
-	if rfStr, ok := args["replication_factor"].(string); ok && rfStr != "" {
-		if rf, err := strconv.ParseUint(rfStr, 10, 32); err == nil {
-			rf32 := uint32(rf)
-			replicationFactor = &rf32
-		}
+	replicationFactor, err := parseTopicCount(args, "replication_factor")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	var topicConfig *godo.TopicConfig
@@ -173,6 +212,9 @@ func (s *KafkaTool) createTopic(ctx context.Context, req mcp.CallToolRequest) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	if res := validateReplicationFactor(ctx, client, id, replicationFactor); res != nil {
+		return res, nil
+	}
 	topic, _, err := client.Databases.CreateTopic(ctx, id, createReq)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
@@ -241,19 +283,13 @@ func (s *KafkaTool) updateTopic(ctx context.Context, req mcp.CallToolRequest) (*
 		return mcp.NewToolResultError("Topic name is required"), nil
 	}
 
-	var partitionCount *uint32
-	if pcStr, ok := args["partition_count"].(string); ok && pcStr != "" {
-		if pc, err := strconv.ParseUint(pcStr, 10, 32); err == nil {
-			pc32 := uint32(pc)
-			partitionCount = &pc32
-		}
+	partitionCount, err := parseTopicCount(args, "partition_count")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-	var replicationFactor *uint32
-	if rfStr, ok := args["replication_factor"].(string); ok && rfStr != "" {
-		if rf, err := strconv.ParseUint(rfStr, 10, 32); err == nil {
-			rf32 := uint32(rf)
-			replicationFactor = &rf32
-		}
+	replicationFactor, err := parseTopicCount(args, "replication_factor")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	var topicConfig *godo.TopicConfig
@@ -275,6 +311,9 @@ func (s *KafkaTool) updateTopic(ctx context.Context, req mcp.CallToolRequest) (*
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	if res := validateReplicationFactor(ctx, client, id, replicationFactor); res != nil {
+		return res, nil
+	}
 	_, err = client.Databases.UpdateTopic(ctx, id, name, updateReq)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
@@ -286,7 +325,7 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
 			Handler: s.listTopics,
-			Tool: mcp.NewTool("db-cluster-list-topics",
+			Tool: mcp.NewTool("db-kafka-topic-list",
 				mcp.WithDescription("List topics for a Kafka cluster by its ID. Supports pagination and filtering."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("The Kafka cluster UUID")),
 				mcp.WithString("page", mcp.Description("Page number (string)")),
@@ -299,12 +338,12 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: s.createTopic,
-			Tool: mcp.NewTool("db-cluster-create-topic",
-				mcp.WithDescription("Create a topic for a Kafka cluster."),
+			Tool: mcp.NewTool("db-kafka-topic-create",
+				mcp.WithDescription("Create a topic for a Kafka cluster. Returns the topic including its config and retention settings."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("Topic name")),
-				mcp.WithString("partition_count", mcp.Description("Number of partitions")),
-				mcp.WithString("replication_factor", mcp.Description("Replication factor")),
+				mcp.WithString("partition_count", mcp.Description("Number of partitions (>= 1)")),
+				mcp.WithString("replication_factor", mcp.Description("Replication factor (>= 1 and <= the cluster node count)")),
 				mcp.WithObject("config",
 					mcp.Description("Kafka topic configuration (optional)"),
 					mcp.Properties(map[string]any{
@@ -336,15 +375,15 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: s.getTopic,
-			Tool: mcp.NewTool("db-cluster-get-topic",
-				mcp.WithDescription("Get a Kafka topic by name."),
+			Tool: mcp.NewTool("db-kafka-topic-get",
+				mcp.WithDescription("Get a Kafka topic by name, including its config and retention settings."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("Topic name")),
 			),
 		},
 		{
 			Handler: s.deleteTopic,
-			Tool: mcp.NewTool("db-cluster-delete-topic",
+			Tool: mcp.NewTool("db-kafka-topic-delete",
 				mcp.WithDescription("Delete a Kafka topic by name."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("Topic name")),
@@ -352,12 +391,12 @@ func (s *KafkaTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: s.updateTopic,
-			Tool: mcp.NewTool("db-cluster-update-topic",
+			Tool: mcp.NewTool("db-kafka-topic-update",
 				mcp.WithDescription("Update a Kafka topic's partition count, replication factor, or config."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("Kafka cluster UUID")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("Topic name")),
-				mcp.WithString("partition_count", mcp.Description("Number of partitions")),
-				mcp.WithString("replication_factor", mcp.Description("Replication factor")),
+				mcp.WithString("partition_count", mcp.Description("Number of partitions (>= 1)")),
+				mcp.WithString("replication_factor", mcp.Description("Replication factor (>= 1 and <= the cluster node count)")),
 				mcp.WithObject("config",
 					mcp.Description("Kafka topic configuration (optional)"),
 					mcp.Properties(map[string]any{
@@ -191,3 +191,50 @@ func TestKafkaTool_updateTopic(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Topic name is required")
 }
+
+func TestKafkaTool_createTopicValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().Get(gomock.Any(), "cid").Return(&godo.Database{NumNodes: 3}, nil, nil).Times(2)
+	mockDB.EXPECT().CreateTopic(gomock.Any(), "cid", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, r *godo.DatabaseCreateTopicRequest) (*godo.DatabaseTopic, *godo.Response, error) {
+		assert.Equal(t, uint32(6), *r.PartitionCount)
+		assert.Equal(t, uint32(3), *r.ReplicationFactor)
+		return &godo.DatabaseTopic{Name: r.Name}, nil, nil
+	})
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	kt := &KafkaTool{client: client}
+	args := map[string]interface{}{"id": "cid", "name": "events", "partition_count": "6", "replication_factor": "3"}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err := kt.createTopic(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "events")
+
+	// Error case: replication factor larger than the node count
+	args = map[string]interface{}{"id": "cid", "name": "events", "replication_factor": "4"}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = kt.createTopic(context.Background(), req)
+	assert.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "must be <= the cluster node count")
+
+	// Error case: zero partitions (should not expect a call to Get)
+	args = map[string]interface{}{"id": "cid", "name": "events", "partition_count": "0"}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = kt.createTopic(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "partition_count must be >= 1")
+
+	// Error case: non-numeric replication factor
+	args = map[string]interface{}{"id": "cid", "name": "events", "replication_factor": "three"}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = kt.createTopic(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "replication_factor must be a positive integer")
+}
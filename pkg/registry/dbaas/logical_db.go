@@ -0,0 +1,95 @@
+package dbaas
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/response"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// The logical database endpoints are shared by the engines that support them, so the engine tools
+// delegate to these handlers.
+
+// listLogicalDBs lists every logical database of a cluster. The API only reports their names.
+func listLogicalDBs(ctx context.Context, getClient func(ctx context.Context) (*godo.Client, error), req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, ok := req.GetArguments()["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	dbs := []godo.DatabaseDB{}
+	opts := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		page, resp, err := client.Databases.ListDBs(ctx, id, opts)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		dbs = append(dbs, page...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opts.Page++
+	}
+	jsonDBs, err := response.CompactJSON(dbs)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonDBs), nil
+}
+
+func createLogicalDB(ctx context.Context, getClient func(ctx context.Context) (*godo.Client, error), req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Database name is required"), nil
+	}
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	db, _, err := client.Databases.CreateDB(ctx, id, &godo.DatabaseCreateDBRequest{Name: name})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonDB, err := response.CompactJSON(db)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonDB), nil
+}
+
+// deleteLogicalDB deletes a logical database once confirmed. contents describes what the deletion
+// removes, e.g. "tables and data", for the confirmation message.
+func deleteLogicalDB(ctx context.Context, getClient func(ctx context.Context) (*godo.Client, error), req mcp.CallToolRequest, contents string) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Database name is required"), nil
+	}
+	if confirm, _ := args["confirm"].(bool); !confirm {
+		return mcp.NewToolResultError(fmt.Sprintf("Deleting a database permanently removes its %s; set confirm to true to proceed", contents)), nil
+	}
+	client, err := getClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	_, err = client.Databases.DeleteDB(ctx, id, name)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return mcp.NewToolResultText("Database deleted successfully"), nil
+}
@@ -0,0 +1,94 @@
+package dbaas
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"regexp"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maintenanceDays are the days a maintenance window can start on.
+var maintenanceDays = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// maintenanceHourRe matches a 24-hour HH:MM time.
+var maintenanceHourRe = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// maintenanceWindow is the maintenance window of a cluster as returned by the maintenance tools.
+type maintenanceWindow struct {
+	ClusterID      string   `json:"cluster_id"`
+	Day            string   `json:"day"`
+	Hour           string   `json:"hour"`
+	Pending        bool     `json:"pending"`
+	PendingUpdates []string `json:"pending_updates,omitempty"`
+}
+
+// maintenanceResult returns the maintenance window of cluster.
+func maintenanceResult(cluster *godo.Database) (*mcp.CallToolResult, error) {
+	window := maintenanceWindow{ClusterID: cluster.ID}
+	if mw := cluster.MaintenanceWindow; mw != nil {
+		window.Day = mw.Day
+		window.Hour = mw.Hour
+		window.Pending = mw.Pending
+		window.PendingUpdates = mw.Description
+	}
+	jsonWindow, err := response.CompactJSON(window)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonWindow), nil
+}
+
+// getMaintenance returns the maintenance window of a cluster and whether maintenance is pending.
+func (s *ClusterTool) getMaintenance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	id := args.RequireString("id")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	cluster, _, err := client.Databases.Get(ctx, id)
+	if err != nil {
+		return common.APIErrorResult(err, "database_cluster", id), nil
+	}
+	return maintenanceResult(cluster)
+}
+
+// updateMaintenance sets the day and hour the maintenance window of a cluster starts at and returns
+// the updated window.
+func (s *ClusterTool) updateMaintenance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	id := args.RequireString("id")
+	day := args.RequireEnum("day", maintenanceDays...)
+	hour := args.RequireString("hour")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !maintenanceHourRe.MatchString(hour) {
+		return mcp.NewToolResultError(fmt.Sprintf("argument 'hour' must be a 24-hour time in HH:MM format, got %q", hour)), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	_, err = client.Databases.UpdateMaintenance(ctx, id, &godo.DatabaseUpdateMaintenanceRequest{Day: day, Hour: hour})
+	if err != nil {
+		return common.APIErrorResult(err, "database_cluster", id), nil
+	}
+
+	cluster, _, err := client.Databases.Get(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("maintenance window updated, but failed to get the cluster", err), nil
+	}
+	return maintenanceResult(cluster)
+}
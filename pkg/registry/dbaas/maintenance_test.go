@@ -0,0 +1,107 @@
+package dbaas
+
+import (
+	"context"
+	"errors"
+	"mcp-digitalocean/pkg/registry/dbaas/mocks"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestClusterTool_getMaintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().Get(gomock.Any(), "db-1").Return(&godo.Database{
+		ID: "db-1",
+		MaintenanceWindow: &godo.DatabaseMaintenanceWindow{
+			Day:         "sunday",
+			Hour:        "03:00:00",
+			Pending:     true,
+			Description: []string{"Update TimescaleDB to version 2.14"},
+		},
+	}, nil, nil)
+
+	ct := &ClusterTool{client: func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Databases: mockDB}, nil
+	}}
+	res, err := ct.getMaintenance(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "db-1"}}})
+	assert.NoError(t, err)
+	assert.False(t, res.IsError)
+	assert.JSONEq(t, `{"cluster_id":"db-1","day":"sunday","hour":"03:00:00","pending":true,"pending_updates":["Update TimescaleDB to version 2.14"]}`, getText(res))
+}
+
+func TestClusterTool_updateMaintenance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*mocks.MockDatabasesService)
+		expectText  string
+		expectError string
+	}{
+		{
+			name: "Updates the window",
+			args: map[string]any{"id": "db-1", "day": "tuesday", "hour": "22:30"},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				gomock.InOrder(
+					m.EXPECT().UpdateMaintenance(gomock.Any(), "db-1", &godo.DatabaseUpdateMaintenanceRequest{Day: "tuesday", Hour: "22:30"}).Return(nil, nil),
+					m.EXPECT().Get(gomock.Any(), "db-1").Return(&godo.Database{
+						ID:                "db-1",
+						MaintenanceWindow: &godo.DatabaseMaintenanceWindow{Day: "tuesday", Hour: "22:30:00"},
+					}, nil, nil),
+				)
+			},
+			expectText: `{"cluster_id":"db-1","day":"tuesday","hour":"22:30:00","pending":false}`,
+		},
+		{
+			name:        "Capitalized day",
+			args:        map[string]any{"id": "db-1", "day": "Tuesday", "hour": "22:30"},
+			expectError: "argument 'day' must be one of",
+		},
+		{
+			name:        "Hour out of range",
+			args:        map[string]any{"id": "db-1", "day": "tuesday", "hour": "24:00"},
+			expectError: "HH:MM",
+		},
+		{
+			name:        "Hour without minutes",
+			args:        map[string]any{"id": "db-1", "day": "tuesday", "hour": "3"},
+			expectError: "HH:MM",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"id": "db-1", "day": "friday", "hour": "01:00"},
+			mockSetup: func(m *mocks.MockDatabasesService) {
+				m.EXPECT().UpdateMaintenance(gomock.Any(), "db-1", gomock.Any()).Return(nil, errors.New("boom"))
+			},
+			expectError: "boom",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB := mocks.NewMockDatabasesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDB)
+			}
+			ct := &ClusterTool{client: func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Databases: mockDB}, nil
+			}}
+			res, err := ct.updateMaintenance(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			assert.NoError(t, err)
+			if tc.expectError != "" {
+				assert.True(t, res.IsError)
+				assert.Contains(t, getText(res), tc.expectError)
+				return
+			}
+			assert.False(t, res.IsError)
+			assert.JSONEq(t, tc.expectText, getText(res))
+		})
+	}
+}
@@ -76,8 +76,45 @@ func (s *MongoTool) updateMongoDBConfig(ctx context.Context, req mcp.CallToolReq
 	return mcp.NewToolResultText("MongoDB config updated successfully"), nil
 }
 
+// listDatabases lists every logical database of a MongoDB cluster.
+func (s *MongoTool) listDatabases(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return listLogicalDBs(ctx, s.client, req)
+}
+
+func (s *MongoTool) createDatabase(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return createLogicalDB(ctx, s.client, req)
+}
+
+func (s *MongoTool) deleteDatabase(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return deleteLogicalDB(ctx, s.client, req, "collections and documents")
+}
+
 func (s *MongoTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
+		{
+			Handler: s.listDatabases,
+			Tool: mcp.NewTool("db-mongo-list-databases",
+				mcp.WithDescription("List the databases of a MongoDB cluster by its id. Only database names are reported by the API."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+			),
+		},
+		{
+			Handler: s.createDatabase,
+			Tool: mcp.NewTool("db-mongo-create-db",
+				mcp.WithDescription("Create a database in a MongoDB cluster"),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The database name")),
+			),
+		},
+		{
+			Handler: s.deleteDatabase,
+			Tool: mcp.NewTool("db-mongo-delete-db",
+				mcp.WithDescription("Delete a database from a MongoDB cluster. This permanently removes its collections and documents and requires confirm=true."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The database name")),
+				mcp.WithBoolean("confirm", mcp.Required(), mcp.Description("Must be true to confirm the deletion")),
+			),
+		},
 		{
 			Handler: s.getMongoDBConfig,
 			Tool: mcp.NewTool("db-cluster-get-mongodb-config",
@@ -62,3 +62,50 @@ func TestMongoTool_updateMongoDBConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Missing or invalid 'config' object")
 }
+
+func TestMongoTool_databases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+	mt := &MongoTool{client: client}
+
+	// List follows every page
+	gomock.InOrder(
+		mockDB.EXPECT().ListDBs(gomock.Any(), "cid", &godo.ListOptions{Page: 1, PerPage: 200}).
+			Return([]godo.DatabaseDB{{Name: "orders"}}, &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+		mockDB.EXPECT().ListDBs(gomock.Any(), "cid", &godo.ListOptions{Page: 2, PerPage: 200}).
+			Return([]godo.DatabaseDB{{Name: "users"}}, &godo.Response{}, nil),
+	)
+	res, err := mt.listDatabases(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "cid"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"name":"orders"},{"name":"users"}]`, res.Content[0].(mcp.TextContent).Text)
+
+	// Create
+	mockDB.EXPECT().CreateDB(gomock.Any(), "cid", &godo.DatabaseCreateDBRequest{Name: "orders"}).Return(&godo.DatabaseDB{Name: "orders"}, nil, nil)
+	res, err = mt.createDatabase(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "cid", "name": "orders"}}})
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "orders")
+
+	// Delete requires confirm
+	res, err = mt.deleteDatabase(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "cid", "name": "orders"}}})
+	assert.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "set confirm to true")
+
+	mockDB.EXPECT().DeleteDB(gomock.Any(), "cid", "orders").Return(&godo.Response{}, nil)
+	res, err = mt.deleteDatabase(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "cid", "name": "orders", "confirm": true}}})
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Database deleted successfully")
+
+	// API error
+	mockDB.EXPECT().DeleteDB(gomock.Any(), "cid", "orders").Return(nil, assert.AnError)
+	res, err = mt.deleteDatabase(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "cid", "name": "orders", "confirm": true}}})
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "api error")
+}
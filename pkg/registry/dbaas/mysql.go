@@ -12,6 +12,47 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// knownSQLModes is the set of sql_mode tokens accepted by MySQL 8, including the ANSI and TRADITIONAL combination modes.
+var knownSQLModes = map[string]struct{}{
+	"ALLOW_INVALID_DATES":        {},
+	"ANSI":                       {},
+	"ANSI_QUOTES":                {},
+	"ERROR_FOR_DIVISION_BY_ZERO": {},
+	"HIGH_NOT_PRECEDENCE":        {},
+	"IGNORE_SPACE":               {},
+	"NO_AUTO_VALUE_ON_ZERO":      {},
+	"NO_BACKSLASH_ESCAPES":       {},
+	"NO_DIR_IN_CREATE":           {},
+	"NO_ENGINE_SUBSTITUTION":     {},
+	"NO_UNSIGNED_SUBTRACTION":    {},
+	"NO_ZERO_DATE":               {},
+	"NO_ZERO_IN_DATE":            {},
+	"ONLY_FULL_GROUP_BY":         {},
+	"PAD_CHAR_TO_FULL_LENGTH":    {},
+	"PIPES_AS_CONCAT":            {},
+	"REAL_AS_FLOAT":              {},
+	"STRICT_ALL_TABLES":          {},
+	"STRICT_TRANS_TABLES":        {},
+	"TIME_TRUNCATE_FRACTIONAL":   {},
+	"TRADITIONAL":                {},
+}
+
+// parseSQLModes splits a comma-separated sql_mode string and rejects any token MySQL does not know.
+func parseSQLModes(modesStr string) ([]string, error) {
+	modes := []string{}
+	for _, m := range strings.Split(modesStr, ",") {
+		m = strings.ToUpper(strings.TrimSpace(m))
+		if m == "" {
+			continue
+		}
+		if _, ok := knownSQLModes[m]; !ok {
+			return nil, fmt.Errorf("unknown SQL mode: %s", m)
+		}
+		modes = append(modes, m)
+	}
+	return modes, nil
+}
+
 type MysqlTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
 }
@@ -54,6 +95,13 @@ func (s *MysqlTool) updateMySQLConfig(ctx context.Context, req mcp.CallToolReque
 	if !ok {
 		return mcp.NewToolResultError("Invalid or missing 'config' object (expected structured object)"), nil
 	}
+	if sqlMode, ok := configMap["sql_mode"].(string); ok {
+		modes, err := parseSQLModes(sqlMode)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		configMap["sql_mode"] = strings.Join(modes, ",")
+	}
 
 	cfgBytes, err := json.Marshal(configMap)
 	if err != nil {
@@ -103,12 +151,9 @@ func (s *MysqlTool) setSQLMode(ctx context.Context, req mcp.CallToolRequest) (*m
 	if !ok || modesStr == "" {
 		return mcp.NewToolResultError("SQL modes are required (comma-separated)"), nil
 	}
-	modes := []string{}
-	for _, m := range strings.Split(modesStr, ",") {
-		m = strings.TrimSpace(m)
-		if m != "" {
-			modes = append(modes, m)
-		}
+	modes, err := parseSQLModes(modesStr)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 	client, err := s.client(ctx)
 	if err != nil {
@@ -121,19 +166,52 @@ func (s *MysqlTool) setSQLMode(ctx context.Context, req mcp.CallToolRequest) (*m
 	return mcp.NewToolResultText("SQL mode set successfully"), nil
 }
 
+func (s *MysqlTool) updateMySQLAuth(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	user, ok := args["user"].(string)
+	if !ok || user == "" {
+		return mcp.NewToolResultError("User name is required"), nil
+	}
+	plugin, _ := args["auth_plugin"].(string)
+	if plugin != godo.SQLAuthPluginCachingSHA2 && plugin != godo.SQLAuthPluginNative {
+		return mcp.NewToolResultError(fmt.Sprintf("auth_plugin must be one of %s or %s", godo.SQLAuthPluginCachingSHA2, godo.SQLAuthPluginNative)), nil
+	}
+
+	resetReq := &godo.DatabaseResetUserAuthRequest{
+		MySQLSettings: &godo.DatabaseMySQLUserSettings{AuthPlugin: plugin},
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	dbUser, _, err := client.Databases.ResetUserAuth(ctx, id, user, resetReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonUser, err := response.CompactJSON(dbUser)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonUser), nil
+}
+
 func (s *MysqlTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
 			Handler: s.getMySQLConfig,
-			Tool: mcp.NewTool("db-cluster-get-mysql-config",
+			Tool: mcp.NewTool("db-mysql-get-config",
 				mcp.WithDescription("Get the MySQL config for a cluster by its id"),
 				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
 			),
 		},
 		{
 			Handler: s.updateMySQLConfig,
-			Tool: mcp.NewTool("db-cluster-update-mysql-config",
-				mcp.WithDescription("Update the MySQL config for a cluster by its id. Accepts a structured 'config' object."),
+			Tool: mcp.NewTool("db-mysql-update-config",
+				mcp.WithDescription("Update the MySQL config for a cluster by its id. Accepts a structured 'config' object. sql_mode is validated against MySQL's known modes."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
 				mcp.WithObject("config",
 					mcp.Required(),
@@ -189,7 +267,16 @@ func (s *MysqlTool) Tools() []server.ServerTool {
 			Tool: mcp.NewTool("db-cluster-set-sql-mode",
 				mcp.WithDescription("Set the SQL mode for a cluster by its id"),
 				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
-				mcp.WithString("modes", mcp.Required(), mcp.Description("Comma-separated SQL modes to set")),
+				mcp.WithString("modes", mcp.Required(), mcp.Description("Comma-separated SQL modes to set (e.g., STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION)")),
+			),
+		},
+		{
+			Handler: s.updateMySQLAuth,
+			Tool: mcp.NewTool("db-mysql-update-auth",
+				mcp.WithDescription("Switch the authentication plugin of a MySQL user. Resets the user's password."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("user", mcp.Required(), mcp.Description("The user name")),
+				mcp.WithString("auth_plugin", mcp.Required(), mcp.Enum(godo.SQLAuthPluginCachingSHA2, godo.SQLAuthPluginNative), mcp.Description("The auth plugin to switch to")),
 			),
 		},
 	}
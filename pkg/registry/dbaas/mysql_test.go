@@ -112,4 +112,69 @@ func TestMysqlTool_setSQLMode(t *testing.T) {
 	res, err = mt.setSQLMode(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "SQL modes are required")
+	// Error case: unknown mode (should not expect a call to SetSQLMode)
+	args = map[string]interface{}{"id": "cid", "modes": "STRICT_TRANS_TABLES,NOT_A_MODE"}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = mt.setSQLMode(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "unknown SQL mode: NOT_A_MODE")
+}
+
+func TestMysqlTool_updateMySQLConfigSQLMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().UpdateMySQLConfig(gomock.Any(), "cid", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, cfg *godo.MySQLConfig) (*godo.Response, error) {
+		assert.Equal(t, "STRICT_TRANS_TABLES,ONLY_FULL_GROUP_BY", *cfg.SQLMode)
+		return nil, nil
+	})
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	mt := &MysqlTool{client: client}
+	args := map[string]interface{}{"id": "cid", "config": map[string]any{"sql_mode": "strict_trans_tables, ONLY_FULL_GROUP_BY"}}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err := mt.updateMySQLConfig(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "MySQL config updated successfully")
+	// Error case: unknown mode (should not expect a call to UpdateMySQLConfig)
+	args = map[string]interface{}{"id": "cid", "config": map[string]any{"sql_mode": "BOGUS"}}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = mt.updateMySQLConfig(context.Background(), req)
+	assert.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "unknown SQL mode: BOGUS")
+}
+
+func TestMysqlTool_updateMySQLAuth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	expectedReq := &godo.DatabaseResetUserAuthRequest{
+		MySQLSettings: &godo.DatabaseMySQLUserSettings{AuthPlugin: godo.SQLAuthPluginNative},
+	}
+	mockDB.EXPECT().ResetUserAuth(gomock.Any(), "cid", "app", expectedReq).Return(&godo.DatabaseUser{Name: "app"}, nil, nil)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	mt := &MysqlTool{client: client}
+	args := map[string]interface{}{"id": "cid", "user": "app", "auth_plugin": "mysql_native_password"}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err := mt.updateMySQLAuth(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "app")
+	// Error case: unsupported plugin
+	args = map[string]interface{}{"id": "cid", "user": "app", "auth_plugin": "sha256_password"}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = mt.updateMySQLAuth(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "auth_plugin must be one of")
 }
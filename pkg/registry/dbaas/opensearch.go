@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"mcp-digitalocean/pkg/response"
+	"strconv"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -71,19 +72,100 @@ func (s *OpenSearchTool) updateOpensearchConfig(ctx context.Context, req mcp.Cal
 	return mcp.NewToolResultText("Opensearch config updated successfully"), nil
 }
 
+func (s *OpenSearchTool) listIndexes(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+
+	// Optional pagination
+	page := 0
+	if pStr, ok := args["page"].(string); ok && pStr != "" {
+		if p, err := strconv.Atoi(pStr); err == nil {
+			page = p
+		}
+	}
+	perPage := 0
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	var opts *godo.ListOptions
+	if page > 0 || perPage > 0 {
+		opts = &godo.ListOptions{Page: page, PerPage: perPage}
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	indexes, _, err := client.Databases.ListIndexes(ctx, id, opts)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonIndexes, err := response.CompactJSON(indexes)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonIndexes), nil
+}
+
+func (s *OpenSearchTool) deleteIndex(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Index name is required"), nil
+	}
+	if confirm, _ := args["confirm"].(bool); !confirm {
+		return mcp.NewToolResultError("Deleting an index permanently removes its documents; set confirm to true to proceed"), nil
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	_, err = client.Databases.DeleteIndex(ctx, id, name)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return mcp.NewToolResultText("Index deleted successfully"), nil
+}
+
 func (s *OpenSearchTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
+		{
+			Handler: s.listIndexes,
+			Tool: mcp.NewTool("db-opensearch-index-list",
+				mcp.WithDescription("List indexes for an OpenSearch cluster by its id, including document counts, sizes, health and shard layout"),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("page", mcp.Description("Page number for pagination (optional, integer as string)")),
+				mcp.WithNumber("per_page", mcp.Description("Number of results per page (optional, integer)")),
+			),
+		},
+		{
+			Handler: s.deleteIndex,
+			Tool: mcp.NewTool("db-opensearch-index-delete",
+				mcp.WithDescription("Delete an index from an OpenSearch cluster. This permanently removes its documents and requires confirm=true."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The index name")),
+				mcp.WithBoolean("confirm", mcp.Required(), mcp.Description("Must be true to confirm the deletion")),
+			),
+		},
 		{
 			Handler: s.getOpensearchConfig,
-			Tool: mcp.NewTool("db-cluster-get-opensearch-config",
-				mcp.WithDescription("Get the Opensearch config for a cluster by its id"),
+			Tool: mcp.NewTool("db-opensearch-get-config",
+				mcp.WithDescription("Get the Opensearch config for a cluster by its id, including ISM (index state management) and retention settings"),
 				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
 			),
 		},
 		{
 			Handler: s.updateOpensearchConfig,
-			Tool: mcp.NewTool("db-cluster-update-os-config",
-				mcp.WithDescription("Update the Opensearch config for a cluster by its id. Accepts a structured config object."),
+			Tool: mcp.NewTool("db-opensearch-update-config",
+				mcp.WithDescription("Update the Opensearch config for a cluster by its id. Accepts a structured config object, e.g. ism_enabled or ism_history_rollover_retention_period_days to manage ISM and retention."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
 				mcp.WithObject("config",
 					mcp.Required(),
@@ -90,3 +90,56 @@ func TestOpenSearchTool_updateOpensearchConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "api error")
 }
+
+func TestOpenSearchTool_listIndexes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().ListIndexes(gomock.Any(), "cid", (*godo.ListOptions)(nil)).Return([]godo.DatabaseIndex{{IndexName: "logs-2025", Size: 2048}}, nil, nil)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	ot := &OpenSearchTool{client: client}
+	args := map[string]interface{}{"id": "cid"}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err := ot.listIndexes(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "logs-2025")
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "2048")
+	// Error case: missing id (should not expect a call to ListIndexes)
+	reqMissing := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{}}}
+	res, err = ot.listIndexes(context.Background(), reqMissing)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Cluster id is required")
+}
+
+func TestOpenSearchTool_deleteIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().DeleteIndex(gomock.Any(), "cid", "logs-2025").Return(nil, nil)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	ot := &OpenSearchTool{client: client}
+	args := map[string]interface{}{"id": "cid", "name": "logs-2025", "confirm": true}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err := ot.deleteIndex(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Index deleted successfully")
+	// Error case: missing confirm (should not expect a call to DeleteIndex)
+	args = map[string]interface{}{"id": "cid", "name": "logs-2025"}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = ot.deleteIndex(context.Background(), req)
+	assert.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "set confirm to true")
+}
@@ -79,8 +79,44 @@ func (s *PostgreSQLTool) updatePostgreSQLConfig(ctx context.Context, req mcp.Cal
 	return mcp.NewToolResultText("PostgreSQL config updated successfully"), nil
 }
 
+func (s *PostgreSQLTool) listDatabases(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return listLogicalDBs(ctx, s.client, req)
+}
+
+func (s *PostgreSQLTool) createDatabase(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return createLogicalDB(ctx, s.client, req)
+}
+
+func (s *PostgreSQLTool) deleteDatabase(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return deleteLogicalDB(ctx, s.client, req, "tables and data")
+}
+
 func (s *PostgreSQLTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
+		{
+			Handler: s.listDatabases,
+			Tool: mcp.NewTool("db-pg-list-databases",
+				mcp.WithDescription("List the databases of a PostgreSQL cluster by its id. Only database names are reported by the API."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+			),
+		},
+		{
+			Handler: s.createDatabase,
+			Tool: mcp.NewTool("db-pg-create-database",
+				mcp.WithDescription("Create a database in a PostgreSQL cluster"),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The database name")),
+			),
+		},
+		{
+			Handler: s.deleteDatabase,
+			Tool: mcp.NewTool("db-pg-delete-database",
+				mcp.WithDescription("Delete a database from a PostgreSQL cluster. This permanently removes its tables and data and requires confirm=true."),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The database name")),
+				mcp.WithBoolean("confirm", mcp.Required(), mcp.Description("Must be true to confirm the deletion")),
+			),
+		},
 		{
 			Handler: s.getPostgreSQLConfig,
 			Tool: mcp.NewTool("db-cluster-get-postgresql-config",
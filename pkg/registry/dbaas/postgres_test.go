@@ -90,3 +90,36 @@ func TestPostgreSQLTool_updatePostgreSQLConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "api error")
 }
+
+func TestPostgreSQLTool_databases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+	pt := &PostgreSQLTool{client: client}
+
+	mockDB.EXPECT().ListDBs(gomock.Any(), "cid", gomock.Any()).Return([]godo.DatabaseDB{{Name: "defaultdb"}}, &godo.Response{}, nil)
+	res, err := pt.listDatabases(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "cid"}}})
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "defaultdb")
+
+	mockDB.EXPECT().CreateDB(gomock.Any(), "cid", &godo.DatabaseCreateDBRequest{Name: "app"}).Return(&godo.DatabaseDB{Name: "app"}, nil, nil)
+	res, err = pt.createDatabase(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "cid", "name": "app"}}})
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, `"name":"app"`)
+
+	res, err = pt.deleteDatabase(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "cid", "name": "app", "confirm": false}}})
+	assert.NoError(t, err)
+	assert.True(t, res.IsError)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "tables and data")
+
+	mockDB.EXPECT().DeleteDB(gomock.Any(), "cid", "app").Return(&godo.Response{}, nil)
+	res, err = pt.deleteDatabase(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"id": "cid", "name": "app", "confirm": true}}})
+	assert.NoError(t, err)
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Database deleted successfully")
+}
@@ -0,0 +1,246 @@
+package dbaas
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	_ "github.com/go-sql-driver/mysql" // registers the "mysql" driver
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" driver
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultQueryMaxRows = 100
+	maxQueryMaxRows     = 1000
+	defaultQueryTimeout = 10 * time.Second
+	maxQueryTimeout     = time.Minute
+)
+
+// queryDrivers are, per engine slug, the names of the database/sql drivers that can run queries on
+// it, in order of preference.
+var queryDrivers = map[string][]string{
+	"pg":    {"pgx"},
+	"mysql": {"mysql"},
+}
+
+// QueryTool runs read-only SQL queries on PostgreSQL and MySQL clusters.
+type QueryTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+	open   func(driverName, dataSourceName string) (*sql.DB, error)
+}
+
+// NewQueryTool creates a new query tool
+func NewQueryTool(client func(ctx context.Context) (*godo.Client, error)) *QueryTool {
+	return &QueryTool{
+		client: client,
+		open:   sql.Open,
+	}
+}
+
+// queryResult is the result of db-run-query. Truncated is set when the query returned more than the
+// row limit.
+type queryResult struct {
+	Columns   []string `json:"columns"`
+	Rows      [][]any  `json:"rows"`
+	RowCount  int      `json:"row_count"`
+	Truncated bool     `json:"truncated"`
+}
+
+// queryDriver returns the name of a registered driver for the engine.
+func queryDriver(engine string) (string, error) {
+	candidates, ok := queryDrivers[engine]
+	if !ok {
+		return "", fmt.Errorf("queries can only be run on PostgreSQL and MySQL clusters, not %s", engine)
+	}
+	registered := sql.Drivers()
+	for _, name := range candidates {
+		if slices.Contains(registered, name) {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("this server was built without a database/sql driver for %s clusters (%s)", engine, strings.Join(candidates, " or "))
+}
+
+// mysqlDSN builds a go-sql-driver/mysql data source name, which is not a URL. Like sslmode=require
+// for PostgreSQL, tls=skip-verify encrypts the connection without checking the cluster's
+// certificate, which is signed by the cluster's own CA.
+func mysqlDSN(conn *godo.DatabaseConnection, dbName, user, password string) string {
+	params := url.Values{}
+	params.Set("parseTime", "true")
+	if conn.SSL {
+		params.Set("tls", "skip-verify")
+	}
+	return fmt.Sprintf("%s:%s@tcp(%s)/%s?%s", user, password, net.JoinHostPort(conn.Host, strconv.Itoa(conn.Port)), dbName, params.Encode())
+}
+
+// readRows reads up to maxRows rows, reporting whether there were more.
+func readRows(rows *sql.Rows, maxRows int) (*queryResult, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := &queryResult{Columns: columns, Rows: [][]any{}}
+	for rows.Next() {
+		if len(result.Rows) == maxRows {
+			result.Truncated = true
+			break
+		}
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		// Text and numeric columns some drivers return as bytes.
+		for i, v := range values {
+			if b, ok := v.([]byte); ok {
+				values[i] = string(b)
+			}
+		}
+		result.Rows = append(result.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	result.RowCount = len(result.Rows)
+	return result, nil
+}
+
+// runQuery runs a SELECT query on a logical database of a PostgreSQL or MySQL cluster, as the given
+// user, over a connection opened for the query only. The query is checked to be a single read-only
+// statement before it is sent, and runs in a read-only transaction that is rolled back.
+func (t *QueryTool) runQuery(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	id := args.RequireString("ClusterID")
+	dbName := args.RequireString("Database")
+	user := args.RequireString("User")
+	query := args.RequireString("SQL")
+	maxRows := args.OptionalInt("MaxRows", defaultQueryMaxRows)
+	// TimeoutSeconds, rather than timeout_seconds, tells the registry the tool bounds its own calls.
+	timeoutSeconds := args.OptionalInt("TimeoutSeconds", int(defaultQueryTimeout.Seconds()))
+	preferPrivate := args.PreferPrivate()
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if maxRows < 1 || maxRows > maxQueryMaxRows {
+		return mcp.NewToolResultError(fmt.Sprintf("MaxRows must be between 1 and %d", maxQueryMaxRows)), nil
+	}
+	if timeoutSeconds < 1 || timeoutSeconds > int(maxQueryTimeout.Seconds()) {
+		return mcp.NewToolResultError(fmt.Sprintf("TimeoutSeconds must be between 1 and %d", int(maxQueryTimeout.Seconds()))), nil
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	cluster, _, err := client.Databases.Get(ctx, id)
+	if err != nil {
+		return common.APIErrorResult(err, "database_cluster", id), nil
+	}
+	driverName, err := queryDriver(cluster.EngineSlug)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := checkReadOnlyQuery(query, cluster.EngineSlug); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	conn := cluster.Connection
+	if preferPrivate && cluster.PrivateConnection != nil {
+		conn = cluster.PrivateConnection
+	}
+	if conn == nil {
+		return mcp.NewToolResultError("Cluster has no connection details yet, it may still be provisioning"), nil
+	}
+	password := conn.Password
+	if user != conn.User {
+		dbUser, _, err := client.Databases.GetUser(ctx, id, user)
+		if err != nil {
+			return common.APIErrorResult(err, "database_user", user), nil
+		}
+		password = dbUser.Password
+	}
+
+	var dsn string
+	if cluster.EngineSlug == "mysql" {
+		dsn = mysqlDSN(conn, dbName, user, password)
+	} else if dsn, err = connectionURI(conn, dbName, user, password); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result, err := t.execute(ctx, driverName, dsn, query, maxRows)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return mcp.NewToolResultError(fmt.Sprintf("query on cluster %s did not complete within %s", id, timeout)), nil
+		}
+		return mcp.NewToolResultErrorFromErr("query failed", err), nil
+	}
+
+	jsonResult, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonResult), nil
+}
+
+// execute opens a single connection, runs query in a read-only transaction and closes the
+// connection.
+func (t *QueryTool) execute(ctx context.Context, driverName, dsn, query string, maxRows int) (*queryResult, error) {
+	db, err := t.open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	// Nothing is committed, even if a function called by the query had side effects.
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return readRows(rows, maxRows)
+}
+
+func (t *QueryTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: t.runQuery,
+			Tool: mcp.NewTool("db-run-query",
+				mcp.WithDescription("Run a read-only SQL query (a single SELECT or WITH ... SELECT statement) on a logical database of a PostgreSQL or MySQL cluster and return the columns and rows. Other statements are rejected. The query runs in a read-only transaction over a connection opened for it, with a row limit and a timeout."),
+				mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the database cluster")),
+				mcp.WithString("Database", mcp.Required(), mcp.Description("The logical database to query, e.g. defaultdb")),
+				mcp.WithString("User", mcp.Required(), mcp.Description("The database user to connect as, e.g. doadmin")),
+				mcp.WithString("SQL", mcp.Required(), mcp.Description("The SELECT query to run")),
+				mcp.WithNumber("MaxRows", mcp.DefaultNumber(defaultQueryMaxRows), mcp.Min(1), mcp.Max(maxQueryMaxRows), mcp.Description("Maximum number of rows to return, the result is marked truncated beyond it")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(defaultQueryTimeout.Seconds()), mcp.Min(1), mcp.Max(maxQueryTimeout.Seconds()), mcp.Description("How long the connection and query may take, in seconds")),
+				common.WithPreferPrivate(),
+				mcp.WithReadOnlyHintAnnotation(true),
+			),
+		},
+	}
+}
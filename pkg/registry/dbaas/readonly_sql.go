@@ -0,0 +1,133 @@
+package dbaas
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// queryStartKeywords are the keywords a read-only query can start with.
+var queryStartKeywords = []string{"SELECT", "WITH"}
+
+// writeQueryKeywords are the keywords that make a SELECT or WITH statement write or lock: data
+// modifying common table expressions, SELECT INTO and its MySQL INTO OUTFILE form, and the FOR
+// UPDATE and FOR SHARE / LOCK IN SHARE MODE locking clauses.
+var writeQueryKeywords = map[string]bool{
+	"INSERT": true,
+	"UPDATE": true,
+	"DELETE": true,
+	"MERGE":  true,
+	"INTO":   true,
+	"SHARE":  true,
+}
+
+// dollarQuoteRe matches the opening tag of a PostgreSQL dollar-quoted string, e.g. $$ or $body$.
+var dollarQuoteRe = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || c == '$' || (c >= '0' && c <= '9')
+}
+
+// skipQuoted returns the index after the quoted string or identifier starting at query[start].
+// A doubled quote stands for the quote itself, and in MySQL strings a backslash escapes the next
+// character.
+func skipQuoted(query string, start int, backslash bool) (int, error) {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch {
+		case backslash && query[i] == '\\':
+			i++
+		case query[i] == quote && i+1 < len(query) && query[i+1] == quote:
+			i++
+		case query[i] == quote:
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("query has an unterminated %c quote", quote)
+}
+
+// checkReadOnlyQuery checks that query is a single SELECT statement for the given engine, "pg" or
+// "mysql". It reads the query the way the engine does, so that keywords in string literals, quoted
+// identifiers and comments are ignored while a statement separator or a writing keyword anywhere
+// else is caught. Where the engines differ, it errs towards rejecting the query.
+func checkReadOnlyQuery(query, engine string) error {
+	mysql := engine == "mysql"
+	var words []string
+	separated := false
+	for i := 0; i < len(query); {
+		c := query[i]
+		rest := query[i:]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+			continue
+		case strings.HasPrefix(rest, "/*"):
+			if mysql && strings.HasPrefix(rest, "/*!") {
+				return fmt.Errorf("MySQL executable comments (/*! ... */) are not allowed")
+			}
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return fmt.Errorf("query has an unterminated comment")
+			}
+			i += end + 4
+			continue
+		case strings.HasPrefix(rest, "--") && (!mysql || len(rest) == 2 || rest[2] <= ' '), mysql && c == '#':
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			i += end
+			continue
+		}
+
+		// Anything but whitespace and comments after a separator is another statement.
+		if separated {
+			return fmt.Errorf("only a single statement can be run")
+		}
+		switch {
+		case c == ';':
+			separated = true
+			i++
+		case c == '\'' || c == '"' || c == '`':
+			end, err := skipQuoted(query, i, mysql && c != '`')
+			if err != nil {
+				return err
+			}
+			i = end
+		case c == '$' && !mysql && (i == 0 || !isIdentChar(query[i-1])) && dollarQuoteRe.MatchString(rest):
+			tag := dollarQuoteRe.FindString(rest)
+			end := strings.Index(rest[len(tag):], tag)
+			if end < 0 {
+				return fmt.Errorf("query has an unterminated %s quote", tag)
+			}
+			i += len(tag) + end + len(tag)
+		case isIdentStart(c):
+			end := i + 1
+			for end < len(query) && isIdentChar(query[end]) {
+				end++
+			}
+			words = append(words, strings.ToUpper(query[i:end]))
+			i = end
+		default:
+			i++
+		}
+	}
+
+	if len(words) == 0 {
+		return fmt.Errorf("query is empty")
+	}
+	if !slices.Contains(queryStartKeywords, words[0]) {
+		return fmt.Errorf("only SELECT queries can be run, the query starts with %s", words[0])
+	}
+	for _, word := range words {
+		if writeQueryKeywords[word] {
+			return fmt.Errorf("only read-only queries can be run, the query uses %s", word)
+		}
+	}
+	return nil
+}
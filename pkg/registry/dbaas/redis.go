@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -15,6 +17,21 @@ type RedisTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
 }
 
+// redisMaxmemoryPolicies are the eviction policies accepted for redis_maxmemory_policy.
+var redisMaxmemoryPolicies = []string{
+	"noeviction",
+	"allkeys-lru",
+	"allkeys-lfu",
+	"allkeys-random",
+	"volatile-lru",
+	"volatile-lfu",
+	"volatile-random",
+	"volatile-ttl",
+}
+
+// redisPersistenceModes are the values accepted for redis_persistence.
+var redisPersistenceModes = []string{"off", "rdb"}
+
 func NewRedisTool(client func(ctx context.Context) (*godo.Client, error)) *RedisTool {
 	return &RedisTool{
 		client: client,
@@ -66,6 +83,15 @@ func (s *RedisTool) updateRedisConfig(ctx context.Context, req mcp.CallToolReque
 	if err := json.Unmarshal(cfgBytes, &config); err != nil {
 		return mcp.NewToolResultError("Invalid config object: " + err.Error()), nil
 	}
+	if p := config.RedisMaxmemoryPolicy; p != nil && !slices.Contains(redisMaxmemoryPolicies, *p) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid redis_maxmemory_policy %q, must be one of: %s", *p, strings.Join(redisMaxmemoryPolicies, ", "))), nil
+	}
+	if p := config.RedisPersistence; p != nil && !slices.Contains(redisPersistenceModes, *p) {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid redis_persistence %q, must be one of: %s", *p, strings.Join(redisPersistenceModes, ", "))), nil
+	}
+	if t := config.RedisTimeout; t != nil && *t < 0 {
+		return mcp.NewToolResultError("redis_timeout must not be negative"), nil
+	}
 
 	client, err := s.client(ctx)
 	if err != nil {
@@ -77,22 +103,31 @@ func (s *RedisTool) updateRedisConfig(ctx context.Context, req mcp.CallToolReque
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
 
-	return mcp.NewToolResultText("Redis config updated successfully"), nil
+	// Read the config back so the caller sees the effective values, including the ones not updated.
+	effective, _, err := client.Databases.GetRedisConfig(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultText(fmt.Sprintf("Redis config updated successfully, but reading it back failed: %v", err)), nil
+	}
+	jsonCfg, err := response.CompactJSON(effective)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText("Redis config updated successfully, effective config: " + jsonCfg), nil
 }
 
 func (s *RedisTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
 			Handler: s.getRedisConfig,
-			Tool: mcp.NewTool("db-cluster-get-redis-config",
+			Tool: mcp.NewTool("db-redis-get-config",
 				mcp.WithDescription("Get the Redis config for a cluster by its id."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
 			),
 		},
 		{
 			Handler: s.updateRedisConfig,
-			Tool: mcp.NewTool("db-cluster-update-redis-config",
-				mcp.WithDescription("Update the Redis config for a cluster by its id. Accepts a structured config object."),
+			Tool: mcp.NewTool("db-redis-update-config",
+				mcp.WithDescription("Update the Redis config for a cluster by its id. Accepts a structured config object and returns the effective config."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster UUID")),
 				mcp.WithObject("config",
 					mcp.Required(),
@@ -100,6 +135,7 @@ func (s *RedisTool) Tools() []server.ServerTool {
 					mcp.Properties(map[string]any{
 						"redis_maxmemory_policy": map[string]any{
 							"type":        "string",
+							"enum":        redisMaxmemoryPolicies,
 							"description": "Policy for eviction when memory is full (e.g., allkeys-lru)",
 						},
 						"redis_pubsub_client_output_buffer_limit": map[string]any{
@@ -121,14 +157,17 @@ func (s *RedisTool) Tools() []server.ServerTool {
 							"type": "boolean",
 						},
 						"redis_timeout": map[string]any{
-							"type": "integer",
+							"type":        "integer",
+							"description": "Seconds of inactivity after which an idle client connection is closed, 0 disables it",
 						},
 						"redis_notify_keyspace_events": map[string]any{
-							"type": "string",
+							"type":        "string",
+							"description": "Keyspace notifications to publish, as Redis flags (e.g., Ex), empty disables them",
 						},
 						"redis_persistence": map[string]any{
 							"type":        "string",
-							"description": "Persistence mode (e.g., aof, rdb, none)",
+							"enum":        redisPersistenceModes,
+							"description": "Persistence mode: rdb to snapshot to disk, off to disable persistence",
 						},
 						"redis_acl_channels_default": map[string]any{
 							"type": "string",
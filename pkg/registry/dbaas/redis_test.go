@@ -50,6 +50,7 @@ func TestRedisTool_updateRedisConfig(t *testing.T) {
 	mockDB := mocks.NewMockDatabasesService(ctrl)
 	val := "allkeys-lru"
 	mockDB.EXPECT().UpdateRedisConfig(gomock.Any(), "cid", gomock.Any()).Return(&godo.Response{}, nil)
+	mockDB.EXPECT().GetRedisConfig(gomock.Any(), "cid").Return(&godo.RedisConfig{RedisMaxmemoryPolicy: &val}, nil, nil)
 
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{
@@ -64,6 +65,7 @@ func TestRedisTool_updateRedisConfig(t *testing.T) {
 	res, err := rt.updateRedisConfig(context.Background(), req)
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "Redis config updated successfully")
+	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, `"redis_maxmemory_policy":"allkeys-lru"`)
 	// Error case: missing id
 	args = map[string]interface{}{"config": config}
 	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
@@ -90,3 +92,34 @@ func TestRedisTool_updateRedisConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, res.Content[0].(mcp.TextContent).Text, "api error")
 }
+
+func TestRedisTool_updateRedisConfigValidation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// No API call is expected for invalid configs.
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	rt := &RedisTool{client: client}
+	tests := []struct {
+		config map[string]any
+		expect string
+	}{
+		{map[string]any{"redis_maxmemory_policy": "evict-everything"}, "Invalid redis_maxmemory_policy"},
+		{map[string]any{"redis_persistence": "aof"}, "Invalid redis_persistence"},
+		{map[string]any{"redis_timeout": float64(-1)}, "redis_timeout must not be negative"},
+	}
+	for _, tc := range tests {
+		args := map[string]interface{}{"id": "cid", "config": tc.config}
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+		res, err := rt.updateRedisConfig(context.Background(), req)
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expect)
+	}
+}
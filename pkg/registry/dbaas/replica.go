@@ -0,0 +1,227 @@
+package dbaas
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/response"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+type ReplicaTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+func NewReplicaTool(client func(ctx context.Context) (*godo.Client, error)) *ReplicaTool {
+	return &ReplicaTool{
+		client: client,
+	}
+}
+
+func (s *ReplicaTool) createReplica(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Replica name is required"), nil
+	}
+	region, _ := args["region"].(string)
+	size, ok := args["size"].(string)
+	if !ok || size == "" {
+		return mcp.NewToolResultError("Replica size is required"), nil
+	}
+
+	createReq := &godo.DatabaseCreateReplicaRequest{
+		Name:   name,
+		Region: region,
+		Size:   size,
+	}
+	if privateNetworkUUID, ok := args["private_network_uuid"].(string); ok && privateNetworkUUID != "" {
+		createReq.PrivateNetworkUUID = privateNetworkUUID
+	}
+	if tagsRaw, ok := args["tags"].(string); ok && tagsRaw != "" {
+		for _, t := range strings.Split(tagsRaw, ",") {
+			t = strings.TrimSpace(t)
+			if t != "" {
+				createReq.Tags = append(createReq.Tags, t)
+			}
+		}
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	replica, _, err := client.Databases.CreateReplica(ctx, clusterID, createReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonReplica, err := response.CompactJSON(replica)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonReplica), nil
+}
+
+func (s *ReplicaTool) listReplicas(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+
+	// Optional pagination
+	page := 0
+	if pStr, ok := args["page"].(string); ok && pStr != "" {
+		if p, err := strconv.Atoi(pStr); err == nil {
+			page = p
+		}
+	}
+	perPage := 0
+	if pp, ok := args["per_page"].(float64); ok {
+		perPage = int(pp)
+	}
+
+	var opts *godo.ListOptions
+	if page > 0 || perPage > 0 {
+		opts = &godo.ListOptions{Page: page, PerPage: perPage}
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	replicas, _, err := client.Databases.ListReplicas(ctx, clusterID, opts)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonReplicas, err := response.CompactJSON(replicas)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonReplicas), nil
+}
+
+func (s *ReplicaTool) getReplica(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Replica name is required"), nil
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	replica, _, err := client.Databases.GetReplica(ctx, clusterID, name)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonReplica, err := response.CompactJSON(replica)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonReplica), nil
+}
+
+func (s *ReplicaTool) deleteReplica(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Replica name is required"), nil
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	_, err = client.Databases.DeleteReplica(ctx, clusterID, name)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return mcp.NewToolResultText("Replica deleted successfully"), nil
+}
+
+func (s *ReplicaTool) promoteReplica(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	clusterID, ok := args["cluster_id"].(string)
+	if !ok || clusterID == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	name, ok := args["name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Replica name is required"), nil
+	}
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	_, err = client.Databases.PromoteReplicaToPrimary(ctx, clusterID, name)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Replica %s promoted to primary successfully. Warning: this action is irreversible, the replica is now a standalone cluster and no longer replicates from %s", name, clusterID)), nil
+}
+
+func (s *ReplicaTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: s.createReplica,
+			Tool: mcp.NewTool("db-replica-create",
+				mcp.WithDescription("Create a read-only replica for a database cluster"),
+				mcp.WithString("cluster_id", mcp.Required(), mcp.Description("The UUID of the primary cluster")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The name of the replica")),
+				mcp.WithString("region", mcp.Description("The region slug for the replica (e.g., nyc1). Defaults to the primary's region")),
+				mcp.WithString("size", mcp.Required(), mcp.Description("The size slug for the replica (e.g., db-s-2vcpu-4gb)")),
+				mcp.WithString("private_network_uuid", mcp.Description("The VPC UUID to place the replica in (optional)")),
+				mcp.WithString("tags", mcp.Description("Comma-separated tags to apply to the replica")),
+			),
+		},
+		{
+			Handler: s.listReplicas,
+			Tool: mcp.NewTool("db-replica-list",
+				mcp.WithDescription("List read-only replicas for a database cluster"),
+				mcp.WithString("cluster_id", mcp.Required(), mcp.Description("The UUID of the primary cluster")),
+				mcp.WithString("page", mcp.Description("Page number for pagination (optional, integer as string)")),
+				mcp.WithNumber("per_page", mcp.Description("Number of results per page (optional, integer)")),
+			),
+		},
+		{
+			Handler: s.getReplica,
+			Tool: mcp.NewTool("db-replica-get",
+				mcp.WithDescription("Get a read-only replica of a database cluster by name"),
+				mcp.WithString("cluster_id", mcp.Required(), mcp.Description("The UUID of the primary cluster")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The name of the replica")),
+			),
+		},
+		{
+			Handler: s.deleteReplica,
+			Tool: mcp.NewTool("db-replica-delete",
+				mcp.WithDescription("Delete a read-only replica of a database cluster by name"),
+				mcp.WithString("cluster_id", mcp.Required(), mcp.Description("The UUID of the primary cluster")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The name of the replica to delete")),
+			),
+		},
+		{
+			Handler: s.promoteReplica,
+			Tool: mcp.NewTool("db-replica-promote",
+				mcp.WithDescription("Promote a read-only replica to a standalone primary cluster. This action is irreversible."),
+				mcp.WithString("cluster_id", mcp.Required(), mcp.Description("The UUID of the primary cluster")),
+				mcp.WithString("name", mcp.Required(), mcp.Description("The name of the replica to promote")),
+			),
+		},
+	}
+}
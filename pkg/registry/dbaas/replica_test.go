@@ -0,0 +1,135 @@
+package dbaas
+
+import (
+	"context"
+	"mcp-digitalocean/pkg/registry/dbaas/mocks"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReplicaTool_createReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	expectedReq := &godo.DatabaseCreateReplicaRequest{
+		Name:   "read-1",
+		Region: "nyc3",
+		Size:   "db-s-2vcpu-4gb",
+		Tags:   []string{"a", "b"},
+	}
+	mockDB.EXPECT().CreateReplica(gomock.Any(), "cid", expectedReq).Return(&godo.DatabaseReplica{Name: "read-1"}, nil, nil)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	rt := &ReplicaTool{client: client}
+	args := map[string]interface{}{"cluster_id": "cid", "name": "read-1", "region": "nyc3", "size": "db-s-2vcpu-4gb", "tags": "a, b"}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err := rt.createReplica(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, getText(res), "read-1")
+
+	// Error case: missing size
+	args = map[string]interface{}{"cluster_id": "cid", "name": "read-1"}
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	res, err = rt.createReplica(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, getText(res), "Replica size is required")
+}
+
+func TestReplicaTool_listReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().ListReplicas(gomock.Any(), "cid", (*godo.ListOptions)(nil)).Return([]godo.DatabaseReplica{{Name: "read-1"}, {Name: "read-2"}}, nil, nil)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	rt := &ReplicaTool{client: client}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"cluster_id": "cid"}}}
+	res, err := rt.listReplicas(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, getText(res), "read-1")
+	assert.Contains(t, getText(res), "read-2")
+}
+
+func TestReplicaTool_getReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().GetReplica(gomock.Any(), "cid", "read-1").Return(&godo.DatabaseReplica{Name: "read-1", Region: "nyc3"}, nil, nil)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	rt := &ReplicaTool{client: client}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"cluster_id": "cid", "name": "read-1"}}}
+	res, err := rt.getReplica(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, getText(res), "nyc3")
+
+	// Error case: missing name
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"cluster_id": "cid"}}}
+	res, err = rt.getReplica(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, getText(res), "Replica name is required")
+}
+
+func TestReplicaTool_deleteReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().DeleteReplica(gomock.Any(), "cid", "read-1").Return(nil, nil)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	rt := &ReplicaTool{client: client}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"cluster_id": "cid", "name": "read-1"}}}
+	res, err := rt.deleteReplica(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, getText(res), "Replica deleted successfully")
+}
+
+func TestReplicaTool_promoteReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDatabasesService(ctrl)
+	mockDB.EXPECT().PromoteReplicaToPrimary(gomock.Any(), "cid", "read-1").Return(nil, nil)
+
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{
+			Databases: mockDB,
+		}, nil
+	}
+
+	rt := &ReplicaTool{client: client}
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"cluster_id": "cid", "name": "read-1"}}}
+	res, err := rt.promoteReplica(context.Background(), req)
+	assert.NoError(t, err)
+	assert.False(t, res.IsError)
+	assert.Contains(t, getText(res), "irreversible")
+
+	// Error case: missing cluster id
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]interface{}{"name": "read-1"}}}
+	res, err = rt.promoteReplica(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Contains(t, getText(res), "Cluster id is required")
+}
@@ -12,6 +12,43 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// mongoUserRoles are the roles that can be assigned to a MongoDB user.
+var mongoUserRoles = map[string]struct{}{
+	"readOnly":  {},
+	"readWrite": {},
+	"dbAdmin":   {},
+}
+
+// engineForUserSettings returns the engine slug that the engine-specific user settings apply to,
+// or an empty string when no engine-specific settings are set.
+func engineForUserSettings(mysqlSettings *godo.DatabaseMySQLUserSettings, settings *godo.DatabaseUserSettings) (string, error) {
+	type requirement struct{ field, engine string }
+	var requirements []requirement
+	if mysqlSettings != nil {
+		requirements = append(requirements, requirement{"mysql_settings", "mysql"})
+	}
+	if settings != nil {
+		if settings.MongoUserSettings != nil {
+			requirements = append(requirements, requirement{"mongo_user_settings", "mongodb"})
+		}
+		if len(settings.ACL) > 0 {
+			requirements = append(requirements, requirement{"acl", "kafka"})
+		}
+		if len(settings.OpenSearchACL) > 0 {
+			requirements = append(requirements, requirement{"opensearch_acl", "opensearch"})
+		}
+	}
+
+	engine := ""
+	for _, r := range requirements {
+		if engine != "" && engine != r.engine {
+			return "", fmt.Errorf("conflicting user settings: %s cannot be combined with settings for %s", r.field, engine)
+		}
+		engine = r.engine
+	}
+	return engine, nil
+}
+
 type UserTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
 }
@@ -108,6 +145,18 @@ func (s *UserTool) createUser(ctx context.Context, req mcp.CallToolRequest) (*mc
 	if plugin, ok := args["mysql_auth_plugin"].(string); ok && plugin != "" {
 		createReq.MySQLSettings = &godo.DatabaseMySQLUserSettings{AuthPlugin: plugin}
 	}
+	if mysqlVal, ok := args["mysql_settings"]; ok {
+		mysqlMap, ok := mysqlVal.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid mysql_settings object: must be an object"), nil
+		}
+		mysqlBytes, _ := json.Marshal(mysqlMap)
+		var mysqlSettings godo.DatabaseMySQLUserSettings
+		if err := json.Unmarshal(mysqlBytes, &mysqlSettings); err != nil {
+			return mcp.NewToolResultError("Invalid mysql_settings object: " + err.Error()), nil
+		}
+		createReq.MySQLSettings = &mysqlSettings
+	}
 
 	if settingsVal, ok := args["settings"]; ok {
 		settingsMap, ok := settingsVal.(map[string]any)
@@ -121,6 +170,31 @@ func (s *UserTool) createUser(ctx context.Context, req mcp.CallToolRequest) (*mc
 		}
 		createReq.Settings = &settings
 	}
+	if mongoVal, ok := args["mongo_user_settings"]; ok {
+		mongoMap, ok := mongoVal.(map[string]any)
+		if !ok {
+			return mcp.NewToolResultError("Invalid mongo_user_settings object: must be an object"), nil
+		}
+		mongoBytes, _ := json.Marshal(mongoMap)
+		var mongoSettings godo.MongoUserSettings
+		if err := json.Unmarshal(mongoBytes, &mongoSettings); err != nil {
+			return mcp.NewToolResultError("Invalid mongo_user_settings object: " + err.Error()), nil
+		}
+		if createReq.Settings == nil {
+			createReq.Settings = &godo.DatabaseUserSettings{}
+		}
+		createReq.Settings.MongoUserSettings = &mongoSettings
+	}
+	if createReq.Settings != nil && createReq.Settings.MongoUserSettings != nil {
+		if _, ok := mongoUserRoles[createReq.Settings.MongoUserSettings.Role]; !ok {
+			return mcp.NewToolResultError("mongo_user_settings.role must be one of readOnly, readWrite or dbAdmin"), nil
+		}
+	}
+
+	requiredEngine, err := engineForUserSettings(createReq.MySQLSettings, createReq.Settings)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := s.client(ctx)
 	if err != nil {
@@ -132,6 +206,16 @@ func (s *UserTool) createUser(ctx context.Context, req mcp.CallToolRequest) (*mc
 		return nil, fmt.Errorf("database client is not configured")
 	}
 
+	if requiredEngine != "" {
+		cluster, _, err := client.Databases.Get(ctx, id)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		if cluster.EngineSlug != requiredEngine {
+			return mcp.NewToolResultError(fmt.Sprintf("The provided settings only apply to %s clusters, but cluster %s runs %s", requiredEngine, id, cluster.EngineSlug)), nil
+		}
+	}
+
 	dbUser, _, err := client.Databases.CreateUser(ctx, id, createReq)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
@@ -194,6 +278,35 @@ func (s *UserTool) updateUser(ctx context.Context, req mcp.CallToolRequest) (*mc
 	return mcp.NewToolResultText(jsonUser), nil
 }
 
+func (s *UserTool) resetUserAuth(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["id"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Cluster id is required"), nil
+	}
+	user, ok := args["user"].(string)
+	if !ok || user == "" {
+		return mcp.NewToolResultError("User name is required"), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	dbUser, _, err := client.Databases.ResetUserAuth(ctx, id, user, &godo.DatabaseResetUserAuthRequest{})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	jsonUser, err := response.CompactJSON(dbUser)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(jsonUser), nil
+}
+
 func (s *UserTool) deleteUser(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 	id, ok := args["id"].(string)
@@ -275,11 +388,33 @@ func (s *UserTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: s.createUser,
-			Tool: mcp.NewTool("db-cluster-create-user",
-				mcp.WithDescription("Create a new database user for a cluster"),
+			Tool: mcp.NewTool("db-user-create",
+				mcp.WithDescription("Create a new database user for a cluster and return its credentials. Engine-specific settings must match the cluster's engine."),
 				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster ID")),
 				mcp.WithString("name", mcp.Required(), mcp.Description("The user name")),
 				mcp.WithString("mysql_auth_plugin", mcp.Description("MySQL auth plugin (optional)")),
+				mcp.WithObject("mysql_settings",
+					mcp.Description("MySQL user settings (optional, MySQL clusters only)"),
+					mcp.Properties(map[string]any{
+						"auth_plugin": map[string]any{
+							"type": "string",
+							"enum": []string{godo.SQLAuthPluginCachingSHA2, godo.SQLAuthPluginNative},
+						},
+					}),
+				),
+				mcp.WithObject("mongo_user_settings",
+					mcp.Description("MongoDB user settings (optional, MongoDB clusters only)"),
+					mcp.Properties(map[string]any{
+						"databases": map[string]any{
+							"type":  "array",
+							"items": map[string]any{"type": "string"},
+						},
+						"role": map[string]any{
+							"type": "string",
+							"enum": []string{"readOnly", "readWrite", "dbAdmin"},
+						},
+					}),
+				),
 				dbSettings,
 			),
 		},
@@ -292,6 +427,14 @@ func (s *UserTool) Tools() []server.ServerTool {
 				dbSettings,
 			),
 		},
+		{
+			Handler: s.resetUserAuth,
+			Tool: mcp.NewTool("db-user-reset-auth",
+				mcp.WithDescription("Regenerate a database user's password and return the new credentials"),
+				mcp.WithString("id", mcp.Required(), mcp.Description("The cluster ID")),
+				mcp.WithString("user", mcp.Required(), mcp.Description("The user name")),
+			),
+		},
 		{
 			Handler: s.deleteUser,
 			Tool: mcp.NewTool("db-cluster-delete-user",
@@ -300,5 +443,6 @@ func (s *UserTool) Tools() []server.ServerTool {
 				mcp.WithString("user", mcp.Required(), mcp.Description("The user name to delete")),
 			),
 		},
+		s.aclTool(),
 	}
 }
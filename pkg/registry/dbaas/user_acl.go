@@ -0,0 +1,134 @@
+package dbaas
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// kafkaACLPermissions maps the permissions accepted by db-user-set-acl to the Kafka ACL permissions
+// of the API.
+var kafkaACLPermissions = map[string]string{
+	"read":      "consume",
+	"write":     "produce",
+	"readwrite": "produceconsume",
+	"admin":     "admin",
+}
+
+// aclEntry is an entry of the acl argument of db-user-set-acl.
+type aclEntry struct {
+	Topic      string `json:"topic"`
+	Permission string `json:"permission"`
+}
+
+// parseKafkaACL converts the acl argument to Kafka ACLs. Entries must only have a topic and a
+// permission, so ACLs shaped for another engine are rejected.
+func parseKafkaACL(raw []any) ([]*godo.KafkaACL, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("argument 'acl' is required")
+	}
+	permissions := slices.Sorted(maps.Keys(kafkaACLPermissions))
+	acls := make([]*godo.KafkaACL, 0, len(raw))
+	for i, item := range raw {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("acl[%d]: %w", i, err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		var entry aclEntry
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("acl[%d] must be an object with a topic and a permission: %v", i, err)
+		}
+		if strings.TrimSpace(entry.Topic) == "" {
+			return nil, fmt.Errorf("acl[%d].topic is required, use * to match every topic", i)
+		}
+		permission, ok := kafkaACLPermissions[entry.Permission]
+		if !ok {
+			return nil, fmt.Errorf("acl[%d].permission must be one of: %s", i, strings.Join(permissions, ", "))
+		}
+		acls = append(acls, &godo.KafkaACL{Topic: entry.Topic, Permission: permission})
+	}
+	return acls, nil
+}
+
+// setUserACL replaces the access control list of a database user, dispatching on the engine of the
+// cluster. Kafka users get topic-level permissions; the API has no per-user ACLs for other engines.
+func (s *UserTool) setUserACL(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	id := args.RequireString("id")
+	user := args.RequireString("user")
+	raw := args.OptionalArray("acl")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	cluster, _, err := client.Databases.Get(ctx, id)
+	if err != nil {
+		return common.APIErrorResult(err, "database cluster", id), nil
+	}
+
+	var settings *godo.DatabaseUserSettings
+	switch cluster.EngineSlug {
+	case "kafka":
+		acls, err := parseKafkaACL(raw)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		settings = &godo.DatabaseUserSettings{ACL: acls}
+	case "redis", "valkey":
+		return mcp.NewToolResultError(fmt.Sprintf("cluster %s runs %s, whose users can't be managed through the DigitalOcean API, so per-user ACLs are not available", id, cluster.EngineSlug)), nil
+	default:
+		return mcp.NewToolResultError(fmt.Sprintf("db-user-set-acl supports kafka clusters, but cluster %s runs %s", id, cluster.EngineSlug)), nil
+	}
+
+	dbUser, _, err := client.Databases.UpdateUser(ctx, id, user, &godo.DatabaseUpdateUserRequest{Settings: settings})
+	if err != nil {
+		return common.APIErrorResult(err, "database user", user), nil
+	}
+
+	// Only the settings are returned, leaving out the credentials of the user.
+	jsonData, err := response.CompactJSON(map[string]any{"user": dbUser.Name, "settings": dbUser.Settings})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// aclTool returns the db-user-set-acl tool.
+func (s *UserTool) aclTool() server.ServerTool {
+	return server.ServerTool{
+		Handler: s.setUserACL,
+		Tool: mcp.NewTool("db-user-set-acl",
+			mcp.WithDescription("Replace the access control list of a database user. For Kafka clusters, each entry grants a permission on a topic: read (consume), write (produce), readwrite (produce and consume) or admin. Redis and Valkey users can't be managed through the API. Returns the updated user settings."),
+			mcp.WithString("id", mcp.Required(), mcp.Description("The cluster ID")),
+			mcp.WithString("user", mcp.Required(), mcp.Description("The user name")),
+			mcp.WithArray("acl", mcp.Required(),
+				mcp.Description("ACL entries, replacing the current ones"),
+				mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"topic":      map[string]any{"type": "string", "description": "Topic name or pattern, e.g. orders.* or *"},
+						"permission": map[string]any{"type": "string", "enum": slices.Sorted(maps.Keys(kafkaACLPermissions))},
+					},
+					"required": []string{"topic", "permission"},
+				}),
+			),
+		),
+	}
+}
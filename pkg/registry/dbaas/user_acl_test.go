@@ -0,0 +1,79 @@
+package dbaas
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserTool_setUserACL(t *testing.T) {
+	ctx := context.Background()
+	call := func(tool *UserTool, args map[string]any) *mcp.CallToolResult {
+		res, err := tool.setUserACL(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.NotNil(t, res)
+		return res
+	}
+
+	t.Run("kafka topic permissions", func(t *testing.T) {
+		tool, mockSvc, _ := newUserToolWithMock(t)
+		acls := []*godo.KafkaACL{
+			{Topic: "orders.*", Permission: "consume"},
+			{Topic: "events", Permission: "produceconsume"},
+		}
+		mockSvc.EXPECT().Get(ctx, "cid").Return(&godo.Database{ID: "cid", EngineSlug: "kafka"}, nil, nil)
+		mockSvc.EXPECT().UpdateUser(ctx, "cid", "app", &godo.DatabaseUpdateUserRequest{Settings: &godo.DatabaseUserSettings{ACL: acls}}).
+			Return(&godo.DatabaseUser{Name: "app", Password: "secret", Settings: &godo.DatabaseUserSettings{ACL: acls}}, nil, nil)
+
+		res := call(tool, map[string]any{"id": "cid", "user": "app", "acl": []any{
+			map[string]any{"topic": "orders.*", "permission": "read"},
+			map[string]any{"topic": "events", "permission": "readwrite"},
+		}})
+		require.False(t, res.IsError)
+		text := getTextContent(res)
+		require.NotContains(t, text, "secret")
+		var out struct {
+			User     string                     `json:"user"`
+			Settings *godo.DatabaseUserSettings `json:"settings"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(text), &out))
+		require.Equal(t, "app", out.User)
+		require.Equal(t, acls, out.Settings.ACL)
+	})
+
+	tests := []struct {
+		name   string
+		engine string
+		acl    []any
+		expect string
+	}{
+		{"redis shaped entry on kafka", "kafka", []any{map[string]any{"key_pattern": "cache:*", "permission": "read"}}, `acl[0] must be an object with a topic and a permission`},
+		{"unknown permission", "kafka", []any{map[string]any{"topic": "t", "permission": "consume"}}, "acl[0].permission must be one of: admin, read, readwrite, write"},
+		{"missing topic", "kafka", []any{map[string]any{"permission": "read"}}, "acl[0].topic is required"},
+		{"empty acl", "kafka", []any{}, "argument 'acl' is required"},
+		{"redis cluster", "redis", []any{map[string]any{"topic": "t", "permission": "read"}}, "cluster cid runs redis, whose users can't be managed"},
+		{"postgres cluster", "pg", []any{map[string]any{"topic": "t", "permission": "read"}}, "db-user-set-acl supports kafka clusters, but cluster cid runs pg"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool, mockSvc, _ := newUserToolWithMock(t)
+			mockSvc.EXPECT().Get(ctx, "cid").Return(&godo.Database{ID: "cid", EngineSlug: tc.engine}, nil, nil)
+			mockSvc.EXPECT().UpdateUser(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			res := call(tool, map[string]any{"id": "cid", "user": "app", "acl": tc.acl})
+			require.True(t, res.IsError)
+			require.Contains(t, getTextContent(res), tc.expect)
+		})
+	}
+
+	t.Run("missing user", func(t *testing.T) {
+		res := call(&UserTool{}, map[string]any{"id": "cid"})
+		require.True(t, res.IsError)
+		require.Equal(t, "argument 'user' is required", getTextContent(res))
+	})
+}
@@ -137,6 +137,7 @@ func TestUserTool_createUser(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		dbUser := &godo.DatabaseUser{Name: "pluginuser"}
+		mockSvc.EXPECT().Get(ctx, "cid").Return(&godo.Database{EngineSlug: "mysql"}, nil, nil)
 		mockSvc.EXPECT().CreateUser(ctx, "cid", mock.MatchedBy(func(req *godo.DatabaseCreateUserRequest) bool {
 			return req.MySQLSettings != nil && req.MySQLSettings.AuthPlugin == "mysql_native_password"
 		})).Return(dbUser, nil, nil)
@@ -156,6 +157,7 @@ func TestUserTool_createUser(t *testing.T) {
 		tool, mockSvc, ctrl := newUserToolWithMock(t)
 		defer ctrl.Finish()
 		dbUser := &godo.DatabaseUser{Name: "settingsuser"}
+		mockSvc.EXPECT().Get(ctx, "cid").Return(&godo.Database{EngineSlug: "kafka"}, nil, nil)
 		mockSvc.EXPECT().CreateUser(ctx, "cid", gomock.Any()).Return(dbUser, nil, nil)
 
 		res, err := tool.createUser(ctx, mcp.CallToolRequest{
@@ -173,6 +175,79 @@ func TestUserTool_createUser(t *testing.T) {
 		assert.Contains(t, getTextContent(res), "settingsuser")
 	})
 
+	t.Run("with mongo_user_settings", func(t *testing.T) {
+		tool, mockSvc, ctrl := newUserToolWithMock(t)
+		defer ctrl.Finish()
+		dbUser := &godo.DatabaseUser{Name: "mongouser", Password: "s3cret"}
+		mockSvc.EXPECT().Get(ctx, "cid").Return(&godo.Database{EngineSlug: "mongodb"}, nil, nil)
+		mockSvc.EXPECT().CreateUser(ctx, "cid", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, req *godo.DatabaseCreateUserRequest) (*godo.DatabaseUser, *godo.Response, error) {
+			assert.Equal(t, "readOnly", req.Settings.MongoUserSettings.Role)
+			assert.Equal(t, []string{"app"}, req.Settings.MongoUserSettings.Databases)
+			return dbUser, nil, nil
+		})
+
+		res, err := tool.createUser(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{
+				"id":   "cid",
+				"name": "mongouser",
+				"mongo_user_settings": map[string]any{
+					"role":      "readOnly",
+					"databases": []any{"app"},
+				},
+			}},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, getTextContent(res), "s3cret")
+	})
+
+	t.Run("mongo settings on a postgres cluster", func(t *testing.T) {
+		tool, mockSvc, ctrl := newUserToolWithMock(t)
+		defer ctrl.Finish()
+		mockSvc.EXPECT().Get(ctx, "cid").Return(&godo.Database{EngineSlug: "pg"}, nil, nil)
+
+		res, err := tool.createUser(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{
+				"id":                  "cid",
+				"name":                "mongouser",
+				"mongo_user_settings": map[string]any{"role": "readWrite"},
+			}},
+		})
+		assert.NoError(t, err)
+		assert.True(t, res.IsError)
+		assert.Contains(t, getTextContent(res), "only apply to mongodb clusters")
+	})
+
+	t.Run("invalid mongo role", func(t *testing.T) {
+		tool, _, ctrl := newUserToolWithMock(t)
+		defer ctrl.Finish()
+
+		res, err := tool.createUser(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{
+				"id":                  "cid",
+				"name":                "mongouser",
+				"mongo_user_settings": map[string]any{"role": "root"},
+			}},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, getTextContent(res), "mongo_user_settings.role must be one of")
+	})
+
+	t.Run("conflicting engine settings", func(t *testing.T) {
+		tool, _, ctrl := newUserToolWithMock(t)
+		defer ctrl.Finish()
+
+		res, err := tool.createUser(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{
+				"id":                  "cid",
+				"name":                "mixed",
+				"mysql_auth_plugin":   "mysql_native_password",
+				"mongo_user_settings": map[string]any{"role": "readOnly"},
+			}},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, getTextContent(res), "conflicting user settings")
+	})
+
 	t.Run("invalid settings (non-object type)", func(t *testing.T) {
 		// Use a real mock, but do not set any expectation, so handler can reach settings parsing
 		tool, _, ctrl := newUserToolWithMock(t)
@@ -296,6 +371,37 @@ func TestUserTool_updateUser(t *testing.T) {
 	})
 }
 
+func TestUserTool_resetUserAuth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		tool, mockSvc, ctrl := newUserToolWithMock(t)
+		defer ctrl.Finish()
+		dbUser := &godo.DatabaseUser{Name: "u1", Password: "n3wpass"}
+		mockSvc.EXPECT().ResetUserAuth(ctx, "cid", "u1", &godo.DatabaseResetUserAuthRequest{}).Return(dbUser, nil, nil)
+		res, err := tool.resetUserAuth(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "u1"}}})
+		assert.NoError(t, err)
+		assert.Contains(t, getTextContent(res), "n3wpass")
+	})
+
+	t.Run("missing user", func(t *testing.T) {
+		tool, _, ctrl := newUserToolWithMock(t)
+		defer ctrl.Finish()
+		res, err := tool.resetUserAuth(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid"}}})
+		assert.NoError(t, err)
+		assert.Equal(t, "User name is required", getTextContent(res))
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		tool, mockSvc, ctrl := newUserToolWithMock(t)
+		defer ctrl.Finish()
+		mockSvc.EXPECT().ResetUserAuth(ctx, "cid", "u1", gomock.Any()).Return(nil, nil, errors.New("api fail"))
+		res, err := tool.resetUserAuth(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"id": "cid", "user": "u1"}}})
+		assert.NoError(t, err)
+		assert.Contains(t, getTextContent(res), "api error")
+	})
+}
+
 func TestUserTool_deleteUser(t *testing.T) {
 	ctx := context.Background()
 
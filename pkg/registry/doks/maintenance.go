@@ -0,0 +1,176 @@
+package doks
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maintenanceDays are the days accepted for a maintenance window, "any" letting DigitalOcean pick.
+var maintenanceDays = []string{"any", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+
+// maintenanceStartTime matches the UTC start time of a maintenance window, e.g. 04:30.
+var maintenanceStartTime = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+// clusterMaintenance is the upgrade configuration of a cluster returned by the maintenance tools.
+type clusterMaintenance struct {
+	ClusterID         string                            `json:"cluster_id"`
+	Name              string                            `json:"name"`
+	Version           string                            `json:"version"`
+	MaintenancePolicy *godo.KubernetesMaintenancePolicy `json:"maintenance_policy,omitempty"`
+	AutoUpgrade       bool                              `json:"auto_upgrade"`
+	SurgeUpgrade      bool                              `json:"surge_upgrade"`
+}
+
+// clusterRoot is the body of a cluster API response.
+type clusterRoot struct {
+	Cluster *godo.KubernetesCluster `json:"kubernetes_cluster"`
+}
+
+// updateCluster applies update to a cluster, first fetching it so the update carries its current
+// name, which the API requires. It returns the updated cluster's upgrade configuration.
+func (d *DoksTool) updateCluster(ctx context.Context, clusterID string, update func(*godo.KubernetesClusterUpdateRequest)) (*mcp.CallToolResult, error) {
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	current, _, err := client.Kubernetes.Get(ctx, clusterID)
+	if err != nil {
+		return common.APIErrorResult(err, "cluster", clusterID), nil
+	}
+	updateRequest := &godo.KubernetesClusterUpdateRequest{Name: current.Name}
+	update(updateRequest)
+	cluster, _, err := client.Kubernetes.Update(ctx, clusterID, updateRequest)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to update cluster", err), nil
+	}
+	return maintenanceResult(cluster)
+}
+
+func maintenanceResult(cluster *godo.KubernetesCluster) (*mcp.CallToolResult, error) {
+	jsonData, err := response.CompactJSON(clusterMaintenance{
+		ClusterID:         cluster.ID,
+		Name:              cluster.Name,
+		Version:           cluster.VersionSlug,
+		MaintenancePolicy: cluster.MaintenancePolicy,
+		AutoUpgrade:       cluster.AutoUpgrade,
+		SurgeUpgrade:      cluster.SurgeUpgrade,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// updateMaintenance sets the weekly window in which DigitalOcean may upgrade and maintain a cluster.
+func (d *DoksTool) updateMaintenance(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	clusterID := args.RequireString("ClusterID")
+	day := args.RequireEnum("Day", maintenanceDays...)
+	startTime := args.RequireString("StartTime")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if !maintenanceStartTime.MatchString(startTime) {
+		return mcp.NewToolResultError(fmt.Sprintf("StartTime must be a UTC time in 24-hour HH:MM format, e.g. 04:30, got %q", startTime)), nil
+	}
+
+	return d.updateCluster(ctx, clusterID, func(update *godo.KubernetesClusterUpdateRequest) {
+		update.MaintenancePolicy = &godo.KubernetesMaintenancePolicy{
+			StartTime: startTime,
+			Day:       godo.KubernetesMaintenancePolicyDay(getDayFromString(day)),
+		}
+	})
+}
+
+// setAutoUpgrade turns automatic patch upgrades of a cluster, run in its maintenance window, on or off.
+func (d *DoksTool) setAutoUpgrade(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	clusterID := args.RequireString("ClusterID")
+	enabled := args.OptionalBool("Enabled", true)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return d.updateCluster(ctx, clusterID, func(update *godo.KubernetesClusterUpdateRequest) {
+		update.AutoUpgrade = &enabled
+	})
+}
+
+// setSurgeUpgrade turns surge upgrades of a cluster, which create new nodes before draining the old
+// ones, on or off.
+func (d *DoksTool) setSurgeUpgrade(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	clusterID := args.RequireString("ClusterID")
+	enabled := args.OptionalBool("Enabled", true)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if enabled {
+		return d.updateCluster(ctx, clusterID, func(update *godo.KubernetesClusterUpdateRequest) {
+			update.SurgeUpgrade = true
+		})
+	}
+
+	// godo omits a false SurgeUpgrade from the update request, so turning surge upgrades off is sent
+	// as a raw update.
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+	current, _, err := client.Kubernetes.Get(ctx, clusterID)
+	if err != nil {
+		return common.APIErrorResult(err, "cluster", clusterID), nil
+	}
+	body := map[string]any{"name": current.Name, "surge_upgrade": false}
+	httpReq, err := client.NewRequest(ctx, http.MethodPut, "v2/kubernetes/clusters/"+url.PathEscape(clusterID), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build update request: %w", err)
+	}
+	root := new(clusterRoot)
+	if _, err := client.Do(ctx, httpReq, root); err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to update cluster", err), nil
+	}
+	return maintenanceResult(root.Cluster)
+}
+
+// MaintenanceTools returns the tools controlling when and how clusters are upgraded.
+func (d *DoksTool) MaintenanceTools() []server.ServerTool {
+	clusterID := mcp.WithString("ClusterID", mcp.Required(), mcp.Description("The ID of the Kubernetes cluster"))
+	return []server.ServerTool{
+		{
+			Handler: d.updateMaintenance,
+			Tool: mcp.NewTool("doks-update-maintenance",
+				mcp.WithDescription("Set the weekly maintenance window of a Kubernetes cluster, in which DigitalOcean applies automatic patch upgrades and maintenance. Returns the cluster's upgrade configuration."),
+				clusterID,
+				mcp.WithString("Day", mcp.Required(), mcp.Enum(maintenanceDays...), mcp.Description("Day of the window, or any to let DigitalOcean pick")),
+				mcp.WithString("StartTime", mcp.Required(), mcp.Description("UTC start time of the window in 24-hour HH:MM format, e.g. 04:30")),
+			),
+		},
+		{
+			Handler: d.setAutoUpgrade,
+			Tool: mcp.NewTool("doks-set-auto-upgrade",
+				mcp.WithDescription("Turn automatic patch upgrades of a Kubernetes cluster on or off. Upgrades run in the cluster's maintenance window. Returns the cluster's upgrade configuration."),
+				clusterID,
+				mcp.WithBoolean("Enabled", mcp.DefaultBool(true), mcp.Description("Whether the cluster is upgraded automatically")),
+			),
+		},
+		{
+			Handler: d.setSurgeUpgrade,
+			Tool: mcp.NewTool("doks-set-surge-upgrade",
+				mcp.WithDescription("Turn surge upgrades of a Kubernetes cluster on or off. With surge upgrades, new nodes are created before the old ones are drained, reducing downtime during upgrades. Returns the cluster's upgrade configuration."),
+				clusterID,
+				mcp.WithBoolean("Enabled", mcp.DefaultBool(true), mcp.Description("Whether the cluster uses surge upgrades")),
+			),
+		},
+	}
+}
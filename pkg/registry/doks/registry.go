@@ -0,0 +1,128 @@
+package doks
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// clusterRegistry is the registry integration of a cluster after an add or remove.
+type clusterRegistry struct {
+	ClusterID       string `json:"cluster_id"`
+	Name            string `json:"name,omitempty"`
+	RegistryEnabled bool   `json:"registry_enabled"`
+	Error           string `json:"error,omitempty"`
+}
+
+// registryAssociation is the result of doks-registry-add and doks-registry-remove.
+type registryAssociation struct {
+	Registry string            `json:"registry"`
+	Action   string            `json:"action"`
+	Clusters []clusterRegistry `json:"clusters"`
+}
+
+// clusterIDsArg reads the required cluster_ids argument.
+func clusterIDsArg(req mcp.CallToolRequest) ([]string, error) {
+	args := common.NewArgs(req)
+	ids := args.OptionalStrings("cluster_ids")
+	if err := args.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("argument 'cluster_ids' is required")
+	}
+	for _, id := range ids {
+		if id == "" {
+			return nil, fmt.Errorf("argument 'cluster_ids' must not contain empty IDs")
+		}
+	}
+	return ids, nil
+}
+
+// updateClusterRegistry connects the account's container registry to clusters, or disconnects it,
+// after checking that the registry exists. It reports the resulting integration of each cluster.
+func (d *DoksTool) updateClusterRegistry(ctx context.Context, req mcp.CallToolRequest, add bool) (*mcp.CallToolResult, error) {
+	clusterIDs, err := clusterIDsArg(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	registry, _, err := client.Registry.Get(ctx)
+	if err != nil {
+		if common.IsNotFound(err) {
+			return mcp.NewToolResultError("the account has no container registry, create one before connecting it to clusters"), nil
+		}
+		return mcp.NewToolResultErrorFromErr("failed to get container registry", err), nil
+	}
+
+	registryReq := &godo.KubernetesClusterRegistryRequest{ClusterUUIDs: clusterIDs}
+	action := "added"
+	if add {
+		_, err = client.Kubernetes.AddRegistry(ctx, registryReq)
+	} else {
+		action = "removed"
+		_, err = client.Kubernetes.RemoveRegistry(ctx, registryReq)
+	}
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to update the registry of clusters", err), nil
+	}
+
+	result := registryAssociation{Registry: registry.Name, Action: action, Clusters: make([]clusterRegistry, len(clusterIDs))}
+	for i, id := range clusterIDs {
+		result.Clusters[i].ClusterID = id
+		cluster, _, err := client.Kubernetes.Get(ctx, id)
+		if err != nil {
+			result.Clusters[i].Error = err.Error()
+			continue
+		}
+		result.Clusters[i].Name = cluster.Name
+		result.Clusters[i].RegistryEnabled = cluster.RegistryEnabled
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// addRegistry connects the account's container registry to clusters.
+func (d *DoksTool) addRegistry(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return d.updateClusterRegistry(ctx, req, true)
+}
+
+// removeRegistry disconnects the account's container registry from clusters.
+func (d *DoksTool) removeRegistry(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return d.updateClusterRegistry(ctx, req, false)
+}
+
+// RegistryTools returns the tools connecting the container registry to clusters.
+func (d *DoksTool) RegistryTools() []server.ServerTool {
+	clusterIDs := mcp.WithArray("cluster_ids", mcp.Required(), mcp.Items(map[string]any{"type": "string"}), mcp.Description("IDs of the Kubernetes clusters"))
+	return []server.ServerTool{
+		{
+			Handler: d.addRegistry,
+			Tool: mcp.NewTool("doks-registry-add",
+				mcp.WithDescription("Connect the account's container registry to Kubernetes clusters so they can pull its private images. Fails when the account has no container registry. Returns whether the registry is enabled on each cluster."),
+				clusterIDs,
+			),
+		},
+		{
+			Handler: d.removeRegistry,
+			Tool: mcp.NewTool("doks-registry-remove",
+				mcp.WithDescription("Disconnect the account's container registry from Kubernetes clusters. Returns whether the registry is enabled on each cluster."),
+				clusterIDs,
+			),
+		},
+	}
+}
@@ -4,7 +4,11 @@ package droplet
 import (
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -13,13 +17,15 @@ import (
 
 // DropletActionsTool provides tools for droplet actions
 type DropletActionsTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	client       func(ctx context.Context) (*godo.Client, error)
+	pollInterval time.Duration
 }
 
 // NewDropletActionsTool creates a new droplet actions tool
 func NewDropletActionsTool(client func(ctx context.Context) (*godo.Client, error)) *DropletActionsTool {
 	return &DropletActionsTool{
-		client: client,
+		client:       client,
+		pollInterval: defaultStopPollInterval,
 	}
 }
 
@@ -45,16 +51,20 @@ func (da *DropletActionsTool) rebootDroplet(ctx context.Context, req mcp.CallToo
 	return mcp.NewToolResultText(jsonAction), nil
 }
 
-// passwordResetDroplet resets the password for a droplet
-func (da *DropletActionsTool) passwordResetDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
+// resetPassword resets the root password of a droplet. The new password is emailed to the account owner.
+func (da *DropletActionsTool) resetPassword(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	dropletID := args.RequireInt("ID")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	action, _, err := client.DropletActions.PasswordReset(ctx, int(dropletID))
+	action, _, err := client.DropletActions.PasswordReset(ctx, dropletID)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -67,17 +77,35 @@ func (da *DropletActionsTool) passwordResetDroplet(ctx context.Context, req mcp.
 	return mcp.NewToolResultText(jsonAction), nil
 }
 
-// RebuildByImageSlugDroplet rebuilds a droplet using an image slug
-func (da *DropletActionsTool) rebuildByImageSlugDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
-	imageSlug := req.GetArguments()["ImageSlug"].(string)
+// rebuild reinstalls a droplet from an image given by ID or slug. It wipes the droplet's disk, so
+// it requires Confirm.
+func (da *DropletActionsTool) rebuild(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	dropletID := args.RequireInt("ID")
+	imageID := args.OptionalInt("ImageID", 0)
+	imageSlug := args.OptionalString("ImageSlug", "")
+	confirm := args.OptionalBool("Confirm", false)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if (imageID == 0) == (imageSlug == "") {
+		return mcp.NewToolResultError("exactly one of ImageID or ImageSlug is required"), nil
+	}
+	if !confirm {
+		return mcp.NewToolResultError("rebuilding a droplet erases all its data, set Confirm to true to proceed"), nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	action, _, err := client.DropletActions.RebuildByImageSlug(ctx, int(dropletID), imageSlug)
+	var action *godo.Action
+	if imageSlug != "" {
+		action, _, err = client.DropletActions.RebuildByImageSlug(ctx, dropletID, imageSlug)
+	} else {
+		action, _, err = client.DropletActions.RebuildByImageID(ctx, dropletID, imageID)
+	}
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -93,6 +121,11 @@ func (da *DropletActionsTool) rebuildByImageSlugDroplet(ctx context.Context, req
 // powerCycleByTag power cycles droplets by tag
 func (da *DropletActionsTool) powerCycleByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	tag := req.GetArguments()["Tag"].(string)
+	args := common.NewArgs(req)
+	loc := args.Timezone()
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
@@ -104,7 +137,11 @@ func (da *DropletActionsTool) powerCycleByTag(ctx context.Context, req mcp.CallT
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
 
-	jsonActions, err := response.CompactJSON(actions)
+	result, err := response.ConvertTimestamps(actions, loc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	jsonActions, err := response.CompactJSON(result)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("marshal error", err), nil
 	}
@@ -424,17 +461,17 @@ func (da *DropletActionsTool) resizeDroplet(ctx context.Context, req mcp.CallToo
 	return mcp.NewToolResultText(jsonAction), nil
 }
 
-// rebuildDroplet rebuilds a droplet using a provided image
-func (da *DropletActionsTool) rebuildDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// renameDroplet renames a droplet
+func (da *DropletActionsTool) renameDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	dropletID := req.GetArguments()["ID"].(float64)
-	imageID := req.GetArguments()["ImageID"].(float64)
+	name := req.GetArguments()["Name"].(string)
 
 	client, err := da.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	action, _, err := client.DropletActions.RebuildByImageID(ctx, int(dropletID), int(imageID))
+	action, _, err := client.DropletActions.Rename(ctx, int(dropletID), name)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -447,17 +484,17 @@ func (da *DropletActionsTool) rebuildDroplet(ctx context.Context, req mcp.CallTo
 	return mcp.NewToolResultText(jsonAction), nil
 }
 
-// renameDroplet renames a droplet
-func (da *DropletActionsTool) renameDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// changeKernel changes a droplet's kernel
+func (da *DropletActionsTool) changeKernel(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	dropletID := req.GetArguments()["ID"].(float64)
-	name := req.GetArguments()["Name"].(string)
+	kernelID := req.GetArguments()["KernelID"].(float64)
 
 	client, err := da.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	action, _, err := client.DropletActions.Rename(ctx, int(dropletID), name)
+	action, _, err := client.DropletActions.ChangeKernel(ctx, int(dropletID), int(kernelID))
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -470,17 +507,16 @@ func (da *DropletActionsTool) renameDroplet(ctx context.Context, req mcp.CallToo
 	return mcp.NewToolResultText(jsonAction), nil
 }
 
-// changeKernel changes a droplet's kernel
-func (da *DropletActionsTool) changeKernel(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// enableIPv6 enables IPv6 on a droplet
+func (da *DropletActionsTool) enableIPv6(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	dropletID := req.GetArguments()["ID"].(float64)
-	kernelID := req.GetArguments()["KernelID"].(float64)
 
 	client, err := da.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	action, _, err := client.DropletActions.ChangeKernel(ctx, int(dropletID), int(kernelID))
+	action, _, err := client.DropletActions.EnableIPv6(ctx, int(dropletID))
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -493,16 +529,70 @@ func (da *DropletActionsTool) changeKernel(ctx context.Context, req mcp.CallTool
 	return mcp.NewToolResultText(jsonAction), nil
 }
 
-// enableIPv6 enables IPv6 on a droplet
-func (da *DropletActionsTool) enableIPv6(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// backupPlans are the supported droplet backup schedules.
+var backupPlans = []string{"daily", "weekly"}
+
+// backupHours are the UTC hours at which a four-hour backup window can start.
+var backupHours = []int{0, 4, 8, 12, 16, 20}
+
+// backupWeekdays are the days on which a weekly backup window can start.
+var backupWeekdays = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// backupPolicyFromArgs builds a backup policy request from the Plan, Hour and Weekday arguments.
+// It returns nil when none of them are set.
+func backupPolicyFromArgs(args map[string]any) (*godo.DropletBackupPolicyRequest, error) {
+	plan, _ := args["Plan"].(string)
+	weekday, _ := args["Weekday"].(string)
+	hour, hasHour := args["Hour"].(float64)
+	if plan == "" && weekday == "" && !hasHour {
+		return nil, nil
+	}
+
+	if !slices.Contains(backupPlans, plan) {
+		return nil, fmt.Errorf("plan must be one of %s", strings.Join(backupPlans, ", "))
+	}
+	policy := &godo.DropletBackupPolicyRequest{Plan: plan}
+
+	weekday = strings.ToUpper(weekday)
+	switch {
+	case plan == "weekly" && weekday == "":
+		return nil, fmt.Errorf("weekday is required for a weekly plan")
+	case plan == "daily" && weekday != "":
+		return nil, fmt.Errorf("weekday is only supported for a weekly plan")
+	case weekday != "" && !slices.Contains(backupWeekdays, weekday):
+		return nil, fmt.Errorf("weekday must be one of %s", strings.Join(backupWeekdays, ", "))
+	}
+	policy.Weekday = weekday
+
+	if hasHour {
+		if !slices.Contains(backupHours, int(hour)) || hour != float64(int(hour)) {
+			return nil, fmt.Errorf("hour must be one of 0, 4, 8, 12, 16, 20")
+		}
+		h := int(hour)
+		policy.Hour = &h
+	}
+	return policy, nil
+}
+
+// enableBackups enables backups on a droplet, optionally with a backup policy
+func (da *DropletActionsTool) enableBackups(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	dropletID := req.GetArguments()["ID"].(float64)
+	policy, err := backupPolicyFromArgs(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	action, _, err := client.DropletActions.EnableIPv6(ctx, int(dropletID))
+	var action *godo.Action
+	if policy != nil {
+		action, _, err = client.DropletActions.EnableBackupsWithPolicy(ctx, int(dropletID), policy)
+	} else {
+		action, _, err = client.DropletActions.EnableBackups(ctx, int(dropletID))
+	}
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -515,16 +605,23 @@ func (da *DropletActionsTool) enableIPv6(ctx context.Context, req mcp.CallToolRe
 	return mcp.NewToolResultText(jsonAction), nil
 }
 
-// enableBackups enables backups on a droplet
-func (da *DropletActionsTool) enableBackups(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// changeBackupPolicy changes the backup schedule of a droplet that already has backups enabled
+func (da *DropletActionsTool) changeBackupPolicy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	dropletID := req.GetArguments()["ID"].(float64)
+	policy, err := backupPolicyFromArgs(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if policy == nil {
+		return mcp.NewToolResultError("Plan is required"), nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	action, _, err := client.DropletActions.EnableBackups(ctx, int(dropletID))
+	action, _, err := client.DropletActions.ChangeBackupPolicy(ctx, int(dropletID), policy)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -559,52 +656,86 @@ func (da *DropletActionsTool) disableBackups(ctx context.Context, req mcp.CallTo
 	return mcp.NewToolResultText(jsonAction), nil
 }
 
-// snapshotDroplet creates a snapshot of a droplet
-func (da *DropletActionsTool) snapshotDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	dropletID := req.GetArguments()["ID"].(float64)
-	name := req.GetArguments()["Name"].(string)
+// listActions returns the full action history of a droplet, e.g. resizes, snapshots and power events,
+// optionally only the actions with a given status.
+func (da *DropletActionsTool) listActions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	dropletID := args.RequireInt("ID")
+	status := args.OptionalEnum("Status", "", godo.ActionCompleted, godo.ActionInProgress, "errored")
+	loc := args.Timezone()
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := da.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	action, _, err := client.DropletActions.Snapshot(ctx, int(dropletID), name)
-	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	actions := []godo.Action{}
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		page, resp, err := client.Droplets.Actions(ctx, dropletID, opt)
+		if err != nil {
+			return common.APIErrorResult(err, "droplet", dropletID), nil
+		}
+		for _, action := range page {
+			if status == "" || action.Status == status {
+				actions = append(actions, action)
+			}
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
 	}
 
-	jsonAction, err := response.CompactJSON(action)
+	result, err := response.ConvertTimestamps(actions, loc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	jsonActions, err := response.CompactJSON(result)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
 
-	return mcp.NewToolResultText(jsonAction), nil
+	return mcp.NewToolResultText(jsonActions), nil
 }
 
 // Tools returns a list of tool functions
 func (da *DropletActionsTool) Tools() []server.ServerTool {
 	tools := []server.ServerTool{
+		{
+			Handler: da.listActions,
+			Tool: mcp.NewTool("droplet-list-actions",
+				mcp.WithDescription("List the action history of a droplet, e.g. resizes, snapshots and power events, with their status and start and completion times."),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
+				mcp.WithString("Status", mcp.Enum(godo.ActionCompleted, godo.ActionInProgress, "errored"), mcp.Description("Only return actions with this status")),
+				common.WithTimezone(),
+			),
+		},
 		{
 			Handler: da.rebootDroplet,
-			Tool: mcp.NewTool("reboot-droplet",
-				mcp.WithDescription("Reboot a droplet"),
+			Tool: mcp.NewTool("droplet-reboot",
+				mcp.WithDescription("Gracefully reboot a droplet by asking its operating system to restart. Prefer this over droplet-power-cycle for a running droplet."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to reboot")),
 			),
 		},
 		{
-			Handler: da.passwordResetDroplet,
-			Tool: mcp.NewTool("reset-droplet-password",
-				mcp.WithDescription("Reset password for a droplet"),
+			Handler: da.resetPassword,
+			Tool: mcp.NewTool("droplet-password-reset",
+				mcp.WithDescription("Reset the root password of a droplet to regain access to it. The new password is emailed to the account owner. Returns the action."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
 			),
 		},
 		{
-			Handler: da.rebuildByImageSlugDroplet,
-			Tool: mcp.NewTool("rebuild-droplet-by-slug",
-				mcp.WithDescription("Rebuild a droplet using an image slug"),
+			Handler: da.rebuild,
+			Tool: mcp.NewTool("droplet-rebuild",
+				mcp.WithDescription("Rebuild a droplet from an image, given by ID or slug, keeping its IP addresses. This erases all data on the droplet's disk and requires Confirm. Returns the action."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to rebuild")),
-				mcp.WithString("ImageSlug", mcp.Required(), mcp.Description("Slug of the image to rebuild from")),
+				mcp.WithNumber("ImageID", mcp.Description("ID of the image to rebuild from, e.g. a snapshot or backup")),
+				mcp.WithString("ImageSlug", mcp.Description("Slug of the image to rebuild from (e.g., ubuntu-24-04-x64)")),
+				mcp.WithBoolean("Confirm", mcp.Required(), mcp.Description("Must be true to confirm that the droplet's data will be erased")),
 			),
 		},
 		{
@@ -612,6 +743,7 @@ func (da *DropletActionsTool) Tools() []server.ServerTool {
 			Tool: mcp.NewTool("power-cycle-droplets-tag",
 				mcp.WithDescription("Power cycle droplets by tag"),
 				mcp.WithString("Tag", mcp.Required(), mcp.Description("Tag of the droplets to power cycle")),
+				common.WithTimezone(),
 			),
 		},
 		{
@@ -673,32 +805,40 @@ func (da *DropletActionsTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: da.powerCycleDroplet,
-			Tool: mcp.NewTool("power-cycle-droplet",
-				mcp.WithDescription("Power cycle a droplet"),
+			Tool: mcp.NewTool("droplet-power-cycle",
+				mcp.WithDescription("Hard power cycle a droplet: power is cut and restored without notifying the operating system, like pressing the reset button. Use droplet-reboot for a graceful restart."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to power cycle")),
 			),
 		},
 		{
 			Handler: da.powerOnDroplet,
-			Tool: mcp.NewTool("power-on-droplet",
-				mcp.WithDescription("Power on a droplet"),
+			Tool: mcp.NewTool("droplet-power-on",
+				mcp.WithDescription("Power on a droplet that is off"),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to power on")),
 			),
 		},
 		{
 			Handler: da.powerOffDroplet,
-			Tool: mcp.NewTool("power-off-droplet",
-				mcp.WithDescription("Power off a droplet"),
+			Tool: mcp.NewTool("droplet-power-off",
+				mcp.WithDescription("Hard power off a droplet: power is cut immediately, like unplugging it, which can lose unsaved data. Use droplet-shutdown for a graceful shutdown."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to power off")),
 			),
 		},
 		{
 			Handler: da.shutdownDroplet,
-			Tool: mcp.NewTool("shutdown-droplet",
-				mcp.WithDescription("Shutdown a droplet"),
+			Tool: mcp.NewTool("droplet-shutdown",
+				mcp.WithDescription("Gracefully shut down a droplet by asking its operating system to halt, like pressing the power button. The droplet is still billed while off."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to shutdown")),
 			),
 		},
+		{
+			Handler: da.stopDroplet,
+			Tool: mcp.NewTool("droplet-stop",
+				mcp.WithDescription("Stop a droplet cleanly: shut it down through its operating system, wait for it to be off, and power it off hard if it is still running after the timeout. Returns the path taken (already_off, graceful_shutdown or forced_power_off) and the final action. Prefer this over droplet-power-off or droplet-shutdown."),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to stop")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(defaultStopTimeout.Seconds()), mcp.Max(maxStopTimeout.Seconds()), mcp.Description("How long to wait for the shutdown before powering off, in seconds")),
+			),
+		},
 		{
 			Handler: da.restoreDroplet,
 			Tool: mcp.NewTool("restore-droplet",
@@ -716,14 +856,6 @@ func (da *DropletActionsTool) Tools() []server.ServerTool {
 				mcp.WithBoolean("ResizeDisk", mcp.DefaultBool(false), mcp.Description("Whether to resize the disk")),
 			),
 		},
-		{
-			Handler: da.rebuildDroplet,
-			Tool: mcp.NewTool("rebuild-droplet",
-				mcp.WithDescription("Rebuild a droplet from an image"),
-				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to rebuild")),
-				mcp.WithNumber("ImageID", mcp.Required(), mcp.Description("ID of the image to rebuild from")),
-			),
-		},
 		{
 			Handler: da.renameDroplet,
 			Tool: mcp.NewTool("rename-droplet",
@@ -749,24 +881,49 @@ func (da *DropletActionsTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: da.enableBackups,
-			Tool: mcp.NewTool("enable-backups-droplet",
-				mcp.WithDescription("Enable backups on a droplet"),
+			Tool: mcp.NewTool("droplet-enable-backups",
+				mcp.WithDescription("Enable backups on a droplet. Optionally set the backup schedule, otherwise the default daily plan is used."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
+				mcp.WithString("Plan", mcp.Enum(backupPlans...), mcp.Description("Backup schedule")),
+				mcp.WithNumber("Hour", mcp.Description("UTC hour at which the four-hour backup window starts: 0, 4, 8, 12, 16 or 20")),
+				mcp.WithString("Weekday", mcp.Enum(backupWeekdays...), mcp.Description("Day of the backup window, required for the weekly plan")),
 			),
 		},
 		{
 			Handler: da.disableBackups,
-			Tool: mcp.NewTool("disable-backups-droplet",
-				mcp.WithDescription("Disable backups on a droplet"),
+			Tool: mcp.NewTool("droplet-disable-backups",
+				mcp.WithDescription("Disable backups on a droplet. Existing backup images are deleted."),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
+			),
+		},
+		{
+			Handler: da.changeBackupPolicy,
+			Tool: mcp.NewTool("droplet-change-backup-policy",
+				mcp.WithDescription("Change the backup schedule of a droplet that has backups enabled"),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
+				mcp.WithString("Plan", mcp.Required(), mcp.Enum(backupPlans...), mcp.Description("Backup schedule")),
+				mcp.WithNumber("Hour", mcp.Description("UTC hour at which the four-hour backup window starts: 0, 4, 8, 12, 16 or 20")),
+				mcp.WithString("Weekday", mcp.Enum(backupWeekdays...), mcp.Description("Day of the backup window, required for the weekly plan")),
 			),
 		},
 		{
-			Handler: da.snapshotDroplet,
-			Tool: mcp.NewTool("snapshot-droplet",
-				mcp.WithDescription("Take a snapshot of a droplet"),
+			Handler: da.snapshotDropletAndWait,
+			Tool: mcp.NewTool("droplet-snapshot",
+				mcp.WithDescription("Take a snapshot of a droplet. Returns the snapshot action right away, or with wait set, waits for the action to complete and returns the id of the resulting snapshot."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name for the snapshot")),
+				mcp.WithBoolean("wait", mcp.DefaultBool(false), mcp.Description("Whether to wait for the snapshot to complete and return its id")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(defaultSnapshotTimeout.Seconds()), mcp.Max(maxSnapshotTimeout.Seconds()), mcp.Description("How long to wait for the snapshot with wait set, in seconds")),
+			),
+		},
+		{
+			Handler: da.bulkAction,
+			Tool: mcp.NewTool("droplet-bulk-action",
+				mcp.WithDescription("Apply one action to every droplet with a tag, a few droplets at a time, and return the outcome per droplet: the started action, or the error for the droplets it could not be started on. power_off and reboot only list the droplets they would act on unless Confirm is set."),
+				mcp.WithString("Tag", mcp.Required(), mcp.Description("Tag of the droplets to act on")),
+				mcp.WithString("Action", mcp.Required(), mcp.Enum("power_on", "power_off", "reboot", "snapshot"), mcp.Description("Action to apply to each droplet")),
+				mcp.WithString("Name", mcp.Description("For snapshot, a prefix for the snapshot names, which end with the droplet name. Defaults to the droplet name followed by the current time")),
+				mcp.WithBoolean("Confirm", mcp.DefaultBool(false), mcp.Description("Must be true to power off or reboot the droplets")),
 			),
 		},
 	}
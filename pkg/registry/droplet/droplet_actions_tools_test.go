@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/digitalocean/godo"
@@ -940,11 +941,11 @@ func TestDropletActionsTool_resizeDroplet(t *testing.T) {
 	}
 }
 
-func TestDropletActionsTool_rebuildDroplet(t *testing.T) {
+func TestDropletActionsTool_renameDroplet(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	testAction := &godo.Action{ID: 777, Status: "completed"}
+	testAction := &godo.Action{ID: 888, Status: "completed"}
 	tests := []struct {
 		name        string
 		args        map[string]any
@@ -952,21 +953,21 @@ func TestDropletActionsTool_rebuildDroplet(t *testing.T) {
 		expectError bool
 	}{
 		{
-			name: "Successful rebuild",
-			args: map[string]any{"ID": float64(123), "ImageID": float64(789)},
+			name: "Successful rename",
+			args: map[string]any{"ID": float64(123), "Name": "new-name"},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					RebuildByImageID(gomock.Any(), 123, 789).
+					Rename(gomock.Any(), 123, "new-name").
 					Return(testAction, nil, nil).
 					Times(1)
 			},
 		},
 		{
 			name: "API error",
-			args: map[string]any{"ID": float64(456), "ImageID": float64(101)},
+			args: map[string]any{"ID": float64(456), "Name": "fail-name"},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					RebuildByImageID(gomock.Any(), 456, 101).
+					Rename(gomock.Any(), 456, "fail-name").
 					Return(nil, nil, errors.New("api error")).
 					Times(1)
 			},
@@ -982,7 +983,7 @@ func TestDropletActionsTool_rebuildDroplet(t *testing.T) {
 			}
 			tool := setupDropletActionsToolWithMocks(mockActions)
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
-			resp, err := tool.rebuildDroplet(context.Background(), req)
+			resp, err := tool.renameDroplet(context.Background(), req)
 			if tc.expectError {
 				require.NotNil(t, resp)
 				require.True(t, resp.IsError)
@@ -998,11 +999,11 @@ func TestDropletActionsTool_rebuildDroplet(t *testing.T) {
 	}
 }
 
-func TestDropletActionsTool_renameDroplet(t *testing.T) {
+func TestDropletActionsTool_changeKernel(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	testAction := &godo.Action{ID: 888, Status: "completed"}
+	testAction := &godo.Action{ID: 999, Status: "completed"}
 	tests := []struct {
 		name        string
 		args        map[string]any
@@ -1010,21 +1011,21 @@ func TestDropletActionsTool_renameDroplet(t *testing.T) {
 		expectError bool
 	}{
 		{
-			name: "Successful rename",
-			args: map[string]any{"ID": float64(123), "Name": "new-name"},
+			name: "Successful change kernel",
+			args: map[string]any{"ID": float64(123), "KernelID": float64(321)},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					Rename(gomock.Any(), 123, "new-name").
+					ChangeKernel(gomock.Any(), 123, 321).
 					Return(testAction, nil, nil).
 					Times(1)
 			},
 		},
 		{
 			name: "API error",
-			args: map[string]any{"ID": float64(456), "Name": "fail-name"},
+			args: map[string]any{"ID": float64(456), "KernelID": float64(654)},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					Rename(gomock.Any(), 456, "fail-name").
+					ChangeKernel(gomock.Any(), 456, 654).
 					Return(nil, nil, errors.New("api error")).
 					Times(1)
 			},
@@ -1040,7 +1041,7 @@ func TestDropletActionsTool_renameDroplet(t *testing.T) {
 			}
 			tool := setupDropletActionsToolWithMocks(mockActions)
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
-			resp, err := tool.renameDroplet(context.Background(), req)
+			resp, err := tool.changeKernel(context.Background(), req)
 			if tc.expectError {
 				require.NotNil(t, resp)
 				require.True(t, resp.IsError)
@@ -1056,11 +1057,11 @@ func TestDropletActionsTool_renameDroplet(t *testing.T) {
 	}
 }
 
-func TestDropletActionsTool_changeKernel(t *testing.T) {
+func TestDropletActionsTool_enableIPv6(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	testAction := &godo.Action{ID: 999, Status: "completed"}
+	testAction := &godo.Action{ID: 1001, Status: "completed"}
 	tests := []struct {
 		name        string
 		args        map[string]any
@@ -1068,21 +1069,21 @@ func TestDropletActionsTool_changeKernel(t *testing.T) {
 		expectError bool
 	}{
 		{
-			name: "Successful change kernel",
-			args: map[string]any{"ID": float64(123), "KernelID": float64(321)},
+			name: "Successful enable IPv6",
+			args: map[string]any{"ID": float64(123)},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					ChangeKernel(gomock.Any(), 123, 321).
+					EnableIPv6(gomock.Any(), 123).
 					Return(testAction, nil, nil).
 					Times(1)
 			},
 		},
 		{
 			name: "API error",
-			args: map[string]any{"ID": float64(456), "KernelID": float64(654)},
+			args: map[string]any{"ID": float64(456)},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					ChangeKernel(gomock.Any(), 456, 654).
+					EnableIPv6(gomock.Any(), 456).
 					Return(nil, nil, errors.New("api error")).
 					Times(1)
 			},
@@ -1098,7 +1099,7 @@ func TestDropletActionsTool_changeKernel(t *testing.T) {
 			}
 			tool := setupDropletActionsToolWithMocks(mockActions)
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
-			resp, err := tool.changeKernel(context.Background(), req)
+			resp, err := tool.enableIPv6(context.Background(), req)
 			if tc.expectError {
 				require.NotNil(t, resp)
 				require.True(t, resp.IsError)
@@ -1114,11 +1115,11 @@ func TestDropletActionsTool_changeKernel(t *testing.T) {
 	}
 }
 
-func TestDropletActionsTool_enableIPv6(t *testing.T) {
+func TestDropletActionsTool_enableBackups(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	testAction := &godo.Action{ID: 1001, Status: "completed"}
+	testAction := &godo.Action{ID: 1002, Status: "completed"}
 	tests := []struct {
 		name        string
 		args        map[string]any
@@ -1126,21 +1127,36 @@ func TestDropletActionsTool_enableIPv6(t *testing.T) {
 		expectError bool
 	}{
 		{
-			name: "Successful enable IPv6",
+			name: "Successful enable backups",
 			args: map[string]any{"ID": float64(123)},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					EnableIPv6(gomock.Any(), 123).
+					EnableBackups(gomock.Any(), 123).
+					Return(testAction, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "Enable with weekly policy",
+			args: map[string]any{"ID": float64(123), "Plan": "weekly", "Weekday": "mon", "Hour": float64(8)},
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().
+					EnableBackupsWithPolicy(gomock.Any(), 123, &godo.DropletBackupPolicyRequest{Plan: "weekly", Weekday: "MON", Hour: godo.PtrTo(8)}).
 					Return(testAction, nil, nil).
 					Times(1)
 			},
 		},
+		{
+			name:        "Weekly policy without weekday",
+			args:        map[string]any{"ID": float64(123), "Plan": "weekly"},
+			expectError: true,
+		},
 		{
 			name: "API error",
 			args: map[string]any{"ID": float64(456)},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					EnableIPv6(gomock.Any(), 456).
+					EnableBackups(gomock.Any(), 456).
 					Return(nil, nil, errors.New("api error")).
 					Times(1)
 			},
@@ -1156,7 +1172,7 @@ func TestDropletActionsTool_enableIPv6(t *testing.T) {
 			}
 			tool := setupDropletActionsToolWithMocks(mockActions)
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
-			resp, err := tool.enableIPv6(context.Background(), req)
+			resp, err := tool.enableBackups(context.Background(), req)
 			if tc.expectError {
 				require.NotNil(t, resp)
 				require.True(t, resp.IsError)
@@ -1172,37 +1188,62 @@ func TestDropletActionsTool_enableIPv6(t *testing.T) {
 	}
 }
 
-func TestDropletActionsTool_enableBackups(t *testing.T) {
+func TestDropletActionsTool_changeBackupPolicy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	testAction := &godo.Action{ID: 1002, Status: "completed"}
+	testAction := &godo.Action{ID: 1004, Status: "in-progress"}
 	tests := []struct {
 		name        string
 		args        map[string]any
 		mockSetup   func(*MockDropletActionsService)
-		expectError bool
+		expectError string
 	}{
 		{
-			name: "Successful enable backups",
-			args: map[string]any{"ID": float64(123)},
+			name: "Successful change to daily",
+			args: map[string]any{"ID": float64(123), "Plan": "daily", "Hour": float64(20)},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					EnableBackups(gomock.Any(), 123).
+					ChangeBackupPolicy(gomock.Any(), 123, &godo.DropletBackupPolicyRequest{Plan: "daily", Hour: godo.PtrTo(20)}).
 					Return(testAction, nil, nil).
 					Times(1)
 			},
 		},
+		{
+			name:        "Missing plan",
+			args:        map[string]any{"ID": float64(123)},
+			expectError: "Plan is required",
+		},
+		{
+			name:        "Unknown plan",
+			args:        map[string]any{"ID": float64(123), "Plan": "hourly"},
+			expectError: "plan must be one of daily, weekly",
+		},
+		{
+			name:        "Invalid hour",
+			args:        map[string]any{"ID": float64(123), "Plan": "daily", "Hour": float64(3)},
+			expectError: "hour must be one of",
+		},
+		{
+			name:        "Weekday with daily plan",
+			args:        map[string]any{"ID": float64(123), "Plan": "daily", "Weekday": "SUN"},
+			expectError: "weekday is only supported for a weekly plan",
+		},
+		{
+			name:        "Unknown weekday",
+			args:        map[string]any{"ID": float64(123), "Plan": "weekly", "Weekday": "FUNDAY"},
+			expectError: "weekday must be one of",
+		},
 		{
 			name: "API error",
-			args: map[string]any{"ID": float64(456)},
+			args: map[string]any{"ID": float64(456), "Plan": "weekly", "Weekday": "SAT"},
 			mockSetup: func(m *MockDropletActionsService) {
 				m.EXPECT().
-					EnableBackups(gomock.Any(), 456).
+					ChangeBackupPolicy(gomock.Any(), 456, &godo.DropletBackupPolicyRequest{Plan: "weekly", Weekday: "SAT"}).
 					Return(nil, nil, errors.New("api error")).
 					Times(1)
 			},
-			expectError: true,
+			expectError: "api error",
 		},
 	}
 
@@ -1214,14 +1255,14 @@ func TestDropletActionsTool_enableBackups(t *testing.T) {
 			}
 			tool := setupDropletActionsToolWithMocks(mockActions)
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
-			resp, err := tool.enableBackups(context.Background(), req)
-			if tc.expectError {
-				require.NotNil(t, resp)
+			resp, err := tool.changeBackupPolicy(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
 				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
 				return
 			}
-			require.NoError(t, err)
-			require.NotNil(t, resp)
 			require.False(t, resp.IsError)
 			var outAction godo.Action
 			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outAction))
@@ -1288,40 +1329,37 @@ func TestDropletActionsTool_disableBackups(t *testing.T) {
 	}
 }
 
-func TestDropletActionsTool_snapshotDroplet(t *testing.T) {
+func TestDropletActionsTool_resetPassword(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-
-	testAction := &godo.Action{ID: 1004, Status: "completed"}
+	testAction := &godo.Action{ID: 2101, Type: "password_reset", Status: "in-progress"}
 	tests := []struct {
 		name        string
 		args        map[string]any
 		mockSetup   func(*MockDropletActionsService)
-		expectError bool
+		expectError string
 	}{
 		{
-			name: "Successful snapshot",
-			args: map[string]any{"ID": float64(123), "Name": "snap1"},
+			name: "Successful reset",
+			args: map[string]any{"ID": float64(123)},
 			mockSetup: func(m *MockDropletActionsService) {
-				m.EXPECT().
-					Snapshot(gomock.Any(), 123, "snap1").
-					Return(testAction, nil, nil).
-					Times(1)
+				m.EXPECT().PasswordReset(gomock.Any(), 123).Return(testAction, nil, nil).Times(1)
 			},
 		},
 		{
 			name: "API error",
-			args: map[string]any{"ID": float64(456), "Name": "fail"},
+			args: map[string]any{"ID": float64(456)},
 			mockSetup: func(m *MockDropletActionsService) {
-				m.EXPECT().
-					Snapshot(gomock.Any(), 456, "fail").
-					Return(nil, nil, errors.New("api error")).
-					Times(1)
+				m.EXPECT().PasswordReset(gomock.Any(), 456).Return(nil, nil, errors.New("api error")).Times(1)
 			},
-			expectError: true,
+			expectError: "api error",
+		},
+		{
+			name:        "Missing ID",
+			args:        map[string]any{},
+			expectError: "argument 'ID' is required",
 		},
 	}
-
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			mockActions := NewMockDropletActionsService(ctrl)
@@ -1330,14 +1368,86 @@ func TestDropletActionsTool_snapshotDroplet(t *testing.T) {
 			}
 			tool := setupDropletActionsToolWithMocks(mockActions)
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
-			resp, err := tool.snapshotDroplet(context.Background(), req)
-			if tc.expectError {
-				require.NotNil(t, resp)
+			resp, err := tool.resetPassword(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
 				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
 				return
 			}
+			require.False(t, resp.IsError)
+			var outAction godo.Action
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outAction))
+			require.Equal(t, testAction.ID, outAction.ID)
+		})
+	}
+}
+
+func TestDropletActionsTool_rebuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	testAction := &godo.Action{ID: 2102, Type: "rebuild", Status: "in-progress"}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDropletActionsService)
+		expectError string
+	}{
+		{
+			name: "Rebuild from a slug",
+			args: map[string]any{"ID": float64(123), "ImageSlug": "ubuntu-24-04-x64", "Confirm": true},
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().RebuildByImageSlug(gomock.Any(), 123, "ubuntu-24-04-x64").Return(testAction, nil, nil).Times(1)
+			},
+		},
+		{
+			name: "Rebuild from an image ID",
+			args: map[string]any{"ID": float64(123), "ImageID": float64(987), "Confirm": true},
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().RebuildByImageID(gomock.Any(), 123, 987).Return(testAction, nil, nil).Times(1)
+			},
+		},
+		{
+			name: "API error",
+			args: map[string]any{"ID": float64(456), "ImageID": float64(987), "Confirm": true},
+			mockSetup: func(m *MockDropletActionsService) {
+				m.EXPECT().RebuildByImageID(gomock.Any(), 456, 987).Return(nil, nil, errors.New("api error")).Times(1)
+			},
+			expectError: "api error",
+		},
+		{
+			name:        "Not confirmed",
+			args:        map[string]any{"ID": float64(123), "ImageSlug": "ubuntu-24-04-x64", "Confirm": false},
+			expectError: "set Confirm to true",
+		},
+		{
+			name:        "No image",
+			args:        map[string]any{"ID": float64(123), "Confirm": true},
+			expectError: "exactly one of ImageID or ImageSlug is required",
+		},
+		{
+			name:        "Both images",
+			args:        map[string]any{"ID": float64(123), "ImageID": float64(987), "ImageSlug": "ubuntu-24-04-x64", "Confirm": true},
+			expectError: "exactly one of ImageID or ImageSlug is required",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockActions := NewMockDropletActionsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockActions)
+			}
+			tool := setupDropletActionsToolWithMocks(mockActions)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.rebuild(context.Background(), req)
 			require.NoError(t, err)
 			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
 			require.False(t, resp.IsError)
 			var outAction godo.Action
 			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outAction))
@@ -1345,3 +1455,98 @@ func TestDropletActionsTool_snapshotDroplet(t *testing.T) {
 		})
 	}
 }
+
+func TestDropletActionsTool_listActions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	firstPage := []godo.Action{
+		{ID: 3, Type: "snapshot", Status: "in-progress"},
+		{ID: 2, Type: "resize", Status: "completed"},
+	}
+	lastPage := []godo.Action{
+		{ID: 1, Type: "power_off", Status: "errored"},
+	}
+	notFound := &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "The resource you were accessing could not be found."}
+	paginated := func(m *MockDropletsService) {
+		gomock.InOrder(
+			m.EXPECT().Actions(gomock.Any(), 123, &godo.ListOptions{Page: 1, PerPage: 200}).
+				Return(firstPage, &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+			m.EXPECT().Actions(gomock.Any(), 123, &godo.ListOptions{Page: 2, PerPage: 200}).
+				Return(lastPage, &godo.Response{Links: &godo.Links{}}, nil),
+		)
+	}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDropletsService)
+		expectIDs   []int
+		expectError string
+	}{
+		{
+			name:      "All pages",
+			args:      map[string]any{"ID": float64(123)},
+			mockSetup: paginated,
+			expectIDs: []int{3, 2, 1},
+		},
+		{
+			name:      "Status filter",
+			args:      map[string]any{"ID": float64(123), "Status": "errored"},
+			mockSetup: paginated,
+			expectIDs: []int{1},
+		},
+		{
+			name: "No actions",
+			args: map[string]any{"ID": float64(123), "Status": "completed"},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().Actions(gomock.Any(), 123, gomock.Any()).Return(nil, &godo.Response{Links: &godo.Links{}}, nil)
+			},
+			expectIDs: []int{},
+		},
+		{
+			name: "Droplet not found",
+			args: map[string]any{"ID": float64(404)},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().Actions(gomock.Any(), 404, gomock.Any()).Return(nil, &godo.Response{Response: notFound.Response}, notFound)
+			},
+			expectError: `"resource_type":"droplet"`,
+		},
+		{
+			name:        "Invalid status",
+			args:        map[string]any{"ID": float64(123), "Status": "pending"},
+			expectError: "argument 'Status' must be one of",
+		},
+		{
+			name:        "Missing ID",
+			args:        map[string]any{},
+			expectError: "argument 'ID' is required",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDroplets := NewMockDropletsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets)
+			}
+			tool := NewDropletActionsTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: mockDroplets}, nil
+			})
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.listActions(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var actions []godo.Action
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &actions))
+			ids := []int{}
+			for _, a := range actions {
+				ids = append(ids, a.ID)
+			}
+			require.Equal(t, tc.expectIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package droplet
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxConcurrentBulkActions bounds the droplet actions droplet-bulk-action requests at once.
+const maxConcurrentBulkActions = 5
+
+// bulkActionNeedsConfirm holds the actions droplet-bulk-action can apply, and whether they need
+// Confirm. Powering off and rebooting interrupt whatever runs on the droplets.
+var bulkActionNeedsConfirm = map[string]bool{
+	"power_on":  false,
+	"power_off": true,
+	"reboot":    true,
+	"snapshot":  false,
+}
+
+// bulkActionResult is the outcome of the action on one droplet. ActionID is set when the action was
+// started and Error when it could not be.
+type bulkActionResult struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	ActionID int    `json:"action_id,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// bulkActionSummary is the result of droplet-bulk-action.
+type bulkActionSummary struct {
+	Tag       string             `json:"tag"`
+	Action    string             `json:"action"`
+	Count     int                `json:"count"`
+	Started   int                `json:"started"`
+	Failed    int                `json:"failed"`
+	Droplets  []bulkActionResult `json:"droplets"`
+	Confirmed bool               `json:"confirmed"`
+	Message   string             `json:"message,omitempty"`
+}
+
+// bulkAction applies one action to every droplet with a tag, a few droplets at a time, and reports
+// the outcome per droplet. Unlike the *ByTag actions of the API, one droplet failing does not hide
+// the others. Unless Confirm is set, power_off and reboot only report the droplets they would act
+// on.
+func (da *DropletActionsTool) bulkAction(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	tag := args.RequireString("Tag")
+	action := args.RequireEnum("Action", "power_on", "power_off", "reboot", "snapshot")
+	name := args.OptionalString("Name", "")
+	confirm := args.OptionalBool("Confirm", false)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if strings.TrimSpace(tag) == "" {
+		return mcp.NewToolResultError("Tag is required"), nil
+	}
+
+	client, err := da.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	var droplets []godo.Droplet
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		page, resp, err := client.Droplets.ListByTag(ctx, tag, opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		droplets = append(droplets, page...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+
+	summary := bulkActionSummary{
+		Tag:       tag,
+		Action:    action,
+		Count:     len(droplets),
+		Droplets:  make([]bulkActionResult, len(droplets)),
+		Confirmed: confirm || !bulkActionNeedsConfirm[action],
+	}
+	for i, droplet := range droplets {
+		summary.Droplets[i] = bulkActionResult{ID: droplet.ID, Name: droplet.Name}
+	}
+	if !summary.Confirmed {
+		summary.Message = fmt.Sprintf("Set Confirm to true to %s these droplets", strings.ReplaceAll(action, "_", " "))
+		return bulkActionResultText(summary)
+	}
+
+	// Snapshot names end with the droplet name, so that the snapshots taken together can be told apart.
+	stamp := time.Now().UTC().Format("20060102-150405")
+	sem := make(chan struct{}, maxConcurrentBulkActions)
+	var wg sync.WaitGroup
+	for i, droplet := range droplets {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			var started *godo.Action
+			var err error
+			switch action {
+			case "power_on":
+				started, _, err = client.DropletActions.PowerOn(ctx, droplet.ID)
+			case "power_off":
+				started, _, err = client.DropletActions.PowerOff(ctx, droplet.ID)
+			case "reboot":
+				started, _, err = client.DropletActions.Reboot(ctx, droplet.ID)
+			case "snapshot":
+				snapshotName := fmt.Sprintf("%s-%s", droplet.Name, stamp)
+				if name != "" {
+					snapshotName = fmt.Sprintf("%s-%s", name, droplet.Name)
+				}
+				started, _, err = client.DropletActions.Snapshot(ctx, droplet.ID, snapshotName)
+			}
+			result := &summary.Droplets[i]
+			if err != nil {
+				result.Error = err.Error()
+				return
+			}
+			if started != nil {
+				result.ActionID = started.ID
+				result.Status = started.Status
+			}
+		})
+	}
+	wg.Wait()
+
+	for _, result := range summary.Droplets {
+		if result.Error != "" {
+			summary.Failed++
+		} else {
+			summary.Started++
+		}
+	}
+	return bulkActionResultText(summary)
+}
+
+func bulkActionResultText(summary bulkActionSummary) (*mcp.CallToolResult, error) {
+	jsonData, err := response.CompactJSON(summary)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
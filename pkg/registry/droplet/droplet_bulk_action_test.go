@@ -0,0 +1,137 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDropletActionsTool_bulkAction(t *testing.T) {
+	tagged := []godo.Droplet{{ID: 1, Name: "web-1"}, {ID: 2, Name: "web-2"}, {ID: 3, Name: "web-3"}}
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		mockSetup     func(*MockDropletsService, *MockDropletActionsService)
+		expect        *bulkActionSummary
+		expectMessage string
+		expectError   string
+	}{
+		{
+			name: "Reboot with one failure",
+			args: map[string]any{"Tag": "web", "Action": "reboot", "Confirm": true},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				d.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return(tagged, &godo.Response{}, nil)
+				a.EXPECT().Reboot(gomock.Any(), 1).Return(&godo.Action{ID: 101, Status: "in-progress"}, nil, nil)
+				a.EXPECT().Reboot(gomock.Any(), 2).Return(nil, nil, errors.New("droplet is locked"))
+				a.EXPECT().Reboot(gomock.Any(), 3).Return(&godo.Action{ID: 103, Status: "in-progress"}, nil, nil)
+			},
+			expect: &bulkActionSummary{
+				Tag: "web", Action: "reboot", Count: 3, Started: 2, Failed: 1, Confirmed: true,
+				Droplets: []bulkActionResult{
+					{ID: 1, Name: "web-1", ActionID: 101, Status: "in-progress"},
+					{ID: 2, Name: "web-2", Error: "droplet is locked"},
+					{ID: 3, Name: "web-3", ActionID: 103, Status: "in-progress"},
+				},
+			},
+		},
+		{
+			name: "Snapshot with a name prefix",
+			args: map[string]any{"Tag": "web", "Action": "snapshot", "Name": "pre-upgrade"},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				d.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return(tagged[:2], &godo.Response{}, nil)
+				a.EXPECT().Snapshot(gomock.Any(), 1, "pre-upgrade-web-1").Return(&godo.Action{ID: 201, Status: "in-progress"}, nil, nil)
+				a.EXPECT().Snapshot(gomock.Any(), 2, "pre-upgrade-web-2").Return(&godo.Action{ID: 202, Status: "in-progress"}, nil, nil)
+			},
+			expect: &bulkActionSummary{
+				Tag: "web", Action: "snapshot", Count: 2, Started: 2, Confirmed: true,
+				Droplets: []bulkActionResult{
+					{ID: 1, Name: "web-1", ActionID: 201, Status: "in-progress"},
+					{ID: 2, Name: "web-2", ActionID: 202, Status: "in-progress"},
+				},
+			},
+		},
+		{
+			name: "Power on across pages",
+			args: map[string]any{"Tag": "web", "Action": "power_on"},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				d.EXPECT().ListByTag(gomock.Any(), "web", &godo.ListOptions{Page: 1, PerPage: 200}).
+					Return(tagged[:1], &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "next", Last: "last"}}}, nil)
+				d.EXPECT().ListByTag(gomock.Any(), "web", &godo.ListOptions{Page: 2, PerPage: 200}).Return(tagged[1:2], &godo.Response{}, nil)
+				a.EXPECT().PowerOn(gomock.Any(), 1).Return(&godo.Action{ID: 301, Status: "completed"}, nil, nil)
+				a.EXPECT().PowerOn(gomock.Any(), 2).Return(&godo.Action{ID: 302, Status: "completed"}, nil, nil)
+			},
+			expect: &bulkActionSummary{
+				Tag: "web", Action: "power_on", Count: 2, Started: 2, Confirmed: true,
+				Droplets: []bulkActionResult{
+					{ID: 1, Name: "web-1", ActionID: 301, Status: "completed"},
+					{ID: 2, Name: "web-2", ActionID: 302, Status: "completed"},
+				},
+			},
+		},
+		{
+			name: "Power off without confirm only lists the droplets",
+			args: map[string]any{"Tag": "web", "Action": "power_off"},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				d.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return(tagged[:2], &godo.Response{}, nil)
+			},
+			expect: &bulkActionSummary{
+				Tag: "web", Action: "power_off", Count: 2,
+				Droplets: []bulkActionResult{{ID: 1, Name: "web-1"}, {ID: 2, Name: "web-2"}},
+				Message:  "Set Confirm to true to power off these droplets",
+			},
+		},
+		{
+			name: "List error",
+			args: map[string]any{"Tag": "web", "Action": "reboot", "Confirm": true},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				d.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return(nil, nil, errors.New("api down"))
+			},
+			expectError: "api error",
+		},
+		{
+			name:        "Unknown action",
+			args:        map[string]any{"Tag": "web", "Action": "rebuild"},
+			expectError: "argument 'Action' must be one of: power_on, power_off, reboot, snapshot",
+		},
+		{
+			name:        "Missing tag",
+			args:        map[string]any{"Action": "reboot"},
+			expectError: "argument 'Tag' is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockActions := NewMockDropletActionsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets, mockActions)
+			}
+			tool := NewDropletActionsTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: mockDroplets, DropletActions: mockActions}, nil
+			})
+
+			result, err := tool.bulkAction(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := result.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, result.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, result.IsError, text)
+
+			var got bulkActionSummary
+			require.NoError(t, json.Unmarshal([]byte(text), &got))
+			require.Equal(t, *tc.expect, got)
+		})
+	}
+}
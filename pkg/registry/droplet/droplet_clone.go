@@ -0,0 +1,230 @@
+package droplet
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultCloneTimeout      = 30 * time.Minute
+	maxCloneTimeout          = 2 * time.Hour
+	defaultClonePollInterval = 10 * time.Second
+)
+
+// cloneStage is a step of droplet-clone and how it went: completed, skipped or failed.
+type cloneStage struct {
+	Stage  string `json:"stage"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// dropletClone is the result of droplet-clone. When a step fails, Error is set and the stages tell
+// how far the clone got, SnapshotKept telling whether the snapshot is left behind.
+type dropletClone struct {
+	SourceID     int           `json:"source_id"`
+	SnapshotID   int           `json:"snapshot_id,omitempty"`
+	SnapshotKept bool          `json:"snapshot_kept"`
+	Droplet      *godo.Droplet `json:"droplet,omitempty"`
+	Stages       []cloneStage  `json:"stages"`
+	Error        string        `json:"error,omitempty"`
+}
+
+func (c *dropletClone) stage(stage, status, detail string) {
+	c.Stages = append(c.Stages, cloneStage{Stage: stage, Status: status, Detail: detail})
+}
+
+// cloneDroplet copies a droplet through a snapshot: it snapshots the source, moves the snapshot to
+// the target region when needed, creates a droplet from it and waits for that droplet to be active,
+// then deletes the snapshot unless keep_snapshot is set.
+func (d *DropletTool) cloneDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	sourceID := args.RequireInt("ID")
+	name := args.OptionalString("Name", "")
+	targetRegion := args.OptionalString("target_region", "")
+	targetSize := args.OptionalString("target_size", "")
+	keepSnapshot := args.OptionalBool("keep_snapshot", false)
+	timeoutSeconds := args.OptionalInt("TimeoutSeconds", int(defaultCloneTimeout.Seconds()))
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if timeoutSeconds <= 0 || timeoutSeconds > int(maxCloneTimeout.Seconds()) {
+		return mcp.NewToolResultError(fmt.Sprintf("TimeoutSeconds must be between 1 and %d", int(maxCloneTimeout.Seconds()))), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	source, _, err := client.Droplets.Get(ctx, sourceID)
+	if err != nil {
+		return common.APIErrorResult(err, "droplet", sourceID), nil
+	}
+	if name == "" {
+		name = source.Name + "-clone"
+	}
+	if targetRegion == "" && source.Region != nil {
+		targetRegion = source.Region.Slug
+	}
+	if targetSize == "" {
+		targetSize = source.SizeSlug
+	}
+	if err := common.ValidateSizeInRegion(ctx, client, targetSize, targetRegion); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := &dropletClone{SourceID: sourceID, Stages: []cloneStage{}}
+	result.stage("validate", "completed", fmt.Sprintf("cloning droplet %d (%s) as %s, size %s in %s", source.ID, source.Name, name, targetSize, targetRegion))
+
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+	defer cancel()
+	if err := d.runClone(ctx, client, source, name, targetRegion, targetSize, keepSnapshot, result); err != nil {
+		result.Error = err.Error()
+		jsonData, err := response.CompactJSON(result)
+		if err != nil {
+			return nil, fmt.Errorf("marshal error: %w", err)
+		}
+		return mcp.NewToolResultError(jsonData), nil
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// runClone runs the steps of droplet-clone after validation, recording them in result. It returns
+// the error of the step that failed.
+func (d *DropletTool) runClone(ctx context.Context, client *godo.Client, source *godo.Droplet, name, region, size string, keepSnapshot bool, result *dropletClone) error {
+	fail := func(stage string, err error) error {
+		result.stage(stage, "failed", err.Error())
+		return fmt.Errorf("%s: %w", stage, err)
+	}
+
+	snapshotName := fmt.Sprintf("%s-clone-%d", source.Name, time.Now().Unix())
+	action, _, err := client.DropletActions.Snapshot(ctx, source.ID, snapshotName)
+	if err != nil {
+		return fail("snapshot", err)
+	}
+	err = waitForAction(ctx, d.pollInterval, func() (*godo.Action, error) {
+		action, _, err := client.DropletActions.Get(ctx, source.ID, action.ID)
+		return action, err
+	})
+	if err != nil {
+		return fail("snapshot", err)
+	}
+	snapshot, err := findDropletSnapshot(ctx, client, source.ID, snapshotName)
+	if err != nil {
+		return fail("snapshot", err)
+	}
+	result.SnapshotID = snapshot.ID
+	result.SnapshotKept = true
+	result.stage("snapshot", "completed", fmt.Sprintf("snapshot %d (%s)", snapshot.ID, snapshotName))
+
+	if slices.Contains(snapshot.Regions, region) {
+		result.stage("transfer", "skipped", "the snapshot is already in "+region)
+	} else {
+		action, _, err := client.ImageActions.Transfer(ctx, snapshot.ID, &godo.ActionRequest{"type": "transfer", "region": region})
+		if err != nil {
+			return fail("transfer", err)
+		}
+		err = waitForAction(ctx, d.pollInterval, func() (*godo.Action, error) {
+			action, _, err := client.ImageActions.Get(ctx, snapshot.ID, action.ID)
+			return action, err
+		})
+		if err != nil {
+			return fail("transfer", err)
+		}
+		result.stage("transfer", "completed", "snapshot transferred to "+region)
+	}
+
+	droplet, _, err := client.Droplets.Create(ctx, &godo.DropletCreateRequest{
+		Name:   name,
+		Region: region,
+		Size:   size,
+		Image:  godo.DropletCreateImage{ID: snapshot.ID},
+		Tags:   source.Tags,
+	})
+	if err != nil {
+		return fail("create", err)
+	}
+	result.Droplet = droplet
+	result.stage("create", "completed", fmt.Sprintf("droplet %d (%s)", droplet.ID, droplet.Name))
+
+	// The snapshot is only deleted once the droplet built from it is active.
+	err = common.Poll(ctx, d.pollInterval, func() (bool, error) {
+		current, _, err := client.Droplets.Get(ctx, droplet.ID)
+		if err != nil {
+			return false, err
+		}
+		result.Droplet = current
+		return current.Status == "active", nil
+	})
+	if err != nil {
+		return fail("boot", err)
+	}
+	result.stage("boot", "completed", "the droplet is active")
+
+	if keepSnapshot {
+		result.stage("cleanup", "skipped", "keep_snapshot is set")
+		return nil
+	}
+	if _, err := client.Images.Delete(ctx, snapshot.ID); err != nil {
+		// The clone itself succeeded, so a leftover snapshot is reported rather than failing.
+		result.stage("cleanup", "failed", fmt.Sprintf("deleting snapshot %d failed: %v", snapshot.ID, err))
+		return nil
+	}
+	result.SnapshotKept = false
+	result.stage("cleanup", "completed", fmt.Sprintf("snapshot %d deleted", snapshot.ID))
+	return nil
+}
+
+// findDropletSnapshot returns the newest snapshot of a droplet with the given name.
+func findDropletSnapshot(ctx context.Context, client *godo.Client, dropletID int, name string) (*godo.Image, error) {
+	var found *godo.Image
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		snapshots, resp, err := client.Droplets.Snapshots(ctx, dropletID, opt)
+		if err != nil {
+			return nil, err
+		}
+		for i := range snapshots {
+			if snapshots[i].Name == name && (found == nil || snapshots[i].ID > found.ID) {
+				found = &snapshots[i]
+			}
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+	if found == nil {
+		return nil, fmt.Errorf("snapshot %s of droplet %d not found", name, dropletID)
+	}
+	return found, nil
+}
+
+// waitForAction polls an action with get every interval until it completes, failing when it errors.
+func waitForAction(ctx context.Context, interval time.Duration, get func() (*godo.Action, error)) error {
+	return common.Poll(ctx, interval, func() (bool, error) {
+		action, err := get()
+		if err != nil {
+			return false, err
+		}
+		switch action.Status {
+		case godo.ActionCompleted:
+			return true, nil
+		case "errored":
+			return false, fmt.Errorf("action %d (%s) errored", action.ID, action.Type)
+		}
+		return false, nil
+	})
+}
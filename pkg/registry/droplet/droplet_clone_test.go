@@ -0,0 +1,190 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type cloneMocks struct {
+	droplets     *MockDropletsService
+	actions      *MockDropletActionsService
+	images       *MockImagesService
+	imageActions *MockImageActionsService
+}
+
+func TestDropletTool_cloneDroplet(t *testing.T) {
+	source := &godo.Droplet{ID: 42, Name: "web", SizeSlug: "s-1vcpu-1gb", Region: &godo.Region{Slug: "nyc1"}, Tags: []string{"prod"}}
+	sizes := []godo.Size{{Slug: "s-1vcpu-1gb", Available: true, Regions: []string{"nyc1", "sfo3"}}}
+	snapshotted := func(m cloneMocks, regions ...string) {
+		m.actions.EXPECT().Snapshot(gomock.Any(), 42, gomock.Any()).DoAndReturn(func(_ context.Context, _ int, name string) (*godo.Action, *godo.Response, error) {
+			m.droplets.EXPECT().Snapshots(gomock.Any(), 42, gomock.Any()).Return([]godo.Image{{ID: 7, Name: name, Regions: regions}}, &godo.Response{Links: &godo.Links{}}, nil)
+			return &godo.Action{ID: 1, Status: "in-progress"}, nil, nil
+		})
+		gomock.InOrder(
+			m.actions.EXPECT().Get(gomock.Any(), 42, 1).Return(&godo.Action{ID: 1, Status: "in-progress"}, nil, nil),
+			m.actions.EXPECT().Get(gomock.Any(), 42, 1).Return(&godo.Action{ID: 1, Status: "completed"}, nil, nil),
+		)
+	}
+	created := func(m cloneMocks, region string) {
+		m.droplets.EXPECT().Create(gomock.Any(), &godo.DropletCreateRequest{
+			Name:   "web-clone",
+			Region: region,
+			Size:   "s-1vcpu-1gb",
+			Image:  godo.DropletCreateImage{ID: 7},
+			Tags:   []string{"prod"},
+		}).Return(&godo.Droplet{ID: 43, Name: "web-clone", Status: "new"}, nil, nil)
+		gomock.InOrder(
+			m.droplets.EXPECT().Get(gomock.Any(), 43).Return(&godo.Droplet{ID: 43, Name: "web-clone", Status: "new"}, nil, nil),
+			m.droplets.EXPECT().Get(gomock.Any(), 43).Return(&godo.Droplet{ID: 43, Name: "web-clone", Status: "active"}, nil, nil),
+		)
+	}
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		mockSetup    func(cloneMocks)
+		expectStages []string
+		expectKept   bool
+		expectError  string
+	}{
+		{
+			name: "Clone in the same region",
+			args: map[string]any{"ID": float64(42)},
+			mockSetup: func(m cloneMocks) {
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(source, nil, nil)
+				snapshotted(m, "nyc1")
+				created(m, "nyc1")
+				m.images.EXPECT().Delete(gomock.Any(), 7).Return(nil, nil)
+			},
+			expectStages: []string{"validate:completed", "snapshot:completed", "transfer:skipped", "create:completed", "boot:completed", "cleanup:completed"},
+		},
+		{
+			name: "Clone to another region keeping the snapshot",
+			args: map[string]any{"ID": float64(42), "target_region": "sfo3", "keep_snapshot": true},
+			mockSetup: func(m cloneMocks) {
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(source, nil, nil)
+				snapshotted(m, "nyc1")
+				m.imageActions.EXPECT().Transfer(gomock.Any(), 7, &godo.ActionRequest{"type": "transfer", "region": "sfo3"}).Return(&godo.Action{ID: 2, Status: "in-progress"}, nil, nil)
+				m.imageActions.EXPECT().Get(gomock.Any(), 7, 2).Return(&godo.Action{ID: 2, Status: "completed"}, nil, nil)
+				created(m, "sfo3")
+			},
+			expectStages: []string{"validate:completed", "snapshot:completed", "transfer:completed", "create:completed", "boot:completed", "cleanup:skipped"},
+			expectKept:   true,
+		},
+		{
+			name: "Snapshot deletion fails",
+			args: map[string]any{"ID": float64(42)},
+			mockSetup: func(m cloneMocks) {
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(source, nil, nil)
+				snapshotted(m, "nyc1")
+				created(m, "nyc1")
+				m.images.EXPECT().Delete(gomock.Any(), 7).Return(nil, errors.New("boom"))
+			},
+			expectStages: []string{"validate:completed", "snapshot:completed", "transfer:skipped", "create:completed", "boot:completed", "cleanup:failed"},
+			expectKept:   true,
+		},
+		{
+			name: "Create fails",
+			args: map[string]any{"ID": float64(42)},
+			mockSetup: func(m cloneMocks) {
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(source, nil, nil)
+				snapshotted(m, "nyc1")
+				m.droplets.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("boom"))
+			},
+			expectStages: []string{"validate:completed", "snapshot:completed", "transfer:skipped", "create:failed"},
+			expectKept:   true,
+			expectError:  "create: boom",
+		},
+		{
+			name: "Snapshot action errors",
+			args: map[string]any{"ID": float64(42)},
+			mockSetup: func(m cloneMocks) {
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(source, nil, nil)
+				m.actions.EXPECT().Snapshot(gomock.Any(), 42, gomock.Any()).Return(&godo.Action{ID: 1, Status: "in-progress"}, nil, nil)
+				m.actions.EXPECT().Get(gomock.Any(), 42, 1).Return(&godo.Action{ID: 1, Type: "snapshot", Status: "errored"}, nil, nil)
+			},
+			expectStages: []string{"validate:completed", "snapshot:failed"},
+			expectError:  "action 1 (snapshot) errored",
+		},
+		{
+			name: "Size not available in the target region",
+			args: map[string]any{"ID": float64(42), "target_region": "ams3"},
+			mockSetup: func(m cloneMocks) {
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(source, nil, nil)
+			},
+			expectError: "size s-1vcpu-1gb is not available in region ams3",
+		},
+		{
+			name: "Source not found",
+			args: map[string]any{"ID": float64(42)},
+			mockSetup: func(m cloneMocks) {
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(nil, nil, errors.New("not found"))
+			},
+			expectError: "not found",
+		},
+		{
+			name:        "Missing ID",
+			args:        map[string]any{},
+			expectError: "argument 'ID' is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := cloneMocks{
+				droplets:     NewMockDropletsService(ctrl),
+				actions:      NewMockDropletActionsService(ctrl),
+				images:       NewMockImagesService(ctrl),
+				imageActions: NewMockImageActionsService(ctrl),
+			}
+			mockSizes := NewMockSizesService(ctrl)
+			mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, nil, nil).AnyTimes()
+			if tc.mockSetup != nil {
+				tc.mockSetup(m)
+			}
+			tool := NewDropletTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: m.droplets, DropletActions: m.actions, Images: m.images, ImageActions: m.imageActions, Sizes: mockSizes}, nil
+			})
+			tool.pollInterval = time.Millisecond
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.cloneDroplet(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				if tc.expectStages == nil {
+					return
+				}
+			} else {
+				require.False(t, resp.IsError)
+			}
+
+			var out dropletClone
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			stages := make([]string, len(out.Stages))
+			for i, s := range out.Stages {
+				stages[i] = s.Stage + ":" + s.Status
+			}
+			require.Equal(t, tc.expectStages, stages)
+			require.Equal(t, 42, out.SourceID)
+			require.Equal(t, tc.expectKept, out.SnapshotKept)
+			if tc.expectError == "" {
+				require.Equal(t, 7, out.SnapshotID)
+				require.Equal(t, 43, out.Droplet.ID)
+				require.Equal(t, "active", out.Droplet.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package droplet
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/registry/networking"
+	"mcp-digitalocean/pkg/response"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// The wait for a new droplet to be active and for its reserved IP to be assigned is bounded by
+// TimeoutSeconds, which defaults to reservedIPAssignTimeout.
+const (
+	reservedIPAssignTimeout    = 10 * time.Minute
+	maxReservedIPAssignTimeout = 30 * time.Minute
+)
+
+// dropletWithReservedIP is the result of droplet-create with ReservedIP. When the droplet was
+// created but the IP could not be assigned, Error is set and ReservedIP is empty.
+type dropletWithReservedIP struct {
+	Droplet    *godo.Droplet    `json:"droplet"`
+	ReservedIP *godo.ReservedIP `json:"reserved_ip,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// assignReservedIP waits for a new droplet to be active, then assigns it the reserved IP ip, or a
+// fresh one when ip is networking.NewReservedIP, for at most timeout. The droplet exists either way,
+// so a failure is reported in the error field of a successful result. An error result would let
+// the idempotency decorator retry the create and start a second droplet.
+func (d *DropletTool) assignReservedIP(ctx context.Context, client *godo.Client, droplet *godo.Droplet, ip string, timeout time.Duration) (*mcp.CallToolResult, error) {
+	result := dropletWithReservedIP{Droplet: droplet}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := common.Poll(ctx, d.pollInterval, func() (bool, error) {
+		current, _, err := client.Droplets.Get(ctx, droplet.ID)
+		if err != nil {
+			return false, err
+		}
+		result.Droplet = current
+		return current.Status == "active", nil
+	})
+	if err != nil {
+		err = fmt.Errorf("droplet %d did not become active, no reserved IP was assigned: %w", droplet.ID, err)
+	} else {
+		result.ReservedIP, err = networking.AssignReservedIP(ctx, client, ip, result.Droplet, func(ip string, action *godo.Action) error {
+			return waitForAction(ctx, d.pollInterval, func() (*godo.Action, error) {
+				action, _, err := client.ReservedIPActions.Get(ctx, ip, action.ID)
+				return action, err
+			})
+		})
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
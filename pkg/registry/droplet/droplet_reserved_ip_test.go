@@ -0,0 +1,152 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type reservedIPMocks struct {
+	droplets    *MockDropletsService
+	reservedIPs *MockReservedIPsService
+	ipActions   *MockReservedIPActionsService
+}
+
+func TestDropletTool_createDropletWithReservedIP(t *testing.T) {
+	sizes := []godo.Size{{Slug: "s-1vcpu-1gb", Available: true, Regions: []string{"nyc1"}}}
+	region := &godo.Region{Slug: "nyc1"}
+	args := func(reservedIP string) map[string]any {
+		return map[string]any{"Name": "web", "Size": "s-1vcpu-1gb", "ImageID": float64(456), "Region": "nyc1", "ReservedIP": reservedIP}
+	}
+	created := func(m reservedIPMocks) {
+		m.droplets.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Droplet{ID: 42, Name: "web", Status: "new"}, nil, nil)
+		gomock.InOrder(
+			m.droplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Name: "web", Status: "new", Region: region}, nil, nil),
+			m.droplets.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Name: "web", Status: "active", Region: region}, nil, nil),
+		)
+	}
+	assigned := func(m reservedIPMocks) {
+		m.ipActions.EXPECT().Assign(gomock.Any(), "192.0.2.1", 42).Return(&godo.Action{ID: 1, Status: "in-progress"}, nil, nil)
+		m.ipActions.EXPECT().Get(gomock.Any(), "192.0.2.1", 1).Return(&godo.Action{ID: 1, Status: "completed"}, nil, nil)
+		m.reservedIPs.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: region, Droplet: &godo.Droplet{ID: 42}}, nil, nil)
+	}
+
+	tests := []struct {
+		name             string
+		args             map[string]any
+		mockSetup        func(reservedIPMocks)
+		expectReservedIP string
+		expectPartial    string
+		expectError      string
+	}{
+		{
+			name: "Existing reserved IP",
+			args: args("192.0.2.1"),
+			mockSetup: func(m reservedIPMocks) {
+				m.reservedIPs.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: region}, nil, nil)
+				created(m)
+				assigned(m)
+			},
+			expectReservedIP: "192.0.2.1",
+		},
+		{
+			name: "New reserved IP",
+			args: args("new"),
+			mockSetup: func(m reservedIPMocks) {
+				created(m)
+				m.reservedIPs.EXPECT().Create(gomock.Any(), &godo.ReservedIPCreateRequest{Region: "nyc1"}).Return(&godo.ReservedIP{IP: "192.0.2.1", Region: region}, nil, nil)
+				assigned(m)
+			},
+			expectReservedIP: "192.0.2.1",
+		},
+		{
+			name: "New reserved IP released when the assignment errors",
+			args: args("new"),
+			mockSetup: func(m reservedIPMocks) {
+				created(m)
+				m.reservedIPs.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.ReservedIP{IP: "192.0.2.1", Region: region}, nil, nil)
+				m.ipActions.EXPECT().Assign(gomock.Any(), "192.0.2.1", 42).Return(&godo.Action{ID: 1, Status: "in-progress"}, nil, nil)
+				m.ipActions.EXPECT().Get(gomock.Any(), "192.0.2.1", 1).Return(&godo.Action{ID: 1, Type: "assign_ip", Status: "errored"}, nil, nil)
+				m.reservedIPs.EXPECT().Delete(gomock.Any(), "192.0.2.1").Return(nil, nil)
+			},
+			expectPartial: "reserved IP 192.0.2.1 was released",
+		},
+		{
+			name: "Droplet never active",
+			args: args("new"),
+			mockSetup: func(m reservedIPMocks) {
+				m.droplets.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.Droplet{ID: 42, Name: "web", Status: "new"}, nil, nil)
+				m.droplets.EXPECT().Get(gomock.Any(), 42).Return(nil, nil, errors.New("droplet get failed"))
+			},
+			expectPartial: "droplet 42 did not become active, no reserved IP was assigned",
+		},
+		{
+			name: "Reserved IP in use, droplet not created",
+			args: args("192.0.2.1"),
+			mockSetup: func(m reservedIPMocks) {
+				m.reservedIPs.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: region, Droplet: &godo.Droplet{ID: 7}}, nil, nil)
+			},
+			expectError: "reserved IP 192.0.2.1 is already assigned to droplet 7",
+		},
+		{
+			name:        "Timeout out of range",
+			args:        map[string]any{"Name": "web", "Size": "s-1vcpu-1gb", "ImageID": float64(456), "Region": "nyc1", "ReservedIP": "new", "TimeoutSeconds": float64(7200)},
+			expectError: "TimeoutSeconds must be between 1 and 1800",
+		},
+		{
+			name:        "Invalid reserved IP",
+			args:        args("any"),
+			expectError: `reserved IP must be an IPv4 address or "new"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := reservedIPMocks{
+				droplets:    NewMockDropletsService(ctrl),
+				reservedIPs: NewMockReservedIPsService(ctrl),
+				ipActions:   NewMockReservedIPActionsService(ctrl),
+			}
+			mockSizes := NewMockSizesService(ctrl)
+			mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, nil, nil).AnyTimes()
+			if tc.mockSetup != nil {
+				tc.mockSetup(m)
+			}
+			tool := NewDropletTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: m.droplets, ReservedIPs: m.reservedIPs, ReservedIPActions: m.ipActions, Sizes: mockSizes}, nil
+			})
+			tool.pollInterval = time.Millisecond
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.createDroplet(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError, text)
+
+			var out dropletWithReservedIP
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, 42, out.Droplet.ID)
+			if tc.expectPartial != "" {
+				require.Contains(t, out.Error, tc.expectPartial)
+				require.Nil(t, out.ReservedIP)
+				return
+			}
+			require.Equal(t, "active", out.Droplet.Status)
+			require.Equal(t, tc.expectReservedIP, out.ReservedIP.IP)
+		})
+	}
+}
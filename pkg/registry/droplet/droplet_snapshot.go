@@ -0,0 +1,109 @@
+package droplet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultSnapshotTimeout = 30 * time.Minute
+	maxSnapshotTimeout     = 2 * time.Hour
+)
+
+// dropletSnapshot is the result of droplet-snapshot. Snapshot is only set once the snapshot action
+// has completed, which droplet-snapshot waits for when wait is set.
+type dropletSnapshot struct {
+	DropletID  int          `json:"droplet_id"`
+	Action     *godo.Action `json:"action"`
+	SnapshotID int          `json:"snapshot_id,omitempty"`
+	Snapshot   *godo.Image  `json:"snapshot,omitempty"`
+}
+
+// snapshotDropletAndWait snapshots a droplet. With wait set, it polls the snapshot action until it
+// completes and looks the snapshot up by name, otherwise it returns the action right away.
+func (da *DropletActionsTool) snapshotDropletAndWait(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	dropletID := args.RequireInt("ID")
+	name := args.RequireString("Name")
+	wait := args.OptionalBool("wait", false)
+	timeoutSeconds := args.OptionalInt("TimeoutSeconds", int(defaultSnapshotTimeout.Seconds()))
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if timeoutSeconds <= 0 || timeoutSeconds > int(maxSnapshotTimeout.Seconds()) {
+		return mcp.NewToolResultError(fmt.Sprintf("TimeoutSeconds must be between 1 and %d", int(maxSnapshotTimeout.Seconds()))), nil
+	}
+
+	client, err := da.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	action, _, err := client.DropletActions.Snapshot(ctx, dropletID, name)
+	if err != nil {
+		return snapshotErrorResult(err, dropletID), nil
+	}
+	result := dropletSnapshot{DropletID: dropletID, Action: action}
+
+	if wait {
+		waitCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutSeconds)*time.Second)
+		defer cancel()
+		err = waitForAction(waitCtx, da.pollInterval, func() (*godo.Action, error) {
+			latest, _, err := client.DropletActions.Get(waitCtx, dropletID, action.ID)
+			if err == nil {
+				result.Action = latest
+			}
+			return latest, err
+		})
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return mcp.NewToolResultError(fmt.Sprintf("snapshot action %d of droplet %d is still in progress after %d seconds, check it with droplet-list-actions", action.ID, dropletID, timeoutSeconds)), nil
+		case err != nil && result.Action.Status == "errored":
+			return mcp.NewToolResultError(fmt.Sprintf("snapshot action %d of droplet %d errored: the droplet may lack the free disk space a snapshot needs, or another action may have been running on it", action.ID, dropletID)), nil
+		case err != nil:
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+
+		snapshot, err := findDropletSnapshot(ctx, client, dropletID, name)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		result.SnapshotID = snapshot.ID
+		result.Snapshot = snapshot
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// snapshotErrorResult returns the result of a rejected snapshot request, explaining the usual
+// reasons DigitalOcean refuses one: another action in progress on the droplet, or a full disk or
+// snapshot limit.
+func snapshotErrorResult(err error, dropletID int) *mcp.CallToolResult {
+	var errResp *godo.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		switch errResp.Response.StatusCode {
+		case http.StatusNotFound:
+			return common.NotFoundResult("droplet", dropletID)
+		case http.StatusUnprocessableEntity, http.StatusConflict:
+			reason := "check the droplet has enough free disk space and the account is under its snapshot limit"
+			if strings.Contains(strings.ToLower(errResp.Message), "pending") {
+				reason = "another action is in progress on the droplet, wait for it to finish with droplet-list-actions and retry"
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("cannot snapshot droplet %d: %s; %s", dropletID, errResp.Message, reason))
+		}
+	}
+	return mcp.NewToolResultErrorFromErr("api error", err)
+}
@@ -0,0 +1,129 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDropletActionsTool_snapshotDropletAndWait(t *testing.T) {
+	started := &godo.Action{ID: 7, Type: "snapshot", Status: "in-progress"}
+	completed := &godo.Action{ID: 7, Type: "snapshot", Status: "completed"}
+	tests := []struct {
+		name             string
+		args             map[string]any
+		mockSetup        func(*MockDropletsService, *MockDropletActionsService)
+		expectStatus     string
+		expectSnapshotID int
+		expectError      string
+	}{
+		{
+			name: "Without wait",
+			args: map[string]any{"ID": float64(42), "Name": "before-upgrade"},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				a.EXPECT().Snapshot(gomock.Any(), 42, "before-upgrade").Return(started, nil, nil)
+			},
+			expectStatus: "in-progress",
+		},
+		{
+			name: "Wait for the snapshot",
+			args: map[string]any{"ID": float64(42), "Name": "before-upgrade", "wait": true},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				gomock.InOrder(
+					a.EXPECT().Snapshot(gomock.Any(), 42, "before-upgrade").Return(started, nil, nil),
+					a.EXPECT().Get(gomock.Any(), 42, 7).Return(started, nil, nil),
+					a.EXPECT().Get(gomock.Any(), 42, 7).Return(completed, nil, nil),
+					d.EXPECT().Snapshots(gomock.Any(), 42, gomock.Any()).Return([]godo.Image{
+						{ID: 100, Name: "nightly"},
+						{ID: 101, Name: "before-upgrade"},
+					}, nil, nil),
+				)
+			},
+			expectStatus:     "completed",
+			expectSnapshotID: 101,
+		},
+		{
+			name: "Snapshot action errors",
+			args: map[string]any{"ID": float64(42), "Name": "before-upgrade", "wait": true},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				a.EXPECT().Snapshot(gomock.Any(), 42, "before-upgrade").Return(started, nil, nil)
+				a.EXPECT().Get(gomock.Any(), 42, 7).Return(&godo.Action{ID: 7, Type: "snapshot", Status: "errored"}, nil, nil)
+			},
+			expectError: "free disk space",
+		},
+		{
+			name: "Still in progress after the timeout",
+			args: map[string]any{"ID": float64(42), "Name": "before-upgrade", "wait": true, "TimeoutSeconds": float64(1)},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				a.EXPECT().Snapshot(gomock.Any(), 42, "before-upgrade").Return(started, nil, nil)
+				a.EXPECT().Get(gomock.Any(), 42, 7).Return(started, nil, nil).AnyTimes()
+			},
+			expectError: "still in progress after 1 seconds",
+		},
+		{
+			name: "Droplet busy",
+			args: map[string]any{"ID": float64(42), "Name": "before-upgrade"},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				a.EXPECT().Snapshot(gomock.Any(), 42, "before-upgrade").Return(nil, nil, &godo.ErrorResponse{
+					Response: &http.Response{StatusCode: http.StatusUnprocessableEntity},
+					Message:  "Droplet already has a pending event.",
+				})
+			},
+			expectError: "another action is in progress on the droplet",
+		},
+		{
+			name: "Snapshot rejected",
+			args: map[string]any{"ID": float64(42), "Name": "before-upgrade"},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				a.EXPECT().Snapshot(gomock.Any(), 42, "before-upgrade").Return(nil, nil, &godo.ErrorResponse{
+					Response: &http.Response{StatusCode: http.StatusUnprocessableEntity},
+					Message:  "Snapshot limit reached.",
+				})
+			},
+			expectError: "snapshot limit",
+		},
+		{
+			name:        "Missing name",
+			args:        map[string]any{"ID": float64(42)},
+			expectError: "Name",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockActions := NewMockDropletActionsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets, mockActions)
+			}
+			tool := NewDropletActionsTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: mockDroplets, DropletActions: mockActions}, nil
+			})
+			tool.pollInterval = 10 * time.Millisecond
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.snapshotDropletAndWait(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out dropletSnapshot
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, 42, out.DropletID)
+			require.Equal(t, 7, out.Action.ID)
+			require.Equal(t, tc.expectStatus, out.Action.Status)
+			require.Equal(t, tc.expectSnapshotID, out.SnapshotID)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package droplet
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	defaultStopTimeout      = 60 * time.Second
+	maxStopTimeout          = 600 * time.Second
+	defaultStopPollInterval = 5 * time.Second
+)
+
+// Paths droplet-stop can take to power a droplet off.
+const (
+	stopPathAlreadyOff = "already_off"
+	stopPathGraceful   = "graceful_shutdown"
+	stopPathPowerOff   = "forced_power_off"
+)
+
+// dropletStop is the result of droplet-stop.
+type dropletStop struct {
+	DropletID int          `json:"droplet_id"`
+	Path      string       `json:"path"`
+	Action    *godo.Action `json:"action,omitempty"`
+}
+
+// stopDroplet powers a droplet off cleanly: it asks the operating system to shut down, waits for the
+// droplet to be off, and powers it off hard when it still runs after the timeout.
+func (da *DropletActionsTool) stopDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	dropletID := args.RequireInt("ID")
+	timeoutSeconds := args.OptionalInt("TimeoutSeconds", int(defaultStopTimeout.Seconds()))
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if timeoutSeconds <= 0 || timeoutSeconds > int(maxStopTimeout.Seconds()) {
+		return mcp.NewToolResultError(fmt.Sprintf("TimeoutSeconds must be between 1 and %d", int(maxStopTimeout.Seconds()))), nil
+	}
+
+	client, err := da.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	droplet, _, err := client.Droplets.Get(ctx, dropletID)
+	if err != nil {
+		return common.APIErrorResult(err, "droplet", dropletID), nil
+	}
+	result := dropletStop{DropletID: dropletID, Path: stopPathAlreadyOff}
+	if droplet.Status != "off" {
+		result.Path, result.Action, err = da.shutdownOrPowerOff(ctx, client, dropletID, time.Duration(timeoutSeconds)*time.Second)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// shutdownOrPowerOff shuts a running droplet down and polls its status until it is off. When it is
+// not off once timeout has elapsed, the droplet is powered off. It returns the path taken and the
+// last action.
+func (da *DropletActionsTool) shutdownOrPowerOff(ctx context.Context, client *godo.Client, dropletID int, timeout time.Duration) (string, *godo.Action, error) {
+	action, _, err := client.DropletActions.Shutdown(ctx, dropletID)
+	if err != nil {
+		return "", nil, fmt.Errorf("shutdown: %w", err)
+	}
+
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(da.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return "", nil, fmt.Errorf("stopped waiting for droplet %d to shut down: %w", dropletID, ctx.Err())
+		case <-deadline.C:
+			action, _, err := client.DropletActions.PowerOff(ctx, dropletID)
+			if err != nil {
+				return "", nil, fmt.Errorf("power off after the shutdown timed out: %w", err)
+			}
+			return stopPathPowerOff, action, nil
+		case <-ticker.C:
+		}
+
+		droplet, _, err := client.Droplets.Get(ctx, dropletID)
+		if err != nil {
+			return "", nil, err
+		}
+		if droplet.Status == "off" {
+			// Report the final status of the shutdown, keeping the action Shutdown returned when it
+			// can't be fetched as the droplet is off either way.
+			if latest, _, err := client.DropletActions.Get(ctx, dropletID, action.ID); err == nil {
+				action = latest
+			}
+			return stopPathGraceful, action, nil
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package droplet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDropletActionsTool_stopDroplet(t *testing.T) {
+	shutdown := &godo.Action{ID: 1, Type: "shutdown", Status: "in-progress"}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDropletsService, *MockDropletActionsService)
+		expectPath  string
+		expectID    int
+		expectError string
+	}{
+		{
+			name: "Graceful shutdown",
+			args: map[string]any{"ID": float64(42)},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				gomock.InOrder(
+					d.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "active"}, nil, nil),
+					a.EXPECT().Shutdown(gomock.Any(), 42).Return(shutdown, nil, nil),
+					d.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "active"}, nil, nil),
+					d.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "off"}, nil, nil),
+					a.EXPECT().Get(gomock.Any(), 42, 1).Return(&godo.Action{ID: 1, Type: "shutdown", Status: "completed"}, nil, nil),
+				)
+			},
+			expectPath: stopPathGraceful,
+			expectID:   1,
+		},
+		{
+			name: "Power off after the timeout",
+			args: map[string]any{"ID": float64(42), "TimeoutSeconds": float64(1)},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				gomock.InOrder(
+					d.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "active"}, nil, nil),
+					a.EXPECT().Shutdown(gomock.Any(), 42).Return(shutdown, nil, nil),
+				)
+				d.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "active"}, nil, nil).AnyTimes()
+				a.EXPECT().PowerOff(gomock.Any(), 42).Return(&godo.Action{ID: 2, Type: "power_off", Status: "in-progress"}, nil, nil)
+			},
+			expectPath: stopPathPowerOff,
+			expectID:   2,
+		},
+		{
+			name: "Already off",
+			args: map[string]any{"ID": float64(42)},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				d.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "off"}, nil, nil)
+			},
+			expectPath: stopPathAlreadyOff,
+		},
+		{
+			name: "Shutdown fails",
+			args: map[string]any{"ID": float64(42)},
+			mockSetup: func(d *MockDropletsService, a *MockDropletActionsService) {
+				d.EXPECT().Get(gomock.Any(), 42).Return(&godo.Droplet{ID: 42, Status: "active"}, nil, nil)
+				a.EXPECT().Shutdown(gomock.Any(), 42).Return(nil, nil, errors.New("boom"))
+			},
+			expectError: "shutdown: boom",
+		},
+		{
+			name:        "Timeout too long",
+			args:        map[string]any{"ID": float64(42), "TimeoutSeconds": float64(3600)},
+			expectError: "TimeoutSeconds must be between 1 and 600",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockActions := NewMockDropletActionsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets, mockActions)
+			}
+			tool := NewDropletActionsTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: mockDroplets, DropletActions: mockActions}, nil
+			})
+			tool.pollInterval = 10 * time.Millisecond
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.stopDroplet(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out dropletStop
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, 42, out.DropletID)
+			require.Equal(t, tc.expectPath, out.Path)
+			if tc.expectID == 0 {
+				require.Nil(t, out.Action)
+				return
+			}
+			require.Equal(t, tc.expectID, out.Action.ID)
+		})
+	}
+}
@@ -3,7 +3,15 @@ package droplet
 import (
 	"context"
 	"fmt"
-
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/registry/networking"
 	"mcp-digitalocean/pkg/response"
 
 	"github.com/digitalocean/godo"
@@ -11,50 +19,57 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// maxUserDataSize is the largest user data DigitalOcean accepts for a droplet.
+const maxUserDataSize = 64 * 1024
+
 // DropletTool provides droplet management tools
 type DropletTool struct {
-	client func(ctx context.Context) (*godo.Client, error)
+	client       func(ctx context.Context) (*godo.Client, error)
+	pollInterval time.Duration
 }
 
 // NewDropletTool creates a new droplet tool
 func NewDropletTool(client func(ctx context.Context) (*godo.Client, error)) *DropletTool {
 	return &DropletTool{
-		client: client,
+		client:       client,
+		pollInterval: defaultClonePollInterval,
 	}
 }
 
 // CreateDroplet creates a new droplet
 func (d *DropletTool) createDroplet(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := req.GetArguments()
-	dropletName := args["Name"].(string)
-	size := args["Size"].(string)
-	imageID := args["ImageID"].(float64)
-	region := args["Region"].(string)
-	backup, _ := args["Backup"].(bool)         // Defaults to false
-	monitoring, _ := args["Monitoring"].(bool) // Defaults to false
-
-	// Handle SSH keys if provided
+	args := common.NewArgs(req)
+	dropletName := args.RequireString("Name")
+	size := args.RequireString("Size")
+	imageID := args.RequireInt("ImageID")
+	region := args.RequireString("Region")
+	backup := args.OptionalBool("Backup", false)
+	monitoring := args.OptionalBool("Monitoring", false)
+	sshKeysList := args.OptionalArray("SSHKeys")
+	tags := args.OptionalStrings("Tags")
+	userData := args.OptionalString("UserData", "")
+	reservedIP := args.OptionalString("ReservedIP", "")
+	timeoutSeconds := args.OptionalInt("TimeoutSeconds", int(reservedIPAssignTimeout.Seconds()))
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if timeoutSeconds <= 0 || timeoutSeconds > int(maxReservedIPAssignTimeout.Seconds()) {
+		return mcp.NewToolResultError(fmt.Sprintf("TimeoutSeconds must be between 1 and %d", int(maxReservedIPAssignTimeout.Seconds()))), nil
+	}
+	if len(userData) > maxUserDataSize {
+		return mcp.NewToolResultError(fmt.Sprintf("argument 'UserData' must not exceed %d bytes, got %d", maxUserDataSize, len(userData))), nil
+	}
+
+	// SSH keys are given by ID or fingerprint
 	var sshKeys []godo.DropletCreateSSHKey
-	if sshKeysRaw, ok := args["SSHKeys"]; ok && sshKeysRaw != nil {
-		sshKeysList := sshKeysRaw.([]interface{})
-		for _, key := range sshKeysList {
-			switch v := key.(type) {
-			case float64:
-				sshKeys = append(sshKeys, godo.DropletCreateSSHKey{ID: int(v)})
-			case string:
-				sshKeys = append(sshKeys, godo.DropletCreateSSHKey{Fingerprint: v})
-			}
-		}
-	}
-
-	// Handle tags if provided
-	var tags []string
-	if tagsRaw, ok := args["Tags"]; ok && tagsRaw != nil {
-		tagsList := tagsRaw.([]interface{})
-		for _, tag := range tagsList {
-			if tagStr, ok := tag.(string); ok {
-				tags = append(tags, tagStr)
-			}
+	for _, key := range sshKeysList {
+		switch v := key.(type) {
+		case float64:
+			sshKeys = append(sshKeys, godo.DropletCreateSSHKey{ID: int(v)})
+		case string:
+			sshKeys = append(sshKeys, godo.DropletCreateSSHKey{Fingerprint: v})
+		default:
+			return mcp.NewToolResultError("argument 'SSHKeys' must contain key IDs or fingerprints"), nil
 		}
 	}
 
@@ -62,23 +77,36 @@ func (d *DropletTool) createDroplet(ctx context.Context, req mcp.CallToolRequest
 	dropletCreateRequest := &godo.DropletCreateRequest{
 		Name:       dropletName,
 		Size:       size,
-		Image:      godo.DropletCreateImage{ID: int(imageID)},
+		Image:      godo.DropletCreateImage{ID: imageID},
 		Region:     region,
 		Backups:    backup,
 		Monitoring: monitoring,
 		SSHKeys:    sshKeys,
 		Tags:       tags,
+		UserData:   userData,
 	}
 
 	client, err := d.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
+	if err := common.ValidateSizeInRegion(ctx, client, size, region); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	// An unusable reserved IP is rejected before the droplet is created.
+	if reservedIP != "" {
+		if err := networking.CheckReservedIP(ctx, client, reservedIP, region); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+	}
 
 	droplet, _, err := client.Droplets.Create(ctx, dropletCreateRequest)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("droplet create", err), nil
 	}
+	if reservedIP != "" {
+		return d.assignReservedIP(ctx, client, droplet, reservedIP, time.Duration(timeoutSeconds)*time.Second)
+	}
 	jsonDroplet, err := response.CompactJSON(droplet)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("json marshal", err), nil
@@ -102,6 +130,151 @@ func (d *DropletTool) deleteDroplet(ctx context.Context, req mcp.CallToolRequest
 	return mcp.NewToolResultText("Droplet deleted successfully"), nil
 }
 
+// deleteDropletsByTag deletes every droplet with a tag. Unless Confirm is set it only reports
+// the droplets that would be deleted. In strict confirmation mode, that report holds the
+// confirm_token the deletion requires.
+func (d *DropletTool) deleteDropletsByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	tag, _ := req.GetArguments()["Tag"].(string)
+	if strings.TrimSpace(tag) == "" {
+		return mcp.NewToolResultError("Tag is required"), nil
+	}
+	confirm, _ := req.GetArguments()["Confirm"].(bool)
+	// The token is bound to the tag, not to the droplets having it when it was issued.
+	resourceID := "tag:" + tag
+	if confirm {
+		if errResult := common.RequireConfirmToken(ctx, req.GetArguments(), "droplet-delete-by-tag", resourceID); errResult != nil {
+			return errResult, nil
+		}
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	if !confirm {
+		names := []string{}
+		opt := &godo.ListOptions{Page: 1, PerPage: 200}
+		for {
+			droplets, resp, err := client.Droplets.ListByTag(ctx, tag, opt)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("api error", err), nil
+			}
+			for _, droplet := range droplets {
+				names = append(names, droplet.Name)
+			}
+			if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+				break
+			}
+			opt.Page++
+		}
+
+		preview := map[string]any{
+			"tag":      tag,
+			"count":    len(names),
+			"droplets": names,
+			"deleted":  false,
+			"message":  "Set Confirm to true to delete these droplets",
+		}
+		if common.StrictConfirm(ctx) {
+			token := common.ConfirmToken(resourceID)
+			preview[common.ConfirmTokenArg] = token
+			preview["message"] = fmt.Sprintf("Set Confirm to true and %s to %s to delete these droplets", common.ConfirmTokenArg, token)
+		}
+		jsonData, err := response.CompactJSON(preview)
+		if err != nil {
+			return nil, fmt.Errorf("marshal error: %w", err)
+		}
+		return mcp.NewToolResultText(jsonData), nil
+	}
+
+	_, err = client.Droplets.DeleteByTag(ctx, tag)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Droplets tagged %s deleted successfully", tag)), nil
+}
+
+// tagNamePattern matches the tag names accepted by the DigitalOcean API.
+var tagNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_\-:]{1,255}$`)
+
+// dropletTagArgs returns the droplet ID and tag name arguments of the tag tools.
+func dropletTagArgs(req mcp.CallToolRequest) (int, string, *mcp.CallToolResult) {
+	id, ok := req.GetArguments()["ID"].(float64)
+	if !ok {
+		return 0, "", mcp.NewToolResultError("Droplet ID is required")
+	}
+	tag, _ := req.GetArguments()["Tag"].(string)
+	if !tagNamePattern.MatchString(tag) {
+		return 0, "", mcp.NewToolResultError("Tag must be 1 to 255 letters, numbers, colons, dashes and underscores")
+	}
+	return int(id), tag, nil
+}
+
+// dropletTagResult returns the current membership of a tag as the tool result.
+func dropletTagResult(ctx context.Context, client *godo.Client, tag string) (*mcp.CallToolResult, error) {
+	updated, _, err := client.Tags.Get(ctx, tag)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonTag, err := response.CompactJSON(updated)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonTag), nil
+}
+
+// addDropletTag tags a droplet, creating the tag if it does not exist yet.
+func (d *DropletTool) addDropletTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, tag, errResult := dropletTagArgs(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	if _, resp, err := client.Tags.Get(ctx, tag); err != nil {
+		if resp == nil || resp.Response == nil || resp.StatusCode != http.StatusNotFound {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		if _, _, err := client.Tags.Create(ctx, &godo.TagCreateRequest{Name: tag}); err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+	}
+
+	_, err = client.Tags.TagResources(ctx, tag, &godo.TagResourcesRequest{
+		Resources: []godo.Resource{{ID: strconv.Itoa(id), Type: godo.DropletResourceType}},
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return dropletTagResult(ctx, client, tag)
+}
+
+// removeDropletTag removes a tag from a droplet.
+func (d *DropletTool) removeDropletTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, tag, errResult := dropletTagArgs(req)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	_, err = client.Tags.UntagResources(ctx, tag, &godo.UntagResourcesRequest{
+		Resources: []godo.Resource{{ID: strconv.Itoa(id), Type: godo.DropletResourceType}},
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return dropletTagResult(ctx, client, tag)
+}
+
 // getDropletNeighbors gets a droplet's neighbors
 func (d *DropletTool) getDropletNeighbors(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	dropletID := req.GetArguments()["ID"].(float64)
@@ -124,6 +297,40 @@ func (d *DropletTool) getDropletNeighbors(ctx context.Context, req mcp.CallToolR
 	return mcp.NewToolResultText(jsonNeighbors), nil
 }
 
+// listDropletFirewalls lists every firewall applied to a droplet
+func (d *DropletTool) listDropletFirewalls(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	dropletID, ok := req.GetArguments()["ID"].(float64)
+	if !ok {
+		return mcp.NewToolResultError("Droplet ID is required"), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	firewalls := []godo.Firewall{}
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		page, resp, err := client.Firewalls.ListByDroplet(ctx, int(dropletID), opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		firewalls = append(firewalls, page...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+
+	jsonFirewalls, err := response.CompactJSON(firewalls)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(jsonFirewalls), nil
+}
+
 // enablePrivateNetworking enables private networking on a droplet
 func (d *DropletTool) enablePrivateNetworking(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	dropletID := req.GetArguments()["ID"].(float64)
@@ -174,12 +381,29 @@ func (d *DropletTool) getDropletKernels(ctx context.Context, req mcp.CallToolReq
 	return mcp.NewToolResultText(jsonKernels), nil
 }
 
-// Tools returns a list of tool functions
+// dropletIPv4 returns the IPv4 address to reach a droplet at: its private address when preferPrivate
+// is set and it has one, its public address otherwise.
+func dropletIPv4(droplet *godo.Droplet, preferPrivate bool) string {
+	if preferPrivate {
+		if ip, err := droplet.PrivateIPv4(); err == nil && ip != "" {
+			return ip
+		}
+	}
+	ip, _ := droplet.PublicIPv4()
+	return ip
+}
+
+// getDropletByID gets a droplet by its ID
 func (d *DropletTool) getDropletByID(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	id, ok := req.GetArguments()["ID"].(float64)
 	if !ok {
 		return mcp.NewToolResultError("Droplet ID is required"), nil
 	}
+	args := common.NewArgs(req)
+	preferPrivate := args.PreferPrivate()
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := d.client(ctx)
 	if err != nil {
@@ -188,9 +412,69 @@ func (d *DropletTool) getDropletByID(ctx context.Context, req mcp.CallToolReques
 
 	droplet, _, err := client.Droplets.Get(ctx, int(id))
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(err, "droplet", int(id)), nil
+	}
+	jsonData, err := response.CompactJSON(struct {
+		*godo.Droplet
+		IPAddress string `json:"ip_address,omitempty"`
+	}{droplet, dropletIPv4(droplet, preferPrivate)})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// maxConcurrentDropletGets bounds the number of droplets droplet-get-many fetches at once.
+const maxConcurrentDropletGets = 10
+
+// dropletGetResult is the outcome of fetching one droplet in droplet-get-many.
+type dropletGetResult struct {
+	ID      int           `json:"id"`
+	Droplet *godo.Droplet `json:"droplet,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+// getManyDroplets fetches several droplets concurrently. A droplet that cannot be fetched is
+// reported in its own entry and does not fail the call.
+func (d *DropletTool) getManyDroplets(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	idArgs, ok := req.GetArguments()["IDs"].([]any)
+	if !ok || len(idArgs) == 0 {
+		return mcp.NewToolResultError("IDs is required"), nil
+	}
+	ids := make([]int, len(idArgs))
+	for i, v := range idArgs {
+		id, ok := v.(float64)
+		if !ok {
+			return mcp.NewToolResultError("IDs must be an array of numbers"), nil
+		}
+		ids[i] = int(id)
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	results := make([]dropletGetResult, len(ids))
+	sem := make(chan struct{}, maxConcurrentDropletGets)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i].ID = id
+			droplet, _, err := client.Droplets.Get(ctx, id)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Droplet = droplet
+		})
 	}
-	jsonData, err := response.CompactJSON(droplet)
+	wg.Wait()
+
+	jsonData, err := response.CompactJSON(results)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
@@ -221,6 +505,38 @@ func (d *DropletTool) getDropletBackupPolicy(ctx context.Context, req mcp.CallTo
 	return mcp.NewToolResultText(jsonData), nil
 }
 
+// listDropletBackups lists the backup images of a droplet.
+func (d *DropletTool) listDropletBackups(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, ok := req.GetArguments()["ID"].(float64)
+	if !ok {
+		return mcp.NewToolResultError("Droplet ID is required"), nil
+	}
+	page, ok := req.GetArguments()["Page"].(float64)
+	if !ok {
+		page = 1
+	}
+	perPage, ok := req.GetArguments()["PerPage"].(float64)
+	if !ok {
+		perPage = 50
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	backups, _, err := client.Droplets.Backups(ctx, int(id), &godo.ListOptions{Page: int(page), PerPage: int(perPage)})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	jsonData, err := response.CompactJSON(backups)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
 func (d *DropletTool) getDropletActionByID(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	dropletID, ok := req.GetArguments()["DropletID"].(float64)
 	if !ok {
@@ -257,6 +573,12 @@ func (d *DropletTool) getDroplets(ctx context.Context, req mcp.CallToolRequest)
 	if !ok {
 		perPage = 50
 	}
+	args := common.NewArgs(req)
+	preferPrivate := args.PreferPrivate()
+	loc := args.Timezone()
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	opt := &godo.ListOptions{
 		Page:    int(page),
@@ -268,7 +590,7 @@ func (d *DropletTool) getDroplets(ctx context.Context, req mcp.CallToolRequest)
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	droplets, _, err := client.Droplets.List(ctx, opt)
+	droplets, resp, err := client.Droplets.List(ctx, opt)
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -292,6 +614,7 @@ func (d *DropletTool) getDroplets(ctx context.Context, req mcp.CallToolRequest)
 			"locked":             droplet.Locked,
 			"status":             droplet.Status,
 			"networks":           droplet.Networks,
+			"ip_address":         dropletIPv4(&droplet, preferPrivate),
 			"created_at":         droplet.Created,
 			"kernel":             droplet.Kernel,
 			"tags":               droplet.Tags,
@@ -300,7 +623,11 @@ func (d *DropletTool) getDroplets(ctx context.Context, req mcp.CallToolRequest)
 		}
 	}
 
-	jsonData, err := response.CompactJSON(filteredDroplets)
+	result, err := response.ConvertTimestamps(response.ListFromResponse(filteredDroplets, resp), loc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	jsonData, err := response.CompactJSON(result)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
@@ -313,7 +640,7 @@ func (d *DropletTool) Tools() []server.ServerTool {
 		{
 			Handler: d.createDroplet,
 			Tool: mcp.NewTool("droplet-create",
-				mcp.WithDescription("Create a new droplet"),
+				mcp.WithDescription("Create a new droplet. With ReservedIP, wait for it to be active and assign it a reserved IP, returning both the droplet and the reserved IP"),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the droplet")),
 				mcp.WithString("Size", mcp.Required(), mcp.Description("Slug of the droplet size (e.g., s-1vcpu-1gb)")),
 				mcp.WithNumber("ImageID", mcp.Required(), mcp.Description("ID of the image to use")),
@@ -322,6 +649,21 @@ func (d *DropletTool) Tools() []server.ServerTool {
 				mcp.WithBoolean("Monitoring", mcp.DefaultBool(false), mcp.Description("Whether to enable monitoring")),
 				mcp.WithArray("SSHKeys", mcp.Description("Array of SSH key IDs (numbers) or fingerprints (strings) to add to the droplet")),
 				mcp.WithArray("Tags", mcp.Description("Array of tag names to apply to the droplet")),
+				mcp.WithString("UserData", mcp.Description("Cloud-init user data, e.g. a #cloud-config document or a shell script, run on first boot. It can't be read back or changed once the droplet exists")),
+				mcp.WithString("ReservedIP", mcp.Description("Reserved IPv4 of the region to assign to the droplet once it is active, or 'new' to reserve a fresh one, which is released if the assignment fails")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(reservedIPAssignTimeout.Seconds()), mcp.Max(maxReservedIPAssignTimeout.Seconds()), mcp.Description("How long to wait for the droplet to be active and the reserved IP assigned with ReservedIP, in seconds")),
+			),
+		},
+		{
+			Handler: d.cloneDroplet,
+			Tool: mcp.NewTool("droplet-clone",
+				mcp.WithDescription("Clone a droplet: snapshot it, wait for the snapshot, create a new droplet from it, optionally in another region or size, and delete the snapshot once the new droplet is active unless keep_snapshot is set. Returns the new droplet and the stages of the clone; on failure, the stages reached so far."),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to clone")),
+				mcp.WithString("Name", mcp.Description("Name of the new droplet, defaults to the source name with a -clone suffix")),
+				mcp.WithString("target_region", mcp.Description("Slug of the region of the new droplet, defaults to the source region")),
+				mcp.WithString("target_size", mcp.Description("Slug of the size of the new droplet, defaults to the source size")),
+				mcp.WithBoolean("keep_snapshot", mcp.DefaultBool(false), mcp.Description("Whether to keep the intermediate snapshot")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(defaultCloneTimeout.Seconds()), mcp.Max(maxCloneTimeout.Seconds()), mcp.Description("How long to wait for the whole clone, in seconds")),
 			),
 		},
 		{
@@ -331,6 +673,38 @@ func (d *DropletTool) Tools() []server.ServerTool {
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet to delete")),
 			),
 		},
+		{
+			Handler: d.deleteDropletsByTag,
+			Tool: mcp.NewTool("droplet-delete-by-tag",
+				mcp.WithDescription("Delete all droplets with a tag. Without Confirm, only lists the droplets that would be deleted, with the confirm_token the deletion requires when the server enforces one."),
+				mcp.WithString("Tag", mcp.Required(), mcp.Description("Tag of the droplets to delete")),
+				mcp.WithBoolean("Confirm", mcp.DefaultBool(false), mcp.Description("Must be true to actually delete the droplets")),
+				mcp.WithDestructiveHintAnnotation(true),
+			),
+		},
+		{
+			Handler: d.addDropletTag,
+			Tool: mcp.NewTool("droplet-add-tag",
+				mcp.WithDescription("Add a tag to a droplet, creating the tag if it does not exist. Returns the tag with its updated resources."),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
+				mcp.WithString("Tag", mcp.Required(), mcp.Description("Name of the tag")),
+			),
+		},
+		{
+			Handler: d.removeDropletTag,
+			Tool: mcp.NewTool("droplet-remove-tag",
+				mcp.WithDescription("Remove a tag from a droplet. Returns the tag with its updated resources."),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
+				mcp.WithString("Tag", mcp.Required(), mcp.Description("Name of the tag")),
+			),
+		},
+		{
+			Handler: d.listDropletFirewalls,
+			Tool: mcp.NewTool("droplet-list-firewalls",
+				mcp.WithDescription("List all firewalls applied to a droplet"),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
+			),
+		},
 		{
 			Handler: d.enablePrivateNetworking,
 			Tool: mcp.NewTool("droplet-enable-private-net",
@@ -342,27 +716,47 @@ func (d *DropletTool) Tools() []server.ServerTool {
 			Handler: d.getDropletKernels,
 			Tool: mcp.NewTool("droplet-kernels",
 				mcp.WithDescription("Get available kernels for a droplet"),
+				mcp.WithReadOnlyHintAnnotation(true),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
 			),
 		},
 		{
 			Handler: d.getDropletByID,
 			Tool: mcp.NewTool("droplet-get",
-				mcp.WithDescription("Get a droplet by its ID"),
+				mcp.WithDescription("Get a droplet by its ID. ip_address is the address to reach it at, see prefer_private."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Droplet ID")),
+				common.WithPreferPrivate(),
+			),
+		},
+		{
+			Handler: d.getManyDroplets,
+			Tool: mcp.NewTool("droplet-get-many",
+				mcp.WithDescription("Get several droplets by ID in one call. Returns one entry per ID with either the droplet or the error fetching it."),
+				mcp.WithArray("IDs", mcp.Required(), mcp.Description("Droplet IDs"), mcp.Items(map[string]any{"type": "number"})),
 			),
 		},
 		{
 			Handler: d.getDropletBackupPolicy,
 			Tool: mcp.NewTool("droplet-backup-policy",
 				mcp.WithDescription("Get a droplet's backup policy"),
+				mcp.WithReadOnlyHintAnnotation(true),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Droplet ID")),
 			),
 		},
+		{
+			Handler: d.listDropletBackups,
+			Tool: mcp.NewTool("droplet-list-backups",
+				mcp.WithDescription("List the backup images of a droplet. Supports pagination."),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("Droplet ID")),
+				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number")),
+				mcp.WithNumber("PerPage", mcp.DefaultNumber(50), mcp.Description("Items per page")),
+			),
+		},
 		{
 			Handler: d.getDropletActionByID,
 			Tool: mcp.NewTool("droplet-action",
 				mcp.WithDescription("Get a droplet action by droplet ID and action ID"),
+				mcp.WithReadOnlyHintAnnotation(true),
 				mcp.WithNumber("DropletID", mcp.Required(), mcp.Description("Droplet ID")),
 				mcp.WithNumber("ActionID", mcp.Required(), mcp.Description("Action ID")),
 			),
@@ -370,9 +764,11 @@ func (d *DropletTool) Tools() []server.ServerTool {
 		{
 			Handler: d.getDroplets,
 			Tool: mcp.NewTool("droplet-list",
-				mcp.WithDescription("List all droplets for the user. Supports pagination."),
+				mcp.WithDescription("List all droplets for the user. Supports pagination: the droplets are returned under items, with the total count, has_more and the next_page to fetch. ip_address is the address to reach each droplet at, see prefer_private."),
 				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(50), mcp.Description("Items per page")),
+				common.WithPreferPrivate(),
+				common.WithTimezone(),
 			),
 		},
 	}
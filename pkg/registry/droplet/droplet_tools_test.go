@@ -4,7 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"mcp-digitalocean/pkg/registry/common"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -30,11 +36,13 @@ func TestDropletTool_createDroplet(t *testing.T) {
 		ID:   123,
 		Name: "test-droplet",
 	}
+	sizes := []godo.Size{{Slug: "s-1vcpu-1gb", Available: true, Regions: []string{"nyc3", "nyc1"}}}
 	tests := []struct {
 		name        string
 		args        map[string]any
 		mockSetup   func(*MockDropletsService)
 		expectError bool
+		expectText  string
 	}{
 		{
 			name: "Successful create",
@@ -60,6 +68,39 @@ func TestDropletTool_createDroplet(t *testing.T) {
 					Times(1)
 			},
 		},
+		{
+			name: "Create with user data",
+			args: map[string]any{
+				"Name":     "test-droplet",
+				"Size":     "s-1vcpu-1gb",
+				"ImageID":  float64(456),
+				"Region":   "nyc1",
+				"UserData": "#cloud-config\npackages:\n  - nginx\n",
+			},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().
+					Create(gomock.Any(), &godo.DropletCreateRequest{
+						Name:     "test-droplet",
+						Region:   "nyc1",
+						Size:     "s-1vcpu-1gb",
+						Image:    godo.DropletCreateImage{ID: 456},
+						UserData: "#cloud-config\npackages:\n  - nginx\n",
+					}).
+					Return(testDroplet, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "User data too large",
+			args: map[string]any{
+				"Name":     "test-droplet",
+				"Size":     "s-1vcpu-1gb",
+				"ImageID":  float64(456),
+				"Region":   "nyc1",
+				"UserData": strings.Repeat("a", maxUserDataSize+1),
+			},
+			expectError: true,
+		},
 		{
 			name: "API error",
 			args: map[string]any{
@@ -85,21 +126,66 @@ func TestDropletTool_createDroplet(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Size not available in region",
+			args: map[string]any{
+				"Name":    "test-droplet",
+				"Size":    "s-1vcpu-1gb",
+				"ImageID": float64(456),
+				"Region":  "blr1",
+			},
+			expectError: true,
+			expectText:  "size s-1vcpu-1gb is not available in region blr1, it is available in: nyc1, nyc3",
+		},
+		{
+			name: "Unknown size",
+			args: map[string]any{
+				"Name":    "test-droplet",
+				"Size":    "s-64vcpu-1gb",
+				"ImageID": float64(456),
+				"Region":  "nyc1",
+			},
+			expectError: true,
+			expectText:  "unknown size s-64vcpu-1gb",
+		},
+		{
+			name: "Missing region",
+			args: map[string]any{
+				"Name":    "test-droplet",
+				"Size":    "s-1vcpu-1gb",
+				"ImageID": float64(456),
+			},
+			expectError: true,
+		},
+		{
+			name: "Fractional image ID",
+			args: map[string]any{
+				"Name":    "test-droplet",
+				"Size":    "s-1vcpu-1gb",
+				"ImageID": 4.5,
+				"Region":  "nyc1",
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			mockDroplets := NewMockDropletsService(ctrl)
-			mockActions := NewMockDropletActionsService(ctrl)
+			mockSizes := NewMockSizesService(ctrl)
+			mockSizes.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, nil, nil).AnyTimes()
 			if tc.mockSetup != nil {
 				tc.mockSetup(mockDroplets)
 			}
-			tool := setupDropletToolWithMocks(mockDroplets, mockActions)
+			tool := NewDropletTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Droplets: mockDroplets, Sizes: mockSizes}, nil
+			})
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
 			resp, err := tool.createDroplet(context.Background(), req)
 			if tc.expectError {
 				require.NotNil(t, resp)
 				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectText)
 				return
 			}
 			require.NoError(t, err)
@@ -177,6 +263,20 @@ func TestDropletTool_getDropletByID(t *testing.T) {
 	}
 }
 
+func TestDropletTool_getDropletByIDNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDroplets := NewMockDropletsService(ctrl)
+	notFound := &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "The resource you were accessing could not be found."}
+	mockDroplets.EXPECT().Get(gomock.Any(), 404).Return(nil, &godo.Response{Response: notFound.Response}, notFound)
+
+	tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(404)}}}
+	resp, err := tool.getDropletByID(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.JSONEq(t, `{"error":"resource not found","resource_type":"droplet","id":404}`, resp.Content[0].(mcp.TextContent).Text)
+}
+
 func TestDropletTool_getDropletActionByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -309,6 +409,293 @@ func TestDropletTool_deleteDroplet(t *testing.T) {
 	}
 }
 
+func TestDropletTool_deleteDropletsByTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDropletsService)
+		expectError bool
+		expectText  string
+	}{
+		{
+			name: "Preview without confirm",
+			args: map[string]any{"Tag": "test-fleet"},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().
+					ListByTag(gomock.Any(), "test-fleet", &godo.ListOptions{Page: 1, PerPage: 200}).
+					Return([]godo.Droplet{{ID: 1, Name: "web-1"}, {ID: 2, Name: "web-2"}}, &godo.Response{}, nil).
+					Times(1)
+			},
+			expectText: `{"count":2,"deleted":false,"droplets":["web-1","web-2"],"message":"Set Confirm to true to delete these droplets","tag":"test-fleet"}`,
+		},
+		{
+			name: "Delete with confirm",
+			args: map[string]any{"Tag": "test-fleet", "Confirm": true},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().
+					DeleteByTag(gomock.Any(), "test-fleet").
+					Return(&godo.Response{}, nil).
+					Times(1)
+			},
+			expectText: "Droplets tagged test-fleet deleted successfully",
+		},
+		{
+			name:        "Empty tag",
+			args:        map[string]any{"Tag": " ", "Confirm": true},
+			expectError: true,
+			expectText:  "Tag is required",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"Tag": "test-fleet", "Confirm": true},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().
+					DeleteByTag(gomock.Any(), "test-fleet").
+					Return(nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockActions := NewMockDropletActionsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets)
+			}
+			tool := setupDropletToolWithMocks(mockDroplets, mockActions)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.deleteDropletsByTag(context.Background(), req)
+			if tc.expectError {
+				require.NotNil(t, resp)
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectText)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			require.False(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectText)
+		})
+	}
+}
+
+func TestDropletTool_deleteDropletsByTagStrictConfirm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDroplets := NewMockDropletsService(ctrl)
+	mockDroplets.EXPECT().
+		ListByTag(gomock.Any(), "test-fleet", gomock.Any()).
+		Return([]godo.Droplet{{ID: 1, Name: "web-1"}}, &godo.Response{}, nil)
+	tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+	ctx := common.WithStrictConfirm(context.Background())
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Tag": "test-fleet"}}}
+	resp, err := tool.deleteDropletsByTag(ctx, req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	var preview map[string]any
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &preview))
+	token := common.ConfirmToken("tag:test-fleet")
+	require.Equal(t, token, preview["confirm_token"])
+
+	// Without the token from the preview, Confirm alone deletes nothing.
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Tag": "test-fleet", "Confirm": true}}}
+	resp, err = tool.deleteDropletsByTag(ctx, req)
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+
+	mockDroplets.EXPECT().DeleteByTag(gomock.Any(), "test-fleet").Return(&godo.Response{}, nil)
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Tag": "test-fleet", "Confirm": true, "confirm_token": token}}}
+	resp, err = tool.deleteDropletsByTag(ctx, req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+}
+
+func TestDropletTool_getManyDroplets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Partial failures are reported per ID", func(t *testing.T) {
+		mockDroplets := NewMockDropletsService(ctrl)
+		mockDroplets.EXPECT().Get(gomock.Any(), 1).Return(&godo.Droplet{ID: 1, Name: "web-1"}, nil, nil).Times(1)
+		mockDroplets.EXPECT().Get(gomock.Any(), 2).Return(nil, nil, errors.New("droplet not found")).Times(1)
+		mockDroplets.EXPECT().Get(gomock.Any(), 3).Return(&godo.Droplet{ID: 3, Name: "web-3"}, nil, nil).Times(1)
+		tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"IDs": []any{float64(1), float64(2), float64(3)}}}}
+		resp, err := tool.getManyDroplets(context.Background(), req)
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+
+		var results []dropletGetResult
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &results))
+		require.Len(t, results, 3)
+		require.Equal(t, "web-1", results[0].Droplet.Name)
+		require.Empty(t, results[0].Error)
+		require.Equal(t, 2, results[1].ID)
+		require.Nil(t, results[1].Droplet)
+		require.Equal(t, "droplet not found", results[1].Error)
+		require.Equal(t, "web-3", results[2].Droplet.Name)
+	})
+
+	t.Run("Concurrency is bounded", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		mockDroplets := NewMockDropletsService(ctrl)
+		mockDroplets.EXPECT().
+			Get(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, id int) (*godo.Droplet, *godo.Response, error) {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					current := maxInFlight.Load()
+					if n <= current || maxInFlight.CompareAndSwap(current, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				return &godo.Droplet{ID: id}, nil, nil
+			}).
+			Times(35)
+		tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+
+		ids := make([]any, 35)
+		for i := range ids {
+			ids[i] = float64(i + 1)
+		}
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"IDs": ids}}}
+		resp, err := tool.getManyDroplets(context.Background(), req)
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		require.LessOrEqual(t, maxInFlight.Load(), int32(maxConcurrentDropletGets))
+
+		var results []dropletGetResult
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &results))
+		require.Len(t, results, 35)
+		for i, result := range results {
+			require.Equal(t, i+1, result.ID)
+			require.Equal(t, i+1, result.Droplet.ID)
+		}
+	})
+
+	t.Run("Invalid IDs", func(t *testing.T) {
+		tool := setupDropletToolWithMocks(NewMockDropletsService(ctrl), NewMockDropletActionsService(ctrl))
+		for _, args := range []map[string]any{{}, {"IDs": []any{}}, {"IDs": []any{"web-1"}}} {
+			resp, err := tool.getManyDroplets(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+			require.NoError(t, err)
+			require.True(t, resp.IsError)
+		}
+	})
+}
+
+func TestDropletTool_addRemoveDropletTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notFound := &godo.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}
+	resources := []godo.Resource{{ID: "123", Type: godo.DropletResourceType}}
+	taggedTag := &godo.Tag{Name: "web", Resources: &godo.TaggedResources{Count: 1, Droplets: &godo.TaggedDropletsResources{Count: 1}}}
+	emptyTag := &godo.Tag{Name: "web", Resources: &godo.TaggedResources{Count: 0}}
+
+	tests := []struct {
+		name        string
+		remove      bool
+		args        map[string]any
+		mockSetup   func(*MockTagsService)
+		expectError bool
+		expectTag   *godo.Tag
+	}{
+		{
+			name: "Add existing tag",
+			args: map[string]any{"ID": float64(123), "Tag": "web"},
+			mockSetup: func(m *MockTagsService) {
+				gomock.InOrder(
+					m.EXPECT().Get(gomock.Any(), "web").Return(emptyTag, &godo.Response{}, nil),
+					m.EXPECT().TagResources(gomock.Any(), "web", &godo.TagResourcesRequest{Resources: resources}).Return(&godo.Response{}, nil),
+					m.EXPECT().Get(gomock.Any(), "web").Return(taggedTag, &godo.Response{}, nil),
+				)
+			},
+			expectTag: taggedTag,
+		},
+		{
+			name: "Add creates missing tag",
+			args: map[string]any{"ID": float64(123), "Tag": "web"},
+			mockSetup: func(m *MockTagsService) {
+				gomock.InOrder(
+					m.EXPECT().Get(gomock.Any(), "web").Return(nil, notFound, errors.New("tag not found")),
+					m.EXPECT().Create(gomock.Any(), &godo.TagCreateRequest{Name: "web"}).Return(emptyTag, &godo.Response{}, nil),
+					m.EXPECT().TagResources(gomock.Any(), "web", &godo.TagResourcesRequest{Resources: resources}).Return(&godo.Response{}, nil),
+					m.EXPECT().Get(gomock.Any(), "web").Return(taggedTag, &godo.Response{}, nil),
+				)
+			},
+			expectTag: taggedTag,
+		},
+		{
+			name: "Add fails on other lookup errors",
+			args: map[string]any{"ID": float64(123), "Tag": "web"},
+			mockSetup: func(m *MockTagsService) {
+				m.EXPECT().Get(gomock.Any(), "web").Return(nil, nil, errors.New("api error"))
+			},
+			expectError: true,
+		},
+		{
+			name:   "Remove tag",
+			remove: true,
+			args:   map[string]any{"ID": float64(123), "Tag": "web"},
+			mockSetup: func(m *MockTagsService) {
+				gomock.InOrder(
+					m.EXPECT().UntagResources(gomock.Any(), "web", &godo.UntagResourcesRequest{Resources: resources}).Return(&godo.Response{}, nil),
+					m.EXPECT().Get(gomock.Any(), "web").Return(emptyTag, &godo.Response{}, nil),
+				)
+			},
+			expectTag: emptyTag,
+		},
+		{
+			name:        "Invalid tag name",
+			args:        map[string]any{"ID": float64(123), "Tag": "has space"},
+			expectError: true,
+		},
+		{
+			name:        "Missing droplet ID",
+			remove:      true,
+			args:        map[string]any{"Tag": "web"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTags := NewMockTagsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockTags)
+			}
+			tool := NewDropletTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Tags: mockTags}, nil
+			})
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			handler := tool.addDropletTag
+			if tc.remove {
+				handler = tool.removeDropletTag
+			}
+			resp, err := handler(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var outTag godo.Tag
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outTag))
+			require.Equal(t, tc.expectTag, &outTag)
+		})
+	}
+}
+
 func TestDropletTool_getDroplets(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -338,10 +725,11 @@ func TestDropletTool_getDroplets(t *testing.T) {
 	}
 
 	tests := []struct {
-		name        string
-		args        map[string]any
-		mockSetup   func(*MockDropletsService)
-		expectError bool
+		name           string
+		args           map[string]any
+		mockSetup      func(*MockDropletsService)
+		expectError    bool
+		expectNextPage int
 	}{
 		{
 			name: "Successful list",
@@ -350,6 +738,17 @@ func TestDropletTool_getDroplets(t *testing.T) {
 				m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 1}).Return([]godo.Droplet{testDroplet}, nil, nil).Times(1)
 			},
 		},
+		{
+			name: "More pages",
+			args: map[string]any{"Page": float64(1), "PerPage": float64(1)},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 1}).Return([]godo.Droplet{testDroplet}, &godo.Response{
+					Meta:  &godo.Meta{Total: 3},
+					Links: &godo.Links{Pages: &godo.Pages{Next: "https://api.digitalocean.com/v2/droplets?page=2&per_page=1", Last: "https://api.digitalocean.com/v2/droplets?page=3&per_page=1"}},
+				}, nil).Times(1)
+			},
+			expectNextPage: 2,
+		},
 		{
 			name: "API error",
 			args: map[string]any{"Page": float64(1), "PerPage": float64(1)},
@@ -378,10 +777,16 @@ func TestDropletTool_getDroplets(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, resp)
 			require.False(t, resp.IsError)
-			var outDroplets []map[string]any
-			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outDroplets))
-			require.Len(t, outDroplets, 1)
-			out := outDroplets[0]
+			var list struct {
+				Items    []map[string]any `json:"items"`
+				HasMore  bool             `json:"has_more"`
+				NextPage int              `json:"next_page"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &list))
+			require.Len(t, list.Items, 1)
+			require.Equal(t, tc.expectNextPage != 0, list.HasMore)
+			require.Equal(t, tc.expectNextPage, list.NextPage)
+			out := list.Items[0]
 			// Check that all expected fields are present
 			for _, field := range []string{
 				"id", "name", "memory", "vcpus", "disk", "region", "image", "size", "size_slug", "backup_ids", "next_backup_window", "snapshot_ids", "features", "locked", "status", "networks", "created_at", "kernel", "tags", "volume_ids", "vpc_uuid",
@@ -395,3 +800,159 @@ func TestDropletTool_getDroplets(t *testing.T) {
 		})
 	}
 }
+
+func TestDropletTool_listDropletBackups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	backups := []godo.Image{{ID: 1, Name: "backup-1", Type: "backup"}}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDropletsService)
+		expectError bool
+	}{
+		{
+			name: "Successful list with pagination",
+			args: map[string]any{"ID": float64(123), "Page": float64(2), "PerPage": float64(10)},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().
+					Backups(gomock.Any(), 123, &godo.ListOptions{Page: 2, PerPage: 10}).
+					Return(backups, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name: "API error",
+			args: map[string]any{"ID": float64(456)},
+			mockSetup: func(m *MockDropletsService) {
+				m.EXPECT().
+					Backups(gomock.Any(), 456, &godo.ListOptions{Page: 1, PerPage: 50}).
+					Return(nil, nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
+		},
+		{
+			name:        "Missing ID",
+			args:        map[string]any{},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockActions := NewMockDropletActionsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDroplets)
+			}
+			tool := setupDropletToolWithMocks(mockDroplets, mockActions)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.listDropletBackups(context.Background(), req)
+			if tc.expectError {
+				require.NotNil(t, resp)
+				require.True(t, resp.IsError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			require.False(t, resp.IsError)
+			var out []godo.Image
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, backups, out)
+		})
+	}
+}
+
+func TestDropletTool_listDropletFirewalls(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	t.Run("Paginates through all firewalls", func(t *testing.T) {
+		mockFirewalls := NewMockFirewallsService(ctrl)
+		gomock.InOrder(
+			mockFirewalls.EXPECT().
+				ListByDroplet(gomock.Any(), 123, &godo.ListOptions{Page: 1, PerPage: 200}).
+				Return([]godo.Firewall{{ID: "fw-1", Name: "web"}}, &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+			mockFirewalls.EXPECT().
+				ListByDroplet(gomock.Any(), 123, &godo.ListOptions{Page: 2, PerPage: 200}).
+				Return([]godo.Firewall{{ID: "fw-2", Name: "ssh"}}, &godo.Response{}, nil),
+		)
+		tool := NewDropletTool(func(ctx context.Context) (*godo.Client, error) {
+			return &godo.Client{Firewalls: mockFirewalls}, nil
+		})
+
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(123)}}}
+		resp, err := tool.listDropletFirewalls(context.Background(), req)
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		var firewalls []godo.Firewall
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &firewalls))
+		require.Len(t, firewalls, 2)
+		require.Equal(t, "fw-2", firewalls[1].ID)
+	})
+
+	t.Run("API error", func(t *testing.T) {
+		mockFirewalls := NewMockFirewallsService(ctrl)
+		mockFirewalls.EXPECT().
+			ListByDroplet(gomock.Any(), 123, gomock.Any()).
+			Return(nil, nil, errors.New("api error"))
+		tool := NewDropletTool(func(ctx context.Context) (*godo.Client, error) {
+			return &godo.Client{Firewalls: mockFirewalls}, nil
+		})
+
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": float64(123)}}}
+		resp, err := tool.listDropletFirewalls(context.Background(), req)
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+	})
+}
+
+func TestDropletTool_getDropletByIDPreferPrivate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	droplet := &godo.Droplet{
+		ID:   123,
+		Name: "web-1",
+		Networks: &godo.Networks{V4: []godo.NetworkV4{
+			{IPAddress: "203.0.113.10", Type: "public"},
+			{IPAddress: "10.10.0.5", Type: "private"},
+		}},
+	}
+	publicOnly := &godo.Droplet{
+		ID:       456,
+		Networks: &godo.Networks{V4: []godo.NetworkV4{{IPAddress: "203.0.113.11", Type: "public"}}},
+	}
+
+	tests := []struct {
+		name   string
+		args   map[string]any
+		result *godo.Droplet
+		expect string
+	}{
+		{"public by default", map[string]any{"ID": float64(123)}, droplet, "203.0.113.10"},
+		{"private when preferred", map[string]any{"ID": float64(123), "prefer_private": true}, droplet, "10.10.0.5"},
+		{"public when there is no private address", map[string]any{"ID": float64(456), "prefer_private": true}, publicOnly, "203.0.113.11"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDroplets := NewMockDropletsService(ctrl)
+			mockDroplets.EXPECT().Get(gomock.Any(), tc.result.ID).Return(tc.result, nil, nil)
+			tool := setupDropletToolWithMocks(mockDroplets, NewMockDropletActionsService(ctrl))
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.getDropletByID(context.Background(), req)
+			require.NoError(t, err)
+			require.False(t, resp.IsError)
+			var out struct {
+				ID        int    `json:"id"`
+				IPAddress string `json:"ip_address"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, tc.result.ID, out.ID)
+			require.Equal(t, tc.expect, out.IPAddress)
+		})
+	}
+}
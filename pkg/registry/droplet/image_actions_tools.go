@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"mcp-digitalocean/pkg/response"
+	"slices"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -36,6 +37,14 @@ func (ia *ImageActionsTool) transferImage(ctx context.Context, req mcp.CallToolR
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	image, _, err := client.Images.GetByID(ctx, int(imageID))
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	if slices.Contains(image.Regions, region) {
+		return mcp.NewToolResultError(fmt.Sprintf("image %d is already available in %s", image.ID, region)), nil
+	}
+
 	transferRequest := &godo.ActionRequest{
 		"type":   "transfer",
 		"region": region,
@@ -114,8 +123,8 @@ func (ia *ImageActionsTool) Tools() []server.ServerTool {
 		{
 			Handler: ia.transferImage,
 			Tool: mcp.NewTool(
-				"image-action-transfer",
-				mcp.WithDescription("Transfer an image to another region."),
+				"image-transfer",
+				mcp.WithDescription("Transfer an image to another region. The image must not already be available in the target region."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the image to transfer")),
 				mcp.WithString("Region", mcp.Required(), mcp.Description("Region slug to transfer to (e.g., nyc3)")),
 			),
@@ -123,7 +132,7 @@ func (ia *ImageActionsTool) Tools() []server.ServerTool {
 		{
 			Handler: ia.convertImageToSnapshot,
 			Tool: mcp.NewTool(
-				"image-action-convert",
+				"image-convert",
 				mcp.WithDescription("Convert an image (backup) to a snapshot."),
 				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the image to convert")),
 			),
@@ -14,12 +14,13 @@ import (
 )
 
 // Helper to initialize tool and mock
-func newTestActionTool(t *testing.T) (*ImageActionsTool, *MockImageActionsService) {
+func newTestActionTool(t *testing.T) (*ImageActionsTool, *MockImageActionsService, *MockImagesService) {
 	ctrl := gomock.NewController(t)
 	m := NewMockImageActionsService(ctrl)
+	images := NewMockImagesService(ctrl)
 	return NewImageActionsTool(func(context.Context) (*godo.Client, error) {
-		return &godo.Client{ImageActions: m}, nil
-	}), m
+		return &godo.Client{ImageActions: m, Images: images}, nil
+	}), m, images
 }
 
 func TestImageActionsTool_transferImage(t *testing.T) {
@@ -28,23 +29,43 @@ func TestImageActionsTool_transferImage(t *testing.T) {
 	tests := []struct {
 		name    string
 		args    map[string]any
-		setup   func(*MockImageActionsService)
+		setup   func(*MockImageActionsService, *MockImagesService)
 		wantErr bool
+		errText string
 	}{
 		{
 			name: "Successful transfer",
 			args: map[string]any{"ID": 123.0, "Region": "nyc3"},
-			setup: func(m *MockImageActionsService) {
+			setup: func(m *MockImageActionsService, images *MockImagesService) {
+				images.EXPECT().GetByID(gomock.Any(), 123).Return(&godo.Image{ID: 123, Regions: []string{"sfo3"}}, nil, nil)
 				req := &godo.ActionRequest{"type": "transfer", "region": "nyc3"}
 				m.EXPECT().Transfer(gomock.Any(), 123, req).Return(action, nil, nil)
 			},
 		},
 		{name: "Missing ID", args: map[string]any{"Region": "nyc3"}, wantErr: true},
 		{name: "Missing Region", args: map[string]any{"ID": 123.0}, wantErr: true},
+		{
+			name: "Already in target region",
+			args: map[string]any{"ID": 123.0, "Region": "nyc3"},
+			setup: func(m *MockImageActionsService, images *MockImagesService) {
+				images.EXPECT().GetByID(gomock.Any(), 123).Return(&godo.Image{ID: 123, Regions: []string{"nyc3"}}, nil, nil)
+			},
+			wantErr: true,
+			errText: "image 123 is already available in nyc3",
+		},
+		{
+			name: "Image not found",
+			args: map[string]any{"ID": 789.0, "Region": "nyc3"},
+			setup: func(m *MockImageActionsService, images *MockImagesService) {
+				images.EXPECT().GetByID(gomock.Any(), 789).Return(nil, nil, errors.New("not found"))
+			},
+			wantErr: true,
+		},
 		{
 			name: "API Error",
 			args: map[string]any{"ID": 456.0, "Region": "ams3"},
-			setup: func(m *MockImageActionsService) {
+			setup: func(m *MockImageActionsService, images *MockImagesService) {
+				images.EXPECT().GetByID(gomock.Any(), 456).Return(&godo.Image{ID: 456, Regions: []string{"nyc3"}}, nil, nil)
 				m.EXPECT().Transfer(gomock.Any(), 456, gomock.Any()).Return(nil, nil, errors.New("error"))
 			},
 			wantErr: true,
@@ -53,9 +74,9 @@ func TestImageActionsTool_transferImage(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tool, m := newTestActionTool(t)
+			tool, m, images := newTestActionTool(t)
 			if tc.setup != nil {
-				tc.setup(m)
+				tc.setup(m, images)
 			}
 
 			res, err := tool.transferImage(context.Background(), mcp.CallToolRequest{
@@ -63,6 +84,9 @@ func TestImageActionsTool_transferImage(t *testing.T) {
 			})
 
 			require.Equal(t, tc.wantErr, res.IsError)
+			if tc.errText != "" {
+				assert.Equal(t, tc.errText, res.Content[0].(mcp.TextContent).Text)
+			}
 			if !tc.wantErr {
 				require.NoError(t, err)
 				var out godo.Action
@@ -102,7 +126,7 @@ func TestImageActionsTool_convertImageToSnapshot(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tool, m := newTestActionTool(t)
+			tool, m, _ := newTestActionTool(t)
 			if tc.setup != nil {
 				tc.setup(m)
 			}
@@ -138,7 +162,7 @@ func TestImageActionsTool_getImageAction(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			tool, m := newTestActionTool(t)
+			tool, m, _ := newTestActionTool(t)
 			if tc.setup != nil {
 				tc.setup(m)
 			}
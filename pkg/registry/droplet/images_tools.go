@@ -3,7 +3,9 @@ package droplet
 import (
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -25,7 +27,69 @@ func NewImageTool(client func(ctx context.Context) (*godo.Client, error)) *Image
 	return &ImageTool{client: client}
 }
 
-// listImages lists images with pagination and optional type filtering.
+// imageTypes are the values of the Type argument of image-list. distribution, application and user
+// select the matching image list; snapshot, backup and custom select the user images of that type.
+var imageTypes = []string{"distribution", "application", "user", "snapshot", "backup", "custom"}
+
+// imageFilter holds the filtering arguments of image-list.
+type imageFilter struct {
+	imageType    string
+	distribution string
+	private      *bool
+}
+
+// imageFilterArgs reads the filtering arguments of image-list.
+func imageFilterArgs(req mcp.CallToolRequest) (imageFilter, error) {
+	args := common.NewArgs(req)
+	f := imageFilter{
+		imageType:    args.OptionalEnum("Type", "", imageTypes...),
+		distribution: args.OptionalString("distribution", ""),
+	}
+	if v, ok := req.GetArguments()["private"]; ok && v != nil {
+		private := args.OptionalBool("private", false)
+		f.private = &private
+	}
+	return f, args.Err()
+}
+
+// active reports whether any filter was requested.
+func (f imageFilter) active() bool {
+	return f.imageType != "" || f.distribution != "" || f.private != nil
+}
+
+// lister returns the list method of the images service serving the filter: the user images when
+// only private images can match, the whole list when no type is given.
+func (f imageFilter) lister(images godo.ImagesService) func(context.Context, *godo.ListOptions) ([]godo.Image, *godo.Response, error) {
+	switch f.imageType {
+	case "distribution":
+		return images.ListDistribution
+	case "application":
+		return images.ListApplication
+	case "user", "snapshot", "backup", "custom":
+		return images.ListUser
+	}
+	if f.private != nil && *f.private {
+		return images.ListUser
+	}
+	return images.List
+}
+
+// match reports whether image passes the filter. Distributions are compared case-insensitively.
+func (f imageFilter) match(image godo.Image) bool {
+	switch f.imageType {
+	case "snapshot", "backup", "custom":
+		if image.Type != f.imageType {
+			return false
+		}
+	}
+	if f.distribution != "" && !strings.EqualFold(image.Distribution, f.distribution) {
+		return false
+	}
+	return f.private == nil || image.Public != *f.private
+}
+
+// listImages lists images with pagination. When filters are given, every matching image is fetched
+// and the page is taken from the filtered images.
 func (i *ImageTool) listImages(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	page, ok := req.GetArguments()["Page"].(float64)
 	if !ok {
@@ -35,7 +99,10 @@ func (i *ImageTool) listImages(ctx context.Context, req mcp.CallToolRequest) (*m
 	if !ok {
 		perPage = defaultImagesPageSize
 	}
-	imageType, _ := req.GetArguments()["Type"].(string)
+	filter, err := imageFilterArgs(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	opt := &godo.ListOptions{
 		Page:    int(page),
@@ -48,23 +115,31 @@ func (i *ImageTool) listImages(ctx context.Context, req mcp.CallToolRequest) (*m
 	}
 
 	var images []godo.Image
-	var apiErr error
-
-	// Dispatch based on requested image type
-	switch imageType {
-	case "distribution":
-		images, _, apiErr = client.Images.ListDistribution(ctx, opt)
-	case "application":
-		images, _, apiErr = client.Images.ListApplication(ctx, opt)
-	case "user":
-		images, _, apiErr = client.Images.ListUser(ctx, opt)
-	default:
-		// Default to listing all if unspecified
-		images, _, apiErr = client.Images.List(ctx, opt)
-	}
-
-	if apiErr != nil {
-		return mcp.NewToolResultErrorFromErr("api error", apiErr), nil
+	if filter.active() {
+		list := filter.lister(client.Images)
+		var matching []godo.Image
+		listOpt := &godo.ListOptions{Page: 1, PerPage: 200}
+		for {
+			batch, resp, err := list(ctx, listOpt)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("api error", err), nil
+			}
+			for _, image := range batch {
+				if filter.match(image) {
+					matching = append(matching, image)
+				}
+			}
+			if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+				break
+			}
+			listOpt.Page++
+		}
+		images = common.Paginate(matching, opt)
+	} else {
+		images, _, err = client.Images.List(ctx, opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
 	}
 
 	// returning mapped structure to match other tools' verbosity.
@@ -228,10 +303,12 @@ func (i *ImageTool) Tools() []server.ServerTool {
 			Handler: i.listImages,
 			Tool: mcp.NewTool(
 				"image-list",
-				mcp.WithDescription("List available images (snapshots, backups, distributions, applications)."),
+				mcp.WithDescription("List available images (snapshots, backups, distributions, applications). Supports pagination, and filtering to find a specific image: the page is then taken from the matching images."),
 				mcp.WithNumber("Page", mcp.DefaultNumber(defaultImagesPage), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultImagesPageSize), mcp.Description("Items per page")),
-				mcp.WithString("Type", mcp.Description("Filter by type: 'distribution', 'application', 'user' (snapshots/backups). If omitted, lists all.")),
+				mcp.WithString("Type", mcp.Enum(imageTypes...), mcp.Description("Filter by type: 'distribution', 'application', 'user' (all of your snapshots, backups and custom images), or only your 'snapshot', 'backup' or 'custom' images. If omitted, lists all.")),
+				mcp.WithString("distribution", mcp.Description("Only images of this distribution (e.g., Ubuntu, Debian), case-insensitive")),
+				mcp.WithBoolean("private", mcp.Description("true for only your own images, false for only public images")),
 			),
 		},
 		{
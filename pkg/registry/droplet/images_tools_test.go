@@ -91,6 +91,124 @@ func TestImageTool_listImages(t *testing.T) {
 	}
 }
 
+func TestImageTool_listImagesFiltered(t *testing.T) {
+	userImages := []godo.Image{
+		{ID: 1, Name: "web snapshot", Type: "snapshot", Distribution: "Ubuntu"},
+		{ID: 2, Name: "nightly", Type: "backup", Distribution: "Ubuntu"},
+		{ID: 3, Name: "imported", Type: "custom", Distribution: "Debian"},
+	}
+	allImages := []godo.Image{
+		{ID: 10, Slug: "ubuntu-24-04-x64", Type: "base", Distribution: "Ubuntu", Public: true},
+		{ID: 11, Slug: "debian-12-x64", Type: "base", Distribution: "Debian", Public: true},
+		{ID: 1, Name: "web snapshot", Type: "snapshot", Distribution: "Ubuntu"},
+	}
+	lastPage := &godo.Response{Links: &godo.Links{}}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		setup       func(*MockImagesService)
+		expectIDs   []int
+		expectError string
+	}{
+		{
+			name: "Snapshots across pages",
+			args: map[string]any{"Type": "snapshot"},
+			setup: func(m *MockImagesService) {
+				gomock.InOrder(
+					m.EXPECT().ListUser(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+						Return(userImages[:2], &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+					m.EXPECT().ListUser(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 200}).
+						Return([]godo.Image{{ID: 4, Type: "snapshot"}, userImages[2]}, lastPage, nil),
+				)
+			},
+			expectIDs: []int{1, 4},
+		},
+		{
+			name: "Custom images",
+			args: map[string]any{"Type": "custom"},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListUser(gomock.Any(), gomock.Any()).Return(userImages, lastPage, nil)
+			},
+			expectIDs: []int{3},
+		},
+		{
+			name: "Distribution images of Debian",
+			args: map[string]any{"Type": "distribution", "distribution": "debian"},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListDistribution(gomock.Any(), gomock.Any()).Return(allImages[:2], lastPage, nil)
+			},
+			expectIDs: []int{11},
+		},
+		{
+			name: "Private images use the user list",
+			args: map[string]any{"private": true, "distribution": "Ubuntu"},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListUser(gomock.Any(), gomock.Any()).Return(userImages, lastPage, nil)
+			},
+			expectIDs: []int{1, 2},
+		},
+		{
+			name: "Public images",
+			args: map[string]any{"private": false},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return(allImages, lastPage, nil)
+			},
+			expectIDs: []int{10, 11},
+		},
+		{
+			name: "Page of the filtered images",
+			args: map[string]any{"Type": "user", "Page": 2.0, "PerPage": 2.0},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListUser(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return(userImages, lastPage, nil)
+			},
+			expectIDs: []int{3},
+		},
+		{
+			name:        "Invalid type",
+			args:        map[string]any{"Type": "kernel"},
+			expectError: "argument 'Type' must be one of",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"Type": "backup"},
+			setup: func(m *MockImagesService) {
+				m.EXPECT().ListUser(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("boom"))
+			},
+			expectError: "boom",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool, m := newTestTool(t)
+			if tc.setup != nil {
+				tc.setup(m)
+			}
+
+			res, err := tool.listImages(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: tc.args},
+			})
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, res.IsError)
+			var out []struct {
+				ID int `json:"id"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &out))
+			ids := []int{}
+			for _, image := range out {
+				ids = append(ids, image.ID)
+			}
+			assert.Equal(t, tc.expectIDs, ids)
+		})
+	}
+}
+
 func TestImageTool_getImageByID(t *testing.T) {
 	image := &godo.Image{ID: 123, Name: "test-image"}
 
@@ -1,9 +1,13 @@
 package droplet
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -15,17 +19,99 @@ const (
 	defaultSizesPage     = 1
 )
 
+// sizeSortKeys are the values of the sort_by argument of size-list.
+var sizeSortKeys = []string{"price_monthly", "memory", "vcpus"}
+
+// sizeFilter holds the filtering and sorting arguments of size-list.
+type sizeFilter struct {
+	minVCPUs        int
+	maxPriceMonthly float64
+	region          string
+	sortBy          string
+}
+
+// sizeFilterArgs reads the filtering and sorting arguments of size-list.
+func sizeFilterArgs(req mcp.CallToolRequest) (sizeFilter, error) {
+	args := common.NewArgs(req)
+	f := sizeFilter{
+		minVCPUs: args.OptionalInt("min_vcpus", 0),
+		region:   args.OptionalString("region", ""),
+		sortBy:   args.OptionalEnum("sort_by", "", sizeSortKeys...),
+	}
+	if err := args.Err(); err != nil {
+		return f, err
+	}
+	if f.minVCPUs < 0 {
+		return f, fmt.Errorf("argument 'min_vcpus' must not be negative")
+	}
+	if v, ok := req.GetArguments()["max_price_monthly"]; ok && v != nil {
+		price, isNumber := v.(float64)
+		if !isNumber || price <= 0 {
+			return f, fmt.Errorf("argument 'max_price_monthly' must be a positive number")
+		}
+		f.maxPriceMonthly = price
+	}
+	return f, nil
+}
+
+// active reports whether any filter or sort order was requested.
+func (f sizeFilter) active() bool {
+	return f.minVCPUs > 0 || f.maxPriceMonthly > 0 || f.region != "" || f.sortBy != ""
+}
+
+// apply returns the sizes matching the filter, sorted in ascending order of the sort key, then by slug.
+// Filtering by region only keeps sizes available there.
+func (f sizeFilter) apply(sizes []godo.Size) []godo.Size {
+	out := make([]godo.Size, 0, len(sizes))
+	for _, size := range sizes {
+		if size.Vcpus < f.minVCPUs {
+			continue
+		}
+		if f.maxPriceMonthly > 0 && size.PriceMonthly > f.maxPriceMonthly {
+			continue
+		}
+		if f.region != "" && (!size.Available || !slices.Contains(size.Regions, f.region)) {
+			continue
+		}
+		out = append(out, size)
+	}
+	if f.sortBy == "" {
+		return out
+	}
+	key := func(size godo.Size) float64 {
+		switch f.sortBy {
+		case "memory":
+			return float64(size.Memory)
+		case "vcpus":
+			return float64(size.Vcpus)
+		default:
+			return size.PriceMonthly
+		}
+	}
+	slices.SortStableFunc(out, func(a, b godo.Size) int {
+		if c := cmp.Compare(key(a), key(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Slug, b.Slug)
+	})
+	return out
+}
+
 // SizesTool provides tool-based handlers for DigitalOcean droplet sizes.
 type SizesTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
+	cache  *common.RegionSizeCache
 }
 
-// NewSizesTool creates a new SizesTool instance.
-func NewSizesTool(client func(ctx context.Context) (*godo.Client, error)) *SizesTool {
-	return &SizesTool{client: client}
+// NewSizesTool creates a new SizesTool instance. size-list serves the sizes held by cache when it
+// has them, cache may be nil.
+func NewSizesTool(client func(ctx context.Context) (*godo.Client, error), cache *common.RegionSizeCache) *SizesTool {
+	return &SizesTool{client: client, cache: cache}
 }
 
-// listSizes lists all available droplet sizes with pagination support.
+// listSizes lists all available droplet sizes with pagination support. When filters or a sort order
+// are given, every size is fetched and the page is taken from the filtered, sorted sizes. Unless
+// fresh is set, the sizes are taken from the cache when it has them.
 func (s *SizesTool) listSizes(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	page, ok := req.GetArguments()["Page"].(float64)
 	if !ok {
@@ -35,6 +121,11 @@ func (s *SizesTool) listSizes(ctx context.Context, req mcp.CallToolRequest) (*mc
 	if !ok {
 		perPage = defaultSizesPageSize
 	}
+	fresh, _ := req.GetArguments()["fresh"].(bool)
+	filter, err := sizeFilterArgs(req)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	opt := &godo.ListOptions{
 		Page:    int(page),
@@ -46,9 +137,25 @@ func (s *SizesTool) listSizes(ctx context.Context, req mcp.CallToolRequest) (*mc
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	sizes, _, err := client.Sizes.List(ctx, opt)
-	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	var sizes []godo.Size
+	all, cached := s.cache.Sizes(client)
+	switch {
+	case cached && !fresh:
+		if filter.active() {
+			all = filter.apply(all)
+		}
+		sizes = common.Paginate(all, opt)
+	case filter.active():
+		all, err := common.ListAllSizes(ctx, client)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		sizes = common.Paginate(filter.apply(all), opt)
+	default:
+		sizes, _, err = client.Sizes.List(ctx, opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
 	}
 
 	filteredSizes := make([]map[string]any, len(sizes))
@@ -74,6 +181,59 @@ func (s *SizesTool) listSizes(ctx context.Context, req mcp.CallToolRequest) (*mc
 	return mcp.NewToolResultText(jsonData), nil
 }
 
+// listSizesForRegion lists the available droplet sizes that can be created in a region.
+func (s *SizesTool) listSizesForRegion(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	slug, _ := req.GetArguments()["Region"].(string)
+	if slug == "" {
+		return mcp.NewToolResultError("Region is required"), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	regions, err := common.ListAllRegions(ctx, client)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	i := slices.IndexFunc(regions, func(r godo.Region) bool { return r.Slug == slug })
+	if i < 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("unknown region slug: %s", slug)), nil
+	}
+	region := regions[i]
+
+	sizes, err := common.ListAllSizes(ctx, client)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	filteredSizes := []map[string]any{}
+	for _, size := range sizes {
+		if !size.Available || !region.Available {
+			continue
+		}
+		if slices.Contains(size.Regions, slug) || slices.Contains(region.Sizes, size.Slug) {
+			filteredSizes = append(filteredSizes, map[string]any{
+				"slug":          size.Slug,
+				"price_monthly": size.PriceMonthly,
+				"price_hourly":  size.PriceHourly,
+				"memory":        size.Memory,
+				"vcpus":         size.Vcpus,
+				"disk":          size.Disk,
+				"transfer":      size.Transfer,
+			})
+		}
+	}
+
+	jsonData, err := response.CompactJSON(filteredSizes)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(jsonData), nil
+}
+
 // Tools returns the list of server tools for droplet sizes.
 func (s *SizesTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
@@ -81,9 +241,22 @@ func (s *SizesTool) Tools() []server.ServerTool {
 			Handler: s.listSizes,
 			Tool: mcp.NewTool(
 				"size-list",
-				mcp.WithDescription("List all available droplet sizes. Supports pagination."),
+				mcp.WithDescription("List droplet sizes. Supports pagination, and filtering and sorting to pick a size for a budget or a requirement: the page is then taken from the matching sizes."),
 				mcp.WithNumber("Page", mcp.DefaultNumber(defaultSizesPage), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultSizesPageSize), mcp.Description("Items per page")),
+				mcp.WithNumber("min_vcpus", mcp.Description("Only sizes with at least this many vCPUs")),
+				mcp.WithNumber("max_price_monthly", mcp.Description("Only sizes costing at most this much per month, in USD")),
+				mcp.WithString("region", mcp.Description("Only sizes available in this region (e.g., nyc3)")),
+				mcp.WithString("sort_by", mcp.Enum(sizeSortKeys...), mcp.Description("Sort the sizes in ascending order of this field")),
+				mcp.WithBoolean("fresh", mcp.DefaultBool(false), mcp.Description("Fetch the sizes from the API instead of the server's cache, which is refreshed every few minutes")),
+			),
+		},
+		{
+			Handler: s.listSizesForRegion,
+			Tool: mcp.NewTool(
+				"size-list-for-region",
+				mcp.WithDescription("List the available droplet sizes that can be created in the given region"),
+				mcp.WithString("Region", mcp.Required(), mcp.Description("Slug of the region (e.g., nyc3)")),
 			),
 		},
 	}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
+
+	"mcp-digitalocean/pkg/registry/common"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -16,7 +19,7 @@ func setupSizesToolWithMock(sizes *MockSizesService) *SizesTool {
 	client := func(ctx context.Context) (*godo.Client, error) {
 		return &godo.Client{Sizes: sizes}, nil
 	}
-	return NewSizesTool(client)
+	return NewSizesTool(client, nil)
 }
 
 func TestSizesTool_listSizes(t *testing.T) {
@@ -130,3 +133,233 @@ func TestSizesTool_listSizes(t *testing.T) {
 		})
 	}
 }
+
+func TestSizesTool_listSizesForRegion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	regions := []godo.Region{
+		{Slug: "nyc3", Available: true, Sizes: []string{"s-1vcpu-1gb", "s-2vcpu-2gb"}},
+		{Slug: "ams2", Available: false, Sizes: []string{"s-1vcpu-1gb"}},
+	}
+	sizes := []godo.Size{
+		{Slug: "s-1vcpu-1gb", Available: true, Regions: []string{"nyc3", "ams2"}},
+		{Slug: "s-2vcpu-2gb", Available: false, Regions: []string{"nyc3"}},
+		{Slug: "s-4vcpu-8gb", Available: true, Regions: []string{"nyc3"}},
+		{Slug: "gpu-h100x1-80gb", Available: true, Regions: []string{"tor1"}},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockRegionsService, *MockSizesService)
+		expectError string
+		expectSizes []string
+	}{
+		{
+			name: "Sizes offered in region",
+			args: map[string]any{"Region": "nyc3"},
+			mockSetup: func(r *MockRegionsService, s *MockSizesService) {
+				r.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return(regions, &godo.Response{}, nil)
+				s.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return(sizes, &godo.Response{}, nil)
+			},
+			expectSizes: []string{"s-1vcpu-1gb", "s-4vcpu-8gb"},
+		},
+		{
+			name: "Unavailable region",
+			args: map[string]any{"Region": "ams2"},
+			mockSetup: func(r *MockRegionsService, s *MockSizesService) {
+				r.EXPECT().List(gomock.Any(), gomock.Any()).Return(regions, &godo.Response{}, nil)
+				s.EXPECT().List(gomock.Any(), gomock.Any()).Return(sizes, &godo.Response{}, nil)
+			},
+			expectSizes: []string{},
+		},
+		{
+			name: "Unknown region",
+			args: map[string]any{"Region": "mars1"},
+			mockSetup: func(r *MockRegionsService, s *MockSizesService) {
+				r.EXPECT().List(gomock.Any(), gomock.Any()).Return(regions, &godo.Response{}, nil)
+			},
+			expectError: "unknown region slug: mars1",
+		},
+		{
+			name:        "Missing region",
+			args:        map[string]any{},
+			expectError: "Region is required",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"Region": "nyc3"},
+			mockSetup: func(r *MockRegionsService, s *MockSizesService) {
+				r.EXPECT().List(gomock.Any(), gomock.Any()).Return(regions, &godo.Response{}, nil)
+				s.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("boom"))
+			},
+			expectError: "boom",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRegions := NewMockRegionsService(ctrl)
+			mockSizes := NewMockSizesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockRegions, mockSizes)
+			}
+			tool := NewSizesTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Regions: mockRegions, Sizes: mockSizes}, nil
+			}, nil)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.listSizesForRegion(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var sizesOut []struct {
+				Slug string `json:"slug"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &sizesOut))
+			slugs := []string{}
+			for _, s := range sizesOut {
+				slugs = append(slugs, s.Slug)
+			}
+			require.Equal(t, tc.expectSizes, slugs)
+		})
+	}
+}
+
+func TestSizesTool_listSizesFiltered(t *testing.T) {
+	sizes := []godo.Size{
+		{Slug: "s-1vcpu-1gb", Vcpus: 1, Memory: 1024, PriceMonthly: 6, Available: true, Regions: []string{"nyc3", "ams3"}},
+		{Slug: "s-2vcpu-4gb", Vcpus: 2, Memory: 4096, PriceMonthly: 24, Available: true, Regions: []string{"nyc3"}},
+		{Slug: "c-2", Vcpus: 2, Memory: 4096, PriceMonthly: 42, Available: true, Regions: []string{"nyc3", "ams3"}},
+		{Slug: "s-4vcpu-8gb", Vcpus: 4, Memory: 8192, PriceMonthly: 48, Available: true, Regions: []string{"ams3"}},
+		{Slug: "m-2vcpu-16gb", Vcpus: 2, Memory: 16384, PriceMonthly: 84, Available: false, Regions: []string{"nyc3"}},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectSlugs []string
+		expectError string
+	}{
+		{
+			name:        "Minimum vCPUs",
+			args:        map[string]any{"min_vcpus": float64(2)},
+			expectSlugs: []string{"s-2vcpu-4gb", "c-2", "s-4vcpu-8gb", "m-2vcpu-16gb"},
+		},
+		{
+			name:        "Budget sorted by price",
+			args:        map[string]any{"max_price_monthly": float64(45), "sort_by": "price_monthly"},
+			expectSlugs: []string{"s-1vcpu-1gb", "s-2vcpu-4gb", "c-2"},
+		},
+		{
+			name:        "Region excludes unavailable sizes",
+			args:        map[string]any{"region": "nyc3"},
+			expectSlugs: []string{"s-1vcpu-1gb", "s-2vcpu-4gb", "c-2"},
+		},
+		{
+			name:        "All filters sorted by memory",
+			args:        map[string]any{"min_vcpus": float64(2), "max_price_monthly": float64(50), "region": "ams3", "sort_by": "memory"},
+			expectSlugs: []string{"c-2", "s-4vcpu-8gb"},
+		},
+		{
+			name:        "Sort by vCPUs breaks ties by slug",
+			args:        map[string]any{"sort_by": "vcpus"},
+			expectSlugs: []string{"s-1vcpu-1gb", "c-2", "m-2vcpu-16gb", "s-2vcpu-4gb", "s-4vcpu-8gb"},
+		},
+		{
+			name:        "Page of the filtered sizes",
+			args:        map[string]any{"min_vcpus": float64(2), "sort_by": "price_monthly", "Page": float64(2), "PerPage": float64(2)},
+			expectSlugs: []string{"s-4vcpu-8gb", "m-2vcpu-16gb"},
+		},
+		{
+			name:        "No match",
+			args:        map[string]any{"max_price_monthly": float64(4)},
+			expectSlugs: []string{},
+		},
+		{
+			name:        "Invalid sort key",
+			args:        map[string]any{"sort_by": "disk"},
+			expectError: "argument 'sort_by' must be one of",
+		},
+		{
+			name:        "Invalid price",
+			args:        map[string]any{"max_price_monthly": float64(-1)},
+			expectError: "argument 'max_price_monthly' must be a positive number",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockSizes := NewMockSizesService(ctrl)
+			if tc.expectError == "" {
+				mockSizes.EXPECT().
+					List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+					Return(sizes, &godo.Response{}, nil)
+			}
+			tool := setupSizesToolWithMock(mockSizes)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.listSizes(context.Background(), req)
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out []map[string]any
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			slugs := make([]string, len(out))
+			for i, size := range out {
+				slugs[i] = size["slug"].(string)
+			}
+			require.Equal(t, tc.expectSlugs, slugs)
+		})
+	}
+}
+
+func TestSizesTool_listSizesCached(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockRegions := NewMockRegionsService(ctrl)
+	mockSizes := NewMockSizesService(ctrl)
+	client := &godo.Client{Regions: mockRegions, Sizes: mockSizes}
+	getClient := func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	}
+
+	cache := common.NewRegionSizeCache(getClient, time.Minute)
+	mockRegions.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, &godo.Response{}, nil)
+	mockSizes.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).Return([]godo.Size{
+		{Slug: "s-2vcpu-2gb", Vcpus: 2, PriceMonthly: 18},
+		{Slug: "s-1vcpu-1gb", Vcpus: 1, PriceMonthly: 6},
+		{Slug: "s-4vcpu-8gb", Vcpus: 4, PriceMonthly: 48},
+	}, &godo.Response{}, nil)
+	require.NoError(t, cache.Refresh(context.Background()))
+	tool := NewSizesTool(getClient, cache)
+
+	list := func(args map[string]any) []string {
+		resp, err := tool.listSizes(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.False(t, resp.IsError)
+		var out []map[string]any
+		require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+		slugs := make([]string, len(out))
+		for i, size := range out {
+			slugs[i] = size["slug"].(string)
+		}
+		return slugs
+	}
+
+	// The cached sizes are paged in the order of the API, and filtered and sorted like fetched ones.
+	require.Equal(t, []string{"s-2vcpu-2gb", "s-1vcpu-1gb"}, list(map[string]any{"Page": float64(1), "PerPage": float64(2)}))
+	require.Equal(t, []string{"s-2vcpu-2gb", "s-4vcpu-8gb"}, list(map[string]any{"min_vcpus": float64(2), "sort_by": "vcpus"}))
+
+	// fresh goes to the API.
+	mockSizes.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 50}).Return([]godo.Size{{Slug: "c-2"}}, &godo.Response{}, nil)
+	require.Equal(t, []string{"c-2"}, list(map[string]any{"fresh": true}))
+}
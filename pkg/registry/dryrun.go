@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"mcp-digitalocean/pkg/dryrun"
+	"mcp-digitalocean/pkg/response"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// dryRunArg is the optional argument that previews a mutating call instead of executing it.
+const dryRunArg = "dry_run"
+
+// dryRunResult is returned to the caller instead of the tool's own result when dry_run is set.
+type dryRunResult struct {
+	DryRun    bool             `json:"dry_run"`
+	Tool      string           `json:"tool"`
+	Arguments map[string]any   `json:"arguments"`
+	Requests  []dryrun.Request `json:"requests"`
+	// ConfirmToken is the confirm_token the call must carry to be executed in strict confirmation mode.
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	Note         string `json:"note,omitempty"`
+}
+
+// WithDryRun adds a dry_run argument to every mutating tool. When it is set, read-only API requests
+// are still sent so the tool can resolve what it acts on, but every other request is captured and
+// returned to the caller instead of being sent to DigitalOcean.
+func WithDryRun() Option {
+	return func(o *options) {
+		o.dryRun = true
+		o.decorators = append(o.decorators, dryRunDecorator)
+	}
+}
+
+// isMutatingTool reports whether a tool may change resources. Tools annotated as read-only and tools
+// whose name contains a get or list segment, such as droplet-get or key-get-by-fingerprint, are not.
+func isMutatingTool(tool mcp.Tool) bool {
+	if hint := tool.Annotations.ReadOnlyHint; hint != nil && *hint {
+		return false
+	}
+	for _, segment := range strings.Split(tool.Name, "-") {
+		if segment == "get" || segment == "list" {
+			return false
+		}
+	}
+	return true
+}
+
+func dryRunDecorator(tool server.ServerTool) server.ServerTool {
+	if !isMutatingTool(tool.Tool) {
+		return tool
+	}
+	addProperty(&tool.Tool, dryRunArg, map[string]any{
+		"type":        "boolean",
+		"default":     false,
+		"description": "If true, validate the call and return the API requests it would make without executing them",
+	})
+
+	name := tool.Tool.Name
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if enabled, _ := req.GetArguments()[dryRunArg].(bool); !enabled {
+			return next(ctx, req)
+		}
+
+		ctx, rec := dryrun.WithRecorder(ctx)
+		result, err := next(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		requests := rec.Requests()
+		if len(requests) == 0 && result != nil && result.IsError {
+			// The call was rejected before reaching the API, e.g. by argument validation.
+			return result, nil
+		}
+
+		preview := dryRunResult{
+			DryRun:       true,
+			Tool:         name,
+			Arguments:    withoutArg(req.GetArguments(), dryRunArg),
+			Requests:     requests,
+			ConfirmToken: rec.ConfirmToken(),
+		}
+		if len(requests) == 0 {
+			preview.Note = "no mutating API requests would be made"
+		}
+		jsonPreview, err := response.CompactJSON(preview)
+		if err != nil {
+			return nil, fmt.Errorf("marshal error: %w", err)
+		}
+		return mcp.NewToolResultText(jsonPreview), nil
+	}
+	return tool
+}
+
+// dryRunClient wraps getClient so that, during a dry-run call, the returned godo client sends its
+// requests through the recorder in the context.
+func dryRunClient(getClient getClientFn) getClientFn {
+	return func(ctx context.Context) (*godo.Client, error) {
+		client, err := getClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		rec := dryrun.FromContext(ctx)
+		if rec == nil {
+			return client, nil
+		}
+
+		recording := godo.NewClient(rec.Client(client.HTTPClient))
+		recording.BaseURL = client.BaseURL
+		recording.UserAgent = client.UserAgent
+		return recording, nil
+	}
+}
+
+func withoutArg(args map[string]any, name string) map[string]any {
+	out := make(map[string]any, len(args))
+	for k, v := range args {
+		if k != name {
+			out[k] = v
+		}
+	}
+	return out
+}
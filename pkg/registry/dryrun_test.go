@@ -0,0 +1,161 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"mcp-digitalocean/pkg/registry/droplet"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+// findTool returns the tool with the given name from tools.
+func findTool(t *testing.T, tools []server.ServerTool, name string) server.ServerTool {
+	t.Helper()
+	for _, tool := range tools {
+		if tool.Tool.Name == name {
+			return tool
+		}
+	}
+	t.Fatalf("tool %s not found", name)
+	return server.ServerTool{}
+}
+
+// setupDryRunDropletTools returns the droplet tools backed by a real godo client pointed at a test
+// server, together with the methods of the requests that reached the server.
+func setupDryRunDropletTools(t *testing.T) ([]server.ServerTool, func() []string) {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/sizes":
+			// droplet-create checks that the size can be created in the region first.
+			_, _ = w.Write([]byte(`{"sizes":[{"slug":"s-1vcpu-1gb","available":true,"regions":["nyc3"]}],"meta":{"total":1}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/regions":
+			_, _ = w.Write([]byte(`{"regions":[{"slug":"nyc3","available":true,"sizes":["s-1vcpu-1gb"]}],"meta":{"total":1}}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"droplet":{"id":123,"name":"web-1"}}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = w.Write([]byte(`{"droplet":{"id":456,"name":"web-2"}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client := godo.NewClient(srv.Client())
+	client.BaseURL = baseURL
+
+	getClient := dryRunClient(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	})
+	tools := droplet.NewDropletTool(getClient).Tools()
+	for i := range tools {
+		tools[i] = dryRunDecorator(tools[i])
+	}
+	return tools, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), methods...)
+	}
+}
+
+func TestDryRunDecorator(t *testing.T) {
+	t.Run("delete is recorded but not sent", func(t *testing.T) {
+		tools, sent := setupDryRunDropletTools(t)
+		tool := findTool(t, tools, "droplet-delete")
+
+		res := callTool(context.Background(), t, tool, map[string]any{"ID": float64(123), "dry_run": true})
+		require.False(t, res.IsError)
+		require.Empty(t, sent())
+
+		var preview dryRunResult
+		require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &preview))
+		require.True(t, preview.DryRun)
+		require.Equal(t, "droplet-delete", preview.Tool)
+		require.Equal(t, map[string]any{"ID": float64(123)}, preview.Arguments)
+		require.Len(t, preview.Requests, 1)
+		require.Equal(t, http.MethodDelete, preview.Requests[0].Method)
+		require.Equal(t, "/v2/droplets/123", preview.Requests[0].Endpoint)
+	})
+
+	t.Run("create body is recorded but not sent", func(t *testing.T) {
+		tools, sent := setupDryRunDropletTools(t)
+		tool := findTool(t, tools, "droplet-create")
+
+		res := callTool(context.Background(), t, tool, map[string]any{
+			"Name":    "web-2",
+			"Size":    "s-1vcpu-1gb",
+			"ImageID": float64(12345),
+			"Region":  "nyc3",
+			"dry_run": true,
+		})
+		require.False(t, res.IsError)
+		require.NotContains(t, sent(), http.MethodPost)
+
+		var preview dryRunResult
+		require.NoError(t, json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &preview))
+		require.Len(t, preview.Requests, 1)
+		require.Equal(t, http.MethodPost, preview.Requests[0].Method)
+		require.Equal(t, "/v2/droplets", preview.Requests[0].Endpoint)
+		require.Equal(t, "web-2", preview.Requests[0].Body.(map[string]any)["name"])
+	})
+
+	t.Run("without dry_run the request is sent", func(t *testing.T) {
+		tools, sent := setupDryRunDropletTools(t)
+		tool := findTool(t, tools, "droplet-delete")
+
+		res := callTool(context.Background(), t, tool, map[string]any{"ID": float64(123)})
+		require.False(t, res.IsError)
+		require.Equal(t, []string{http.MethodDelete}, sent())
+	})
+
+	t.Run("read-only tools are not decorated", func(t *testing.T) {
+		tools, _ := setupDryRunDropletTools(t)
+		tool := findTool(t, tools, "droplet-get")
+		require.NotContains(t, tool.Tool.InputSchema.Properties, dryRunArg)
+
+		tool = findTool(t, tools, "droplet-delete")
+		require.Contains(t, tool.Tool.InputSchema.Properties, dryRunArg)
+
+		// Read-only tools without a get or list segment are annotated as such.
+		for _, name := range []string{"droplet-kernels", "droplet-backup-policy", "droplet-action"} {
+			require.NotContains(t, findTool(t, tools, name).Tool.InputSchema.Properties, dryRunArg, name)
+		}
+	})
+}
+
+func TestIsMutatingTool(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     mcp.Tool
+		mutating bool
+	}{
+		{name: "create", tool: mcp.NewTool("droplet-create"), mutating: true},
+		{name: "delete", tool: mcp.NewTool("spaces-bucket-delete"), mutating: true},
+		{name: "get", tool: mcp.NewTool("droplet-get"), mutating: false},
+		{name: "list", tool: mcp.NewTool("spaces-bucket-list-objects"), mutating: false},
+		{name: "get with suffix", tool: mcp.NewTool("key-get-by-fingerprint"), mutating: false},
+		{name: "read-only annotation", tool: mcp.NewTool("action-wait", mcp.WithReadOnlyHintAnnotation(true)), mutating: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.mutating, isMutatingTool(tc.tool))
+		})
+	}
+}
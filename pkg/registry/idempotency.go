@@ -0,0 +1,164 @@
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	middleware "mcp-digitalocean/internal"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultIdempotencyWindow is how long a create result is replayed when WithIdempotency is given a zero duration.
+	DefaultIdempotencyWindow = 10 * time.Minute
+
+	// idempotencyKeyArg is the optional argument identifying a create call across retries.
+	idempotencyKeyArg = "idempotency_key"
+)
+
+// idempotentTools are the create tools whose results are replayed. None of their DigitalOcean API
+// endpoints accept an idempotency key, so deduplication happens in this process only.
+var idempotentTools = map[string]struct{}{
+	"droplet-create":    {},
+	"lb-create":         {},
+	"db-cluster-create": {},
+}
+
+// WithIdempotency adds an idempotency_key argument to the create tools in idempotentTools and replays
+// the result of a successful create when the same call is made again within window, or
+// DefaultIdempotencyWindow when window is zero. Calls with the same idempotency_key are the same
+// call; without a key, calls with identical arguments are.
+func WithIdempotency(window time.Duration) Option {
+	if window <= 0 {
+		window = DefaultIdempotencyWindow
+	}
+	cache := &idempotencyCache{window: window, now: time.Now, entries: map[string]*idempotencyEntry{}}
+	return func(o *options) {
+		o.decorators = append(o.decorators, cache.decorator)
+	}
+}
+
+// idempotencyCache holds the results of recent create calls.
+type idempotencyCache struct {
+	mu      sync.Mutex
+	window  time.Duration
+	now     func() time.Time
+	entries map[string]*idempotencyEntry
+}
+
+// idempotencyEntry is a create call, either in flight or completed. done is closed when the call
+// completes; result is only set when it succeeded.
+type idempotencyEntry struct {
+	argsHash string
+	done     chan struct{}
+	result   *mcp.CallToolResult
+	expires  time.Time
+}
+
+// hashArgs returns a stable hash of the call arguments, ignoring the arguments added by decorators.
+func hashArgs(args map[string]any) string {
+	args = withoutArg(withoutArg(withoutArg(args, idempotencyKeyArg), timeoutArg), dryRunArg)
+	// Map keys are marshalled in sorted order, so equal arguments hash the same.
+	data, _ := json.Marshal(args)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// callerID identifies the token of the caller so calls of different users are never replayed to each other.
+func callerID(ctx context.Context) string {
+	auth, _ := ctx.Value(middleware.AuthKey{}).(string)
+	if auth == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(auth))
+	return hex.EncodeToString(sum[:])
+}
+
+// begin returns the entry of the call identified by key, creating it if none is live. owner is true
+// when the caller created the entry and must complete it.
+func (c *idempotencyCache) begin(key, argsHash string) (entry *idempotencyEntry, owner bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, e := range c.entries {
+		if e.result != nil && now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	if e, ok := c.entries[key]; ok {
+		return e, false
+	}
+	e := &idempotencyEntry{argsHash: argsHash, done: make(chan struct{})}
+	c.entries[key] = e
+	return e, true
+}
+
+// complete records the outcome of the call. Failed calls are forgotten so they can be retried.
+func (c *idempotencyCache) complete(key string, entry *idempotencyEntry, result *mcp.CallToolResult, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil && result != nil && !result.IsError {
+		entry.result = result
+		entry.expires = c.now().Add(c.window)
+	} else {
+		delete(c.entries, key)
+	}
+	close(entry.done)
+}
+
+func (c *idempotencyCache) decorator(tool server.ServerTool) server.ServerTool {
+	name := tool.Tool.Name
+	if _, ok := idempotentTools[name]; !ok {
+		return tool
+	}
+	addProperty(&tool.Tool, idempotencyKeyArg, map[string]any{
+		"type":        "string",
+		"description": fmt.Sprintf("Unique key for this create. Retrying with the same key within %s returns the original result instead of creating a duplicate", c.window),
+	})
+
+	next := tool.Handler
+	tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if dryRun, _ := req.GetArguments()[dryRunArg].(bool); dryRun {
+			return next(ctx, req)
+		}
+
+		argsHash := hashArgs(req.GetArguments())
+		key := name + "\x00" + callerID(ctx) + "\x00"
+		if idempotencyKey, _ := req.GetArguments()[idempotencyKeyArg].(string); idempotencyKey != "" {
+			key += "key:" + idempotencyKey
+		} else {
+			key += "args:" + argsHash
+		}
+
+		for {
+			entry, owner := c.begin(key, argsHash)
+			if owner {
+				result, err := next(ctx, req)
+				c.complete(key, entry, result, err)
+				return result, err
+			}
+			if entry.argsHash != argsHash {
+				return mcp.NewToolResultError(fmt.Sprintf("%s was already used with different arguments", idempotencyKeyArg)), nil
+			}
+			// Wait for an identical call in flight, then replay its result or, if it failed, retry.
+			select {
+			case <-entry.done:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if entry.result != nil {
+				return entry.result, nil
+			}
+		}
+	}
+	return tool
+}
@@ -0,0 +1,145 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	middleware "mcp-digitalocean/internal"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCreateTool returns a tool named name whose handler numbers each call, failing the calls
+// listed in failCalls.
+func countingCreateTool(name string, calls *atomic.Int32, failCalls ...int32) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(name),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			n := calls.Add(1)
+			for _, f := range failCalls {
+				if f == n {
+					return mcp.NewToolResultError("api error"), nil
+				}
+			}
+			return mcp.NewToolResultText(fmt.Sprintf("created %d", n)), nil
+		},
+	}
+}
+
+func newTestIdempotencyCache(now *time.Time) *idempotencyCache {
+	return &idempotencyCache{
+		window:  time.Minute,
+		now:     func() time.Time { return *now },
+		entries: map[string]*idempotencyEntry{},
+	}
+}
+
+func resultText(res *mcp.CallToolResult) string {
+	return res.Content[0].(mcp.TextContent).Text
+}
+
+func TestIdempotencyDecorator(t *testing.T) {
+	t.Run("replays identical calls within the window", func(t *testing.T) {
+		now := time.Now()
+		var calls atomic.Int32
+		tool := newTestIdempotencyCache(&now).decorator(countingCreateTool("droplet-create", &calls))
+		require.Contains(t, tool.Tool.InputSchema.Properties, idempotencyKeyArg)
+
+		args := map[string]any{"Name": "web-1", "Size": "s-1vcpu-1gb"}
+		require.Equal(t, "created 1", resultText(callTool(context.Background(), t, tool, args)))
+		require.Equal(t, "created 1", resultText(callTool(context.Background(), t, tool, map[string]any{"Size": "s-1vcpu-1gb", "Name": "web-1", "timeout_seconds": float64(60)})))
+		require.Equal(t, "created 2", resultText(callTool(context.Background(), t, tool, map[string]any{"Name": "web-2", "Size": "s-1vcpu-1gb"})))
+
+		now = now.Add(2 * time.Minute)
+		require.Equal(t, "created 3", resultText(callTool(context.Background(), t, tool, args)))
+	})
+
+	t.Run("idempotency key identifies the call", func(t *testing.T) {
+		now := time.Now()
+		var calls atomic.Int32
+		tool := newTestIdempotencyCache(&now).decorator(countingCreateTool("lb-create", &calls))
+
+		require.Equal(t, "created 1", resultText(callTool(context.Background(), t, tool, map[string]any{"Name": "lb", idempotencyKeyArg: "k1"})))
+		require.Equal(t, "created 1", resultText(callTool(context.Background(), t, tool, map[string]any{"Name": "lb", idempotencyKeyArg: "k1"})))
+		require.Equal(t, "created 2", resultText(callTool(context.Background(), t, tool, map[string]any{"Name": "lb", idempotencyKeyArg: "k2"})))
+
+		res := callTool(context.Background(), t, tool, map[string]any{"Name": "other", idempotencyKeyArg: "k1"})
+		require.True(t, res.IsError)
+		require.Contains(t, resultText(res), "already used with different arguments")
+	})
+
+	t.Run("failed calls are not replayed", func(t *testing.T) {
+		now := time.Now()
+		var calls atomic.Int32
+		tool := newTestIdempotencyCache(&now).decorator(countingCreateTool("db-cluster-create", &calls, 1))
+
+		args := map[string]any{"name": "db-1"}
+		require.True(t, callTool(context.Background(), t, tool, args).IsError)
+		require.Equal(t, "created 2", resultText(callTool(context.Background(), t, tool, args)))
+	})
+
+	t.Run("calls of different tokens are independent", func(t *testing.T) {
+		now := time.Now()
+		var calls atomic.Int32
+		tool := newTestIdempotencyCache(&now).decorator(countingCreateTool("droplet-create", &calls))
+
+		args := map[string]any{"Name": "web-1"}
+		alice := context.WithValue(context.Background(), middleware.AuthKey{}, "Bearer token-a")
+		bob := context.WithValue(context.Background(), middleware.AuthKey{}, "Bearer token-b")
+		require.Equal(t, "created 1", resultText(callTool(alice, t, tool, args)))
+		require.Equal(t, "created 2", resultText(callTool(bob, t, tool, args)))
+		require.Equal(t, "created 1", resultText(callTool(alice, t, tool, args)))
+	})
+
+	t.Run("concurrent identical calls create once", func(t *testing.T) {
+		now := time.Now()
+		var calls atomic.Int32
+		release := make(chan struct{})
+		slow := countingCreateTool("droplet-create", &calls)
+		next := slow.Handler
+		slow.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			<-release
+			return next(ctx, req)
+		}
+		tool := newTestIdempotencyCache(&now).decorator(slow)
+
+		var wg sync.WaitGroup
+		results := make([]*mcp.CallToolResult, 5)
+		for i := range results {
+			wg.Go(func() {
+				results[i], _ = tool.Handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Name": "web-1"}}})
+			})
+		}
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, int32(1), calls.Load())
+		for _, res := range results {
+			require.NotNil(t, res)
+			require.Equal(t, "created 1", resultText(res))
+		}
+	})
+
+	t.Run("dry runs and other tools are not cached", func(t *testing.T) {
+		now := time.Now()
+		var calls atomic.Int32
+		cache := newTestIdempotencyCache(&now)
+		tool := cache.decorator(countingCreateTool("droplet-create", &calls))
+
+		args := map[string]any{"Name": "web-1", dryRunArg: true}
+		require.Equal(t, "created 1", resultText(callTool(context.Background(), t, tool, args)))
+		require.Equal(t, "created 2", resultText(callTool(context.Background(), t, tool, args)))
+
+		other := cache.decorator(countingCreateTool("droplet-reboot", &calls))
+		require.NotContains(t, other.Tool.InputSchema.Properties, idempotencyKeyArg)
+		require.Equal(t, "created 3", resultText(callTool(context.Background(), t, other, map[string]any{"ID": float64(1)})))
+		require.Equal(t, "created 4", resultText(callTool(context.Background(), t, other, map[string]any{"ID": float64(1)})))
+	})
+}
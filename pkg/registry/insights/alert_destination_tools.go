@@ -0,0 +1,321 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"net/mail"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// alertDestinationTypes are the kinds of notification destinations alert policies support.
+var alertDestinationTypes = []string{"email", "slack"}
+
+// slackWebhookHost is the host of Slack incoming webhook URLs.
+const slackWebhookHost = "hooks.slack.com"
+
+// AlertDestinationTool manages the email addresses and Slack channels alert policies notify. The API
+// keeps them inline on each policy rather than as resources of their own, so a destination is the set
+// of policies notifying it.
+type AlertDestinationTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+}
+
+// NewAlertDestinationTool creates a new alert destination tool
+func NewAlertDestinationTool(client func(ctx context.Context) (*godo.Client, error)) *AlertDestinationTool {
+	return &AlertDestinationTool{
+		client: client,
+	}
+}
+
+// alertDestination is an email address or a Slack channel notified by alert policies.
+type alertDestination struct {
+	Type     string   `json:"type"`
+	Email    string   `json:"email,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	Channel  string   `json:"channel,omitempty"`
+	Policies []string `json:"policies,omitempty"`
+}
+
+// matches reports whether the destination is notified by the policy.
+func (d alertDestination) matches(alerts godo.Alerts) bool {
+	if d.Type == "email" {
+		return slices.ContainsFunc(alerts.Email, func(email string) bool { return strings.EqualFold(email, d.Email) })
+	}
+	return slices.ContainsFunc(alerts.Slack, d.matchesSlack)
+}
+
+func (d alertDestination) matchesSlack(slack godo.SlackDetails) bool {
+	return slack.URL == d.URL && (d.Channel == "" || slack.Channel == d.Channel)
+}
+
+// destinationArgs reads and validates the destination arguments. The Slack channel is only required
+// when adding a destination, a removed Slack destination matches every channel of its webhook URL
+// when the channel is omitted.
+func destinationArgs(args *common.Args, requireChannel bool) (alertDestination, error) {
+	d := alertDestination{Type: args.RequireEnum("Type", alertDestinationTypes...)}
+	email := args.OptionalString("Email", "")
+	slackURL := args.OptionalString("SlackURL", "")
+	channel := args.OptionalString("SlackChannel", "")
+	if err := args.Err(); err != nil {
+		return d, err
+	}
+	switch d.Type {
+	case "email":
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email {
+			return d, fmt.Errorf("Email must be a bare email address such as ops@example.com, got %q", email)
+		}
+		d.Email = email
+	case "slack":
+		u, err := url.Parse(slackURL)
+		if err != nil || u.Scheme != "https" || u.Host != slackWebhookHost || len(u.Path) <= 1 {
+			return d, fmt.Errorf("SlackURL must be a Slack incoming webhook URL such as https://%s/services/..., got %q", slackWebhookHost, slackURL)
+		}
+		channel = strings.TrimSpace(channel)
+		if requireChannel && channel == "" {
+			return d, fmt.Errorf("SlackChannel is required for a slack destination, e.g. #alerts")
+		}
+		d.URL, d.Channel = slackURL, channel
+	}
+	return d, nil
+}
+
+// listPolicies returns every alert policy of the account.
+func (a *AlertDestinationTool) listPolicies(ctx context.Context, client *godo.Client) ([]godo.AlertPolicy, error) {
+	var policies []godo.AlertPolicy
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		page, resp, err := client.Monitoring.ListAlertPolicies(ctx, opt)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, page...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+	return policies, nil
+}
+
+// policyUpdateRequest returns the update request keeping every setting of the policy but its alerts.
+func policyUpdateRequest(policy godo.AlertPolicy, alerts godo.Alerts) *godo.AlertPolicyUpdateRequest {
+	enabled := policy.Enabled
+	return &godo.AlertPolicyUpdateRequest{
+		Type:        policy.Type,
+		Description: policy.Description,
+		Compare:     policy.Compare,
+		Value:       policy.Value,
+		Window:      policy.Window,
+		Entities:    policy.Entities,
+		Tags:        policy.Tags,
+		Alerts:      alerts,
+		Enabled:     &enabled,
+	}
+}
+
+// listDestinations lists the distinct destinations notified by the alert policies.
+func (a *AlertDestinationTool) listDestinations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	policies, err := a.listPolicies(ctx, client)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	destinations := []*alertDestination{}
+	byKey := map[string]*alertDestination{}
+	add := func(key string, d alertDestination, policy string) {
+		if existing, ok := byKey[key]; ok {
+			if !slices.Contains(existing.Policies, policy) {
+				existing.Policies = append(existing.Policies, policy)
+			}
+			return
+		}
+		d.Policies = []string{policy}
+		byKey[key] = &d
+		destinations = append(destinations, &d)
+	}
+	for _, policy := range policies {
+		for _, email := range policy.Alerts.Email {
+			add("email|"+strings.ToLower(email), alertDestination{Type: "email", Email: email}, policy.UUID)
+		}
+		for _, slack := range policy.Alerts.Slack {
+			add("slack|"+slack.URL+"|"+slack.Channel, alertDestination{Type: "slack", URL: slack.URL, Channel: slack.Channel}, policy.UUID)
+		}
+	}
+
+	jsonData, err := response.CompactJSON(destinations)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// destinationChange is the result of alert-destination-create and alert-destination-delete.
+type destinationChange struct {
+	Destination alertDestination  `json:"destination"`
+	Updated     []string          `json:"updated"`
+	Unchanged   []string          `json:"unchanged"`
+	Skipped     map[string]string `json:"skipped,omitempty"`
+}
+
+// updatePolicies applies change to the alerts of each given policy, or of every policy notifying the
+// destination when uuids is empty, and saves the policies whose alerts changed. change returns the new alerts, whether they
+// changed, or a reason to skip the policy.
+func (a *AlertDestinationTool) updatePolicies(ctx context.Context, uuids []string, result *destinationChange, change func(godo.Alerts) (godo.Alerts, bool, string)) (*mcp.CallToolResult, error) {
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	var policies []godo.AlertPolicy
+	if len(uuids) == 0 {
+		all, err := a.listPolicies(ctx, client)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		for _, policy := range all {
+			if result.Destination.matches(policy.Alerts) {
+				policies = append(policies, policy)
+			}
+		}
+	} else {
+		for _, uuid := range uuids {
+			policy, _, err := client.Monitoring.GetAlertPolicy(ctx, uuid)
+			if err != nil {
+				return common.APIErrorResult(err, "alert policy", uuid), nil
+			}
+			policies = append(policies, *policy)
+		}
+	}
+
+	result.Updated, result.Unchanged = []string{}, []string{}
+	for _, policy := range policies {
+		alerts, changed, skip := change(policy.Alerts)
+		switch {
+		case skip != "":
+			if result.Skipped == nil {
+				result.Skipped = map[string]string{}
+			}
+			result.Skipped[policy.UUID] = skip
+		case !changed:
+			result.Unchanged = append(result.Unchanged, policy.UUID)
+		default:
+			if _, _, err := client.Monitoring.UpdateAlertPolicy(ctx, policy.UUID, policyUpdateRequest(policy, alerts)); err != nil {
+				return mcp.NewToolResultErrorFromErr(fmt.Sprintf("updated policies %v but failed to update policy %s", result.Updated, policy.UUID), err), nil
+			}
+			result.Updated = append(result.Updated, policy.UUID)
+		}
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// createDestination adds a destination to the notifications of the given alert policies.
+func (a *AlertDestinationTool) createDestination(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	uuids := args.OptionalStrings("PolicyUUIDs")
+	destination, err := destinationArgs(args, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(uuids) == 0 {
+		return mcp.NewToolResultError("PolicyUUIDs must list at least one alert policy to notify the destination"), nil
+	}
+
+	result := &destinationChange{Destination: destination}
+	return a.updatePolicies(ctx, uuids, result, func(alerts godo.Alerts) (godo.Alerts, bool, string) {
+		if destination.matches(alerts) {
+			return alerts, false, ""
+		}
+		if destination.Type == "email" {
+			alerts.Email = append(slices.Clone(alerts.Email), destination.Email)
+		} else {
+			alerts.Slack = append(slices.Clone(alerts.Slack), godo.SlackDetails{URL: destination.URL, Channel: destination.Channel})
+		}
+		return alerts, true, ""
+	})
+}
+
+// deleteDestination removes a destination from the notifications of the given alert policies, or of
+// every policy notifying it.
+func (a *AlertDestinationTool) deleteDestination(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	uuids := args.OptionalStrings("PolicyUUIDs")
+	destination, err := destinationArgs(args, false)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := &destinationChange{Destination: destination}
+	return a.updatePolicies(ctx, uuids, result, func(alerts godo.Alerts) (godo.Alerts, bool, string) {
+		if !destination.matches(alerts) {
+			return alerts, false, ""
+		}
+		remaining := alerts
+		if destination.Type == "email" {
+			remaining.Email = slices.DeleteFunc(slices.Clone(alerts.Email), func(email string) bool { return strings.EqualFold(email, destination.Email) })
+		} else {
+			remaining.Slack = slices.DeleteFunc(slices.Clone(alerts.Slack), destination.matchesSlack)
+		}
+		if len(remaining.Email) == 0 && len(remaining.Slack) == 0 {
+			// A policy notifying nobody would fire silently.
+			return alerts, false, "it is the only destination of the policy, add another destination or delete the policy"
+		}
+		return remaining, true, ""
+	})
+}
+
+// Tools returns a list of tool functions
+func (a *AlertDestinationTool) Tools() []server.ServerTool {
+	destinationOptions := func(channelDescription string) []mcp.ToolOption {
+		return []mcp.ToolOption{
+			mcp.WithString("Type", mcp.Required(), mcp.Enum(alertDestinationTypes...), mcp.Description("Kind of destination")),
+			mcp.WithString("Email", mcp.Description("Email address, for an email destination")),
+			mcp.WithString("SlackURL", mcp.Description("Slack incoming webhook URL (https://hooks.slack.com/services/...), for a slack destination")),
+			mcp.WithString("SlackChannel", mcp.Description(channelDescription)),
+		}
+	}
+	return []server.ServerTool{
+		{
+			Handler: a.listDestinations,
+			Tool: mcp.NewTool("alert-destination-list",
+				mcp.WithDescription("List the email addresses and Slack channels notified by alert policies, each with the UUIDs of the policies notifying it"),
+			),
+		},
+		{
+			Handler: a.createDestination,
+			Tool: mcp.NewTool("alert-destination-create",
+				append([]mcp.ToolOption{
+					mcp.WithDescription("Add an email address or Slack channel to the notifications of one or more alert policies, so they share the destination. Alert policies store their destinations inline, there is no standalone destination resource"),
+					mcp.WithArray("PolicyUUIDs", mcp.Required(), mcp.Description("UUIDs of the alert policies to notify the destination"), mcp.Items(map[string]any{"type": "string"})),
+				}, destinationOptions("Slack channel, e.g. #alerts, required for a slack destination")...)...,
+			),
+		},
+		{
+			Handler: a.deleteDestination,
+			Tool: mcp.NewTool("alert-destination-delete",
+				append([]mcp.ToolOption{
+					mcp.WithDescription("Remove an email address or Slack channel from the notifications of alert policies. Policies for which it is the only destination are skipped"),
+					mcp.WithArray("PolicyUUIDs", mcp.Description("UUIDs of the alert policies to update, every policy notifying the destination when omitted"), mcp.Items(map[string]any{"type": "string"})),
+				}, destinationOptions("Slack channel, every channel of the webhook URL when omitted")...)...,
+			),
+		},
+	}
+}
@@ -0,0 +1,172 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const testSlackURL = "https://hooks.slack.com/services/T000/B000/XXXX"
+
+func setupAlertDestinationToolWithMock(mockMonitoring *MockMonitoringService) *AlertDestinationTool {
+	client := func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Monitoring: mockMonitoring}, nil
+	}
+	return NewAlertDestinationTool(client)
+}
+
+// testAlertPolicies returns a cpu policy notifying ops by email and Slack and a memory policy only
+// notifying ops by email.
+func testAlertPolicies() []godo.AlertPolicy {
+	return []godo.AlertPolicy{
+		{
+			UUID: "cpu", Type: "v1/insights/droplet/cpu", Description: "cpu", Compare: godo.GreaterThan, Value: 80, Window: "5m",
+			Tags: []string{"web"}, Enabled: true,
+			Alerts: godo.Alerts{Email: []string{"ops@example.com"}, Slack: []godo.SlackDetails{{URL: testSlackURL, Channel: "#ops"}}},
+		},
+		{
+			UUID: "memory", Type: "v1/insights/droplet/memory_utilization", Description: "memory", Compare: godo.GreaterThan, Value: 90, Window: "10m",
+			Entities: []string{"123"},
+			Alerts:   godo.Alerts{Email: []string{"OPS@example.com"}},
+		},
+	}
+}
+
+func TestAlertDestinationTool_listDestinations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMonitoring := NewMockMonitoringService(ctrl)
+	mockMonitoring.EXPECT().ListAlertPolicies(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+		Return(testAlertPolicies(), &godo.Response{Links: &godo.Links{}}, nil)
+	tool := setupAlertDestinationToolWithMock(mockMonitoring)
+
+	resp, err := tool.listDestinations(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var destinations []alertDestination
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &destinations))
+	require.Equal(t, []alertDestination{
+		{Type: "email", Email: "ops@example.com", Policies: []string{"cpu", "memory"}},
+		{Type: "slack", URL: testSlackURL, Channel: "#ops", Policies: []string{"cpu"}},
+	}, destinations)
+}
+
+func TestAlertDestinationTool_createDestination(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockMonitoringService)
+		expectError string
+		expect      destinationChange
+	}{
+		{
+			name: "Adds a Slack channel to the policies missing it",
+			args: map[string]any{"Type": "slack", "SlackURL": testSlackURL, "SlackChannel": "#ops", "PolicyUUIDs": []any{"cpu", "memory"}},
+			mockSetup: func(m *MockMonitoringService) {
+				policies := testAlertPolicies()
+				m.EXPECT().GetAlertPolicy(gomock.Any(), "cpu").Return(&policies[0], nil, nil)
+				m.EXPECT().GetAlertPolicy(gomock.Any(), "memory").Return(&policies[1], nil, nil)
+				disabled := false
+				m.EXPECT().UpdateAlertPolicy(gomock.Any(), "memory", &godo.AlertPolicyUpdateRequest{
+					Type: "v1/insights/droplet/memory_utilization", Description: "memory", Compare: godo.GreaterThan, Value: 90, Window: "10m",
+					Entities: []string{"123"}, Enabled: &disabled,
+					Alerts: godo.Alerts{Email: []string{"OPS@example.com"}, Slack: []godo.SlackDetails{{URL: testSlackURL, Channel: "#ops"}}},
+				}).Return(&policies[1], nil, nil)
+			},
+			expect: destinationChange{
+				Destination: alertDestination{Type: "slack", URL: testSlackURL, Channel: "#ops"},
+				Updated:     []string{"memory"},
+				Unchanged:   []string{"cpu"},
+			},
+		},
+		{
+			name:        "Invalid email",
+			args:        map[string]any{"Type": "email", "Email": "Ops <ops@example.com>", "PolicyUUIDs": []any{"cpu"}},
+			expectError: "Email must be a bare email address",
+		},
+		{
+			name:        "Not a Slack webhook",
+			args:        map[string]any{"Type": "slack", "SlackURL": "http://example.com/hook", "SlackChannel": "#ops", "PolicyUUIDs": []any{"cpu"}},
+			expectError: "SlackURL must be a Slack incoming webhook URL",
+		},
+		{
+			name:        "Missing channel",
+			args:        map[string]any{"Type": "slack", "SlackURL": testSlackURL, "PolicyUUIDs": []any{"cpu"}},
+			expectError: "SlackChannel is required",
+		},
+		{
+			name:        "Missing policies",
+			args:        map[string]any{"Type": "email", "Email": "ops@example.com"},
+			expectError: "PolicyUUIDs must list at least one alert policy",
+		},
+		{
+			name: "Update fails",
+			args: map[string]any{"Type": "email", "Email": "dev@example.com", "PolicyUUIDs": []any{"cpu"}},
+			mockSetup: func(m *MockMonitoringService) {
+				policies := testAlertPolicies()
+				m.EXPECT().GetAlertPolicy(gomock.Any(), "cpu").Return(&policies[0], nil, nil)
+				m.EXPECT().UpdateAlertPolicy(gomock.Any(), "cpu", gomock.Any()).Return(nil, nil, errors.New("boom"))
+			},
+			expectError: "updated policies [] but failed to update policy cpu",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockMonitoring := NewMockMonitoringService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockMonitoring)
+			}
+			tool := setupAlertDestinationToolWithMock(mockMonitoring)
+
+			resp, err := tool.createDestination(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError, text)
+
+			var got destinationChange
+			require.NoError(t, json.Unmarshal([]byte(text), &got))
+			require.Equal(t, tc.expect, got)
+		})
+	}
+}
+
+func TestAlertDestinationTool_deleteDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockMonitoring := NewMockMonitoringService(ctrl)
+	mockMonitoring.EXPECT().ListAlertPolicies(gomock.Any(), gomock.Any()).Return(testAlertPolicies(), &godo.Response{Links: &godo.Links{}}, nil)
+	enabled := true
+	mockMonitoring.EXPECT().UpdateAlertPolicy(gomock.Any(), "cpu", &godo.AlertPolicyUpdateRequest{
+		Type: "v1/insights/droplet/cpu", Description: "cpu", Compare: godo.GreaterThan, Value: 80, Window: "5m",
+		Tags: []string{"web"}, Enabled: &enabled,
+		Alerts: godo.Alerts{Email: []string{}, Slack: []godo.SlackDetails{{URL: testSlackURL, Channel: "#ops"}}},
+	}).Return(nil, nil, nil)
+	tool := setupAlertDestinationToolWithMock(mockMonitoring)
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Type": "email", "Email": "ops@example.com"}}}
+	resp, err := tool.deleteDestination(context.Background(), req)
+	require.NoError(t, err)
+	text := resp.Content[0].(mcp.TextContent).Text
+	require.False(t, resp.IsError, text)
+
+	var got destinationChange
+	require.NoError(t, json.Unmarshal([]byte(text), &got))
+	require.Equal(t, destinationChange{
+		Destination: alertDestination{Type: "email", Email: "ops@example.com"},
+		Updated:     []string{"cpu"},
+		Unchanged:   []string{},
+		Skipped:     map[string]string{"memory": "it is the only destination of the policy, add another destination or delete the policy"},
+	}, got)
+}
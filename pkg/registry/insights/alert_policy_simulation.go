@@ -0,0 +1,242 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultAlertTestHours = 24
+	maxAlertTestHours     = 7 * 24
+)
+
+// dropletMetricFetchers fetch, for the droplet alert policy types that can be tested, the metric
+// the policy compares with its value.
+var dropletMetricFetchers = map[string]func(ctx context.Context, client *godo.Client, req *godo.DropletMetricsRequest) ([]metricPoint, error){
+	"v1/insights/droplet/cpu": func(ctx context.Context, client *godo.Client, req *godo.DropletMetricsRequest) ([]metricPoint, error) {
+		resp, _, err := client.Monitoring.GetDropletCPU(ctx, req)
+		return cpuSeries(resp), err
+	},
+	"v1/insights/droplet/memory_utilization_percent": memoryUtilizationPoints,
+	"v1/insights/droplet/disk_utilization_percent":   diskUtilizationPoints,
+	"v1/insights/droplet/load_1": func(ctx context.Context, client *godo.Client, req *godo.DropletMetricsRequest) ([]metricPoint, error) {
+		resp, _, err := client.Monitoring.GetDropletLoad1(ctx, req)
+		return gaugeSeries(resp), err
+	},
+	"v1/insights/droplet/load_5": func(ctx context.Context, client *godo.Client, req *godo.DropletMetricsRequest) ([]metricPoint, error) {
+		resp, _, err := client.Monitoring.GetDropletLoad5(ctx, req)
+		return gaugeSeries(resp), err
+	},
+	"v1/insights/droplet/load_15": func(ctx context.Context, client *godo.Client, req *godo.DropletMetricsRequest) ([]metricPoint, error) {
+		resp, _, err := client.Monitoring.GetDropletLoad15(ctx, req)
+		return gaugeSeries(resp), err
+	},
+}
+
+func memoryUtilizationPoints(ctx context.Context, client *godo.Client, req *godo.DropletMetricsRequest) ([]metricPoint, error) {
+	total, _, err := client.Monitoring.GetDropletTotalMemory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	available, _, err := client.Monitoring.GetDropletAvailableMemory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return usedPercentSeries(total, available), nil
+}
+
+func diskUtilizationPoints(ctx context.Context, client *godo.Client, req *godo.DropletMetricsRequest) ([]metricPoint, error) {
+	size, _, err := client.Monitoring.GetDropletFilesystemSize(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	free, _, err := client.Monitoring.GetDropletFilesystemFree(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return usedPercentSeries(size, free), nil
+}
+
+// alertDataPoint is a metric sample evaluated against an alert policy.
+type alertDataPoint struct {
+	metricPoint
+	// Breaching is whether the sample is beyond the policy's value.
+	Breaching bool `json:"breaching"`
+	// Firing is whether the policy would be firing at the time of the sample, every sample of the
+	// window before it breaching too.
+	Firing bool `json:"firing"`
+}
+
+// alertPolicyTest is the result of alert-policy-test.
+type alertPolicyTest struct {
+	DropletID  int                  `json:"droplet_id"`
+	Type       string               `json:"type"`
+	Compare    godo.AlertPolicyComp `json:"compare"`
+	Value      float32              `json:"value"`
+	Window     string               `json:"window"`
+	Firing     bool                 `json:"firing"`
+	LastFiring *time.Time           `json:"last_firing,omitempty"`
+	Datapoints []alertDataPoint     `json:"datapoints"`
+}
+
+// evaluateAlertPolicy marks the samples beyond value and those at which a policy would be firing:
+// when the samples of the whole window up to them breach. A sample only fires once the series
+// covers a full window before it.
+func evaluateAlertPolicy(points []metricPoint, compare godo.AlertPolicyComp, value float32, window time.Duration) []alertDataPoint {
+	out := make([]alertDataPoint, len(points))
+	streakStart := -1
+	for i, p := range points {
+		breaching := p.Value > float64(value)
+		if compare == godo.LessThan {
+			breaching = p.Value < float64(value)
+		}
+		out[i] = alertDataPoint{metricPoint: p, Breaching: breaching}
+		if !breaching {
+			streakStart = -1
+			continue
+		}
+		if streakStart < 0 {
+			streakStart = i
+		}
+		// The samples of the window are all breaching when the streak started at or before the
+		// start of the window, or started with the series itself while it spans the window.
+		windowStart := p.Time.Add(-window)
+		switch {
+		case streakStart > 0 && !points[streakStart-1].Time.After(windowStart):
+			out[i].Firing = true
+		case streakStart == 0 && !points[0].Time.After(windowStart):
+			out[i].Firing = true
+		}
+	}
+	return out
+}
+
+// testAlertPolicy evaluates a droplet alert policy, given by UUID or by its thresholds, against the
+// recent metrics of a droplet. It reports whether the policy would be firing now and when it last
+// was, so thresholds can be validated without waiting for a real alert.
+func (a *AlertPolicyTool) testAlertPolicy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	dropletID := args.RequireInt("DropletID")
+	uuid := args.OptionalString("UUID", "")
+	hours := args.OptionalInt("Hours", defaultAlertTestHours)
+	policy := godo.AlertPolicy{
+		Type:    args.OptionalString("Type", ""),
+		Compare: godo.AlertPolicyComp(args.OptionalEnum("Compare", "", string(godo.GreaterThan), string(godo.LessThan))),
+		Window:  args.OptionalEnum("Window", "", alertPolicyWindows...),
+	}
+	value, hasValue := req.GetArguments()["Value"].(float64)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if hours < 1 || hours > maxAlertTestHours {
+		return mcp.NewToolResultError(fmt.Sprintf("Hours must be between 1 and %d", maxAlertTestHours)), nil
+	}
+	if uuid == "" && (policy.Type == "" || policy.Compare == "" || policy.Window == "" || !hasValue) {
+		return mcp.NewToolResultError("either UUID, or Type, Compare, Value and Window are required"), nil
+	}
+
+	client, err := a.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	if uuid != "" {
+		stored, _, err := client.Monitoring.GetAlertPolicy(ctx, uuid)
+		if err != nil {
+			return common.APIErrorResult(err, "alert policy", uuid), nil
+		}
+		// Thresholds given alongside the UUID override those of the policy, to try out new ones.
+		if policy.Type == "" {
+			policy.Type = stored.Type
+		}
+		if policy.Compare == "" {
+			policy.Compare = stored.Compare
+		}
+		if policy.Window == "" {
+			policy.Window = stored.Window
+		}
+		if !hasValue {
+			value = float64(stored.Value)
+		}
+	}
+	policy.Value = float32(value)
+
+	fetch, ok := dropletMetricFetchers[policy.Type]
+	if !ok {
+		types := make([]string, 0, len(dropletMetricFetchers))
+		for t := range dropletMetricFetchers {
+			types = append(types, t)
+		}
+		slices.Sort(types)
+		return mcp.NewToolResultError(fmt.Sprintf("policies of type %s can't be tested, supported types are: %s", policy.Type, strings.Join(types, ", "))), nil
+	}
+	window, err := time.ParseDuration(policy.Window)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid policy window %q", policy.Window)), nil
+	}
+
+	end := a.now()
+	points, err := fetch(ctx, client, &godo.DropletMetricsRequest{
+		HostID: fmt.Sprint(dropletID),
+		Start:  end.Add(-time.Duration(hours) * time.Hour),
+		End:    end,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	if len(points) == 0 {
+		return noMetricsResult(dropletID, fmt.Sprintf("%d hours", hours)), nil
+	}
+
+	result := alertPolicyTest{
+		DropletID:  dropletID,
+		Type:       policy.Type,
+		Compare:    policy.Compare,
+		Value:      policy.Value,
+		Window:     policy.Window,
+		Datapoints: evaluateAlertPolicy(points, policy.Compare, policy.Value, window),
+	}
+	for i := len(result.Datapoints) - 1; i >= 0; i-- {
+		if result.Datapoints[i].Firing {
+			last := result.Datapoints[i].Time
+			result.LastFiring = &last
+			result.Firing = i == len(result.Datapoints)-1
+			break
+		}
+	}
+
+	jsonResult, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonResult), nil
+}
+
+// SimulationTools returns the tools evaluating alert policies against past metrics.
+func (a *AlertPolicyTool) SimulationTools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: a.testAlertPolicy,
+			Tool: mcp.NewTool("alert-policy-test",
+				mcp.WithDescription("Evaluate a droplet alert policy against a droplet's recent metrics, to validate its thresholds without waiting for a real alert. Give an existing policy's UUID, its thresholds, or both to override some. Returns whether the policy would be firing now, when it last would have, and the evaluated datapoints. Supports the cpu, memory, disk and load policy types. Requires the metrics agent on the droplet."),
+				mcp.WithNumber("DropletID", mcp.Required(), mcp.Description("ID of the droplet to evaluate the policy against")),
+				mcp.WithString("UUID", mcp.Description("UUID of an existing alert policy to test")),
+				mcp.WithString("Type", mcp.Description("Type of the policy, e.g. v1/insights/droplet/cpu or v1/insights/droplet/memory_utilization_percent")),
+				mcp.WithString("Compare", mcp.Enum(string(godo.GreaterThan), string(godo.LessThan)), mcp.Description("Comparison operator: 'GreaterThan' or 'LessThan'")),
+				mcp.WithNumber("Value", mcp.Description("Threshold value (e.g., 80 for 80% CPU)")),
+				mcp.WithString("Window", mcp.Enum(alertPolicyWindows...), mcp.Description("How long the metric must be beyond the value for the policy to fire")),
+				mcp.WithNumber("Hours", mcp.DefaultNumber(defaultAlertTestHours), mcp.Min(1), mcp.Max(maxAlertTestHours), mcp.Description("Number of past hours of metrics to evaluate")),
+				mcp.WithReadOnlyHintAnnotation(true),
+			),
+		},
+	}
+}
@@ -0,0 +1,212 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateAlertPolicy(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	// series returns samples five minutes apart.
+	series := func(values ...float64) []metricPoint {
+		points := make([]metricPoint, len(values))
+		for i, v := range values {
+			points[i] = metricPoint{Time: start.Add(time.Duration(i) * 5 * time.Minute), Value: v}
+		}
+		return points
+	}
+	firing := func(points []alertDataPoint) []bool {
+		out := make([]bool, len(points))
+		for i, p := range points {
+			out[i] = p.Firing
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		points  []metricPoint
+		compare godo.AlertPolicyComp
+		window  time.Duration
+		expect  []bool
+	}{
+		{
+			name:    "Fires once the window breaches",
+			points:  series(90, 90, 90, 90),
+			compare: godo.GreaterThan,
+			window:  10 * time.Minute,
+			expect:  []bool{false, false, true, true},
+		},
+		{
+			name:    "Dip resets the window",
+			points:  series(10, 90, 90, 10, 90, 90),
+			compare: godo.GreaterThan,
+			window:  10 * time.Minute,
+			expect:  []bool{false, false, true, false, false, true},
+		},
+		{
+			name:    "Window shorter than the step",
+			points:  series(10, 90, 10),
+			compare: godo.GreaterThan,
+			window:  time.Minute,
+			expect:  []bool{false, true, false},
+		},
+		{
+			name:    "LessThan",
+			points:  series(5, 5, 90),
+			compare: godo.LessThan,
+			window:  5 * time.Minute,
+			expect:  []bool{false, true, false},
+		},
+		{
+			name:    "Equal to the value does not breach",
+			points:  series(80, 80, 80),
+			compare: godo.GreaterThan,
+			window:  5 * time.Minute,
+			expect:  []bool{false, false, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := evaluateAlertPolicy(tc.points, tc.compare, 80, tc.window)
+			require.Equal(t, tc.expect, firing(got))
+		})
+	}
+}
+
+// alertTestServer answers for droplet 1, whose CPU is busy the given percent of each five minutes
+// after the first sample, and for alert policy p1, firing over 80% CPU for 10 minutes.
+func alertTestServer(t *testing.T, cpuBusy ...float64) *AlertPolicyTool {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+	idle, user := []string{fmt.Sprintf(`[%d,"0"]`, start)}, []string{fmt.Sprintf(`[%d,"0"]`, start)}
+	var idleTotal, userTotal float64
+	for i, busy := range cpuBusy {
+		ts := start + int64(i+1)*300
+		idleTotal += 300 * (100 - busy) / 100
+		userTotal += 300 * busy / 100
+		idle = append(idle, fmt.Sprintf(`[%d,"%g"]`, ts, idleTotal))
+		user = append(user, fmt.Sprintf(`[%d,"%g"]`, ts, userTotal))
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body string
+		switch {
+		case r.URL.Path == "/v2/monitoring/alerts/p1":
+			body = `{"policy":{"uuid":"p1","type":"v1/insights/droplet/cpu","compare":"GreaterThan","value":80,"window":"10m","entities":["1"]}}`
+		case r.URL.Path == "/v2/monitoring/metrics/droplet/cpu" && r.URL.Query().Get("host_id") == "1" && len(cpuBusy) > 0:
+			body = `{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{"mode":"idle"},"values":[` + strings.Join(idle, ",") + `]},` +
+				`{"metric":{"mode":"user"},"values":[` + strings.Join(user, ",") + `]}]}}`
+		case strings.HasPrefix(r.URL.Path, "/v2/monitoring/metrics/droplet/"):
+			body = `{"status":"success","data":{"resultType":"matrix","result":[]}}`
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			body = `{"id":"not_found","message":"The resource you were accessing could not be found."}`
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client := godo.NewClient(srv.Client())
+	client.BaseURL = baseURL
+
+	tool := NewAlertPolicyTool(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	})
+	tool.now = func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return tool
+}
+
+func TestAlertPolicyTool_testAlertPolicy(t *testing.T) {
+	lastFiring := time.Date(2025, 3, 1, 0, 15, 0, 0, time.UTC)
+	tests := []struct {
+		name             string
+		cpuBusy          []float64
+		args             map[string]any
+		expectFiring     bool
+		expectLastFiring *time.Time
+		expectPoints     int
+		expectError      string
+	}{
+		{
+			name:         "Policy firing",
+			cpuBusy:      []float64{50, 90, 95, 90},
+			args:         map[string]any{"DropletID": float64(1), "UUID": "p1"},
+			expectFiring: true,
+			// The policy fires from the third sample, 10 minutes after the first breach.
+			expectLastFiring: func() *time.Time { t := time.Date(2025, 3, 1, 0, 20, 0, 0, time.UTC); return &t }(),
+			expectPoints:     4,
+		},
+		{
+			name:             "Policy fired earlier",
+			cpuBusy:          []float64{90, 90, 90, 50},
+			args:             map[string]any{"DropletID": float64(1), "UUID": "p1"},
+			expectLastFiring: &lastFiring,
+			expectPoints:     4,
+		},
+		{
+			name:         "Thresholds override the policy",
+			cpuBusy:      []float64{90, 90, 90, 50},
+			args:         map[string]any{"DropletID": float64(1), "UUID": "p1", "Value": float64(95)},
+			expectPoints: 4,
+		},
+		{
+			name:         "Thresholds without policy",
+			cpuBusy:      []float64{10, 10},
+			args:         map[string]any{"DropletID": float64(1), "Type": "v1/insights/droplet/cpu", "Compare": "LessThan", "Value": float64(20), "Window": "5m"},
+			expectFiring: true,
+			expectLastFiring: func() *time.Time {
+				t := time.Date(2025, 3, 1, 0, 10, 0, 0, time.UTC)
+				return &t
+			}(),
+			expectPoints: 2,
+		},
+		{name: "Monitoring not enabled", args: map[string]any{"DropletID": float64(1), "UUID": "p1"}, expectError: "enable monitoring"},
+		{name: "Policy not found", args: map[string]any{"DropletID": float64(1), "UUID": "p2"}, expectError: "resource not found"},
+		{
+			name:        "Unsupported type",
+			args:        map[string]any{"DropletID": float64(1), "Type": "v1/insights/droplet/public_outbound_bandwidth", "Compare": "GreaterThan", "Value": float64(1), "Window": "5m"},
+			expectError: "can't be tested",
+		},
+		{name: "Missing thresholds", args: map[string]any{"DropletID": float64(1), "Type": "v1/insights/droplet/cpu"}, expectError: "either UUID, or Type, Compare, Value and Window are required"},
+		{name: "Hours out of range", args: map[string]any{"DropletID": float64(1), "UUID": "p1", "Hours": float64(500)}, expectError: "Hours must be between 1 and 168"},
+		{name: "Missing DropletID", args: map[string]any{"UUID": "p1"}, expectError: "argument 'DropletID' is required"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := alertTestServer(t, tc.cpuBusy...)
+			res, err := tool.testAlertPolicy(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := res.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, res.IsError, text)
+
+			var got alertPolicyTest
+			require.NoError(t, json.Unmarshal([]byte(text), &got))
+			require.Equal(t, 1, got.DropletID)
+			require.Equal(t, tc.expectFiring, got.Firing)
+			require.Equal(t, tc.expectLastFiring, got.LastFiring)
+			require.Len(t, got.Datapoints, tc.expectPoints)
+		})
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -18,12 +21,14 @@ const (
 // AlertPolicyTool provides alert policy management tools
 type AlertPolicyTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
+	now    func() time.Time
 }
 
 // NewAlertPolicyTool creates a new alert policy tool
 func NewAlertPolicyTool(client func(ctx context.Context) (*godo.Client, error)) *AlertPolicyTool {
 	return &AlertPolicyTool{
 		client: client,
+		now:    time.Now,
 	}
 }
 
@@ -81,56 +86,69 @@ func (a *AlertPolicyTool) listAlertPolicies(ctx context.Context, req mcp.CallToo
 	return mcp.NewToolResultText(jsonAlertPolicies), nil
 }
 
-// createAlertPolicy creates a new alert policy
-func (a *AlertPolicyTool) createAlertPolicy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	alertType := req.GetArguments()["Type"].(string)
-	description := req.GetArguments()["Description"].(string)
-	compare := godo.AlertPolicyComp(req.GetArguments()["Compare"].(string))
-	value := float32(req.GetArguments()["Value"].(float64))
-	window := req.GetArguments()["Window"].(string)
-
-	// Parse entities array
-	rawEntities, _ := req.GetArguments()["Entities"]
-	var entities []string
-	if arr, ok := rawEntities.([]interface{}); ok {
+// alertPolicyWindows are the evaluation windows supported by alert policies.
+var alertPolicyWindows = []string{"5m", "10m", "30m", "1h"}
+
+// stringArg reads a string argument, returning an empty string when it is missing or not a string.
+func stringArg(args map[string]any, key string) string {
+	v, _ := args[key].(string)
+	return v
+}
+
+// stringSliceArg reads an array argument of strings, skipping non-string items.
+func stringSliceArg(args map[string]any, key string) []string {
+	var out []string
+	if arr, ok := args[key].([]any); ok {
 		for _, v := range arr {
-			if s, ok := v.(string); ok {
-				entities = append(entities, s)
+			if s, ok := v.(string); ok && s != "" {
+				out = append(out, s)
 			}
 		}
 	}
+	return out
+}
 
-	// Parse tags array
-	rawTags, _ := req.GetArguments()["Tags"]
-	var tags []string
-	if arr, ok := rawTags.([]interface{}); ok {
-		for _, v := range arr {
-			if s, ok := v.(string); ok {
-				tags = append(tags, s)
-			}
-		}
+// alertPolicyRequestFromArgs parses and validates the alert policy arguments shared by create and update.
+func alertPolicyRequestFromArgs(args map[string]any) (*godo.AlertPolicyCreateRequest, error) {
+	alertType, _ := args["Type"].(string)
+	if alertType == "" {
+		return nil, fmt.Errorf("Type is required")
+	}
+	description, _ := args["Description"].(string)
+	if description == "" {
+		return nil, fmt.Errorf("Description is required")
+	}
+	compare := godo.AlertPolicyComp(stringArg(args, "Compare"))
+	if compare != godo.GreaterThan && compare != godo.LessThan {
+		return nil, fmt.Errorf("Compare must be GreaterThan or LessThan")
+	}
+	value, ok := args["Value"].(float64)
+	if !ok {
+		return nil, fmt.Errorf("Value is required")
+	}
+	window, _ := args["Window"].(string)
+	if !slices.Contains(alertPolicyWindows, window) {
+		return nil, fmt.Errorf("Window must be one of %s", strings.Join(alertPolicyWindows, ", "))
+	}
+
+	entities := stringSliceArg(args, "Entities")
+	tags := stringSliceArg(args, "Tags")
+	if len(entities) == 0 && len(tags) == 0 {
+		return nil, fmt.Errorf("at least one of Entities or Tags is required to select the resources to monitor")
 	}
 
 	// Parse alerts
-	rawAlerts, _ := req.GetArguments()["Alerts"]
 	var alerts godo.Alerts
-	if alertsMap, ok := rawAlerts.(map[string]interface{}); ok {
-		// Parse email alerts
-		if rawEmails, ok := alertsMap["Email"].([]interface{}); ok {
-			for _, v := range rawEmails {
-				if email, ok := v.(string); ok {
-					alerts.Email = append(alerts.Email, email)
-				}
-			}
-		}
+	if alertsMap, ok := args["Alerts"].(map[string]any); ok {
+		alerts.Email = stringSliceArg(alertsMap, "Email")
 
 		// Parse Slack alerts
-		if rawSlack, ok := alertsMap["Slack"].([]interface{}); ok {
+		if rawSlack, ok := alertsMap["Slack"].([]any); ok {
 			for _, v := range rawSlack {
-				if slackMap, ok := v.(map[string]interface{}); ok {
+				if slackMap, ok := v.(map[string]any); ok {
 					slackDetails := godo.SlackDetails{
-						URL:     slackMap["URL"].(string),
-						Channel: slackMap["Channel"].(string),
+						URL:     stringArg(slackMap, "URL"),
+						Channel: stringArg(slackMap, "Channel"),
 					}
 					alerts.Slack = append(alerts.Slack, slackDetails)
 				}
@@ -139,20 +157,28 @@ func (a *AlertPolicyTool) createAlertPolicy(ctx context.Context, req mcp.CallToo
 	}
 
 	enabled := true
-	if v, ok := req.GetArguments()["Enabled"].(bool); ok {
+	if v, ok := args["Enabled"].(bool); ok {
 		enabled = v
 	}
 
-	createRequest := &godo.AlertPolicyCreateRequest{
+	return &godo.AlertPolicyCreateRequest{
 		Type:        alertType,
 		Description: description,
 		Compare:     compare,
-		Value:       value,
+		Value:       float32(value),
 		Window:      window,
 		Entities:    entities,
 		Tags:        tags,
 		Alerts:      alerts,
 		Enabled:     &enabled,
+	}, nil
+}
+
+// createAlertPolicy creates a new alert policy
+func (a *AlertPolicyTool) createAlertPolicy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	createRequest, err := alertPolicyRequestFromArgs(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	client, err := a.client(ctx)
@@ -180,76 +206,20 @@ func (a *AlertPolicyTool) updateAlertPolicy(ctx context.Context, req mcp.CallToo
 		return mcp.NewToolResultError("Alert Policy UUID is required"), nil
 	}
 
-	alertType := req.GetArguments()["Type"].(string)
-	description := req.GetArguments()["Description"].(string)
-	compare := godo.AlertPolicyComp(req.GetArguments()["Compare"].(string))
-	value := float32(req.GetArguments()["Value"].(float64))
-	window := req.GetArguments()["Window"].(string)
-
-	// Parse entities array
-	rawEntities, _ := req.GetArguments()["Entities"]
-	var entities []string
-	if arr, ok := rawEntities.([]interface{}); ok {
-		for _, v := range arr {
-			if s, ok := v.(string); ok {
-				entities = append(entities, s)
-			}
-		}
-	}
-
-	// Parse tags array
-	rawTags, _ := req.GetArguments()["Tags"]
-	var tags []string
-	if arr, ok := rawTags.([]interface{}); ok {
-		for _, v := range arr {
-			if s, ok := v.(string); ok {
-				tags = append(tags, s)
-			}
-		}
-	}
-
-	// Parse alerts
-	rawAlerts, _ := req.GetArguments()["Alerts"]
-	var alerts godo.Alerts
-	if alertsMap, ok := rawAlerts.(map[string]interface{}); ok {
-		// Parse email alerts
-		if rawEmails, ok := alertsMap["Email"].([]interface{}); ok {
-			for _, v := range rawEmails {
-				if email, ok := v.(string); ok {
-					alerts.Email = append(alerts.Email, email)
-				}
-			}
-		}
-
-		// Parse Slack alerts
-		if rawSlack, ok := alertsMap["Slack"].([]interface{}); ok {
-			for _, v := range rawSlack {
-				if slackMap, ok := v.(map[string]interface{}); ok {
-					slackDetails := godo.SlackDetails{
-						URL:     slackMap["URL"].(string),
-						Channel: slackMap["Channel"].(string),
-					}
-					alerts.Slack = append(alerts.Slack, slackDetails)
-				}
-			}
-		}
-	}
-
-	enabled := true
-	if v, ok := req.GetArguments()["Enabled"].(bool); ok {
-		enabled = v
+	spec, err := alertPolicyRequestFromArgs(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-
 	updateRequest := &godo.AlertPolicyUpdateRequest{
-		Type:        alertType,
-		Description: description,
-		Compare:     compare,
-		Value:       value,
-		Window:      window,
-		Entities:    entities,
-		Tags:        tags,
-		Alerts:      alerts,
-		Enabled:     &enabled,
+		Type:        spec.Type,
+		Description: spec.Description,
+		Compare:     spec.Compare,
+		Value:       spec.Value,
+		Window:      spec.Window,
+		Entities:    spec.Entities,
+		Tags:        spec.Tags,
+		Alerts:      spec.Alerts,
+		Enabled:     spec.Enabled,
 	}
 
 	client, err := a.client(ctx)
@@ -311,7 +281,7 @@ func (c *AlertPolicyTool) Tools() []server.ServerTool {
 		{
 			Handler: c.createAlertPolicy,
 			Tool: mcp.NewTool("alert-policy-create",
-				mcp.WithDescription("Create a new Alert Policy"),
+				mcp.WithDescription("Create a new Alert Policy. Returns the created policy, including its UUID"),
 				mcp.WithString("Type", mcp.Required(), mcp.Description(`Type of the Alert Policy. Available types:
 Droplet metrics:
 - 'v1/insights/droplet/load_1'
@@ -334,10 +304,10 @@ Database metrics:
 - 'v1/insights/database/memory_utilization'
 - 'v1/insights/database/disk_utilization'`)),
 				mcp.WithString("Description", mcp.Required(), mcp.Description("Human-readable description of the alert policy")),
-				mcp.WithString("Compare", mcp.Required(), mcp.Description("Comparison operator: 'GreaterThan' or 'LessThan'")),
+				mcp.WithString("Compare", mcp.Required(), mcp.Enum(string(godo.GreaterThan), string(godo.LessThan)), mcp.Description("Comparison operator: 'GreaterThan' or 'LessThan'")),
 				mcp.WithNumber("Value", mcp.Required(), mcp.Description("Threshold value for the alert (e.g., 80 for 80% CPU)")),
-				mcp.WithString("Window", mcp.Required(), mcp.Description("Time window for the alert: '5m', '10m', '30m', '1h' (5 minutes, 10 minutes, 30 minutes, 1 hour)")),
-				mcp.WithArray("Entities", mcp.Description("List of resource IDs to monitor (e.g., Droplet IDs: '12345678', '23456789')"),
+				mcp.WithString("Window", mcp.Required(), mcp.Enum(alertPolicyWindows...), mcp.Description("Time window for the alert: '5m', '10m', '30m', '1h' (5 minutes, 10 minutes, 30 minutes, 1 hour)")),
+				mcp.WithArray("Entities", mcp.Description("List of resource IDs to monitor (e.g., Droplet IDs: '12345678', '23456789'). At least one of Entities or Tags is required"),
 					mcp.Items(map[string]any{
 						"type": "string",
 					})),
@@ -396,10 +366,10 @@ Database metrics:
 - 'v1/insights/database/memory_utilization'
 - 'v1/insights/database/disk_utilization'`)),
 				mcp.WithString("Description", mcp.Required(), mcp.Description("Human-readable description of the alert policy")),
-				mcp.WithString("Compare", mcp.Required(), mcp.Description("Comparison operator: 'GreaterThan' or 'LessThan'")),
+				mcp.WithString("Compare", mcp.Required(), mcp.Enum(string(godo.GreaterThan), string(godo.LessThan)), mcp.Description("Comparison operator: 'GreaterThan' or 'LessThan'")),
 				mcp.WithNumber("Value", mcp.Required(), mcp.Description("Threshold value for the alert (e.g., 80 for 80% CPU)")),
-				mcp.WithString("Window", mcp.Required(), mcp.Description("Time window for the alert: '5m', '10m', '30m', '1h' (5 minutes, 10 minutes, 30 minutes, 1 hour)")),
-				mcp.WithArray("Entities", mcp.Description("List of resource IDs to monitor (e.g., Droplet IDs: '12345678', '23456789')"),
+				mcp.WithString("Window", mcp.Required(), mcp.Enum(alertPolicyWindows...), mcp.Description("Time window for the alert: '5m', '10m', '30m', '1h' (5 minutes, 10 minutes, 30 minutes, 1 hour)")),
+				mcp.WithArray("Entities", mcp.Description("List of resource IDs to monitor (e.g., Droplet IDs: '12345678', '23456789'). At least one of Entities or Tags is required"),
 					mcp.Items(map[string]any{
 						"type": "string",
 					})),
@@ -146,6 +146,49 @@ func TestAlertPolicyTool_createAlertPolicy(t *testing.T) {
 		mockSetup   func(*MockMonitoringService)
 		expectError bool
 	}{
+		{
+			name: "invalid window",
+			args: map[string]any{
+				"Type":        "v1/insights/droplet/cpu",
+				"Description": "High CPU usage",
+				"Compare":     "GreaterThan",
+				"Value":       float64(80),
+				"Window":      "15m",
+				"Tags":        []any{"production"},
+			},
+			expectError: true,
+		},
+		{
+			name: "missing entities and tags",
+			args: map[string]any{
+				"Type":        "v1/insights/droplet/cpu",
+				"Description": "High CPU usage",
+				"Compare":     "GreaterThan",
+				"Value":       float64(80),
+				"Window":      "5m",
+			},
+			expectError: true,
+		},
+		{
+			name: "entities only",
+			args: map[string]any{
+				"Type":        "v1/insights/droplet/cpu",
+				"Description": "High CPU usage",
+				"Compare":     "GreaterThan",
+				"Value":       float64(80),
+				"Window":      "1h",
+				"Entities":    []any{"12345678"},
+			},
+			mockSetup: func(m *MockMonitoringService) {
+				m.EXPECT().CreateAlertPolicy(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, req *godo.AlertPolicyCreateRequest) (*godo.AlertPolicy, *godo.Response, error) {
+						require.Equal(t, []string{"12345678"}, req.Entities)
+						require.Equal(t, "1h", req.Window)
+						return testPolicy, nil, nil
+					},
+				)
+			},
+		},
 		{
 			name: "api error",
 			args: map[string]any{
@@ -154,6 +197,7 @@ func TestAlertPolicyTool_createAlertPolicy(t *testing.T) {
 				"Compare":     "GreaterThan",
 				"Value":       float64(80),
 				"Window":      "5m",
+				"Tags":        []any{"production"},
 				"Alerts": map[string]any{
 					"Email": []string{"test@example.com"},
 					"Slack": []map[string]any{{
@@ -176,6 +220,7 @@ func TestAlertPolicyTool_createAlertPolicy(t *testing.T) {
 				"Compare":     "GreaterThan",
 				"Value":       float64(80),
 				"Window":      "5m",
+				"Tags":        []any{"production"},
 				"Alerts": map[string]any{
 					"Email": []string{"test@example.com"},
 					"Slack": []map[string]any{{
@@ -240,6 +285,7 @@ func TestAlertPolicyTool_updateAlertPolicy(t *testing.T) {
 				"Compare":     "GreaterThan",
 				"Value":       float64(80),
 				"Window":      "5m",
+				"Tags":        []any{"production"},
 				"Alerts": map[string]any{
 					"Email": []string{"test@example.com"},
 					"Slack": []map[string]any{{
@@ -263,6 +309,7 @@ func TestAlertPolicyTool_updateAlertPolicy(t *testing.T) {
 				"Compare":     "GreaterThan",
 				"Value":       float64(80),
 				"Window":      "5m",
+				"Tags":        []any{"production"},
 				"Alerts": map[string]any{
 					"Email": []string{"test@example.com"},
 					"Slack": []map[string]any{{
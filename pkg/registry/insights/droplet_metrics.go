@@ -0,0 +1,125 @@
+package insights
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// metricPoint is a sample of a droplet metric.
+type metricPoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// sumByTimestamp adds up the samples the series of a metrics response have at each timestamp, in
+// milliseconds.
+func sumByTimestamp(resp *godo.MetricsResponse) map[int64]float64 {
+	sums := map[int64]float64{}
+	if resp == nil {
+		return sums
+	}
+	for _, series := range resp.Data.Result {
+		for _, sample := range series.Values {
+			sums[int64(sample.Timestamp)] += float64(sample.Value)
+		}
+	}
+	return sums
+}
+
+// sortedTimestamps returns the timestamps of values in ascending order.
+func sortedTimestamps[T any](values map[int64]T) []int64 {
+	timestamps := make([]int64, 0, len(values))
+	for ts := range values {
+		timestamps = append(timestamps, ts)
+	}
+	slices.Sort(timestamps)
+	return timestamps
+}
+
+// gaugeSeries returns the samples of a gauge metric such as the load average, summing its series.
+func gaugeSeries(resp *godo.MetricsResponse) []metricPoint {
+	sums := sumByTimestamp(resp)
+	points := make([]metricPoint, 0, len(sums))
+	for _, ts := range sortedTimestamps(sums) {
+		points = append(points, metricPoint{Time: time.UnixMilli(ts).UTC(), Value: sums[ts]})
+	}
+	return points
+}
+
+// usedPercentSeries returns, at each timestamp both gauges have, the share of total that is not
+// free, in percent. It serves memory (total and available) and disk (size and free) utilization.
+func usedPercentSeries(total, free *godo.MetricsResponse) []metricPoint {
+	totals, frees := sumByTimestamp(total), sumByTimestamp(free)
+	var points []metricPoint
+	for _, ts := range sortedTimestamps(totals) {
+		f, ok := frees[ts]
+		if !ok || totals[ts] <= 0 {
+			continue
+		}
+		points = append(points, metricPoint{Time: time.UnixMilli(ts).UTC(), Value: 100 * (totals[ts] - f) / totals[ts]})
+	}
+	return points
+}
+
+// cpuSeries derives CPU utilization from the per-mode CPU time counters: the share of CPU time not
+// spent idle over each interval between samples, in percent, stamped with the end of the interval.
+func cpuSeries(resp *godo.MetricsResponse) []metricPoint {
+	if resp == nil {
+		return nil
+	}
+	type cpuTime struct{ total, idle float64 }
+	times := map[int64]cpuTime{}
+	for _, series := range resp.Data.Result {
+		idle := series.Metric["mode"] == "idle"
+		for _, sample := range series.Values {
+			t := times[int64(sample.Timestamp)]
+			t.total += float64(sample.Value)
+			if idle {
+				t.idle += float64(sample.Value)
+			}
+			times[int64(sample.Timestamp)] = t
+		}
+	}
+
+	timestamps := sortedTimestamps(times)
+	var points []metricPoint
+	for i := 1; i < len(timestamps); i++ {
+		prev, cur := times[timestamps[i-1]], times[timestamps[i]]
+		dTotal, dIdle := cur.total-prev.total, cur.idle-prev.idle
+		// Counters reset when the droplet reboots.
+		if dTotal <= 0 || dIdle < 0 {
+			continue
+		}
+		points = append(points, metricPoint{Time: time.UnixMilli(timestamps[i]).UTC(), Value: 100 * (dTotal - dIdle) / dTotal})
+	}
+	return points
+}
+
+// summarize returns the average and peak of a percentage series, reporting false when it is empty.
+func summarize(points []metricPoint) (utilization, bool) {
+	if len(points) == 0 {
+		return utilization{}, false
+	}
+	var usage utilization
+	var sum float64
+	for _, p := range points {
+		sum += p.Value
+		usage.PeakPercent = max(usage.PeakPercent, p.Value)
+	}
+	usage.AvgPercent = sum / float64(len(points))
+	return utilization{
+		AvgPercent:  math.Round(usage.AvgPercent*10) / 10,
+		PeakPercent: math.Round(usage.PeakPercent*10) / 10,
+	}, true
+}
+
+// noMetricsResult is the error result for a droplet that reported no metrics over a period, which
+// happens when the metrics agent is not installed.
+func noMetricsResult(dropletID int, period string) *mcp.CallToolResult {
+	return mcp.NewToolResultError(fmt.Sprintf("droplet %d has no metrics for the last %s: enable monitoring by installing the DigitalOcean metrics agent on it (https://docs.digitalocean.com/products/monitoring/how-to/install-agent/), then retry once it has reported for a while", dropletID, period))
+}
@@ -0,0 +1,228 @@
+package insights
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultRecommendationDays = 7
+	maxRecommendationDays     = 30
+
+	// A droplet is upsized when its CPU averages or its memory peaks above these percentages, and
+	// downsized when both stay below the lower ones.
+	upsizeCPUAvgPercent       = 70.0
+	upsizeMemoryPeakPercent   = 85.0
+	downsizeCPUAvgPercent     = 20.0
+	downsizeMemoryPeakPercent = 40.0
+
+	// A suggested size is the cheapest one that would run the observed load at or below these.
+	targetCPUAvgPercent     = 50.0
+	targetMemoryPeakPercent = 70.0
+)
+
+// Recommendations returned by droplet-size-recommendation.
+const (
+	recommendUpsize   = "upsize"
+	recommendDownsize = "downsize"
+	recommendKeep     = "keep"
+)
+
+// utilization summarizes a metric over the recommendation window, as percentages of capacity.
+type utilization struct {
+	AvgPercent  float64 `json:"avg_percent"`
+	PeakPercent float64 `json:"peak_percent"`
+}
+
+// sizeRecommendation is the result of droplet-size-recommendation.
+type sizeRecommendation struct {
+	DropletID          int         `json:"droplet_id"`
+	CurrentSize        string      `json:"current_size"`
+	Days               int         `json:"days"`
+	CPU                utilization `json:"cpu"`
+	Memory             utilization `json:"memory"`
+	Recommendation     string      `json:"recommendation"`
+	SuggestedSize      string      `json:"suggested_size,omitempty"`
+	MonthlyPriceChange float64     `json:"monthly_price_change,omitempty"`
+	Rationale          string      `json:"rationale"`
+}
+
+// SizeRecommendationTool recommends droplet sizes from their monitoring metrics.
+type SizeRecommendationTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+	now    func() time.Time
+}
+
+// NewSizeRecommendationTool creates a new size recommendation tool
+func NewSizeRecommendationTool(client func(ctx context.Context) (*godo.Client, error)) *SizeRecommendationTool {
+	return &SizeRecommendationTool{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// recommendSize compares a droplet's CPU and memory usage over the last days with the capacity of
+// its size, and suggests a larger or cheaper size of the same family when the usage calls for it.
+func (s *SizeRecommendationTool) recommendSize(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	dropletID := args.RequireInt("ID")
+	days := args.OptionalInt("Days", defaultRecommendationDays)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if days < 1 || days > maxRecommendationDays {
+		return mcp.NewToolResultError(fmt.Sprintf("Days must be between 1 and %d", maxRecommendationDays)), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	droplet, _, err := client.Droplets.Get(ctx, dropletID)
+	if err != nil {
+		return common.APIErrorResult(err, "droplet", dropletID), nil
+	}
+
+	end := s.now()
+	metricsReq := &godo.DropletMetricsRequest{
+		HostID: fmt.Sprint(dropletID),
+		Start:  end.Add(-time.Duration(days) * 24 * time.Hour),
+		End:    end,
+	}
+	cpu, _, err := client.Monitoring.GetDropletCPU(ctx, metricsReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	memTotal, _, err := client.Monitoring.GetDropletTotalMemory(ctx, metricsReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	memAvailable, _, err := client.Monitoring.GetDropletAvailableMemory(ctx, metricsReq)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	cpuUsage, cpuOK := summarize(cpuSeries(cpu))
+	memUsage, memOK := summarize(usedPercentSeries(memTotal, memAvailable))
+	if !cpuOK || !memOK {
+		return noMetricsResult(dropletID, fmt.Sprintf("%d days", days)), nil
+	}
+
+	sizes, err := common.ListAllSizes(ctx, client)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	result := recommendDropletSize(droplet, sizes, cpuUsage, memUsage)
+	result.DropletID = dropletID
+	result.Days = days
+
+	jsonResult, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonResult), nil
+}
+
+// recommendDropletSize decides whether a droplet should change size given its CPU and memory
+// usage, and picks the cheapest size of the same family available in its region that runs the
+// load at the target utilization. A droplet is only downsized to a size whose disk is at least as
+// large as its own, as a disk can't shrink.
+func recommendDropletSize(droplet *godo.Droplet, sizes []godo.Size, cpu, memory utilization) sizeRecommendation {
+	current := currentDropletSize(droplet, sizes)
+	result := sizeRecommendation{CurrentSize: current.Slug, CPU: cpu, Memory: memory, Recommendation: recommendKeep}
+
+	usage := fmt.Sprintf("CPU averaged %.1f%% and memory peaked at %.1f%% of %s (%d vCPUs, %d MB)", cpu.AvgPercent, memory.PeakPercent, current.Slug, current.Vcpus, current.Memory)
+	switch {
+	case cpu.AvgPercent >= upsizeCPUAvgPercent || memory.PeakPercent >= upsizeMemoryPeakPercent:
+		result.Recommendation = recommendUpsize
+	case cpu.AvgPercent < downsizeCPUAvgPercent && memory.PeakPercent < downsizeMemoryPeakPercent:
+		result.Recommendation = recommendDownsize
+	default:
+		result.Rationale = fmt.Sprintf("%s, within the range the current size suits", usage)
+		return result
+	}
+
+	needVcpus := max(1, int(math.Ceil(float64(current.Vcpus)*cpu.AvgPercent/targetCPUAvgPercent)))
+	needMemory := int(math.Ceil(float64(current.Memory) * memory.PeakPercent / targetMemoryPeakPercent))
+	region := ""
+	if droplet.Region != nil {
+		region = droplet.Region.Slug
+	}
+
+	var candidates []godo.Size
+	for _, size := range sizes {
+		if !size.Available || size.Description != current.Description || !slices.Contains(size.Regions, region) {
+			continue
+		}
+		if size.Vcpus < needVcpus || size.Memory < needMemory {
+			continue
+		}
+		if result.Recommendation == recommendUpsize && size.PriceMonthly <= current.PriceMonthly {
+			continue
+		}
+		if result.Recommendation == recommendDownsize && (size.PriceMonthly >= current.PriceMonthly || size.Disk < droplet.Disk) {
+			continue
+		}
+		candidates = append(candidates, size)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].PriceMonthly < candidates[j].PriceMonthly
+	})
+
+	if len(candidates) == 0 {
+		if result.Recommendation == recommendUpsize {
+			result.Rationale = fmt.Sprintf("%s; no larger %s size available in %s has the %d vCPUs and %d MB needed, consider another size family", usage, current.Description, region, needVcpus, needMemory)
+			return result
+		}
+		result.Recommendation = recommendKeep
+		result.Rationale = fmt.Sprintf("%s; no cheaper %s size available in %s has the %d vCPUs, %d MB and %d GB disk needed", usage, current.Description, region, needVcpus, needMemory, droplet.Disk)
+		return result
+	}
+
+	suggested := candidates[0]
+	result.SuggestedSize = suggested.Slug
+	result.MonthlyPriceChange = math.Round((suggested.PriceMonthly-current.PriceMonthly)*100) / 100
+	result.Rationale = fmt.Sprintf("%s; %s (%d vCPUs, %d MB) is the cheapest %s size in %s that would keep CPU near %.0f%% and memory peaks under %.0f%%", usage, suggested.Slug, suggested.Vcpus, suggested.Memory, current.Description, region, targetCPUAvgPercent, targetMemoryPeakPercent)
+	return result
+}
+
+// currentDropletSize returns the droplet's size from the size list, which carries the regions and
+// availability the embedded size may lack.
+func currentDropletSize(droplet *godo.Droplet, sizes []godo.Size) godo.Size {
+	for _, size := range sizes {
+		if size.Slug == droplet.SizeSlug {
+			return size
+		}
+	}
+	if droplet.Size != nil {
+		return *droplet.Size
+	}
+	return godo.Size{Slug: droplet.SizeSlug, Vcpus: droplet.Vcpus, Memory: droplet.Memory, Disk: droplet.Disk}
+}
+
+// Tools returns a list of tool functions
+func (s *SizeRecommendationTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: s.recommendSize,
+			Tool: mcp.NewTool("droplet-size-recommendation",
+				mcp.WithDescription("Recommend whether to upsize, downsize or keep a droplet's size from its CPU and memory usage over the last days, with a suggested size slug and the rationale. Requires the metrics agent on the droplet."),
+				mcp.WithNumber("ID", mcp.Required(), mcp.Description("ID of the droplet")),
+				mcp.WithNumber("Days", mcp.DefaultNumber(defaultRecommendationDays), mcp.Min(1), mcp.Max(maxRecommendationDays), mcp.Description("Number of days of metrics to base the recommendation on")),
+				mcp.WithReadOnlyHintAnnotation(true),
+			),
+		},
+	}
+}
@@ -0,0 +1,194 @@
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+const testSizes = `{"sizes":[
+	{"slug":"s-1vcpu-2gb","description":"Basic","vcpus":1,"memory":2048,"disk":50,"price_monthly":12,"regions":["nyc1"],"available":true},
+	{"slug":"s-2vcpu-2gb","description":"Basic","vcpus":2,"memory":2048,"disk":60,"price_monthly":18,"regions":["nyc1"],"available":true},
+	{"slug":"s-2vcpu-4gb","description":"Basic","vcpus":2,"memory":4096,"disk":80,"price_monthly":24,"regions":["nyc1"],"available":true},
+	{"slug":"s-4vcpu-8gb","description":"Basic","vcpus":4,"memory":8192,"disk":160,"price_monthly":48,"regions":["nyc1"],"available":true},
+	{"slug":"s-8vcpu-16gb","description":"Basic","vcpus":8,"memory":16384,"disk":320,"price_monthly":96,"regions":["sfo3"],"available":true},
+	{"slug":"c-2","description":"CPU-Optimized","vcpus":2,"memory":4096,"disk":25,"price_monthly":42,"regions":["nyc1"],"available":true}
+]}`
+
+// metricsServer answers for droplet 1, an s-2vcpu-4gb in nyc1 with a 50 GB disk, whose CPU is
+// busy cpuBusy percent of every hour and whose memory is memUsed percent used.
+func metricsServer(t *testing.T, cpuBusy, memUsed float64, hasMetrics bool) *SizeRecommendationTool {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+	matrix := func(series ...string) string {
+		if !hasMetrics {
+			series = nil
+		}
+		out := `{"status":"success","data":{"resultType":"matrix","result":[`
+		for i, s := range series {
+			if i > 0 {
+				out += ","
+			}
+			out += s
+		}
+		return out + `]}}`
+	}
+	// values returns three hourly samples starting at value and growing by step.
+	values := func(value, step float64) string {
+		return fmt.Sprintf(`[[%d,"%g"],[%d,"%g"],[%d,"%g"]]`, start, value, start+3600, value+step, start+7200, value+2*step)
+	}
+	const totalMemory = 4096 * 1024 * 1024
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body string
+		switch r.URL.Path {
+		case "/v2/droplets/1":
+			body = `{"droplet":{"id":1,"size_slug":"s-2vcpu-4gb","vcpus":2,"memory":4096,"disk":50,"region":{"slug":"nyc1"}}}`
+		case "/v2/sizes":
+			body = testSizes
+		case "/v2/monitoring/metrics/droplet/cpu":
+			body = matrix(
+				`{"metric":{"mode":"idle"},"values":`+values(1000, 3600*(100-cpuBusy)/100)+`}`,
+				`{"metric":{"mode":"user"},"values":`+values(500, 3600*cpuBusy/100)+`}`,
+			)
+		case "/v2/monitoring/metrics/droplet/memory_total":
+			body = matrix(`{"metric":{},"values":` + values(totalMemory, 0) + `}`)
+		case "/v2/monitoring/metrics/droplet/memory_available":
+			body = matrix(`{"metric":{},"values":` + values(totalMemory*(100-memUsed)/100, 0) + `}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			body = `{"id":"not_found","message":"The resource you were accessing could not be found."}`
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client := godo.NewClient(srv.Client())
+	client.BaseURL = baseURL
+
+	tool := NewSizeRecommendationTool(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	})
+	tool.now = func() time.Time { return time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC) }
+	return tool
+}
+
+func TestSizeRecommendationTool_recommendSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		cpuBusy     float64
+		memUsed     float64
+		noMetrics   bool
+		args        map[string]any
+		expect      sizeRecommendation
+		expectError string
+	}{
+		{
+			name:    "Busy CPU",
+			cpuBusy: 80,
+			memUsed: 50,
+			args:    map[string]any{"ID": float64(1)},
+			expect: sizeRecommendation{
+				CPU:                utilization{AvgPercent: 80, PeakPercent: 80},
+				Memory:             utilization{AvgPercent: 50, PeakPercent: 50},
+				Recommendation:     recommendUpsize,
+				SuggestedSize:      "s-4vcpu-8gb",
+				MonthlyPriceChange: 24,
+			},
+		},
+		{
+			name:    "Idle",
+			cpuBusy: 10,
+			memUsed: 30,
+			args:    map[string]any{"ID": float64(1)},
+			expect: sizeRecommendation{
+				CPU:                utilization{AvgPercent: 10, PeakPercent: 10},
+				Memory:             utilization{AvgPercent: 30, PeakPercent: 30},
+				Recommendation:     recommendDownsize,
+				SuggestedSize:      "s-1vcpu-2gb",
+				MonthlyPriceChange: -12,
+			},
+		},
+		{
+			name:    "Steady",
+			cpuBusy: 40,
+			memUsed: 60,
+			args:    map[string]any{"ID": float64(1)},
+			expect: sizeRecommendation{
+				CPU:            utilization{AvgPercent: 40, PeakPercent: 40},
+				Memory:         utilization{AvgPercent: 60, PeakPercent: 60},
+				Recommendation: recommendKeep,
+			},
+		},
+		{
+			name:    "Memory pressure",
+			cpuBusy: 40,
+			memUsed: 99,
+			args:    map[string]any{"ID": float64(1)},
+			expect: sizeRecommendation{
+				CPU:            utilization{AvgPercent: 40, PeakPercent: 40},
+				Memory:         utilization{AvgPercent: 99, PeakPercent: 99},
+				Recommendation: recommendUpsize,
+				// Running 99% of 4096 MB at 70% takes 5794 MB.
+				SuggestedSize:      "s-4vcpu-8gb",
+				MonthlyPriceChange: 24,
+			},
+		},
+		{name: "Monitoring not enabled", noMetrics: true, args: map[string]any{"ID": float64(1)}, expectError: "enable monitoring"},
+		{name: "Droplet not found", args: map[string]any{"ID": float64(2)}, expectError: "resource not found"},
+		{name: "Days out of range", args: map[string]any{"ID": float64(1), "Days": float64(90)}, expectError: "Days must be between 1 and 30"},
+		{name: "Missing ID", args: map[string]any{}, expectError: "argument 'ID' is required"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := metricsServer(t, tc.cpuBusy, tc.memUsed, !tc.noMetrics)
+			res, err := tool.recommendSize(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := res.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, res.IsError, text)
+
+			var got sizeRecommendation
+			require.NoError(t, json.Unmarshal([]byte(text), &got))
+			require.NotEmpty(t, got.Rationale)
+			got.Rationale = ""
+			tc.expect.DropletID = 1
+			tc.expect.CurrentSize = "s-2vcpu-4gb"
+			tc.expect.Days = defaultRecommendationDays
+			require.Equal(t, tc.expect, got)
+		})
+	}
+}
+
+func TestRecommendDropletSize_noLargerSize(t *testing.T) {
+	droplet := &godo.Droplet{SizeSlug: "s-2vcpu-4gb", Disk: 80, Region: &godo.Region{Slug: "sfo3"}}
+	sizes := []godo.Size{
+		{Slug: "s-2vcpu-4gb", Description: "Basic", Vcpus: 2, Memory: 4096, Disk: 80, PriceMonthly: 24, Regions: []string{"nyc1", "sfo3"}, Available: true},
+		{Slug: "s-4vcpu-8gb", Description: "Basic", Vcpus: 4, Memory: 8192, Disk: 160, PriceMonthly: 48, Regions: []string{"nyc1"}, Available: true},
+	}
+
+	got := recommendDropletSize(droplet, sizes, utilization{AvgPercent: 90, PeakPercent: 100}, utilization{AvgPercent: 50, PeakPercent: 50})
+	require.Equal(t, recommendUpsize, got.Recommendation)
+	require.Empty(t, got.SuggestedSize)
+	require.Contains(t, got.Rationale, "no larger Basic size available in sfo3")
+
+	got = recommendDropletSize(droplet, sizes, utilization{AvgPercent: 5, PeakPercent: 10}, utilization{AvgPercent: 20, PeakPercent: 20})
+	require.Equal(t, recommendKeep, got.Recommendation)
+	require.Contains(t, got.Rationale, "no cheaper Basic size")
+}
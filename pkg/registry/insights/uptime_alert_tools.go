@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -90,51 +92,114 @@ func (u *UptimeCheckAlertTool) listUptimeCheckAlerts(ctx context.Context, req mc
 	return mcp.NewToolResultText(jsonUptimeCheckAlerts), nil
 }
 
-// createUptimeCheck creates a new UptimeCheck
-func (u *UptimeCheckAlertTool) createUptimeCheckAlert(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	checkID, ok := req.GetArguments()["CheckID"].(string)
-	if !ok || checkID == "" {
-		return mcp.NewToolResultError("Uptime CheckID is required"), nil
+// uptimeAlertTypes are the supported uptime alert types.
+var uptimeAlertTypes = []string{"latency", "down", "down_global", "ssl_expiry"}
+
+// uptimeAlertSpec holds the alert fields shared by the create and update requests.
+type uptimeAlertSpec struct {
+	name          string
+	alertType     string
+	threshold     int
+	comparison    godo.UptimeAlertComp
+	period        string
+	notifications *godo.Notifications
+}
+
+// uptimeAlertSpecFromArgs parses and validates the alert arguments. Latency and ssl_expiry alerts need a threshold
+// and comparison, down and down_global alerts take neither, and at least one notification channel is required.
+func uptimeAlertSpecFromArgs(args map[string]any) (*uptimeAlertSpec, error) {
+	spec := &uptimeAlertSpec{}
+	spec.name, _ = args["Name"].(string)
+	if spec.name == "" {
+		return nil, fmt.Errorf("Name is required")
 	}
-	name := req.GetArguments()["Name"].(string)
-	alertType := req.GetArguments()["Type"].(string)
-	var threshold int
-	if vArg, ok := req.GetArguments()["Threshold"].(float64); ok && int(vArg) > 0 {
-		threshold = int(vArg)
+	spec.alertType, _ = args["Type"].(string)
+	if !slices.Contains(uptimeAlertTypes, spec.alertType) {
+		return nil, fmt.Errorf("Type must be one of %s", strings.Join(uptimeAlertTypes, ", "))
 	}
-	period := req.GetArguments()["Period"].(string)
-	comparison := req.GetArguments()["Comparison"].(string)
-	emailsRaw, ok := req.GetArguments()["Emails"]
-	var emails []string
-	if ok && emailsRaw != nil {
-		bytes, _ := json.Marshal(emailsRaw)
-		_ = json.Unmarshal(bytes, &emails)
+	spec.period, _ = args["Period"].(string)
+	if spec.period == "" {
+		return nil, fmt.Errorf("Period is required")
+	}
+	if vArg, ok := args["Threshold"].(float64); ok && int(vArg) > 0 {
+		spec.threshold = int(vArg)
+	}
+	comparison, _ := args["Comparison"].(string)
+	spec.comparison = godo.UptimeAlertComp(comparison)
+	if comparison != "" && spec.comparison != godo.UptimeAlertGreaterThan && spec.comparison != godo.UptimeAlertLessThan {
+		return nil, fmt.Errorf("Comparison must be greater_than or less_than")
+	}
+
+	switch spec.alertType {
+	case "latency":
+		if spec.threshold == 0 || comparison == "" {
+			return nil, fmt.Errorf("latency alerts require a Threshold in milliseconds and a Comparison")
+		}
+	case "ssl_expiry":
+		if spec.threshold == 0 {
+			return nil, fmt.Errorf("ssl_expiry alerts require a Threshold in days")
+		}
+		if comparison == "" {
+			spec.comparison = godo.UptimeAlertLessThan
+		} else if spec.comparison != godo.UptimeAlertLessThan {
+			return nil, fmt.Errorf("ssl_expiry alerts only support the less_than Comparison")
+		}
+	default:
+		if spec.threshold != 0 || comparison != "" {
+			return nil, fmt.Errorf("%s alerts do not take a Threshold or Comparison", spec.alertType)
+		}
 	}
 
-	slackDetailsRaw, ok := req.GetArguments()["SlackDetails"]
+	var emails []string
+	if emailsRaw, ok := args["Emails"]; ok && emailsRaw != nil {
+		bytes, err := json.Marshal(emailsRaw)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid Emails format")
+		}
+		if err := json.Unmarshal(bytes, &emails); err != nil {
+			return nil, fmt.Errorf("Failed to parse Emails")
+		}
+	}
 	var slackDetails []godo.SlackDetails
-	if ok && slackDetailsRaw != nil {
+	if slackDetailsRaw, ok := args["SlackDetails"]; ok && slackDetailsRaw != nil {
 		// Marshal the interface{} to JSON
 		slackDetailsBytes, err := json.Marshal(slackDetailsRaw)
 		if err != nil {
-			return mcp.NewToolResultError("Invalid SlackDetails format"), nil
+			return nil, fmt.Errorf("Invalid SlackDetails format")
 		}
 		// Unmarshal JSON to your struct
 		if err := json.Unmarshal(slackDetailsBytes, &slackDetails); err != nil {
-			return mcp.NewToolResultError("Failed to parse SlackDetails"), nil
+			return nil, fmt.Errorf("Failed to parse SlackDetails")
 		}
 	}
+	if len(emails) == 0 && len(slackDetails) == 0 {
+		return nil, fmt.Errorf("at least one notification channel is required, set Emails or SlackDetails")
+	}
+	spec.notifications = &godo.Notifications{
+		Email: emails,
+		Slack: slackDetails,
+	}
+	return spec, nil
+}
+
+// createUptimeCheck creates a new UptimeCheck
+func (u *UptimeCheckAlertTool) createUptimeCheckAlert(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	checkID, ok := req.GetArguments()["CheckID"].(string)
+	if !ok || checkID == "" {
+		return mcp.NewToolResultError("Uptime CheckID is required"), nil
+	}
+	spec, err := uptimeAlertSpecFromArgs(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	createRequest := &godo.CreateUptimeAlertRequest{
-		Name:       name,
-		Type:       alertType,
-		Threshold:  threshold,
-		Period:     period,
-		Comparison: godo.UptimeAlertComp(comparison),
-		Notifications: &godo.Notifications{
-			Email: emails,
-			Slack: slackDetails,
-		},
+		Name:          spec.name,
+		Type:          spec.alertType,
+		Threshold:     spec.threshold,
+		Period:        spec.period,
+		Comparison:    spec.comparison,
+		Notifications: spec.notifications,
 	}
 
 	client, err := u.client(ctx)
@@ -167,45 +232,18 @@ func (u *UptimeCheckAlertTool) updateUptimeCheckAlert(ctx context.Context, req m
 		return mcp.NewToolResultError("UptimeCheck AlertID is required"), nil
 	}
 
-	name := req.GetArguments()["Name"].(string)
-	alertType := req.GetArguments()["Type"].(string)
-	var threshold int
-	if vArg, ok := req.GetArguments()["Threshold"].(float64); ok && int(vArg) > 0 {
-		threshold = int(vArg)
-	}
-	period := req.GetArguments()["Period"].(string)
-	comparison := req.GetArguments()["Comparison"].(string)
-	emailsRaw, ok := req.GetArguments()["Emails"]
-	var emails []string
-	if ok && emailsRaw != nil {
-		bytes, _ := json.Marshal(emailsRaw)
-		_ = json.Unmarshal(bytes, &emails)
-	}
-
-	slackDetailsRaw, ok := req.GetArguments()["SlackDetails"]
-	var slackDetails []godo.SlackDetails
-	if ok && slackDetailsRaw != nil {
-		// Marshal the interface{} to JSON
-		slackDetailsBytes, err := json.Marshal(slackDetailsRaw)
-		if err != nil {
-			return mcp.NewToolResultError("Invalid SlackDetails format"), nil
-		}
-		// Unmarshal JSON to your struct
-		if err := json.Unmarshal(slackDetailsBytes, &slackDetails); err != nil {
-			return mcp.NewToolResultError("Failed to parse SlackDetails"), nil
-		}
+	spec, err := uptimeAlertSpecFromArgs(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	updateRequest := &godo.UpdateUptimeAlertRequest{
-		Name:       name,
-		Type:       alertType,
-		Threshold:  threshold,
-		Period:     period,
-		Comparison: godo.UptimeAlertComp(comparison),
-		Notifications: &godo.Notifications{
-			Email: emails,
-			Slack: slackDetails,
-		},
+		Name:          spec.name,
+		Type:          spec.alertType,
+		Threshold:     spec.threshold,
+		Period:        spec.period,
+		Comparison:    spec.comparison,
+		Notifications: spec.notifications,
 	}
 
 	client, err := u.client(ctx)
@@ -256,7 +294,7 @@ func (c *UptimeCheckAlertTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
 			Handler: c.getUptimeCheckAlert,
-			Tool: mcp.NewTool("uptimecheck-alert-get",
+			Tool: mcp.NewTool("uptime-alert-get",
 				mcp.WithDescription("Get UptimeCheck Alert information by CheckID and AlertID"),
 				mcp.WithString("CheckID", mcp.Required(), mcp.Description("A unique identifier for a check")),
 				mcp.WithString("AlertID", mcp.Required(), mcp.Description("A unique identifier for a alert")),
@@ -264,7 +302,7 @@ func (c *UptimeCheckAlertTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: c.listUptimeCheckAlerts,
-			Tool: mcp.NewTool("uptimecheck-alert-list",
+			Tool: mcp.NewTool("uptime-alert-list",
 				mcp.WithDescription("List UptimeChecks Alerts with pagination"),
 				mcp.WithString("CheckID", mcp.Required(), mcp.Description("A unique identifier for a check")),
 				mcp.WithNumber("Page", mcp.DefaultNumber(defaultAlertsPage), mcp.Description("Page number")),
@@ -273,21 +311,20 @@ func (c *UptimeCheckAlertTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: c.createUptimeCheckAlert,
-			Tool: mcp.NewTool("uptimecheck-alert-create",
+			Tool: mcp.NewTool("uptime-alert-create",
 				mcp.WithDescription("Create a new UptimeCheck"),
 				mcp.WithString("CheckID", mcp.Required(), mcp.Description("A unique identifier for a check")),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the UptimeCheck Alert")),
-				mcp.WithString("Type", mcp.Required(), mcp.Description("latency, down, down_global or ssl_expiry. type of the UptimeCheck Alert")),
-				mcp.WithNumber("Threshold", mcp.Description("The threshold at which the alert will enter a trigger state. The specific threshold is dependent on the alert type")),
+				mcp.WithString("Type", mcp.Required(), mcp.Enum(uptimeAlertTypes...), mcp.Description("Type of the UptimeCheck Alert. latency and ssl_expiry need a Threshold and Comparison, down and down_global take neither")),
+				mcp.WithNumber("Threshold", mcp.Description("The threshold at which the alert will enter a trigger state: milliseconds for latency, days until expiry for ssl_expiry")),
 				mcp.WithString("Comparison", mcp.Description("The comparison operator used against the alert's threshold. values : greater_than or less_than")),
 				mcp.WithString("Period", mcp.Required(), mcp.WithStringEnumItems([]string{"2m", "3m", "5m", "10m", "15m", "30m", "1h"}), mcp.Description("Period of time the threshold must be exceeded to trigger the alert")),
-				mcp.WithArray("Emails", mcp.Required(), mcp.Description("email addresses to notify"), mcp.Items(map[string]any{
+				mcp.WithArray("Emails", mcp.Description("email addresses to notify. At least one of Emails or SlackDetails is required"), mcp.Items(map[string]any{
 					"type":        "string",
 					"description": "email address to notify",
 				})),
 				mcp.WithArray(
 					"SlackDetails",
-					mcp.Required(),
 					mcp.Items(map[string]any{
 						"type": "object",
 						"properties": map[string]any{
@@ -301,22 +338,21 @@ func (c *UptimeCheckAlertTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: c.updateUptimeCheckAlert,
-			Tool: mcp.NewTool("uptimecheck-alert-update",
+			Tool: mcp.NewTool("uptime-alert-update",
 				mcp.WithDescription("Update a UptimeCheck"),
 				mcp.WithString("CheckID", mcp.Required(), mcp.Description("A unique identifier for a check")),
 				mcp.WithString("AlertID", mcp.Required(), mcp.Description("A unique identifier for a check alert")),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the UptimeCheck Alert")),
-				mcp.WithString("Type", mcp.Required(), mcp.Description("Type of the UptimeCheck Alert. value: latency, down, down_global or ssl_expiry")),
-				mcp.WithNumber("Threshold", mcp.Description("The threshold at which the alert will enter a trigger state. The specific threshold is dependent on the alert type")),
+				mcp.WithString("Type", mcp.Required(), mcp.Enum(uptimeAlertTypes...), mcp.Description("Type of the UptimeCheck Alert. latency and ssl_expiry need a Threshold and Comparison, down and down_global take neither")),
+				mcp.WithNumber("Threshold", mcp.Description("The threshold at which the alert will enter a trigger state: milliseconds for latency, days until expiry for ssl_expiry")),
 				mcp.WithString("Comparison", mcp.Description("The comparison operator used against the alert's threshold. value : greater_than or less_than")),
 				mcp.WithString("Period", mcp.Required(), mcp.WithStringEnumItems([]string{"2m", "3m", "5m", "10m", "15m", "30m", "1h"}), mcp.Description("Period of time the threshold must be exceeded to trigger the alert")),
-				mcp.WithArray("Emails", mcp.Required(), mcp.Description("Email addresses to notify"), mcp.Items(map[string]any{
+				mcp.WithArray("Emails", mcp.Description("Email addresses to notify. At least one of Emails or SlackDetails is required"), mcp.Items(map[string]any{
 					"type":        "string",
 					"description": "email address to notify",
 				})),
 				mcp.WithArray(
 					"SlackDetails",
-					mcp.Required(),
 					mcp.Items(map[string]any{
 						"type": "object",
 						"properties": map[string]any{
@@ -330,7 +366,7 @@ func (c *UptimeCheckAlertTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: c.deleteUptimeCheckAlert,
-			Tool: mcp.NewTool("uptimecheck-alert-delete",
+			Tool: mcp.NewTool("uptime-alert-delete",
 				mcp.WithDescription("Delete a uptimeCheck"),
 				mcp.WithString("CheckID", mcp.Required(), mcp.Description("A unique identifier for a check")),
 				mcp.WithString("AlertID", mcp.Required(), mcp.Description("A unique identifier for a alert")),
@@ -163,6 +163,39 @@ func TestUptimeCheckAlertTool_createUptimeCheckAlert(t *testing.T) {
 			mockSetup:   nil,
 			expectError: true,
 		},
+		{
+			name:        "latency without comparison",
+			args:        map[string]any{"CheckID": "check1", "Name": "alert", "Type": "latency", "Threshold": float64(100), "Period": "2m", "Emails": []string{"a@b.com"}},
+			expectError: true,
+		},
+		{
+			name:        "down with threshold",
+			args:        map[string]any{"CheckID": "check1", "Name": "alert", "Type": "down", "Threshold": float64(100), "Comparison": "greater_than", "Period": "2m", "Emails": []string{"a@b.com"}},
+			expectError: true,
+		},
+		{
+			name:        "ssl_expiry with greater_than",
+			args:        map[string]any{"CheckID": "check1", "Name": "alert", "Type": "ssl_expiry", "Threshold": float64(14), "Comparison": "greater_than", "Period": "2m", "Emails": []string{"a@b.com"}},
+			expectError: true,
+		},
+		{
+			name:        "no notification channel",
+			args:        map[string]any{"CheckID": "check1", "Name": "alert", "Type": "down", "Period": "2m", "Emails": []string{}, "SlackDetails": []map[string]any{}},
+			expectError: true,
+		},
+		{
+			name: "ssl_expiry defaults to less_than",
+			args: map[string]any{"CheckID": "check1", "Name": "alert", "Type": "ssl_expiry", "Threshold": float64(14), "Period": "2m", "Emails": []string{"a@b.com"}},
+			mockSetup: func(m *MockUptimeChecksService) {
+				m.EXPECT().CreateAlert(gomock.Any(), "check1", gomock.Any()).DoAndReturn(
+					func(_ context.Context, checkID string, req *godo.CreateUptimeAlertRequest) (*godo.UptimeAlert, *godo.Response, error) {
+						require.Equal(t, godo.UptimeAlertLessThan, req.Comparison)
+						require.Equal(t, 14, req.Threshold)
+						return testAlert, nil, nil
+					},
+				)
+			},
+		},
 		{
 			name: "api error",
 			args: map[string]any{"CheckID": "check1", "Name": "alert", "Type": "latency", "Threshold": float64(100), "Period": "2m", "Comparison": "greater_than", "Emails": []string{"a@b.com"}, "SlackDetails": []map[string]any{{"channel": "alerts", "url": "https://slack"}}},
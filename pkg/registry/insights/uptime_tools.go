@@ -3,7 +3,11 @@ package insights
 import (
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -104,79 +108,112 @@ func (c *UptimeTool) listUptimeChecks(ctx context.Context, req mcp.CallToolReque
 	return mcp.NewToolResultText(jsonUptimeChecks), nil
 }
 
-// createUptimeCheck creates a new UptimeCheck
-func (c *UptimeTool) createUptimeCheck(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	name := req.GetArguments()["Name"].(string)
-	checkType := req.GetArguments()["Type"].(string)
-	target := req.GetArguments()["Target"].(string)
-
-	rawRegions, _ := req.GetArguments()["Regions"]
-	var regions []string
-	if arr, ok := rawRegions.([]interface{}); ok {
-		for _, v := range arr {
-			if s, ok := v.(string); ok {
-				regions = append(regions, s)
-			}
-		}
-	}
+var (
+	uptimeCheckTypes   = []string{"ping", "http", "https"}
+	uptimeCheckRegions = []string{"us_east", "us_west", "eu_west", "se_asia"}
+)
 
-	enabled := req.GetArguments()["Enabled"].(bool)
+// uptimeCheckWithState is the result of uptime-check-create and uptime-check-update: the check and its
+// current state, which is left out with the reason when it can't be fetched.
+type uptimeCheckWithState struct {
+	ID         string                 `json:"id"`
+	Check      *godo.UptimeCheck      `json:"check"`
+	State      *godo.UptimeCheckState `json:"state,omitempty"`
+	StateError string                 `json:"state_error,omitempty"`
+}
 
-	createRequest := &godo.CreateUptimeCheckRequest{
-		Name:    name,
-		Type:    checkType,
-		Target:  target,
-		Regions: regions,
-		Enabled: enabled,
+// validateUptimeCheck checks that target suits the check type, a host name or IP address for ping and
+// a URL of the same scheme for http and https, and that regions holds at least one known region. It
+// returns the regions without duplicates.
+func validateUptimeCheck(checkType, target string, regions []string) ([]string, error) {
+	if !slices.Contains(uptimeCheckTypes, checkType) {
+		return nil, fmt.Errorf("argument 'Type' must be one of: %s", strings.Join(uptimeCheckTypes, ", "))
+	}
+	if checkType == "ping" {
+		if strings.Contains(target, "://") || strings.ContainsAny(target, "/?#") {
+			return nil, fmt.Errorf("a ping check targets a host name or IP address, without a scheme or path, got %s", target)
+		}
+	} else if u, err := url.Parse(target); err != nil || u.Scheme != checkType || u.Host == "" {
+		return nil, fmt.Errorf("an %s check must target an %s:// URL, got %s", checkType, checkType, target)
 	}
 
-	client, err := c.client(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	var valid []string
+	for _, region := range regions {
+		if !slices.Contains(uptimeCheckRegions, region) {
+			return nil, fmt.Errorf("unknown region %s, regions are: %s", region, strings.Join(uptimeCheckRegions, ", "))
+		}
+		if !slices.Contains(valid, region) {
+			valid = append(valid, region)
+		}
+	}
+	if len(valid) == 0 {
+		return nil, fmt.Errorf("at least one region is required, regions are: %s", strings.Join(uptimeCheckRegions, ", "))
 	}
+	return valid, nil
+}
 
-	uptimeCheck, _, err := client.UptimeChecks.Create(ctx, createRequest)
+// uptimeCheckResult returns a created or updated check along with its state.
+func uptimeCheckResult(ctx context.Context, client *godo.Client, check *godo.UptimeCheck) (*mcp.CallToolResult, error) {
+	result := uptimeCheckWithState{ID: check.ID, Check: check}
+	state, _, err := client.UptimeChecks.GetState(ctx, check.ID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		result.StateError = err.Error()
+	} else {
+		result.State = state
 	}
 
-	jsonUptimeCheck, err := response.CompactJSON(uptimeCheck)
-
+	jsonData, err := response.CompactJSON(result)
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
-
-	return mcp.NewToolResultText(jsonUptimeCheck), nil
+	return mcp.NewToolResultText(jsonData), nil
 }
 
-// updateUptimeCheck updates a existing UptimeCheck
-func (c *UptimeTool) updateUptimeCheck(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	id, ok := req.GetArguments()["ID"].(string)
-	if !ok || id == "" {
-		return mcp.NewToolResultError("UptimeCheck ID is required"), nil
+// createUptimeCheck creates a new UptimeCheck probing the target from the given regions.
+func (c *UptimeTool) createUptimeCheck(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	name := args.RequireString("Name")
+	checkType := strings.ToLower(args.RequireString("Type"))
+	target := args.RequireString("Target")
+	regions := args.OptionalStrings("Regions")
+	enabled := args.OptionalBool("Enabled", true)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	regions, err := validateUptimeCheck(checkType, target, regions)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	name := req.GetArguments()["Name"].(string)
-	checkType := req.GetArguments()["Type"].(string)
-	target := req.GetArguments()["Target"].(string)
-	enabled := req.GetArguments()["Enabled"].(bool)
-
-	rawRegions, _ := req.GetArguments()["Regions"]
-	var regions []string
-	if arr, ok := rawRegions.([]interface{}); ok {
-		for _, v := range arr {
-			if s, ok := v.(string); ok {
-				regions = append(regions, s)
-			}
-		}
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	updateRequest := &godo.UpdateUptimeCheckRequest{
+	uptimeCheck, _, err := client.UptimeChecks.Create(ctx, &godo.CreateUptimeCheckRequest{
 		Name:    name,
 		Type:    checkType,
 		Target:  target,
 		Regions: regions,
 		Enabled: enabled,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return uptimeCheckResult(ctx, client, uptimeCheck)
+}
+
+// updateUptimeCheck updates an existing UptimeCheck. Arguments that are not set keep their current
+// value, and the resulting type, target and regions are validated together.
+func (c *UptimeTool) updateUptimeCheck(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	id := args.RequireString("ID")
+	name := args.OptionalString("Name", "")
+	checkType := strings.ToLower(args.OptionalString("Type", ""))
+	target := args.OptionalString("Target", "")
+	regions := args.OptionalStrings("Regions")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	client, err := c.client(ctx)
@@ -184,17 +221,42 @@ func (c *UptimeTool) updateUptimeCheck(ctx context.Context, req mcp.CallToolRequ
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	uptimeCheck, _, err := client.UptimeChecks.Update(ctx, id, updateRequest)
+	current, _, err := client.UptimeChecks.Get(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(err, "uptime check", id), nil
 	}
-
-	jsonUptimeCheck, err := response.CompactJSON(uptimeCheck)
+	if name == "" {
+		name = current.Name
+	}
+	if checkType == "" {
+		checkType = current.Type
+	}
+	if target == "" {
+		target = current.Target
+	}
+	if regions == nil {
+		regions = current.Regions
+	}
+	enabled := args.OptionalBool("Enabled", current.Enabled)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	regions, err = validateUptimeCheck(checkType, target, regions)
 	if err != nil {
-		return nil, fmt.Errorf("marshal error: %w", err)
+		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(jsonUptimeCheck), nil
+	uptimeCheck, _, err := client.UptimeChecks.Update(ctx, id, &godo.UpdateUptimeCheckRequest{
+		Name:    name,
+		Type:    checkType,
+		Target:  target,
+		Regions: regions,
+		Enabled: enabled,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return uptimeCheckResult(ctx, client, uptimeCheck)
 }
 
 // deleteUptimeCheck deletes a UptimeCheck
@@ -211,7 +273,7 @@ func (c *UptimeTool) deleteUptimeCheck(ctx context.Context, req mcp.CallToolRequ
 
 	_, err = client.UptimeChecks.Delete(ctx, id)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(err, "uptime check", id), nil
 	}
 
 	return mcp.NewToolResultText("uptimeCheckID deleted successfully"), nil
@@ -222,21 +284,21 @@ func (c *UptimeTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
 			Handler: c.getUptimeCheck,
-			Tool: mcp.NewTool("uptimecheck-get",
+			Tool: mcp.NewTool("uptime-check-get",
 				mcp.WithDescription("Get UptimeCheck information by ID"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the UptimeCheck")),
 			),
 		},
 		{
 			Handler: c.getUptimeCheckState,
-			Tool: mcp.NewTool("uptimecheck-get-state",
+			Tool: mcp.NewTool("uptime-check-get-state",
 				mcp.WithDescription("Get UptimeCheck information by ID"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the UptimeCheck")),
 			),
 		},
 		{
 			Handler: c.listUptimeChecks,
-			Tool: mcp.NewTool("uptimecheck-list",
+			Tool: mcp.NewTool("uptime-check-list",
 				mcp.WithDescription("List UptimeChecks with pagination"),
 				mcp.WithNumber("Page", mcp.DefaultNumber(defaultChecksPage), mcp.Description("Page number")),
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(defaultChecksPageSize), mcp.Description("Items per page")),
@@ -244,32 +306,32 @@ func (c *UptimeTool) Tools() []server.ServerTool {
 		},
 		{
 			Handler: c.createUptimeCheck,
-			Tool: mcp.NewTool("uptimecheck-create",
-				mcp.WithDescription("Create a new UptimeCheck"),
+			Tool: mcp.NewTool("uptime-check-create",
+				mcp.WithDescription("Create a new UptimeCheck probing a target from one or more regions. Returns the check ID, the check and its state."),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the UptimeCheck")),
-				mcp.WithString("Type", mcp.Required(), mcp.Description("Type of the UptimeCheck. value : HTTPS, HTTP or PING")),
-				mcp.WithString("Target", mcp.Required(), mcp.Description("Endpoint to check for the UptimeCheck")),
-				mcp.WithArray("Regions", mcp.Description("Regions where you'd like to perform these checks. values : \"us_east\", \"us_west\", \"eu_west\", \"se_asia\""),
-					mcp.WithStringEnumItems([]string{"us_east", "us_west", "eu_west", "se_asia"})),
-				mcp.WithBoolean("Enabled", mcp.Required(), mcp.Description("A boolean value indicating whether the check is enabled or disabled.")),
+				mcp.WithString("Type", mcp.Required(), mcp.Enum(uptimeCheckTypes...), mcp.Description("Type of the UptimeCheck. A ping check targets a host name or IP address, an http or https check a URL of that scheme")),
+				mcp.WithString("Target", mcp.Required(), mcp.Description("Endpoint to check, e.g. example.com for ping or https://example.com/health for https")),
+				mcp.WithArray("Regions", mcp.Required(), mcp.Description("Regions to perform the checks from, at least one"),
+					mcp.WithStringEnumItems(uptimeCheckRegions)),
+				mcp.WithBoolean("Enabled", mcp.DefaultBool(true), mcp.Description("Whether the check is enabled")),
 			),
 		},
 		{
 			Handler: c.updateUptimeCheck,
-			Tool: mcp.NewTool("uptimecheck-update",
-				mcp.WithDescription("Update a UptimeCheck"),
+			Tool: mcp.NewTool("uptime-check-update",
+				mcp.WithDescription("Update a UptimeCheck. Arguments that are not set keep their current value. Returns the check ID, the check and its state."),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the UptimeCheck")),
-				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the UptimeCheck")),
-				mcp.WithString("Type", mcp.Required(), mcp.Description("Type of the UptimeCheck. value : HTTPS, HTTP or PING")),
-				mcp.WithString("Target", mcp.Required(), mcp.Description("Endpoint to check for the UptimeCheck")),
-				mcp.WithArray("Regions", mcp.Description("Regions where you'd like to perform these checks. values : \"us_east\", \"us_west\", \"eu_west\", \"se_asia\""),
-					mcp.WithStringEnumItems([]string{"us_east", "us_west", "eu_west", "se_asia"})),
-				mcp.WithBoolean("Enabled", mcp.Required(), mcp.Description("A boolean value indicating whether the check is enabled or disabled.")),
+				mcp.WithString("Name", mcp.Description("Name of the UptimeCheck")),
+				mcp.WithString("Type", mcp.Enum(uptimeCheckTypes...), mcp.Description("Type of the UptimeCheck. A ping check targets a host name or IP address, an http or https check a URL of that scheme")),
+				mcp.WithString("Target", mcp.Description("Endpoint to check")),
+				mcp.WithArray("Regions", mcp.Description("Regions to perform the checks from, at least one"),
+					mcp.WithStringEnumItems(uptimeCheckRegions)),
+				mcp.WithBoolean("Enabled", mcp.Description("Whether the check is enabled")),
 			),
 		},
 		{
 			Handler: c.deleteUptimeCheck,
-			Tool: mcp.NewTool("uptimecheck-delete",
+			Tool: mcp.NewTool("uptime-check-delete",
 				mcp.WithDescription("Delete a uptimeCheck"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the uptimeCheck to delete")),
 			),
@@ -2,7 +2,9 @@ package insights
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/digitalocean/godo"
@@ -205,35 +207,76 @@ func TestUptimeTool_listUptimeChecks(t *testing.T) {
 func TestUptimeTool_createUptimeCheck(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	testUptimeCheck := &godo.UptimeCheck{ID: "id1", Name: "n"}
+	testUptimeCheck := &godo.UptimeCheck{ID: "id1", Name: "n", Type: "https", Target: "https://example.com", Regions: []string{"us_east", "eu_west"}, Enabled: true}
+	testState := &godo.UptimeCheckState{}
 
 	tests := []struct {
 		name        string
 		args        map[string]any
 		mockSetup   func(*MockUptimeChecksService)
-		expectError bool
-		expect      *godo.UptimeCheck
+		expectError string
+		expectState bool
 	}{
 		{
 			name: "api error",
-			args: map[string]any{"Name": "n", "Type": "t", "Target": "x", "Regions": []string{"nyc"}, "Enabled": true},
+			args: map[string]any{"Name": "n", "Type": "https", "Target": "https://example.com", "Regions": []any{"us_east"}},
 			mockSetup: func(m *MockUptimeChecksService) {
 				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api error"))
 			},
-			expectError: true,
+			expectError: "api error",
 		},
 		{
 			name: "success",
-			args: map[string]any{"Name": "n", "Type": "t", "Target": "x", "Regions": []string{"nyc"}, "Enabled": true},
+			args: map[string]any{"Name": "n", "Type": "HTTPS", "Target": "https://example.com", "Regions": []any{"us_east", "eu_west", "us_east"}},
 			mockSetup: func(m *MockUptimeChecksService) {
-				m.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
-					func(_ context.Context, req *godo.CreateUptimeCheckRequest) (*godo.UptimeCheck, *godo.Response, error) {
-						require.Equal(t, "n", req.Name)
-						return testUptimeCheck, nil, nil
-					},
-				)
+				m.EXPECT().Create(gomock.Any(), &godo.CreateUptimeCheckRequest{
+					Name:    "n",
+					Type:    "https",
+					Target:  "https://example.com",
+					Regions: []string{"us_east", "eu_west"},
+					Enabled: true,
+				}).Return(testUptimeCheck, nil, nil)
+				m.EXPECT().GetState(gomock.Any(), "id1").Return(testState, nil, nil)
 			},
-			expect: testUptimeCheck,
+			expectState: true,
+		},
+		{
+			name: "state not available",
+			args: map[string]any{"Name": "n", "Type": "ping", "Target": "203.0.113.10", "Regions": []any{"se_asia"}, "Enabled": false},
+			mockSetup: func(m *MockUptimeChecksService) {
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(testUptimeCheck, nil, nil)
+				m.EXPECT().GetState(gomock.Any(), "id1").Return(nil, nil, errors.New("no state yet"))
+			},
+		},
+		{
+			name:        "ping with a path",
+			args:        map[string]any{"Name": "n", "Type": "ping", "Target": "example.com/health", "Regions": []any{"us_east"}},
+			expectError: "a ping check targets a host name or IP address",
+		},
+		{
+			name:        "scheme not matching the type",
+			args:        map[string]any{"Name": "n", "Type": "http", "Target": "https://example.com", "Regions": []any{"us_east"}},
+			expectError: "an http check must target an http:// URL",
+		},
+		{
+			name:        "unknown type",
+			args:        map[string]any{"Name": "n", "Type": "tcp", "Target": "example.com", "Regions": []any{"us_east"}},
+			expectError: "argument 'Type' must be one of: ping, http, https",
+		},
+		{
+			name:        "unknown region",
+			args:        map[string]any{"Name": "n", "Type": "ping", "Target": "example.com", "Regions": []any{"nyc"}},
+			expectError: "unknown region nyc",
+		},
+		{
+			name:        "no region",
+			args:        map[string]any{"Name": "n", "Type": "ping", "Target": "example.com", "Regions": []any{}},
+			expectError: "at least one region is required",
+		},
+		{
+			name:        "missing name",
+			args:        map[string]any{"Type": "ping", "Target": "example.com", "Regions": []any{"us_east"}},
+			expectError: "argument 'Name' is required",
 		},
 	}
 
@@ -246,15 +289,25 @@ func TestUptimeTool_createUptimeCheck(t *testing.T) {
 			tool := setupUptimeToolWithMock(mockChecks)
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
 			resp, err := tool.createUptimeCheck(context.Background(), req)
-			if tc.expectError {
-				require.NotNil(t, resp)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
 				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
 				return
 			}
-			require.NoError(t, err)
-			require.NotNil(t, resp)
 			require.False(t, resp.IsError)
-			require.NotEmpty(t, resp.Content)
+			var out uptimeCheckWithState
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, "id1", out.ID)
+			require.Equal(t, testUptimeCheck, out.Check)
+			if tc.expectState {
+				require.NotNil(t, out.State)
+				require.Empty(t, out.StateError)
+			} else {
+				require.Nil(t, out.State)
+				require.Equal(t, "no state yet", out.StateError)
+			}
 		})
 	}
 }
@@ -262,41 +315,67 @@ func TestUptimeTool_createUptimeCheck(t *testing.T) {
 func TestUptimeTool_updateUptimeCheck(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	testUptimeCheck := &godo.UptimeCheck{ID: "id1", Name: "n"}
+	current := &godo.UptimeCheck{ID: "id1", Name: "n", Type: "https", Target: "https://example.com", Regions: []string{"us_east"}, Enabled: true}
+	testUptimeCheck := &godo.UptimeCheck{ID: "id1", Name: "renamed"}
 
 	tests := []struct {
 		name        string
 		args        map[string]any
 		mockSetup   func(*MockUptimeChecksService)
-		expectError bool
-		expect      *godo.UptimeCheck
+		expectError string
 	}{
 		{
 			name:        "missing ID",
 			args:        map[string]any{},
-			mockSetup:   nil,
-			expectError: true,
+			expectError: "argument 'ID' is required",
 		},
 		{
 			name: "api error",
-			args: map[string]any{"ID": "id1", "Name": "n", "Type": "t", "Target": "x", "Regions": []string{"nyc"}, "Enabled": true},
+			args: map[string]any{"ID": "id1", "Name": "renamed"},
 			mockSetup: func(m *MockUptimeChecksService) {
+				m.EXPECT().Get(gomock.Any(), "id1").Return(current, nil, nil)
 				m.EXPECT().Update(gomock.Any(), "id1", gomock.Any()).Return(nil, nil, errors.New("api error"))
 			},
-			expectError: true,
+			expectError: "api error",
 		},
 		{
-			name: "success",
-			args: map[string]any{"ID": "id1", "Name": "n", "Type": "t", "Target": "x", "Regions": []string{"nyc"}, "Enabled": true},
+			name: "success keeps the fields not set",
+			args: map[string]any{"ID": "id1", "Name": "renamed", "Enabled": false},
 			mockSetup: func(m *MockUptimeChecksService) {
-				m.EXPECT().Update(gomock.Any(), "id1", gomock.Any()).DoAndReturn(
-					func(_ context.Context, id string, req *godo.UpdateUptimeCheckRequest) (*godo.UptimeCheck, *godo.Response, error) {
-						require.Equal(t, "id1", id)
-						return testUptimeCheck, nil, nil
-					},
-				)
+				m.EXPECT().Get(gomock.Any(), "id1").Return(current, nil, nil)
+				m.EXPECT().Update(gomock.Any(), "id1", &godo.UpdateUptimeCheckRequest{
+					Name:    "renamed",
+					Type:    "https",
+					Target:  "https://example.com",
+					Regions: []string{"us_east"},
+					Enabled: false,
+				}).Return(testUptimeCheck, nil, nil)
+				m.EXPECT().GetState(gomock.Any(), "id1").Return(&godo.UptimeCheckState{}, nil, nil)
 			},
-			expect: testUptimeCheck,
+		},
+		{
+			name: "type change checked against the current target",
+			args: map[string]any{"ID": "id1", "Type": "ping"},
+			mockSetup: func(m *MockUptimeChecksService) {
+				m.EXPECT().Get(gomock.Any(), "id1").Return(current, nil, nil)
+			},
+			expectError: "a ping check targets a host name or IP address",
+		},
+		{
+			name: "empty regions",
+			args: map[string]any{"ID": "id1", "Regions": []any{}},
+			mockSetup: func(m *MockUptimeChecksService) {
+				m.EXPECT().Get(gomock.Any(), "id1").Return(current, nil, nil)
+			},
+			expectError: "at least one region is required",
+		},
+		{
+			name: "check not found",
+			args: map[string]any{"ID": "id1"},
+			mockSetup: func(m *MockUptimeChecksService) {
+				m.EXPECT().Get(gomock.Any(), "id1").Return(nil, nil, &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}})
+			},
+			expectError: "resource not found",
 		},
 	}
 
@@ -309,15 +388,17 @@ func TestUptimeTool_updateUptimeCheck(t *testing.T) {
 			tool := setupUptimeToolWithMock(mockChecks)
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
 			resp, err := tool.updateUptimeCheck(context.Background(), req)
-			if tc.expectError {
-				require.NotNil(t, resp)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
 				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
 				return
 			}
-			require.NoError(t, err)
-			require.NotNil(t, resp)
 			require.False(t, resp.IsError)
-			require.NotEmpty(t, resp.Content)
+			var out uptimeCheckWithState
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, testUptimeCheck, out.Check)
 		})
 	}
 }
@@ -0,0 +1,116 @@
+package registry
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	middleware "mcp-digitalocean/internal"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// redacted replaces the value of sensitive arguments in call logs.
+const redacted = "[REDACTED]"
+
+// sensitiveArgNames are substrings of argument names whose values are never logged.
+var sensitiveArgNames = []string{"key", "token", "password", "secret", "credential"}
+
+// WithCallLogging logs every tool call at the given level with its name, sanitized arguments,
+// duration and outcome. Failed calls are logged at warn level or above.
+func WithCallLogging(level slog.Level) Option {
+	return func(o *options) {
+		o.callLogging = true
+		o.callLogLevel = level
+	}
+}
+
+// isSensitiveArg reports whether the value of the named argument must be redacted.
+func isSensitiveArg(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveArgNames {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecretTyped reports whether args describe a value typed as a secret, such as an app
+// environment variable of type SECRET.
+func isSecretTyped(args map[string]any) bool {
+	for k, v := range args {
+		if s, ok := v.(string); ok && strings.EqualFold(k, "type") && strings.EqualFold(s, "secret") {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeArgs returns a copy of args safe to log. Values of sensitive arguments, at any depth,
+// values typed as secrets and strings containing a PEM private key are replaced.
+func sanitizeArgs(args map[string]any) map[string]any {
+	if args == nil {
+		return nil
+	}
+	secret := isSecretTyped(args)
+	out := make(map[string]any, len(args))
+	for k, v := range args {
+		if isSensitiveArg(k) || (secret && strings.EqualFold(k, "value")) {
+			out[k] = redacted
+			continue
+		}
+		out[k] = sanitizeValue(v)
+	}
+	return out
+}
+
+func sanitizeValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return sanitizeArgs(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = sanitizeValue(item)
+		}
+		return out
+	case string:
+		if strings.Contains(v, "PRIVATE KEY-----") {
+			return redacted
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func loggingDecorator(logger *slog.Logger, level slog.Level) toolDecorator {
+	failureLevel := max(level, slog.LevelWarn)
+	return func(tool server.ServerTool) server.ServerTool {
+		name := tool.Tool.Name
+		next := tool.Handler
+		tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, req)
+
+			attrs := []any{
+				"tool", name,
+				"arguments", sanitizeArgs(req.GetArguments()),
+				"duration_seconds", time.Since(start).Seconds(),
+			}
+			switch {
+			case err != nil:
+				logger.Log(ctx, failureLevel, "tool call", append(attrs, "error", err, "tool_call_outcome", middleware.ToolCallError)...)
+			case result != nil && result.IsError:
+				logger.Log(ctx, failureLevel, "tool call", append(attrs, "tool_call_outcome", middleware.ToolCallResultError)...)
+			default:
+				logger.Log(ctx, level, "tool call", append(attrs, "tool_call_outcome", middleware.ToolCallSuccess)...)
+			}
+			return result, err
+		}
+		return tool
+	}
+}
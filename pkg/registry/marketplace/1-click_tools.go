@@ -3,6 +3,7 @@ package marketplace
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"mcp-digitalocean/pkg/response"
 
@@ -11,6 +12,9 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// kubernetesOneClickType is the 1-click app type installable on Kubernetes clusters.
+const kubernetesOneClickType = "kubernetes"
+
 type OneClickTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
 }
@@ -95,16 +99,37 @@ func (o *OneClickTool) installKubernetesApps(ctx context.Context, req mcp.CallTo
 		return mcp.NewToolResultError("AppSlugs cannot be empty"), nil
 	}
 
-	installRequest := &godo.InstallKubernetesAppsRequest{
-		Slugs:       slugs,
-		ClusterUUID: clusterUUID,
-	}
-
 	client, err := o.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	// Reject unknown slugs up front, the install API only reports them after scheduling the job.
+	available, _, err := client.OneClick.List(ctx, kubernetesOneClickType)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to list Kubernetes 1-click apps: %v", err)), nil
+	}
+	known := make(map[string]bool, len(available))
+	for _, app := range available {
+		if app != nil {
+			known[app.Slug] = true
+		}
+	}
+	var unknown []string
+	for _, slug := range slugs {
+		if !known[slug] {
+			unknown = append(unknown, slug)
+		}
+	}
+	if len(unknown) > 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("unknown Kubernetes 1-click app slugs: %s. Use 1-click-list with Type \"kubernetes\" to see available apps", strings.Join(unknown, ", "))), nil
+	}
+
+	installRequest := &godo.InstallKubernetesAppsRequest{
+		Slugs:       slugs,
+		ClusterUUID: clusterUUID,
+	}
+
 	installResponse, _, err := client.OneClick.InstallKubernetes(ctx, installRequest)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to install Kubernetes apps: %v", err)), nil
@@ -127,12 +152,20 @@ func (o *OneClickTool) Tools() []server.ServerTool {
 				mcp.WithString("Type", mcp.Description("Type of 1-click apps to list (e.g., 'droplet', 'kubernetes'). Defaults to 'droplet'")),
 			),
 		},
+	}
+}
+
+// InstallTools returns the tools installing 1-click applications.
+func (o *OneClickTool) InstallTools() []server.ServerTool {
+	return []server.ServerTool{
 		{
 			Handler: o.installKubernetesApps,
-			Tool: mcp.NewTool("1-click-kubernetes-app-install",
-				mcp.WithDescription("Install 1-click applications on a Kubernetes cluster"),
+			Tool: mcp.NewTool("oneclick-install-kubernetes",
+				mcp.WithDescription("Install 1-click applications on a Kubernetes cluster. Slugs are checked against the available Kubernetes 1-click apps before installing. Returns the installation status"),
 				mcp.WithString("ClusterUUID", mcp.Required(), mcp.Description("UUID of the Kubernetes cluster to install apps on")),
-				mcp.WithArray("AppSlugs", mcp.Required(), mcp.Description("Array of app slugs to install")),
+				mcp.WithArray("AppSlugs", mcp.Required(), mcp.Description("Array of Kubernetes 1-click app slugs to install (see 1-click-list with Type kubernetes)"), mcp.Items(map[string]any{
+					"type": "string",
+				})),
 			),
 		},
 	}
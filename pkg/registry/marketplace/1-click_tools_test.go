@@ -19,7 +19,7 @@ func TestOneClickTool_Tools(t *testing.T) {
 
 	tool := NewOneClickTool(client)
 
-	tools := tool.Tools()
+	tools := append(tool.Tools(), tool.InstallTools()...)
 	assert.Len(t, tools, 2)
 
 	// Check tool names
@@ -29,7 +29,7 @@ func TestOneClickTool_Tools(t *testing.T) {
 	}
 
 	assert.Contains(t, toolNames, "1-click-list")
-	assert.Contains(t, toolNames, "1-click-kubernetes-app-install")
+	assert.Contains(t, toolNames, "oneclick-install-kubernetes")
 }
 
 func setupOneClickToolWithMock(mockOneClick *MockOneClickService) *OneClickTool {
@@ -144,6 +144,10 @@ func TestOneClickTool_installKubernetesApps(t *testing.T) {
 	testResponse := &godo.InstallKubernetesAppsResponse{
 		Message: "Apps installed successfully",
 	}
+	kubernetesApps := []*godo.OneClick{
+		{Slug: "wordpress", Type: "kubernetes"},
+		{Slug: "mysql", Type: "kubernetes"},
+	}
 
 	tests := []struct {
 		name        string
@@ -159,6 +163,10 @@ func TestOneClickTool_installKubernetesApps(t *testing.T) {
 				"AppSlugs":    []interface{}{"wordpress", "mysql"},
 			},
 			mockSetup: func(m *MockOneClickService) {
+				m.EXPECT().
+					List(gomock.Any(), "kubernetes").
+					Return(kubernetesApps, nil, nil).
+					Times(1)
 				expectedRequest := &godo.InstallKubernetesAppsRequest{
 					Slugs:       []string{"wordpress", "mysql"},
 					ClusterUUID: "k8s-1234567890abcdef",
@@ -244,6 +252,10 @@ func TestOneClickTool_installKubernetesApps(t *testing.T) {
 				"AppSlugs":    []interface{}{"wordpress"},
 			},
 			mockSetup: func(m *MockOneClickService) {
+				m.EXPECT().
+					List(gomock.Any(), "kubernetes").
+					Return(kubernetesApps, nil, nil).
+					Times(1)
 				m.EXPECT().
 					InstallKubernetes(gomock.Any(), gomock.Any()).
 					Return(nil, nil, errors.New("api error")).
@@ -252,6 +264,36 @@ func TestOneClickTool_installKubernetesApps(t *testing.T) {
 			expectError: true,
 			errorMsg:    "Failed to install Kubernetes apps",
 		},
+		{
+			name: "Unknown app slug",
+			args: map[string]interface{}{
+				"ClusterUUID": "k8s-1234567890abcdef",
+				"AppSlugs":    []interface{}{"wordpress", "not-an-app"},
+			},
+			mockSetup: func(m *MockOneClickService) {
+				m.EXPECT().
+					List(gomock.Any(), "kubernetes").
+					Return(kubernetesApps, nil, nil).
+					Times(1)
+			},
+			expectError: true,
+			errorMsg:    "unknown Kubernetes 1-click app slugs: not-an-app",
+		},
+		{
+			name: "List error",
+			args: map[string]interface{}{
+				"ClusterUUID": "k8s-1234567890abcdef",
+				"AppSlugs":    []interface{}{"wordpress"},
+			},
+			mockSetup: func(m *MockOneClickService) {
+				m.EXPECT().
+					List(gomock.Any(), "kubernetes").
+					Return(nil, nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
+			errorMsg:    "Failed to list Kubernetes 1-click apps",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"context"
+	"time"
+
+	"mcp-digitalocean/pkg/metrics"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// WithMetrics records the count, outcome and duration of every tool call in m.
+func WithMetrics(m *metrics.Registry) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+func metricsDecorator(m *metrics.Registry) toolDecorator {
+	return func(tool server.ServerTool) server.ServerTool {
+		name := tool.Tool.Name
+		next := tool.Handler
+		tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, req)
+
+			outcome := metrics.OutcomeSuccess
+			switch {
+			case err != nil:
+				outcome = metrics.OutcomeError
+			case result != nil && result.IsError:
+				outcome = metrics.OutcomeResultError
+			}
+			m.ObserveCall(name, outcome, time.Since(start))
+			return result, err
+		}
+		return tool
+	}
+}
@@ -0,0 +1,42 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"mcp-digitalocean/pkg/metrics"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsDecorator(t *testing.T) {
+	m := metrics.NewRegistry()
+	var (
+		result *mcp.CallToolResult
+		err    error
+	)
+	tool := metricsDecorator(m)(server.ServerTool{
+		Tool: mcp.NewTool("droplet-get"),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return result, err
+		},
+	})
+	call := func() {
+		_, _ = tool.Handler(context.Background(), mcp.CallToolRequest{})
+	}
+
+	result = mcp.NewToolResultText("ok")
+	call()
+	call()
+	result = mcp.NewToolResultError("droplet not found")
+	call()
+	result, err = nil, errors.New("failed to get DigitalOcean client")
+	call()
+
+	require.Equal(t, uint64(2), m.Calls("droplet-get", metrics.OutcomeSuccess))
+	require.Equal(t, uint64(1), m.Calls("droplet-get", metrics.OutcomeResultError))
+	require.Equal(t, uint64(1), m.Calls("droplet-get", metrics.OutcomeError))
+}
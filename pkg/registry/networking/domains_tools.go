@@ -2,8 +2,12 @@ package networking
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -156,6 +160,308 @@ func (d *DomainsTool) createDomain(ctx context.Context, req mcp.CallToolRequest)
 	return mcp.NewToolResultText(jsonDomain), nil
 }
 
+// getDomainZoneFile fetches the BIND zone file of a domain
+func (d *DomainsTool) getDomainZoneFile(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, ok := req.GetArguments()["Name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Domain name is required"), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	domain, _, err := client.Domains.Get(ctx, name)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	return mcp.NewToolResultText(domain.ZoneFile), nil
+}
+
+// parseDomainRecords converts the Records argument into record create requests
+func parseDomainRecords(records []any) ([]*godo.DomainRecordEditRequest, error) {
+	requests := make([]*godo.DomainRecordEditRequest, 0, len(records))
+	for i, recordData := range records {
+		record, ok := recordData.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("record %d: invalid record format", i)
+		}
+		recordType, _ := record["Type"].(string)
+		if recordType == "" {
+			return nil, fmt.Errorf("record %d: Type is required", i)
+		}
+		name, _ := record["Name"].(string)
+		if name == "" {
+			return nil, fmt.Errorf("record %d: Name is required", i)
+		}
+		data, _ := record["Data"].(string)
+		if data == "" {
+			return nil, fmt.Errorf("record %d: Data is required", i)
+		}
+		priority, _ := record["Priority"].(float64)
+		port, _ := record["Port"].(float64)
+		ttl, _ := record["TTL"].(float64)
+		weight, _ := record["Weight"].(float64)
+
+		requests = append(requests, &godo.DomainRecordEditRequest{
+			Type:     recordType,
+			Name:     name,
+			Data:     data,
+			Priority: int(priority),
+			Port:     int(port),
+			TTL:      int(ttl),
+			Weight:   int(weight),
+		})
+	}
+	return requests, nil
+}
+
+// createDomainWithRecords creates a domain and its records, deleting the domain again if a record
+// cannot be created
+func (d *DomainsTool) createDomainWithRecords(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	name, ok := req.GetArguments()["Name"].(string)
+	if !ok || name == "" {
+		return mcp.NewToolResultError("Domain name is required"), nil
+	}
+	ipAddress, _ := req.GetArguments()["IPAddress"].(string)
+	recordArgs, ok := req.GetArguments()["Records"].([]any)
+	if !ok || len(recordArgs) == 0 {
+		return mcp.NewToolResultError("Records is required"), nil
+	}
+	recordRequests, err := parseDomainRecords(recordArgs)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	domain, _, err := client.Domains.Create(ctx, &godo.DomainCreateRequest{Name: name, IPAddress: ipAddress})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	records := make([]*godo.DomainRecord, 0, len(recordRequests))
+	for i, recordRequest := range recordRequests {
+		record, _, err := client.Domains.CreateRecord(ctx, name, recordRequest)
+		if err != nil {
+			if _, delErr := client.Domains.Delete(ctx, name); delErr != nil {
+				return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to create record %d and to roll back domain %s", i, name), errors.Join(err, delErr)), nil
+			}
+			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("failed to create record %d, domain %s was rolled back", i, name), err), nil
+		}
+		records = append(records, record)
+	}
+
+	jsonResult, err := response.CompactJSON(map[string]any{
+		"domain":  domain,
+		"records": records,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonResult), nil
+}
+
+// zoneImportRecord is the outcome of importing one record of a zone file.
+type zoneImportRecord struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Data   string `json:"data,omitempty"`
+	Status string `json:"status"`
+	ID     int    `json:"id,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// zoneImportReport is the result of dns-import-zone-file.
+type zoneImportReport struct {
+	Domain  string             `json:"domain"`
+	Created int                `json:"created"`
+	Skipped int                `json:"skipped"`
+	Failed  int                `json:"failed"`
+	Records []zoneImportRecord `json:"records"`
+}
+
+// importZoneFile creates the records of a BIND zone file in an existing domain. Every record is
+// attempted, the report lists the created, skipped and failed ones.
+func (d *DomainsTool) importZoneFile(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	domain, ok := req.GetArguments()["Domain"].(string)
+	if !ok || domain == "" {
+		return mcp.NewToolResultError("Domain name is required"), nil
+	}
+	zoneFile, ok := req.GetArguments()["ZoneFile"].(string)
+	if !ok || strings.TrimSpace(zoneFile) == "" {
+		return mcp.NewToolResultError("ZoneFile is required"), nil
+	}
+	entries, err := parseZoneFile(domain, zoneFile)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("invalid zone file: %s", err)), nil
+	}
+	if len(entries) == 0 {
+		return mcp.NewToolResultError("the zone file has no records"), nil
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	report := zoneImportReport{Domain: domain, Records: make([]zoneImportRecord, 0, len(entries))}
+	for _, entry := range entries {
+		result := zoneImportRecord{Name: entry.Name, Type: entry.Type}
+		if entry.Request != nil {
+			result.Data = entry.Request.Data
+		}
+		switch {
+		case entry.Skip != "":
+			result.Status, result.Reason = "skipped", entry.Skip
+			report.Skipped++
+		case entry.Err != nil:
+			result.Status, result.Reason = "failed", entry.Err.Error()
+			report.Failed++
+		default:
+			record, _, err := client.Domains.CreateRecord(ctx, domain, entry.Request)
+			if err != nil {
+				result.Status, result.Reason = "failed", err.Error()
+				report.Failed++
+				break
+			}
+			result.Status = "created"
+			if record != nil {
+				result.ID = record.ID
+			}
+			report.Created++
+		}
+		report.Records = append(report.Records, result)
+	}
+
+	jsonReport, err := response.CompactJSON(report)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonReport), nil
+}
+
+// DigitalOcean accepts record TTLs between these bounds, in seconds.
+const (
+	minRecordTTL = 30
+	maxRecordTTL = 604800
+)
+
+// recordTTLUpdate is the outcome of setting the TTL of one record.
+type recordTTLUpdate struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	PreviousTTL int    `json:"previous_ttl"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// recordTTLReport is the result of dns-set-all-ttl.
+type recordTTLReport struct {
+	Domain    string            `json:"domain"`
+	TTL       int               `json:"ttl"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Failed    int               `json:"failed"`
+	Records   []recordTTLUpdate `json:"records"`
+}
+
+// listAllDomainRecords returns every record of a domain, following pagination.
+func listAllDomainRecords(ctx context.Context, client *godo.Client, domain string) ([]godo.DomainRecord, error) {
+	var all []godo.DomainRecord
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		records, resp, err := client.Domains.Records(ctx, domain, opt)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, records...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return all, nil
+		}
+		opt.Page++
+	}
+}
+
+// setAllRecordTTL sets the TTL of the records of a domain, optionally only those of some types or
+// with a given name. The SOA record is left alone as DigitalOcean manages it. Every record is
+// attempted, the report lists the updated, unchanged and failed ones.
+func (d *DomainsTool) setAllRecordTTL(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	domain := args.RequireString("Domain")
+	ttl := args.RequireInt("TTL")
+	types := args.OptionalStrings("Types")
+	name := args.OptionalString("Name", "")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if ttl < minRecordTTL || ttl > maxRecordTTL {
+		return mcp.NewToolResultError(fmt.Sprintf("TTL must be between %d and %d seconds", minRecordTTL, maxRecordTTL)), nil
+	}
+	for i, t := range types {
+		types[i] = strings.ToUpper(t)
+	}
+
+	client, err := d.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	records, err := listAllDomainRecords(ctx, client, domain)
+	if err != nil {
+		return common.APIErrorResult(err, "domain", domain), nil
+	}
+
+	report := recordTTLReport{Domain: domain, TTL: ttl, Records: []recordTTLUpdate{}}
+	for _, record := range records {
+		if record.Type == "SOA" || (len(types) > 0 && !slices.Contains(types, record.Type)) || (name != "" && !strings.EqualFold(record.Name, name)) {
+			continue
+		}
+		result := recordTTLUpdate{ID: record.ID, Type: record.Type, Name: record.Name, PreviousTTL: record.TTL}
+		if record.TTL == ttl {
+			result.Status = "unchanged"
+			report.Unchanged++
+			report.Records = append(report.Records, result)
+			continue
+		}
+		// The whole record is sent back, as zero priorities, ports, weights and flags are not
+		// omitted from the request and would overwrite those of MX, SRV and CAA records.
+		_, _, err := client.Domains.EditRecord(ctx, domain, record.ID, &godo.DomainRecordEditRequest{
+			Type:     record.Type,
+			Name:     record.Name,
+			Data:     record.Data,
+			Priority: record.Priority,
+			Port:     record.Port,
+			TTL:      ttl,
+			Weight:   record.Weight,
+			Flags:    record.Flags,
+			Tag:      record.Tag,
+		})
+		if err != nil {
+			result.Status, result.Reason = "failed", err.Error()
+			report.Failed++
+		} else {
+			result.Status = "updated"
+			report.Updated++
+		}
+		report.Records = append(report.Records, result)
+	}
+	if len(report.Records) == 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("no record of domain %s matches the filters", domain)), nil
+	}
+
+	jsonReport, err := response.CompactJSON(report)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonReport), nil
+}
+
 func (d *DomainsTool) deleteDomain(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name := req.GetArguments()["Name"].(string)
 
@@ -292,6 +598,52 @@ func (d *DomainsTool) Tools() []server.ServerTool {
 				mcp.WithString("IPAddress", mcp.Required(), mcp.Description("IP address for the domain")),
 			),
 		},
+		{
+			Handler: d.getDomainZoneFile,
+			Tool: mcp.NewTool("domain-get-zone-file",
+				mcp.WithDescription("Get the BIND zone file of a domain"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the domain")),
+			),
+		},
+		{
+			Handler: d.createDomainWithRecords,
+			Tool: mcp.NewTool("domain-create-with-records",
+				mcp.WithDescription("Create a new domain together with its records. If a record cannot be created, the domain is deleted again."),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the domain")),
+				mcp.WithString("IPAddress", mcp.Description("IP address for an A record at the domain apex")),
+				mcp.WithArray("Records", mcp.Required(), mcp.Description("Records to create, each an object with Type, Name and Data and optional Priority, Port, TTL and Weight"), mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"Type":     map[string]any{"type": "string", "description": "Record type (e.g., A, CNAME, TXT)"},
+						"Name":     map[string]any{"type": "string", "description": "Record name"},
+						"Data":     map[string]any{"type": "string", "description": "Record data"},
+						"Priority": map[string]any{"type": "number", "description": "Priority for MX and SRV records"},
+						"Port":     map[string]any{"type": "number", "description": "Port for SRV records"},
+						"TTL":      map[string]any{"type": "number", "description": "Time to live in seconds"},
+						"Weight":   map[string]any{"type": "number", "description": "Weight for SRV records"},
+					},
+					"required": []string{"Type", "Name", "Data"},
+				})),
+			),
+		},
+		{
+			Handler: d.importZoneFile,
+			Tool: mcp.NewTool("dns-import-zone-file",
+				mcp.WithDescription("Import the records of a BIND zone file into an existing domain. Supports A, AAAA, CAA, CNAME, MX, NS, SRV and TXT records and the $ORIGIN, $TTL and $GENERATE directives. SOA and apex NS records are skipped as DigitalOcean manages them. Every record is attempted and a per-record report is returned."),
+				mcp.WithString("Domain", mcp.Required(), mcp.Description("Name of the domain to import the records into")),
+				mcp.WithString("ZoneFile", mcp.Required(), mcp.Description("Content of the BIND zone file")),
+			),
+		},
+		{
+			Handler: d.setAllRecordTTL,
+			Tool: mcp.NewTool("dns-set-all-ttl",
+				mcp.WithDescription("Set the TTL of all records of a domain, or of those matching the type and name filters, e.g. to shorten TTLs ahead of a migration. The SOA record is left alone. Every record is attempted and a per-record report is returned."),
+				mcp.WithString("Domain", mcp.Required(), mcp.Description("Domain name")),
+				mcp.WithNumber("TTL", mcp.Required(), mcp.Min(minRecordTTL), mcp.Max(maxRecordTTL), mcp.Description("TTL to set, in seconds, between 30 and 604800")),
+				mcp.WithArray("Types", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Only update records of these types (e.g., A, CNAME), all types when omitted")),
+				mcp.WithString("Name", mcp.Description("Only update records with this name (e.g., www, or @ for the apex), all names when omitted")),
+			),
+		},
 		{
 			Handler: d.deleteDomain,
 			Tool: mcp.NewTool("domain-delete",
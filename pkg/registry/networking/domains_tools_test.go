@@ -717,3 +717,343 @@ func TestDomainsTool_editRecord(t *testing.T) {
 		})
 	}
 }
+
+func TestDomainsTool_getDomainZoneFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	zoneFile := "$ORIGIN example.com.\n$TTL 1800\nexample.com. IN SOA ns1.digitalocean.com. hostmaster.example.com. 1 10800 3600 604800 1800\n"
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDomainsService)
+		expectError bool
+	}{
+		{
+			name: "Successful get",
+			args: map[string]any{"Name": "example.com"},
+			mockSetup: func(m *MockDomainsService) {
+				m.EXPECT().
+					Get(gomock.Any(), "example.com").
+					Return(&godo.Domain{Name: "example.com", ZoneFile: zoneFile}, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "Missing domain argument",
+			args:        map[string]any{},
+			expectError: true,
+		},
+		{
+			name: "API error",
+			args: map[string]any{"Name": "fail.com"},
+			mockSetup: func(m *MockDomainsService) {
+				m.EXPECT().
+					Get(gomock.Any(), "fail.com").
+					Return(nil, nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDomains := NewMockDomainsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDomains)
+			}
+			tool := setupDomainsToolWithMock(mockDomains)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.getDomainZoneFile(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+			require.Equal(t, zoneFile, resp.Content[0].(mcp.TextContent).Text)
+		})
+	}
+}
+
+func TestDomainsTool_createDomainWithRecords(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	recordsArg := []any{
+		map[string]any{"Type": "A", "Name": "www", "Data": "192.0.2.1"},
+		map[string]any{"Type": "MX", "Name": "@", "Data": "mail.example.com.", "Priority": float64(10), "TTL": float64(3600)},
+	}
+	wwwRequest := &godo.DomainRecordEditRequest{Type: "A", Name: "www", Data: "192.0.2.1"}
+	mxRequest := &godo.DomainRecordEditRequest{Type: "MX", Name: "@", Data: "mail.example.com.", Priority: 10, TTL: 3600}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockDomainsService)
+		expectError bool
+		expectText  string
+	}{
+		{
+			name: "Successful create",
+			args: map[string]any{"Name": "example.com", "Records": recordsArg},
+			mockSetup: func(m *MockDomainsService) {
+				gomock.InOrder(
+					m.EXPECT().
+						Create(gomock.Any(), &godo.DomainCreateRequest{Name: "example.com"}).
+						Return(&godo.Domain{Name: "example.com", TTL: 1800}, nil, nil),
+					m.EXPECT().
+						CreateRecord(gomock.Any(), "example.com", wwwRequest).
+						Return(&godo.DomainRecord{ID: 1, Type: "A", Name: "www", Data: "192.0.2.1"}, nil, nil),
+					m.EXPECT().
+						CreateRecord(gomock.Any(), "example.com", mxRequest).
+						Return(&godo.DomainRecord{ID: 2, Type: "MX", Name: "@", Data: "mail.example.com.", Priority: 10, TTL: 3600}, nil, nil),
+				)
+			},
+		},
+		{
+			name: "Record failure rolls back the domain",
+			args: map[string]any{"Name": "example.com", "Records": recordsArg},
+			mockSetup: func(m *MockDomainsService) {
+				gomock.InOrder(
+					m.EXPECT().
+						Create(gomock.Any(), &godo.DomainCreateRequest{Name: "example.com"}).
+						Return(&godo.Domain{Name: "example.com"}, nil, nil),
+					m.EXPECT().
+						CreateRecord(gomock.Any(), "example.com", wwwRequest).
+						Return(&godo.DomainRecord{ID: 1}, nil, nil),
+					m.EXPECT().
+						CreateRecord(gomock.Any(), "example.com", mxRequest).
+						Return(nil, nil, errors.New("invalid MX record")),
+					m.EXPECT().
+						Delete(gomock.Any(), "example.com").
+						Return(nil, nil),
+				)
+			},
+			expectError: true,
+			expectText:  "failed to create record 1, domain example.com was rolled back",
+		},
+		{
+			name: "Invalid record",
+			args: map[string]any{
+				"Name":    "example.com",
+				"Records": []any{map[string]any{"Type": "A", "Name": "www"}},
+			},
+			expectError: true,
+			expectText:  "record 0: Data is required",
+		},
+		{
+			name:        "Missing records",
+			args:        map[string]any{"Name": "example.com"},
+			expectError: true,
+			expectText:  "Records is required",
+		},
+		{
+			name: "Domain create error",
+			args: map[string]any{"Name": "example.com", "Records": recordsArg},
+			mockSetup: func(m *MockDomainsService) {
+				m.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil, nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDomains := NewMockDomainsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDomains)
+			}
+			tool := setupDomainsToolWithMock(mockDomains)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.createDomainWithRecords(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectText)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out struct {
+				Domain  godo.Domain         `json:"domain"`
+				Records []godo.DomainRecord `json:"records"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, "example.com", out.Domain.Name)
+			require.Len(t, out.Records, 2)
+			require.Equal(t, 2, out.Records[1].ID)
+		})
+	}
+}
+
+func TestDomainsTool_importZoneFile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	zoneFile := `$TTL 3600
+@	IN	SOA	ns1.digitalocean.com. hostmaster.example.com. 1 3600 600 604800 1800
+@	IN	A	192.0.2.1
+www	IN	CNAME	@
+host	IN	HINFO	"x86" "linux"
+mail	IN	MX	10 mx1
+`
+	mockDomains := NewMockDomainsService(ctrl)
+	mockDomains.EXPECT().
+		CreateRecord(gomock.Any(), "example.com", &godo.DomainRecordEditRequest{Type: "A", Name: "@", Data: "192.0.2.1", TTL: 3600}).
+		Return(&godo.DomainRecord{ID: 1}, nil, nil)
+	mockDomains.EXPECT().
+		CreateRecord(gomock.Any(), "example.com", &godo.DomainRecordEditRequest{Type: "CNAME", Name: "www", Data: "example.com.", TTL: 3600}).
+		Return(nil, nil, errors.New("record already exists"))
+	mockDomains.EXPECT().
+		CreateRecord(gomock.Any(), "example.com", &godo.DomainRecordEditRequest{Type: "MX", Name: "mail", Data: "mx1.example.com.", Priority: 10, TTL: 3600}).
+		Return(&godo.DomainRecord{ID: 3}, nil, nil)
+	tool := setupDomainsToolWithMock(mockDomains)
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Domain": "example.com", "ZoneFile": zoneFile}}}
+	resp, err := tool.importZoneFile(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+
+	var report zoneImportReport
+	require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &report))
+	require.Equal(t, 2, report.Created)
+	require.Equal(t, 1, report.Skipped)
+	require.Equal(t, 2, report.Failed)
+	require.Equal(t, []zoneImportRecord{
+		{Name: "@", Type: "SOA", Status: "skipped", Reason: "SOA records are managed by DigitalOcean"},
+		{Name: "@", Type: "A", Data: "192.0.2.1", Status: "created", ID: 1},
+		{Name: "www", Type: "CNAME", Data: "example.com.", Status: "failed", Reason: "record already exists"},
+		{Name: "host", Type: "HINFO", Status: "failed", Reason: "unsupported record type HINFO"},
+		{Name: "mail", Type: "MX", Data: "mx1.example.com.", Status: "created", ID: 3},
+	}, report.Records)
+
+	for _, args := range []map[string]any{
+		{"ZoneFile": zoneFile},
+		{"Domain": "example.com"},
+		{"Domain": "example.com", "ZoneFile": "$TTL 3600\n"},
+		{"Domain": "example.com", "ZoneFile": "@ IN TXT \"open\n"},
+	} {
+		resp, err := tool.importZoneFile(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+	}
+}
+
+func TestDomainsTool_setAllRecordTTL(t *testing.T) {
+	records := []godo.DomainRecord{
+		{ID: 1, Type: "SOA", Name: "@", Data: "1800", TTL: 1800},
+		{ID: 2, Type: "A", Name: "@", Data: "192.0.2.1", TTL: 3600},
+		{ID: 3, Type: "MX", Name: "mail", Data: "mx1.example.com", Priority: 10, TTL: 3600},
+		{ID: 4, Type: "CNAME", Name: "www", Data: "@", TTL: 300},
+		{ID: 5, Type: "TXT", Name: "@", Data: "v=spf1 -all", TTL: 3600},
+	}
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		mockSetup     func(*MockDomainsService)
+		expectRecords []recordTTLUpdate
+		expectError   string
+	}{
+		{
+			name: "All records",
+			args: map[string]any{"Domain": "example.com", "TTL": float64(300)},
+			mockSetup: func(m *MockDomainsService) {
+				m.EXPECT().Records(gomock.Any(), "example.com", &godo.ListOptions{Page: 1, PerPage: 200}).Return(records, nil, nil)
+				m.EXPECT().
+					EditRecord(gomock.Any(), "example.com", 2, &godo.DomainRecordEditRequest{Type: "A", Name: "@", Data: "192.0.2.1", TTL: 300}).
+					Return(&godo.DomainRecord{ID: 2}, nil, nil)
+				m.EXPECT().
+					EditRecord(gomock.Any(), "example.com", 3, &godo.DomainRecordEditRequest{Type: "MX", Name: "mail", Data: "mx1.example.com", Priority: 10, TTL: 300}).
+					Return(&godo.DomainRecord{ID: 3}, nil, nil)
+				m.EXPECT().
+					EditRecord(gomock.Any(), "example.com", 5, gomock.Any()).
+					Return(nil, nil, errors.New("rate limited"))
+			},
+			expectRecords: []recordTTLUpdate{
+				{ID: 2, Type: "A", Name: "@", PreviousTTL: 3600, Status: "updated"},
+				{ID: 3, Type: "MX", Name: "mail", PreviousTTL: 3600, Status: "updated"},
+				{ID: 4, Type: "CNAME", Name: "www", PreviousTTL: 300, Status: "unchanged"},
+				{ID: 5, Type: "TXT", Name: "@", PreviousTTL: 3600, Status: "failed", Reason: "rate limited"},
+			},
+		},
+		{
+			name: "Filtered by type and name",
+			args: map[string]any{"Domain": "example.com", "TTL": float64(60), "Types": []any{"a", "txt"}, "Name": "@"},
+			mockSetup: func(m *MockDomainsService) {
+				m.EXPECT().Records(gomock.Any(), "example.com", gomock.Any()).Return(records, nil, nil)
+				m.EXPECT().EditRecord(gomock.Any(), "example.com", 2, gomock.Any()).Return(&godo.DomainRecord{ID: 2}, nil, nil)
+				m.EXPECT().EditRecord(gomock.Any(), "example.com", 5, gomock.Any()).Return(&godo.DomainRecord{ID: 5}, nil, nil)
+			},
+			expectRecords: []recordTTLUpdate{
+				{ID: 2, Type: "A", Name: "@", PreviousTTL: 3600, Status: "updated"},
+				{ID: 5, Type: "TXT", Name: "@", PreviousTTL: 3600, Status: "updated"},
+			},
+		},
+		{
+			name: "No matching record",
+			args: map[string]any{"Domain": "example.com", "TTL": float64(60), "Types": []any{"AAAA"}},
+			mockSetup: func(m *MockDomainsService) {
+				m.EXPECT().Records(gomock.Any(), "example.com", gomock.Any()).Return(records, nil, nil)
+			},
+			expectError: "no record of domain example.com matches the filters",
+		},
+		{
+			name:        "TTL too short",
+			args:        map[string]any{"Domain": "example.com", "TTL": float64(10)},
+			expectError: "TTL must be between 30 and 604800 seconds",
+		},
+		{
+			name:        "TTL too long",
+			args:        map[string]any{"Domain": "example.com", "TTL": float64(604801)},
+			expectError: "TTL must be between 30 and 604800 seconds",
+		},
+		{
+			name:        "Missing domain",
+			args:        map[string]any{"TTL": float64(300)},
+			expectError: "argument 'Domain' is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDomains := NewMockDomainsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockDomains)
+			}
+			tool := setupDomainsToolWithMock(mockDomains)
+
+			resp, err := tool.setAllRecordTTL(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError, text)
+
+			var report recordTTLReport
+			require.NoError(t, json.Unmarshal([]byte(text), &report))
+			require.Equal(t, tc.expectRecords, report.Records)
+			updated, failed := 0, 0
+			for _, r := range tc.expectRecords {
+				switch r.Status {
+				case "updated":
+					updated++
+				case "failed":
+					failed++
+				}
+			}
+			require.Equal(t, updated, report.Updated)
+			require.Equal(t, failed, report.Failed)
+		})
+	}
+}
@@ -0,0 +1,345 @@
+package networking
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// firewallProtocols are the protocols a firewall rule can match.
+var firewallProtocols = []string{"tcp", "udp", "icmp"}
+
+// desiredFirewallRule is an entry of the InboundRules or OutboundRules argument of firewall-diff.
+// Addresses are given in Sources for inbound rules and in Destinations for outbound rules.
+type desiredFirewallRule struct {
+	Protocol     string   `json:"Protocol"`
+	PortRange    string   `json:"PortRange"`
+	Sources      []string `json:"Sources"`
+	Destinations []string `json:"Destinations"`
+	Tags         []string `json:"Tags"`
+	DropletIDs   []int    `json:"DropletIDs"`
+}
+
+// ruleTargets are the sources of an inbound rule or the destinations of an outbound rule.
+type ruleTargets struct {
+	Addresses        []string
+	Tags             []string
+	DropletIDs       []int
+	LoadBalancerUIDs []string
+	KubernetesIDs    []string
+}
+
+// normalizedRule is a firewall rule in canonical form, so that equivalent rules compare equal
+// whatever the order and spelling of their ports and targets.
+type normalizedRule struct {
+	protocol  string
+	portRange string
+	targets   ruleTargets
+}
+
+// key identifies the rule for comparison.
+func (r normalizedRule) key() string {
+	ids := make([]string, len(r.targets.DropletIDs))
+	for i, id := range r.targets.DropletIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	return strings.Join([]string{
+		r.protocol,
+		r.portRange,
+		strings.Join(r.targets.Addresses, ","),
+		strings.Join(r.targets.Tags, ","),
+		strings.Join(ids, ","),
+		strings.Join(r.targets.LoadBalancerUIDs, ","),
+		strings.Join(r.targets.KubernetesIDs, ","),
+	}, "|")
+}
+
+// unmanaged reports whether the rule targets load balancers or Kubernetes clusters, which a desired
+// rule can't express, so firewall-diff leaves it alone.
+func (r normalizedRule) unmanaged() bool {
+	return len(r.targets.LoadBalancerUIDs) > 0 || len(r.targets.KubernetesIDs) > 0
+}
+
+// normalizePortRange returns the canonical form of a port range: "0" for every port, as the API
+// reports it, a single port for a range of one port, and "" for ICMP, which has no ports.
+func normalizePortRange(protocol, portRange string) string {
+	if protocol == "icmp" {
+		return ""
+	}
+	portRange = strings.ReplaceAll(strings.ToLower(portRange), " ", "")
+	switch portRange {
+	case "", "0", "all", "1-65535":
+		return "0"
+	}
+	if from, to, ok := strings.Cut(portRange, "-"); ok && from == to {
+		return from
+	}
+	return portRange
+}
+
+// validPortRange reports whether a normalized port range is "0", a port or a range of ports.
+func validPortRange(portRange string) bool {
+	if portRange == "0" {
+		return true
+	}
+	port := func(s string) (int, bool) {
+		n, err := strconv.Atoi(s)
+		return n, err == nil && n >= 1 && n <= 65535
+	}
+	from, to, isRange := strings.Cut(portRange, "-")
+	first, ok := port(from)
+	if !isRange || !ok {
+		return ok
+	}
+	last, ok := port(to)
+	return ok && first < last
+}
+
+// normalizeAddress returns the canonical CIDR of an address, so that 10.0.0.1 and 10.0.0.1/32 or
+// differently written IPv6 addresses compare equal. Anything else is returned lower-cased.
+func normalizeAddress(address string) string {
+	address = strings.TrimSpace(address)
+	if prefix, err := netip.ParsePrefix(address); err == nil {
+		return prefix.Masked().String()
+	}
+	if addr, err := netip.ParseAddr(address); err == nil {
+		return netip.PrefixFrom(addr, addr.BitLen()).String()
+	}
+	return strings.ToLower(address)
+}
+
+// sortedUnique returns a sorted copy of values without duplicates.
+func sortedUnique[T cmp.Ordered](values []T) []T {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return slices.Compact(values)
+}
+
+// normalizeRule returns the canonical form of a rule.
+func normalizeRule(protocol, portRange string, targets ruleTargets) normalizedRule {
+	protocol = strings.ToLower(strings.TrimSpace(protocol))
+	addresses := make([]string, len(targets.Addresses))
+	for i, address := range targets.Addresses {
+		addresses[i] = normalizeAddress(address)
+	}
+	return normalizedRule{
+		protocol:  protocol,
+		portRange: normalizePortRange(protocol, portRange),
+		targets: ruleTargets{
+			Addresses:        sortedUnique(addresses),
+			Tags:             sortedUnique(targets.Tags),
+			DropletIDs:       sortedUnique(targets.DropletIDs),
+			LoadBalancerUIDs: sortedUnique(targets.LoadBalancerUIDs),
+			KubernetesIDs:    sortedUnique(targets.KubernetesIDs),
+		},
+	}
+}
+
+func normalizeInbound(rule godo.InboundRule) normalizedRule {
+	var targets ruleTargets
+	if s := rule.Sources; s != nil {
+		targets = ruleTargets{s.Addresses, s.Tags, s.DropletIDs, s.LoadBalancerUIDs, s.KubernetesIDs}
+	}
+	return normalizeRule(rule.Protocol, rule.PortRange, targets)
+}
+
+func normalizeOutbound(rule godo.OutboundRule) normalizedRule {
+	var targets ruleTargets
+	if d := rule.Destinations; d != nil {
+		targets = ruleTargets{d.Addresses, d.Tags, d.DropletIDs, d.LoadBalancerUIDs, d.KubernetesIDs}
+	}
+	return normalizeRule(rule.Protocol, rule.PortRange, targets)
+}
+
+func inboundFromNormalized(r normalizedRule) godo.InboundRule {
+	return godo.InboundRule{
+		Protocol:  r.protocol,
+		PortRange: r.portRange,
+		Sources:   &godo.Sources{Addresses: r.targets.Addresses, Tags: r.targets.Tags, DropletIDs: r.targets.DropletIDs},
+	}
+}
+
+func outboundFromNormalized(r normalizedRule) godo.OutboundRule {
+	return godo.OutboundRule{
+		Protocol:     r.protocol,
+		PortRange:    r.portRange,
+		Destinations: &godo.Destinations{Addresses: r.targets.Addresses, Tags: r.targets.Tags, DropletIDs: r.targets.DropletIDs},
+	}
+}
+
+// parseDesiredRules decodes and normalizes the desired rules of one direction, dropping duplicates.
+// Inbound rules take their addresses from Sources, outbound rules from Destinations.
+func parseDesiredRules(name string, raw []any, inbound bool) ([]normalizedRule, error) {
+	addressField, otherField := "Destinations", "Sources"
+	if inbound {
+		addressField, otherField = "Sources", "Destinations"
+	}
+	var rules []normalizedRule
+	seen := map[string]bool{}
+	for i, item := range raw {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", name, i, err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		var entry desiredFirewallRule
+		if err := dec.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("%s[%d] must be an object with a Protocol, a PortRange and %s, Tags or DropletIDs: %v", name, i, addressField, err)
+		}
+		addresses, other := entry.Destinations, entry.Sources
+		if inbound {
+			addresses, other = entry.Sources, entry.Destinations
+		}
+		if len(other) > 0 {
+			return nil, fmt.Errorf("%s[%d] must list its addresses in %s, not %s", name, i, addressField, otherField)
+		}
+		rule := normalizeRule(entry.Protocol, entry.PortRange, ruleTargets{Addresses: addresses, Tags: entry.Tags, DropletIDs: entry.DropletIDs})
+		if !slices.Contains(firewallProtocols, rule.protocol) {
+			return nil, fmt.Errorf("%s[%d].Protocol must be one of: %s", name, i, strings.Join(firewallProtocols, ", "))
+		}
+		if rule.protocol != "icmp" && !validPortRange(rule.portRange) {
+			return nil, fmt.Errorf("%s[%d].PortRange must be a port, a range such as 8000-8080, or 0 or all for every port, got %q", name, i, entry.PortRange)
+		}
+		for _, address := range rule.targets.Addresses {
+			if _, err := netip.ParsePrefix(address); err != nil {
+				return nil, fmt.Errorf("%s[%d].%s must hold IP addresses or CIDR blocks, got %q", name, i, addressField, address)
+			}
+		}
+		if len(rule.targets.Addresses) == 0 && len(rule.targets.Tags) == 0 && len(rule.targets.DropletIDs) == 0 {
+			return nil, fmt.Errorf("%s[%d] must have at least one address in %s, a tag or a droplet ID", name, i, addressField)
+		}
+		if key := rule.key(); !seen[key] {
+			seen[key] = true
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// ruleDiff is the difference between the current and desired rules of one direction. Add holds
+// the desired rules in canonical form; Remove, Unchanged and Unmanaged hold the current rules as
+// the API returned them.
+type ruleDiff[R any] struct {
+	Add       []R `json:"add"`
+	Remove    []R `json:"remove"`
+	Unchanged []R `json:"unchanged"`
+	Unmanaged []R `json:"unmanaged,omitempty"`
+}
+
+// diffRules compares the current rules of one direction with the desired ones.
+func diffRules[R any](current []R, desired []normalizedRule, normalize func(R) normalizedRule, build func(normalizedRule) R) ruleDiff[R] {
+	diff := ruleDiff[R]{Add: []R{}, Remove: []R{}, Unchanged: []R{}}
+	wanted := map[string]bool{}
+	for _, rule := range desired {
+		wanted[rule.key()] = true
+	}
+	existing := map[string]bool{}
+	for _, rule := range current {
+		n := normalize(rule)
+		switch {
+		case n.unmanaged():
+			diff.Unmanaged = append(diff.Unmanaged, rule)
+		case wanted[n.key()]:
+			diff.Unchanged = append(diff.Unchanged, rule)
+		default:
+			diff.Remove = append(diff.Remove, rule)
+		}
+		existing[n.key()] = true
+	}
+	for _, rule := range desired {
+		if !existing[rule.key()] {
+			diff.Add = append(diff.Add, build(rule))
+		}
+	}
+	return diff
+}
+
+// firewallDiff is the result of firewall-diff. A direction whose desired rules were not given is
+// not compared and is omitted.
+type firewallDiff struct {
+	FirewallID string                       `json:"firewall_id"`
+	Inbound    *ruleDiff[godo.InboundRule]  `json:"inbound,omitempty"`
+	Outbound   *ruleDiff[godo.OutboundRule] `json:"outbound,omitempty"`
+	Applied    bool                         `json:"applied"`
+}
+
+// diffFirewall compares a firewall's rules with the desired rules and, when apply_diff is set,
+// adds the missing rules and removes the extra ones.
+func (f *FirewallTool) diffFirewall(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	firewallID := args.RequireString("ID")
+	inboundRaw := args.OptionalArray("InboundRules")
+	outboundRaw := args.OptionalArray("OutboundRules")
+	apply := args.OptionalBool("apply_diff", false)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if inboundRaw == nil && outboundRaw == nil {
+		return mcp.NewToolResultError("at least one of InboundRules or OutboundRules must be provided, use an empty array to remove every rule of a direction"), nil
+	}
+	inbound, err := parseDesiredRules("InboundRules", inboundRaw, true)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	outbound, err := parseDesiredRules("OutboundRules", outboundRaw, false)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := f.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	firewall, _, err := client.Firewalls.Get(ctx, firewallID)
+	if err != nil {
+		return common.APIErrorResult(err, "firewall", firewallID), nil
+	}
+
+	result := firewallDiff{FirewallID: firewallID}
+	add, remove := &godo.FirewallRulesRequest{}, &godo.FirewallRulesRequest{}
+	if inboundRaw != nil {
+		diff := diffRules(firewall.InboundRules, inbound, normalizeInbound, inboundFromNormalized)
+		result.Inbound = &diff
+		add.InboundRules, remove.InboundRules = diff.Add, diff.Remove
+	}
+	if outboundRaw != nil {
+		diff := diffRules(firewall.OutboundRules, outbound, normalizeOutbound, outboundFromNormalized)
+		result.Outbound = &diff
+		add.OutboundRules, remove.OutboundRules = diff.Add, diff.Remove
+	}
+
+	if apply {
+		// Rules are added before the extra ones are removed, so traffic allowed by both the current
+		// and the desired rules is never interrupted.
+		if len(add.InboundRules) > 0 || len(add.OutboundRules) > 0 {
+			if _, err := client.Firewalls.AddRules(ctx, firewallID, add); err != nil {
+				return mcp.NewToolResultErrorFromErr("api error", err), nil
+			}
+		}
+		if len(remove.InboundRules) > 0 || len(remove.OutboundRules) > 0 {
+			if _, err := client.Firewalls.RemoveRules(ctx, firewallID, remove); err != nil {
+				return mcp.NewToolResultErrorFromErr("added the missing rules but failed to remove the extra rules", err), nil
+			}
+		}
+		result.Applied = true
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
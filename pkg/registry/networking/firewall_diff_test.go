@@ -0,0 +1,164 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNormalizeRule(t *testing.T) {
+	a := normalizeInbound(godo.InboundRule{Protocol: "TCP", PortRange: "all", Sources: &godo.Sources{Addresses: []string{"::/0", "0.0.0.0/0", "10.0.0.1"}}})
+	b := normalizeInbound(godo.InboundRule{Protocol: "tcp", PortRange: "0", Sources: &godo.Sources{Addresses: []string{"10.0.0.1/32", "0.0.0.0/0", "0:0::/0", "::/0"}}})
+	require.Equal(t, a.key(), b.key())
+
+	require.Equal(t, "22", normalizePortRange("tcp", "22-22"))
+	require.Equal(t, "", normalizePortRange("icmp", "0"))
+	require.NotEqual(t,
+		normalizeInbound(godo.InboundRule{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Tags: []string{"web"}}}).key(),
+		normalizeInbound(godo.InboundRule{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{DropletIDs: []int{1}}}).key(),
+	)
+}
+
+func TestFirewallTool_diffFirewall(t *testing.T) {
+	ssh := godo.InboundRule{Protocol: "tcp", PortRange: "22", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0", "::/0"}}}
+	http := godo.InboundRule{Protocol: "tcp", PortRange: "80", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0"}}}
+	lb := godo.InboundRule{Protocol: "tcp", PortRange: "8080", Sources: &godo.Sources{LoadBalancerUIDs: []string{"lb-1"}}}
+	allOut := godo.OutboundRule{Protocol: "tcp", PortRange: "0", Destinations: &godo.Destinations{Addresses: []string{"0.0.0.0/0"}}}
+	firewall := &godo.Firewall{ID: "fw-1", InboundRules: []godo.InboundRule{ssh, http, lb}, OutboundRules: []godo.OutboundRule{allOut}}
+
+	desiredInbound := []any{
+		// Same as the current SSH rule, written differently.
+		map[string]any{"Protocol": "TCP", "PortRange": "22-22", "Sources": []any{"::/0", "0.0.0.0/0", "0.0.0.0/0"}},
+		map[string]any{"Protocol": "tcp", "PortRange": "443", "Sources": []any{"0.0.0.0/0"}},
+	}
+	https := godo.InboundRule{Protocol: "tcp", PortRange: "443", Sources: &godo.Sources{Addresses: []string{"0.0.0.0/0"}}}
+	expectInbound := &ruleDiff[godo.InboundRule]{
+		Add:       []godo.InboundRule{https},
+		Remove:    []godo.InboundRule{http},
+		Unchanged: []godo.InboundRule{ssh},
+		Unmanaged: []godo.InboundRule{lb},
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockFirewallsService)
+		expectError string
+		expect      firewallDiff
+	}{
+		{
+			name: "Plans without applying",
+			args: map[string]any{"ID": "fw-1", "InboundRules": desiredInbound},
+			mockSetup: func(m *MockFirewallsService) {
+				m.EXPECT().Get(gomock.Any(), "fw-1").Return(firewall, nil, nil)
+			},
+			expect: firewallDiff{FirewallID: "fw-1", Inbound: expectInbound},
+		},
+		{
+			name: "Applies the diff",
+			args: map[string]any{"ID": "fw-1", "InboundRules": desiredInbound, "OutboundRules": []any{
+				map[string]any{"Protocol": "tcp", "PortRange": "all", "Destinations": []any{"0.0.0.0/0"}},
+			}, "apply_diff": true},
+			mockSetup: func(m *MockFirewallsService) {
+				m.EXPECT().Get(gomock.Any(), "fw-1").Return(firewall, nil, nil)
+				gomock.InOrder(
+					m.EXPECT().AddRules(gomock.Any(), "fw-1", &godo.FirewallRulesRequest{InboundRules: []godo.InboundRule{https}, OutboundRules: []godo.OutboundRule{}}).Return(nil, nil),
+					m.EXPECT().RemoveRules(gomock.Any(), "fw-1", &godo.FirewallRulesRequest{InboundRules: []godo.InboundRule{http}, OutboundRules: []godo.OutboundRule{}}).Return(nil, nil),
+				)
+			},
+			expect: firewallDiff{
+				FirewallID: "fw-1",
+				Inbound:    expectInbound,
+				Outbound:   &ruleDiff[godo.OutboundRule]{Add: []godo.OutboundRule{}, Remove: []godo.OutboundRule{}, Unchanged: []godo.OutboundRule{allOut}},
+				Applied:    true,
+			},
+		},
+		{
+			name: "Empty array removes every rule",
+			args: map[string]any{"ID": "fw-1", "OutboundRules": []any{}, "apply_diff": true},
+			mockSetup: func(m *MockFirewallsService) {
+				m.EXPECT().Get(gomock.Any(), "fw-1").Return(firewall, nil, nil)
+				m.EXPECT().RemoveRules(gomock.Any(), "fw-1", &godo.FirewallRulesRequest{OutboundRules: []godo.OutboundRule{allOut}}).Return(nil, nil)
+			},
+			expect: firewallDiff{
+				FirewallID: "fw-1",
+				Outbound:   &ruleDiff[godo.OutboundRule]{Add: []godo.OutboundRule{}, Remove: []godo.OutboundRule{allOut}, Unchanged: []godo.OutboundRule{}},
+				Applied:    true,
+			},
+		},
+		{
+			name: "Remove fails after add",
+			args: map[string]any{"ID": "fw-1", "InboundRules": desiredInbound, "apply_diff": true},
+			mockSetup: func(m *MockFirewallsService) {
+				m.EXPECT().Get(gomock.Any(), "fw-1").Return(firewall, nil, nil)
+				m.EXPECT().AddRules(gomock.Any(), "fw-1", gomock.Any()).Return(nil, nil)
+				m.EXPECT().RemoveRules(gomock.Any(), "fw-1", gomock.Any()).Return(nil, errors.New("boom"))
+			},
+			expectError: "added the missing rules but failed to remove the extra rules",
+		},
+		{
+			name:        "No desired rules",
+			args:        map[string]any{"ID": "fw-1"},
+			expectError: "at least one of InboundRules or OutboundRules must be provided",
+		},
+		{
+			name:        "Addresses in the wrong field",
+			args:        map[string]any{"ID": "fw-1", "InboundRules": []any{map[string]any{"Protocol": "tcp", "PortRange": "22", "Destinations": []any{"0.0.0.0/0"}}}},
+			expectError: "InboundRules[0] must list its addresses in Sources, not Destinations",
+		},
+		{
+			name:        "Invalid port range",
+			args:        map[string]any{"ID": "fw-1", "InboundRules": []any{map[string]any{"Protocol": "tcp", "PortRange": "90-80", "Sources": []any{"0.0.0.0/0"}}}},
+			expectError: "InboundRules[0].PortRange",
+		},
+		{
+			name:        "Invalid address",
+			args:        map[string]any{"ID": "fw-1", "InboundRules": []any{map[string]any{"Protocol": "tcp", "PortRange": "22", "Sources": []any{"example.com"}}}},
+			expectError: "InboundRules[0].Sources must hold IP addresses or CIDR blocks",
+		},
+		{
+			name:        "Unknown field",
+			args:        map[string]any{"ID": "fw-1", "InboundRules": []any{map[string]any{"Protocol": "tcp", "Ports": "22"}}},
+			expectError: "InboundRules[0] must be an object",
+		},
+		{
+			name: "Firewall not found",
+			args: map[string]any{"ID": "fw-1", "InboundRules": []any{}},
+			mockSetup: func(m *MockFirewallsService) {
+				m.EXPECT().Get(gomock.Any(), "fw-1").Return(nil, nil, errors.New("boom"))
+			},
+			expectError: "boom",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFirewalls := NewMockFirewallsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockFirewalls)
+			}
+			tool := setupFirewallToolWithMock(mockFirewalls)
+
+			resp, err := tool.diffFirewall(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError, text)
+
+			var got firewallDiff
+			require.NoError(t, json.Unmarshal([]byte(text), &got))
+			require.Equal(t, tc.expect, got)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// firewallTagSync is the result of firewall-sync-tags.
+type firewallTagSync struct {
+	FirewallID string `json:"firewall_id"`
+	Tag        string `json:"tag"`
+	Added      []int  `json:"added"`
+	Removed    []int  `json:"removed"`
+	DropletIDs []int  `json:"droplet_ids"`
+	Note       string `json:"note,omitempty"`
+}
+
+// syncFirewallTags makes the droplets a firewall applies to by ID exactly those carrying a tag,
+// adding the tagged droplets it misses and removing those no longer tagged.
+func (f *FirewallTool) syncFirewallTags(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	firewallID := args.RequireString("ID")
+	tag := args.RequireString("Tag")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := f.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	firewall, _, err := client.Firewalls.Get(ctx, firewallID)
+	if err != nil {
+		return common.APIErrorResult(err, "firewall", firewallID), nil
+	}
+
+	tagged := []int{}
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		droplets, resp, err := client.Droplets.ListByTag(ctx, tag, opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		for _, droplet := range droplets {
+			tagged = append(tagged, droplet.ID)
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+	slices.Sort(tagged)
+	tagged = slices.Compact(tagged)
+
+	result := firewallTagSync{FirewallID: firewallID, Tag: tag, Added: []int{}, Removed: []int{}, DropletIDs: tagged}
+	for _, id := range tagged {
+		if !slices.Contains(firewall.DropletIDs, id) {
+			result.Added = append(result.Added, id)
+		}
+	}
+	for _, id := range firewall.DropletIDs {
+		if !slices.Contains(tagged, id) {
+			result.Removed = append(result.Removed, id)
+		}
+	}
+	slices.Sort(result.Removed)
+
+	if len(result.Added) > 0 {
+		if _, err := client.Firewalls.AddDroplets(ctx, firewallID, result.Added...); err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+	}
+	if len(result.Removed) > 0 {
+		if _, err := client.Firewalls.RemoveDroplets(ctx, firewallID, result.Removed...); err != nil {
+			return mcp.NewToolResultErrorFromErr(fmt.Sprintf("added droplets %v but failed to remove droplets", result.Added), err), nil
+		}
+	}
+	if slices.Contains(firewall.Tags, tag) {
+		result.Note = fmt.Sprintf("the firewall also targets the tag %s itself, which already applies it to every tagged droplet", tag)
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
@@ -0,0 +1,116 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFirewallTool_syncFirewallTags(t *testing.T) {
+	lastPage := &godo.Response{Links: &godo.Links{}}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockFirewallsService, *MockDropletsService)
+		expectError string
+		expect      firewallTagSync
+	}{
+		{
+			name: "Adds and removes droplets",
+			args: map[string]any{"ID": "fw-1", "Tag": "web"},
+			mockSetup: func(fw *MockFirewallsService, d *MockDropletsService) {
+				fw.EXPECT().Get(gomock.Any(), "fw-1").Return(&godo.Firewall{ID: "fw-1", DropletIDs: []int{5, 1, 2}}, nil, nil)
+				gomock.InOrder(
+					d.EXPECT().ListByTag(gomock.Any(), "web", &godo.ListOptions{Page: 1, PerPage: 200}).
+						Return([]godo.Droplet{{ID: 2}, {ID: 4}}, &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+					d.EXPECT().ListByTag(gomock.Any(), "web", &godo.ListOptions{Page: 2, PerPage: 200}).
+						Return([]godo.Droplet{{ID: 3}}, lastPage, nil),
+				)
+				fw.EXPECT().AddDroplets(gomock.Any(), "fw-1", 3, 4).Return(nil, nil)
+				fw.EXPECT().RemoveDroplets(gomock.Any(), "fw-1", 1, 5).Return(nil, nil)
+			},
+			expect: firewallTagSync{FirewallID: "fw-1", Tag: "web", Added: []int{3, 4}, Removed: []int{1, 5}, DropletIDs: []int{2, 3, 4}},
+		},
+		{
+			name: "Already in sync",
+			args: map[string]any{"ID": "fw-1", "Tag": "web"},
+			mockSetup: func(fw *MockFirewallsService, d *MockDropletsService) {
+				fw.EXPECT().Get(gomock.Any(), "fw-1").Return(&godo.Firewall{ID: "fw-1", DropletIDs: []int{2}, Tags: []string{"web"}}, nil, nil)
+				d.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return([]godo.Droplet{{ID: 2}}, lastPage, nil)
+			},
+			expect: firewallTagSync{
+				FirewallID: "fw-1", Tag: "web", Added: []int{}, Removed: []int{}, DropletIDs: []int{2},
+				Note: "the firewall also targets the tag web itself, which already applies it to every tagged droplet",
+			},
+		},
+		{
+			name: "No tagged droplet left",
+			args: map[string]any{"ID": "fw-1", "Tag": "web"},
+			mockSetup: func(fw *MockFirewallsService, d *MockDropletsService) {
+				fw.EXPECT().Get(gomock.Any(), "fw-1").Return(&godo.Firewall{ID: "fw-1", DropletIDs: []int{7}}, nil, nil)
+				d.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return(nil, lastPage, nil)
+				fw.EXPECT().RemoveDroplets(gomock.Any(), "fw-1", 7).Return(nil, nil)
+			},
+			expect: firewallTagSync{FirewallID: "fw-1", Tag: "web", Added: []int{}, Removed: []int{7}, DropletIDs: []int{}},
+		},
+		{
+			name: "Unknown firewall",
+			args: map[string]any{"ID": "fw-404", "Tag": "web"},
+			mockSetup: func(fw *MockFirewallsService, d *MockDropletsService) {
+				fw.EXPECT().Get(gomock.Any(), "fw-404").Return(nil, nil, &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}})
+			},
+			expectError: "resource not found",
+		},
+		{
+			name: "Add error",
+			args: map[string]any{"ID": "fw-1", "Tag": "web"},
+			mockSetup: func(fw *MockFirewallsService, d *MockDropletsService) {
+				fw.EXPECT().Get(gomock.Any(), "fw-1").Return(&godo.Firewall{ID: "fw-1"}, nil, nil)
+				d.EXPECT().ListByTag(gomock.Any(), "web", gomock.Any()).Return([]godo.Droplet{{ID: 2}}, lastPage, nil)
+				fw.EXPECT().AddDroplets(gomock.Any(), "fw-1", 2).Return(nil, errors.New("boom"))
+			},
+			expectError: "boom",
+		},
+		{
+			name:        "Missing tag",
+			args:        map[string]any{"ID": "fw-1"},
+			expectError: "argument 'Tag' is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFirewalls := NewMockFirewallsService(ctrl)
+			mockDroplets := NewMockDropletsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockFirewalls, mockDroplets)
+			}
+			tool := NewFirewallTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Firewalls: mockFirewalls, Droplets: mockDroplets}, nil
+			})
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.syncFirewallTags(context.Background(), req)
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out firewallTagSync
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, tc.expect, out)
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var (
+	// anywhere are the sources or destinations matching every IPv4 and IPv6 address.
+	anywhere = []string{"0.0.0.0/0", "::/0"}
+	// privateNetworks are the IPv4 ranges used by VPC networks.
+	privateNetworks = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+)
+
+// firewallTemplate is a named set of firewall rules applied by firewall-apply-template.
+type firewallTemplate struct {
+	Description string
+	Inbound     []godo.InboundRule
+}
+
+// allowAllOutbound are the outbound rules of every template, allowing all outgoing traffic.
+var allowAllOutbound = []godo.OutboundRule{
+	{Protocol: "tcp", PortRange: "0", Destinations: &godo.Destinations{Addresses: anywhere}},
+	{Protocol: "udp", PortRange: "0", Destinations: &godo.Destinations{Addresses: anywhere}},
+	{Protocol: "icmp", Destinations: &godo.Destinations{Addresses: anywhere}},
+}
+
+// inboundTCP returns a rule allowing inbound TCP traffic on port from the given addresses.
+func inboundTCP(port string, addresses []string) godo.InboundRule {
+	return godo.InboundRule{Protocol: "tcp", PortRange: port, Sources: &godo.Sources{Addresses: addresses}}
+}
+
+// firewallTemplates are the templates firewall-apply-template can apply, by name.
+var firewallTemplates = map[string]firewallTemplate{
+	"web": {
+		Description: "HTTP (80) and HTTPS (443) from anywhere",
+		Inbound:     []godo.InboundRule{inboundTCP("80", anywhere), inboundTCP("443", anywhere)},
+	},
+	"ssh-only": {
+		Description: "SSH (22) from anywhere",
+		Inbound:     []godo.InboundRule{inboundTCP("22", anywhere)},
+	},
+	"database": {
+		Description: "MySQL (3306), PostgreSQL (5432), Redis (6379) and MongoDB (27017) from private networks only",
+		Inbound: []godo.InboundRule{
+			inboundTCP("3306", privateNetworks),
+			inboundTCP("5432", privateNetworks),
+			inboundTCP("6379", privateNetworks),
+			inboundTCP("27017", privateNetworks),
+		},
+	},
+}
+
+// firewallTemplateNames returns the names of the templates, sorted.
+func firewallTemplateNames() []string {
+	return slices.Sorted(maps.Keys(firewallTemplates))
+}
+
+// firewallTemplatesDescription describes each template for the tool description.
+func firewallTemplatesDescription() string {
+	var b strings.Builder
+	for _, name := range firewallTemplateNames() {
+		fmt.Fprintf(&b, "%s: %s. ", name, firewallTemplates[name].Description)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// dropletIDsArg reads a required array of droplet IDs, which must be positive whole numbers.
+func dropletIDsArg(args *common.Args, name string) ([]int, error) {
+	items := args.OptionalArray(name)
+	if err := args.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("argument '%s' is required", name)
+	}
+	ids := make([]int, 0, len(items))
+	for _, item := range items {
+		f, ok := item.(float64)
+		if !ok || f <= 0 || f != float64(int(f)) {
+			return nil, fmt.Errorf("argument '%s' must only contain positive whole numbers, got %v", name, item)
+		}
+		if !slices.Contains(ids, int(f)) {
+			ids = append(ids, int(f))
+		}
+	}
+	return ids, nil
+}
+
+// applyFirewallTemplate sets the rules of the named firewall to a template and applies it to the
+// given droplets. The firewall is created when no firewall has that name, otherwise its rules are
+// replaced and the droplets are added to those it already protects.
+func (f *FirewallTool) applyFirewallTemplate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	templateName := args.RequireEnum("Template", firewallTemplateNames()...)
+	name := args.OptionalString("Name", "")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	dropletIDs, err := dropletIDsArg(args, "DropletIDs")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if name == "" {
+		name = "template-" + templateName
+	}
+	template := firewallTemplates[templateName]
+
+	client, err := f.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	existing := map[int]struct{}{}
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		droplets, resp, err := client.Droplets.List(ctx, opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		for _, droplet := range droplets {
+			existing[droplet.ID] = struct{}{}
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+	var unknown []string
+	for _, id := range dropletIDs {
+		if _, ok := existing[id]; !ok {
+			unknown = append(unknown, fmt.Sprint(id))
+		}
+	}
+	if len(unknown) > 0 {
+		return mcp.NewToolResultError(fmt.Sprintf("droplets not found: %s", strings.Join(unknown, ", "))), nil
+	}
+
+	var current *godo.Firewall
+	opt = &godo.ListOptions{Page: 1, PerPage: 200}
+	for current == nil {
+		firewalls, resp, err := client.Firewalls.List(ctx, opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		if i := slices.IndexFunc(firewalls, func(fw godo.Firewall) bool { return fw.Name == name }); i >= 0 {
+			current = &firewalls[i]
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+
+	firewallRequest := &godo.FirewallRequest{
+		Name:          name,
+		InboundRules:  template.Inbound,
+		OutboundRules: allowAllOutbound,
+		DropletIDs:    dropletIDs,
+	}
+	action := "created"
+	var firewall *godo.Firewall
+	if current == nil {
+		firewall, _, err = client.Firewalls.Create(ctx, firewallRequest)
+	} else {
+		action = "updated"
+		for _, id := range current.DropletIDs {
+			if !slices.Contains(firewallRequest.DropletIDs, id) {
+				firewallRequest.DropletIDs = append(firewallRequest.DropletIDs, id)
+			}
+		}
+		firewallRequest.Tags = current.Tags
+		firewall, _, err = client.Firewalls.Update(ctx, current.ID, firewallRequest)
+	}
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	jsonData, err := response.CompactJSON(map[string]any{
+		"action":   action,
+		"template": templateName,
+		"firewall": firewall,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
@@ -0,0 +1,127 @@
+package networking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFirewallTool_applyFirewallTemplate(t *testing.T) {
+	droplets := []godo.Droplet{{ID: 1}, {ID: 2}, {ID: 3}}
+	lastPage := &godo.Response{Links: &godo.Links{}}
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		mockSetup    func(*MockFirewallsService, *MockDropletsService)
+		expectError  string
+		expectAction string
+	}{
+		{
+			name: "Creates the firewall",
+			args: map[string]any{"Template": "web", "DropletIDs": []any{float64(1), float64(2), float64(1)}},
+			mockSetup: func(fw *MockFirewallsService, d *MockDropletsService) {
+				d.EXPECT().List(gomock.Any(), gomock.Any()).Return(droplets, lastPage, nil)
+				fw.EXPECT().List(gomock.Any(), gomock.Any()).Return([]godo.Firewall{{ID: "fw-other", Name: "other"}}, lastPage, nil)
+				fw.EXPECT().Create(gomock.Any(), &godo.FirewallRequest{
+					Name:          "template-web",
+					InboundRules:  firewallTemplates["web"].Inbound,
+					OutboundRules: allowAllOutbound,
+					DropletIDs:    []int{1, 2},
+				}).Return(&godo.Firewall{ID: "fw-new", Name: "template-web"}, nil, nil)
+			},
+			expectAction: "created",
+		},
+		{
+			name: "Updates the firewall with that name",
+			args: map[string]any{"Template": "database", "DropletIDs": []any{float64(3)}, "Name": "db"},
+			mockSetup: func(fw *MockFirewallsService, d *MockDropletsService) {
+				d.EXPECT().List(gomock.Any(), gomock.Any()).Return(droplets, lastPage, nil)
+				gomock.InOrder(
+					fw.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+						Return([]godo.Firewall{{ID: "fw-other", Name: "other"}}, &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+					fw.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 200}).
+						Return([]godo.Firewall{{ID: "fw-db", Name: "db", DropletIDs: []int{1, 3}, Tags: []string{"db"}}}, lastPage, nil),
+				)
+				fw.EXPECT().Update(gomock.Any(), "fw-db", &godo.FirewallRequest{
+					Name:          "db",
+					InboundRules:  firewallTemplates["database"].Inbound,
+					OutboundRules: allowAllOutbound,
+					DropletIDs:    []int{3, 1},
+					Tags:          []string{"db"},
+				}).Return(&godo.Firewall{ID: "fw-db", Name: "db"}, nil, nil)
+			},
+			expectAction: "updated",
+		},
+		{
+			name: "Unknown droplet",
+			args: map[string]any{"Template": "ssh-only", "DropletIDs": []any{float64(1), float64(42)}},
+			mockSetup: func(fw *MockFirewallsService, d *MockDropletsService) {
+				d.EXPECT().List(gomock.Any(), gomock.Any()).Return(droplets, lastPage, nil)
+			},
+			expectError: "droplets not found: 42",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"Template": "ssh-only", "DropletIDs": []any{float64(1)}},
+			mockSetup: func(fw *MockFirewallsService, d *MockDropletsService) {
+				d.EXPECT().List(gomock.Any(), gomock.Any()).Return(droplets, lastPage, nil)
+				fw.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("api error"))
+			},
+			expectError: "api error",
+		},
+		{
+			name:        "Unknown template",
+			args:        map[string]any{"Template": "mail", "DropletIDs": []any{float64(1)}},
+			expectError: "argument 'Template' must be one of: database, ssh-only, web",
+		},
+		{
+			name:        "Missing droplets",
+			args:        map[string]any{"Template": "web"},
+			expectError: "argument 'DropletIDs' is required",
+		},
+		{
+			name:        "Invalid droplet ID",
+			args:        map[string]any{"Template": "web", "DropletIDs": []any{float64(1.5)}},
+			expectError: "argument 'DropletIDs' must only contain positive whole numbers, got 1.5",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockFirewalls := NewMockFirewallsService(ctrl)
+			mockDroplets := NewMockDropletsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockFirewalls, mockDroplets)
+			}
+			tool := NewFirewallTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Firewalls: mockFirewalls, Droplets: mockDroplets}, nil
+			})
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.applyFirewallTemplate(context.Background(), req)
+			require.NoError(t, err)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out struct {
+				Action   string        `json:"action"`
+				Firewall godo.Firewall `json:"firewall"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, tc.expectAction, out.Action)
+			require.NotEmpty(t, out.Firewall.ID)
+		})
+	}
+}
@@ -72,6 +72,71 @@ func (f *FirewallTool) listFirewalls(ctx context.Context, req mcp.CallToolReques
 	return mcp.NewToolResultText(jsonFirewalls), nil
 }
 
+// firewallDroplet is a droplet a firewall is applied to.
+type firewallDroplet struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// listFirewallDroplets resolves the droplet IDs of a firewall to droplet names. IDs of droplets that
+// no longer exist are returned as unresolved.
+func (f *FirewallTool) listFirewallDroplets(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	id, ok := req.GetArguments()["ID"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("Firewall ID is required"), nil
+	}
+
+	client, err := f.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	firewall, _, err := client.Firewalls.Get(ctx, id)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	names := make(map[int]string, len(firewall.DropletIDs))
+	if len(firewall.DropletIDs) > 0 {
+		opt := &godo.ListOptions{Page: 1, PerPage: 200}
+		for {
+			droplets, resp, err := client.Droplets.List(ctx, opt)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("api error", err), nil
+			}
+			for _, droplet := range droplets {
+				names[droplet.ID] = droplet.Name
+			}
+			if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+				break
+			}
+			opt.Page++
+		}
+	}
+
+	droplets := []firewallDroplet{}
+	unresolved := []int{}
+	for _, dropletID := range firewall.DropletIDs {
+		if name, ok := names[dropletID]; ok {
+			droplets = append(droplets, firewallDroplet{ID: dropletID, Name: name})
+		} else {
+			unresolved = append(unresolved, dropletID)
+		}
+	}
+
+	jsonData, err := response.CompactJSON(map[string]any{
+		"firewall_id":    firewall.ID,
+		"name":           firewall.Name,
+		"droplets":       droplets,
+		"unresolved_ids": unresolved,
+		"tags":           firewall.Tags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
 // createFirewall creates a new firewall
 func (f *FirewallTool) createFirewall(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	name := req.GetArguments()["Name"].(string)
@@ -422,6 +487,13 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 				mcp.WithNumber("PerPage", mcp.DefaultNumber(20), mcp.Description("Items per page")),
 			),
 		},
+		{
+			Handler: f.listFirewallDroplets,
+			Tool: mcp.NewTool("firewall-list-droplets",
+				mcp.WithDescription("List the droplets a firewall is applied to, by ID and name. The firewall's tags are returned as well, as droplets with those tags are also protected."),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall")),
+			),
+		},
 		{
 			Handler: f.createFirewall,
 			Tool: mcp.NewTool("firewall-create",
@@ -443,6 +515,26 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 				})),
 			),
 		},
+		{
+			Handler: f.applyFirewallTemplate,
+			Tool: mcp.NewTool("firewall-apply-template",
+				mcp.WithDescription("Apply a built-in set of firewall rules to droplets, creating the firewall or, when a firewall with that name exists, replacing its rules and adding the droplets to it. All outbound traffic is allowed. Templates: "+firewallTemplatesDescription()),
+				mcp.WithString("Template", mcp.Required(), mcp.Enum(firewallTemplateNames()...), mcp.Description("Name of the rules template")),
+				mcp.WithArray("DropletIDs", mcp.Required(), mcp.Description("Droplet IDs to apply the firewall to"), mcp.Items(map[string]any{
+					"type":        "number",
+					"description": "droplet ID to apply the firewall to",
+				})),
+				mcp.WithString("Name", mcp.Description("Name of the firewall to create or update (default template-<Template>)")),
+			),
+		},
+		{
+			Handler: f.syncFirewallTags,
+			Tool: mcp.NewTool("firewall-sync-tags",
+				mcp.WithDescription("Make the droplets a firewall applies to by ID exactly the droplets carrying a tag: tagged droplets are added and droplets no longer tagged are removed. Returns the added and removed droplet IDs."),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall to sync")),
+				mcp.WithString("Tag", mcp.Required(), mcp.Description("Tag of the droplets the firewall should apply to")),
+			),
+		},
 		{
 			Handler: f.deleteFirewall,
 			Tool: mcp.NewTool("firewall-delete",
@@ -601,5 +693,54 @@ func (f *FirewallTool) Tools() []server.ServerTool {
 				})),
 			),
 		},
+		{
+			Handler: f.diffFirewall,
+			Tool: mcp.NewTool("firewall-diff",
+				mcp.WithDescription("Compare a firewall's rules with a desired rule set and return the rules to add, remove and keep, without applying anything unless apply_diff is set. Order, duplicates and formatting, such as 10.0.0.1 vs 10.0.0.1/32 or all vs 0 ports, don't count as differences. A direction whose rules are omitted is not compared"),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the firewall to compare")),
+				mcp.WithArray("InboundRules", mcp.Description("Desired inbound rules, an empty array removes every inbound rule"), mcp.Items(desiredRuleSchema("Sources", "source", "Desired inbound firewall rule"))),
+				mcp.WithArray("OutboundRules", mcp.Description("Desired outbound rules, an empty array removes every outbound rule"), mcp.Items(desiredRuleSchema("Destinations", "destination", "Desired outbound firewall rule"))),
+				mcp.WithBoolean("apply_diff", mcp.DefaultBool(false), mcp.Description("Add the missing rules and then remove the extra ones")),
+			),
+		},
+	}
+}
+
+// desiredRuleSchema is the item schema of the rules of firewall-diff, whose addresses are given in
+// the addressField property.
+func desiredRuleSchema(addressField, addressKind, description string) map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"Protocol": map[string]any{
+				"type":        "string",
+				"enum":        firewallProtocols,
+				"description": "Protocol (tcp, udp, icmp)",
+			},
+			"PortRange": map[string]any{
+				"type":        "string",
+				"description": "Port range (e.g., '80', '8000-8080', or '0' or 'all' for every port), ignored for icmp",
+			},
+			addressField: map[string]any{
+				"type": "array",
+				"items": map[string]any{
+					"type":        "string",
+					"description": "IP address or CIDR block",
+				},
+				"description": "List of " + addressKind + " addresses",
+			},
+			"Tags": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "string"},
+				"description": "Droplet tags to match",
+			},
+			"DropletIDs": map[string]any{
+				"type":        "array",
+				"items":       map[string]any{"type": "number"},
+				"description": "Droplet IDs to match",
+			},
+		},
+		"required":    []string{"Protocol"},
+		"description": description,
 	}
 }
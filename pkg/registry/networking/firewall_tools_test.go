@@ -824,3 +824,43 @@ func TestFirewallTool_deleteFirewall(t *testing.T) {
 		})
 	}
 }
+
+func TestFirewallTool_listFirewallDroplets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFirewalls := NewMockFirewallsService(ctrl)
+	mockDroplets := NewMockDropletsService(ctrl)
+	mockFirewalls.EXPECT().
+		Get(gomock.Any(), "fw-123").
+		Return(&godo.Firewall{ID: "fw-123", Name: "web", DropletIDs: []int{1, 3, 9}, Tags: []string{"frontend"}}, nil, nil)
+	gomock.InOrder(
+		mockDroplets.EXPECT().
+			List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+			Return([]godo.Droplet{{ID: 1, Name: "web-1"}, {ID: 2, Name: "web-2"}}, &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+		mockDroplets.EXPECT().
+			List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 200}).
+			Return([]godo.Droplet{{ID: 3, Name: "web-3"}}, &godo.Response{}, nil),
+	)
+	tool := NewFirewallTool(func(ctx context.Context) (*godo.Client, error) {
+		return &godo.Client{Firewalls: mockFirewalls, Droplets: mockDroplets}, nil
+	})
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": "fw-123"}}}
+	resp, err := tool.listFirewallDroplets(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	require.JSONEq(t,
+		`{"firewall_id":"fw-123","name":"web","droplets":[{"id":1,"name":"web-1"},{"id":3,"name":"web-3"}],"unresolved_ids":[9],"tags":["frontend"]}`,
+		resp.Content[0].(mcp.TextContent).Text)
+
+	// A firewall without droplets doesn't list droplets.
+	mockFirewalls.EXPECT().
+		Get(gomock.Any(), "fw-empty").
+		Return(&godo.Firewall{ID: "fw-empty", Name: "empty"}, nil, nil)
+	req = mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": "fw-empty"}}}
+	resp, err = tool.listFirewallDroplets(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, `"droplets":[]`)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"mcp-digitalocean/pkg/response"
+	"slices"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -532,6 +534,145 @@ func (l *LoadBalancersTool) removeForwardingRules(ctx context.Context, req mcp.C
 	return mcp.NewToolResultText("Forwarding rules removed successfully"), nil
 }
 
+var (
+	healthCheckProtocols = []string{"http", "https", "tcp"}
+	stickySessionTypes   = []string{"none", "cookies"}
+)
+
+// positiveIntArg returns the named argument as an int. It reports whether the argument was set and
+// fails if it is not a positive number.
+func positiveIntArg(args map[string]any, name string) (int, bool, error) {
+	v, ok := args[name]
+	if !ok || v == nil {
+		return 0, false, nil
+	}
+	n, ok := v.(float64)
+	if !ok || n <= 0 || n != float64(int(n)) {
+		return 0, false, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return int(n), true, nil
+}
+
+// patchLoadBalancer fetches a load balancer, applies patch to its current configuration and updates it.
+func (l *LoadBalancersTool) patchLoadBalancer(ctx context.Context, lbID string, patch func(*godo.LoadBalancerRequest)) (*mcp.CallToolResult, error) {
+	client, err := l.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	current, _, err := client.LoadBalancers.Get(ctx, lbID)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	lbr := current.AsRequest()
+	patch(lbr)
+
+	lb, _, err := client.LoadBalancers.Update(ctx, lbID, lbr)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonLB, err := response.CompactJSON(lb)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonLB), nil
+}
+
+func (l *LoadBalancersTool) updateHealthCheck(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	lbID, ok := args["LoadBalancerID"].(string)
+	if !ok || lbID == "" {
+		return mcp.NewToolResultError("LoadBalancerID is required"), nil
+	}
+
+	protocol, _ := args["Protocol"].(string)
+	protocol = strings.ToLower(protocol)
+	if protocol != "" && !slices.Contains(healthCheckProtocols, protocol) {
+		return mcp.NewToolResultError("Protocol must be one of: http, https, tcp"), nil
+	}
+	path, _ := args["Path"].(string)
+
+	ints := map[string]int{}
+	for _, name := range []string{"Port", "CheckIntervalSeconds", "ResponseTimeoutSeconds", "HealthyThreshold", "UnhealthyThreshold"} {
+		n, set, err := positiveIntArg(args, name)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		if set {
+			ints[name] = n
+		}
+	}
+
+	return l.patchLoadBalancer(ctx, lbID, func(lbr *godo.LoadBalancerRequest) {
+		hc := godo.HealthCheck{}
+		if lbr.HealthCheck != nil {
+			hc = *lbr.HealthCheck
+		}
+		if protocol != "" {
+			hc.Protocol = protocol
+		}
+		if path != "" {
+			hc.Path = path
+		}
+		if n, ok := ints["Port"]; ok {
+			hc.Port = n
+		}
+		if n, ok := ints["CheckIntervalSeconds"]; ok {
+			hc.CheckIntervalSeconds = n
+		}
+		if n, ok := ints["ResponseTimeoutSeconds"]; ok {
+			hc.ResponseTimeoutSeconds = n
+		}
+		if n, ok := ints["HealthyThreshold"]; ok {
+			hc.HealthyThreshold = n
+		}
+		if n, ok := ints["UnhealthyThreshold"]; ok {
+			hc.UnhealthyThreshold = n
+		}
+		// TCP health checks have no path, HTTP(S) checks need one.
+		if hc.Protocol == "tcp" {
+			hc.Path = ""
+		} else if hc.Path == "" {
+			hc.Path = "/"
+		}
+		lbr.HealthCheck = &hc
+	})
+}
+
+func (l *LoadBalancersTool) updateStickySessions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	lbID, ok := args["LoadBalancerID"].(string)
+	if !ok || lbID == "" {
+		return mcp.NewToolResultError("LoadBalancerID is required"), nil
+	}
+	sessionType, _ := args["Type"].(string)
+	sessionType = strings.ToLower(sessionType)
+	if !slices.Contains(stickySessionTypes, sessionType) {
+		return mcp.NewToolResultError("Type must be one of: none, cookies"), nil
+	}
+	cookieName, _ := args["CookieName"].(string)
+	cookieTTL, ttlSet, err := positiveIntArg(args, "CookieTtlSeconds")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	sticky := &godo.StickySessions{Type: sessionType}
+	if sessionType == "cookies" {
+		if cookieName == "" || !ttlSet {
+			return mcp.NewToolResultError("CookieName and CookieTtlSeconds are required when Type is cookies"), nil
+		}
+		sticky.CookieName = cookieName
+		sticky.CookieTtlSeconds = cookieTTL
+	} else if cookieName != "" || ttlSet {
+		return mcp.NewToolResultError("CookieName and CookieTtlSeconds can only be set when Type is cookies"), nil
+	}
+
+	return l.patchLoadBalancer(ctx, lbID, func(lbr *godo.LoadBalancerRequest) {
+		lbr.StickySessions = sticky
+	})
+}
+
 func (l *LoadBalancersTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
@@ -618,6 +759,30 @@ func (l *LoadBalancersTool) Tools() []server.ServerTool {
 				mcp.WithObject("GLBSettings", mcp.Description("Forward configurations for a global load balancer")),
 			),
 		},
+		{
+			Handler: l.updateHealthCheck,
+			Tool: mcp.NewTool("lb-update-health-check",
+				mcp.WithDescription("Update the health check of a Load Balancer. Unset fields keep their current value."),
+				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
+				mcp.WithString("Protocol", mcp.Enum("http", "https", "tcp"), mcp.Description("Protocol used for health checks")),
+				mcp.WithNumber("Port", mcp.Description("Port on the backend Droplets to check")),
+				mcp.WithString("Path", mcp.Description("Path to request for http and https checks (defaults to /)")),
+				mcp.WithNumber("CheckIntervalSeconds", mcp.Description("Number of seconds between two checks")),
+				mcp.WithNumber("ResponseTimeoutSeconds", mcp.Description("Number of seconds to wait for a response before a check fails")),
+				mcp.WithNumber("HealthyThreshold", mcp.Description("Number of consecutive passing checks before a Droplet is marked healthy")),
+				mcp.WithNumber("UnhealthyThreshold", mcp.Description("Number of consecutive failing checks before a Droplet is marked unhealthy")),
+			),
+		},
+		{
+			Handler: l.updateStickySessions,
+			Tool: mcp.NewTool("lb-update-sticky-sessions",
+				mcp.WithDescription("Update the sticky sessions of a Load Balancer"),
+				mcp.WithString("LoadBalancerID", mcp.Required(), mcp.Description("ID of the load balancer")),
+				mcp.WithString("Type", mcp.Required(), mcp.Enum("none", "cookies"), mcp.Description("Sticky session type")),
+				mcp.WithString("CookieName", mcp.Description("Name of the session cookie, required when Type is cookies")),
+				mcp.WithNumber("CookieTtlSeconds", mcp.Description("Lifetime of the session cookie in seconds, required when Type is cookies")),
+			),
+		},
 		{
 			Handler: l.addForwardingRules,
 			Tool: mcp.NewTool("lb-add-fwd-rules",
@@ -1292,3 +1292,197 @@ func TestLoadBalancersTool_removeForwardingRules(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadBalancersTool_updateHealthCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	currentLoadBalancer := func() *godo.LoadBalancer {
+		return &godo.LoadBalancer{
+			ID:     "12345",
+			Name:   "example-lb",
+			Region: &godo.Region{Slug: "nyc3"},
+			HealthCheck: &godo.HealthCheck{
+				Protocol:               "http",
+				Port:                   80,
+				Path:                   "/health",
+				CheckIntervalSeconds:   10,
+				ResponseTimeoutSeconds: 5,
+				HealthyThreshold:       3,
+				UnhealthyThreshold:     3,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectCheck *godo.HealthCheck
+		expectError bool
+		expectText  string
+	}{
+		{
+			name: "Partial update keeps current values",
+			args: map[string]any{
+				"LoadBalancerID":       "12345",
+				"CheckIntervalSeconds": float64(30),
+				"HealthyThreshold":     float64(5),
+			},
+			expectCheck: &godo.HealthCheck{
+				Protocol:               "http",
+				Port:                   80,
+				Path:                   "/health",
+				CheckIntervalSeconds:   30,
+				ResponseTimeoutSeconds: 5,
+				HealthyThreshold:       5,
+				UnhealthyThreshold:     3,
+			},
+		},
+		{
+			name: "Switch to tcp clears path",
+			args: map[string]any{
+				"LoadBalancerID": "12345",
+				"Protocol":       "TCP",
+				"Port":           float64(5432),
+			},
+			expectCheck: &godo.HealthCheck{
+				Protocol:               "tcp",
+				Port:                   5432,
+				CheckIntervalSeconds:   10,
+				ResponseTimeoutSeconds: 5,
+				HealthyThreshold:       3,
+				UnhealthyThreshold:     3,
+			},
+		},
+		{
+			name:        "Missing LoadBalancerID argument",
+			args:        map[string]any{"Protocol": "http"},
+			expectError: true,
+			expectText:  "LoadBalancerID is required",
+		},
+		{
+			name:        "Invalid protocol",
+			args:        map[string]any{"LoadBalancerID": "12345", "Protocol": "udp"},
+			expectError: true,
+			expectText:  "Protocol must be one of: http, https, tcp",
+		},
+		{
+			name:        "Non-positive threshold",
+			args:        map[string]any{"LoadBalancerID": "12345", "UnhealthyThreshold": float64(0)},
+			expectError: true,
+			expectText:  "UnhealthyThreshold must be a positive integer",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockLoadBalancers := NewMockLoadBalancersService(ctrl)
+			if tc.expectCheck != nil {
+				mockLoadBalancers.EXPECT().Get(gomock.Any(), "12345").Return(currentLoadBalancer(), nil, nil).Times(1)
+				mockLoadBalancers.EXPECT().
+					Update(gomock.Any(), "12345", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, lbID string, lbr *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+						require.Equal(t, "example-lb", lbr.Name)
+						require.Equal(t, "nyc3", lbr.Region)
+						require.Equal(t, tc.expectCheck, lbr.HealthCheck)
+						return &godo.LoadBalancer{ID: lbID, Name: lbr.Name, HealthCheck: lbr.HealthCheck}, nil, nil
+					}).
+					Times(1)
+			}
+			tool := setupLoadBalancersToolWithMock(mockLoadBalancers)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.updateHealthCheck(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectText)
+				return
+			}
+			require.False(t, resp.IsError)
+			var outLoadBalancer godo.LoadBalancer
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outLoadBalancer))
+			require.Equal(t, tc.expectCheck, outLoadBalancer.HealthCheck)
+		})
+	}
+}
+
+func TestLoadBalancersTool_updateStickySessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		expectSticky *godo.StickySessions
+		expectError  bool
+		expectText   string
+	}{
+		{
+			name: "Enable cookies",
+			args: map[string]any{
+				"LoadBalancerID":   "12345",
+				"Type":             "cookies",
+				"CookieName":       "DO-LB",
+				"CookieTtlSeconds": float64(300),
+			},
+			expectSticky: &godo.StickySessions{Type: "cookies", CookieName: "DO-LB", CookieTtlSeconds: 300},
+		},
+		{
+			name:         "Disable",
+			args:         map[string]any{"LoadBalancerID": "12345", "Type": "none"},
+			expectSticky: &godo.StickySessions{Type: "none"},
+		},
+		{
+			name:        "Invalid type",
+			args:        map[string]any{"LoadBalancerID": "12345", "Type": "ip"},
+			expectError: true,
+			expectText:  "Type must be one of: none, cookies",
+		},
+		{
+			name:        "Cookies without name",
+			args:        map[string]any{"LoadBalancerID": "12345", "Type": "cookies", "CookieTtlSeconds": float64(300)},
+			expectError: true,
+			expectText:  "CookieName and CookieTtlSeconds are required when Type is cookies",
+		},
+		{
+			name:        "Cookie fields with none",
+			args:        map[string]any{"LoadBalancerID": "12345", "Type": "none", "CookieName": "DO-LB"},
+			expectError: true,
+			expectText:  "CookieName and CookieTtlSeconds can only be set when Type is cookies",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockLoadBalancers := NewMockLoadBalancersService(ctrl)
+			if tc.expectSticky != nil {
+				mockLoadBalancers.EXPECT().
+					Get(gomock.Any(), "12345").
+					Return(&godo.LoadBalancer{ID: "12345", Name: "example-lb", StickySessions: &godo.StickySessions{Type: "none"}}, nil, nil).
+					Times(1)
+				mockLoadBalancers.EXPECT().
+					Update(gomock.Any(), "12345", gomock.Any()).
+					DoAndReturn(func(ctx context.Context, lbID string, lbr *godo.LoadBalancerRequest) (*godo.LoadBalancer, *godo.Response, error) {
+						require.Equal(t, tc.expectSticky, lbr.StickySessions)
+						return &godo.LoadBalancer{ID: lbID, Name: lbr.Name, StickySessions: lbr.StickySessions}, nil, nil
+					}).
+					Times(1)
+			}
+			tool := setupLoadBalancersToolWithMock(mockLoadBalancers)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.updateStickySessions(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectText)
+				return
+			}
+			require.False(t, resp.IsError)
+			var outLoadBalancer godo.LoadBalancer
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outLoadBalancer))
+			require.Equal(t, tc.expectSticky, outLoadBalancer.StickySessions)
+		})
+	}
+}
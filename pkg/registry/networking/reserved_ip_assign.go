@@ -0,0 +1,79 @@
+package networking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/digitalocean/godo"
+)
+
+// NewReservedIP asks AssignReservedIP to reserve a fresh IP rather than use an existing one.
+const NewReservedIP = "new"
+
+// CheckReservedIP checks, before a droplet is created in region, that ip can be assigned to it:
+// NewReservedIP, or an existing reserved IPv4 of the region that is not assigned to a droplet.
+func CheckReservedIP(ctx context.Context, client *godo.Client, ip, region string) error {
+	if ip == NewReservedIP {
+		return nil
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil || !addr.Is4() {
+		return fmt.Errorf("reserved IP must be an IPv4 address or %q, got %q", NewReservedIP, ip)
+	}
+	reservedIP, _, err := client.ReservedIPs.Get(ctx, ip)
+	if err != nil {
+		return fmt.Errorf("failed to get reserved IP %s: %w", ip, err)
+	}
+	if reservedIP.Region != nil && reservedIP.Region.Slug != region {
+		return fmt.Errorf("reserved IP %s is in region %s, not %s", ip, reservedIP.Region.Slug, region)
+	}
+	if reservedIP.Droplet != nil {
+		return fmt.Errorf("reserved IP %s is already assigned to droplet %d", ip, reservedIP.Droplet.ID)
+	}
+	return nil
+}
+
+// AssignReservedIP assigns a reserved IPv4 to a droplet, reserving a fresh one in the droplet's
+// region when ip is NewReservedIP. wait waits for the assign action of the IP to complete. When the
+// assignment fails, an IP reserved for it is released, so no unused reserved IP is left behind.
+func AssignReservedIP(ctx context.Context, client *godo.Client, ip string, droplet *godo.Droplet, wait func(ip string, action *godo.Action) error) (*godo.ReservedIP, error) {
+	reserved := ip == NewReservedIP
+	if reserved {
+		if droplet.Region == nil {
+			return nil, fmt.Errorf("droplet %d has no region to reserve an IP in", droplet.ID)
+		}
+		reservedIP, _, err := client.ReservedIPs.Create(ctx, &godo.ReservedIPCreateRequest{Region: droplet.Region.Slug})
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve an IP in %s: %w", droplet.Region.Slug, err)
+		}
+		ip = reservedIP.IP
+	}
+
+	err := func() error {
+		action, _, err := client.ReservedIPActions.Assign(ctx, ip, droplet.ID)
+		if err != nil {
+			return err
+		}
+		return wait(ip, action)
+	}()
+	if err != nil {
+		err = fmt.Errorf("failed to assign reserved IP %s to droplet %d: %w", ip, droplet.ID, err)
+		if reserved {
+			// The release must not be cut short by the context the assignment timed out with.
+			if _, releaseErr := client.ReservedIPs.Delete(context.WithoutCancel(ctx), ip); releaseErr != nil {
+				return nil, errors.Join(err, fmt.Errorf("failed to release reserved IP %s: %w", ip, releaseErr))
+			}
+			return nil, fmt.Errorf("%w, reserved IP %s was released", err, ip)
+		}
+		return nil, err
+	}
+
+	reservedIP, _, err := client.ReservedIPs.Get(ctx, ip)
+	if err != nil {
+		// The assignment went through, only its report could not be fetched.
+		return &godo.ReservedIP{IP: ip, Region: droplet.Region, Droplet: droplet}, nil
+	}
+	return reservedIP, nil
+}
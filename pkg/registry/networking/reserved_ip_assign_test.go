@@ -0,0 +1,152 @@
+package networking
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCheckReservedIP(t *testing.T) {
+	tests := []struct {
+		name        string
+		ip          string
+		mockSetup   func(*MockReservedIPsService)
+		expectError string
+	}{
+		{name: "New", ip: NewReservedIP},
+		{
+			name: "Unassigned IP of the region",
+			ip:   "192.0.2.1",
+			mockSetup: func(m *MockReservedIPsService) {
+				m.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: &godo.Region{Slug: "nyc1"}}, nil, nil)
+			},
+		},
+		{
+			name: "Other region",
+			ip:   "192.0.2.1",
+			mockSetup: func(m *MockReservedIPsService) {
+				m.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: &godo.Region{Slug: "sfo3"}}, nil, nil)
+			},
+			expectError: "reserved IP 192.0.2.1 is in region sfo3, not nyc1",
+		},
+		{
+			name: "Already assigned",
+			ip:   "192.0.2.1",
+			mockSetup: func(m *MockReservedIPsService) {
+				m.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(&godo.ReservedIP{IP: "192.0.2.1", Region: &godo.Region{Slug: "nyc1"}, Droplet: &godo.Droplet{ID: 7}}, nil, nil)
+			},
+			expectError: "already assigned to droplet 7",
+		},
+		{name: "IPv6", ip: "2001:db8::1", expectError: "must be an IPv4 address"},
+		{name: "Invalid", ip: "latest", expectError: "must be an IPv4 address"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			reservedIPs := NewMockReservedIPsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(reservedIPs)
+			}
+			err := CheckReservedIP(context.Background(), &godo.Client{ReservedIPs: reservedIPs}, tc.ip, "nyc1")
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAssignReservedIP(t *testing.T) {
+	droplet := &godo.Droplet{ID: 42, Region: &godo.Region{Slug: "nyc1"}}
+	assigned := &godo.ReservedIP{IP: "192.0.2.1", Region: droplet.Region, Droplet: droplet}
+	waitOK := func(string, *godo.Action) error { return nil }
+
+	tests := []struct {
+		name        string
+		ip          string
+		wait        func(string, *godo.Action) error
+		mockSetup   func(*MockReservedIPsService, *MockReservedIPActionsService)
+		expectError string
+	}{
+		{
+			name: "Existing IP",
+			ip:   "192.0.2.1",
+			wait: waitOK,
+			mockSetup: func(ips *MockReservedIPsService, actions *MockReservedIPActionsService) {
+				actions.EXPECT().Assign(gomock.Any(), "192.0.2.1", 42).Return(&godo.Action{ID: 1}, nil, nil)
+				ips.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(assigned, nil, nil)
+			},
+		},
+		{
+			name: "New IP",
+			ip:   NewReservedIP,
+			wait: func(ip string, action *godo.Action) error {
+				if ip != "192.0.2.1" || action.ID != 1 {
+					return errors.New("waited for the wrong action")
+				}
+				return nil
+			},
+			mockSetup: func(ips *MockReservedIPsService, actions *MockReservedIPActionsService) {
+				ips.EXPECT().Create(gomock.Any(), &godo.ReservedIPCreateRequest{Region: "nyc1"}).Return(&godo.ReservedIP{IP: "192.0.2.1", Region: droplet.Region}, nil, nil)
+				actions.EXPECT().Assign(gomock.Any(), "192.0.2.1", 42).Return(&godo.Action{ID: 1}, nil, nil)
+				ips.EXPECT().Get(gomock.Any(), "192.0.2.1").Return(assigned, nil, nil)
+			},
+		},
+		{
+			name: "New IP released when the assignment fails",
+			ip:   NewReservedIP,
+			wait: waitOK,
+			mockSetup: func(ips *MockReservedIPsService, actions *MockReservedIPActionsService) {
+				ips.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.ReservedIP{IP: "192.0.2.1", Region: droplet.Region}, nil, nil)
+				actions.EXPECT().Assign(gomock.Any(), "192.0.2.1", 42).Return(nil, nil, errors.New("unprocessable"))
+				ips.EXPECT().Delete(gomock.Any(), "192.0.2.1").Return(nil, nil)
+			},
+			expectError: "failed to assign reserved IP 192.0.2.1 to droplet 42: unprocessable, reserved IP 192.0.2.1 was released",
+		},
+		{
+			name: "New IP released when the assign action errors",
+			ip:   NewReservedIP,
+			wait: func(string, *godo.Action) error { return errors.New("action 1 (assign_ip) errored") },
+			mockSetup: func(ips *MockReservedIPsService, actions *MockReservedIPActionsService) {
+				ips.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&godo.ReservedIP{IP: "192.0.2.1", Region: droplet.Region}, nil, nil)
+				actions.EXPECT().Assign(gomock.Any(), "192.0.2.1", 42).Return(&godo.Action{ID: 1}, nil, nil)
+				ips.EXPECT().Delete(gomock.Any(), "192.0.2.1").Return(nil, errors.New("locked"))
+			},
+			expectError: "failed to release reserved IP 192.0.2.1: locked",
+		},
+		{
+			name: "Existing IP kept when the assignment fails",
+			ip:   "192.0.2.1",
+			wait: waitOK,
+			mockSetup: func(ips *MockReservedIPsService, actions *MockReservedIPActionsService) {
+				actions.EXPECT().Assign(gomock.Any(), "192.0.2.1", 42).Return(nil, nil, errors.New("unprocessable"))
+			},
+			expectError: "failed to assign reserved IP 192.0.2.1 to droplet 42: unprocessable",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ips := NewMockReservedIPsService(ctrl)
+			actions := NewMockReservedIPActionsService(ctrl)
+			tc.mockSetup(ips, actions)
+			client := &godo.Client{ReservedIPs: ips, ReservedIPActions: actions}
+
+			got, err := AssignReservedIP(context.Background(), client, tc.ip, droplet, tc.wait)
+			if tc.expectError != "" {
+				require.ErrorContains(t, err, tc.expectError)
+				require.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, assigned, got)
+		})
+	}
+}
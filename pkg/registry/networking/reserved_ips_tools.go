@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"net/http"
 	"net/netip"
+	"strconv"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -162,9 +165,16 @@ func (t *ReservedIPTool) releaseIP(ctx context.Context, req mcp.CallToolRequest)
 
 // assignIP assigns a reserved IP to a droplet
 func (t *ReservedIPTool) assignIP(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ip := req.GetArguments()["IP"].(string)
-	dropletID := int(req.GetArguments()["DropletID"].(float64))
-	ipType := req.GetArguments()["Type"].(string) // "ipv4" or "ipv6"
+	ip, ok := req.GetArguments()["IP"].(string)
+	if !ok || ip == "" {
+		return mcp.NewToolResultError("IP is required"), nil
+	}
+	dropletIDArg, ok := req.GetArguments()["DropletID"].(float64)
+	if !ok || dropletIDArg <= 0 {
+		return mcp.NewToolResultError("DropletID is required"), nil
+	}
+	dropletID := int(dropletIDArg)
+	ipType, _ := req.GetArguments()["Type"].(string) // "ipv4" or "ipv6"
 
 	var action *godo.Action
 	var err error
@@ -184,6 +194,15 @@ func (t *ReservedIPTool) assignIP(ctx context.Context, req mcp.CallToolRequest)
 	}
 
 	if err != nil {
+		var errResp *godo.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response != nil {
+			switch errResp.Response.StatusCode {
+			case http.StatusNotFound:
+				return mcp.NewToolResultError(fmt.Sprintf("reserved IP %s or droplet %d not found", ip, dropletID)), nil
+			case http.StatusUnprocessableEntity:
+				return mcp.NewToolResultError(fmt.Sprintf("cannot assign reserved IP %s to droplet %d: %s", ip, dropletID, errResp.Message)), nil
+			}
+		}
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
 
@@ -197,8 +216,11 @@ func (t *ReservedIPTool) assignIP(ctx context.Context, req mcp.CallToolRequest)
 
 // unassignIP unassigns a reserved IP from a droplet
 func (t *ReservedIPTool) unassignIP(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ip := req.GetArguments()["IP"].(string)
-	ipType := req.GetArguments()["Type"].(string) // "ipv4" or "ipv6"
+	ip, ok := req.GetArguments()["IP"].(string)
+	if !ok || ip == "" {
+		return mcp.NewToolResultError("IP is required"), nil
+	}
+	ipType, _ := req.GetArguments()["Type"].(string) // "ipv4" or "ipv6"
 
 	var action *godo.Action
 	var err error
@@ -208,15 +230,36 @@ func (t *ReservedIPTool) unassignIP(ctx context.Context, req mcp.CallToolRequest
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	// Check the IP is assigned first, the API error for an unassigned IP is not self-explanatory.
+	var droplet *godo.Droplet
 	switch ipType {
 	case "ipv4":
-		action, _, err = client.ReservedIPActions.Unassign(ctx, ip)
+		var reservedIP *godo.ReservedIP
+		reservedIP, _, err = client.ReservedIPs.Get(ctx, ip)
+		if reservedIP != nil {
+			droplet = reservedIP.Droplet
+		}
 	case "ipv6":
-		action, _, err = client.ReservedIPV6Actions.Unassign(ctx, ip)
+		var reservedIP *godo.ReservedIPV6
+		reservedIP, _, err = client.ReservedIPV6s.Get(ctx, ip)
+		if reservedIP != nil {
+			droplet = reservedIP.Droplet
+		}
 	default:
 		return mcp.NewToolResultErrorFromErr("invalid IP type. Use 'ipv4' or 'ipv6'", errors.New("invalid IP type")), nil
 	}
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	if droplet == nil {
+		return mcp.NewToolResultError(fmt.Sprintf("reserved IP %s is not assigned to a droplet", ip)), nil
+	}
 
+	if ipType == "ipv4" {
+		action, _, err = client.ReservedIPActions.Unassign(ctx, ip)
+	} else {
+		action, _, err = client.ReservedIPV6Actions.Unassign(ctx, ip)
+	}
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
@@ -229,6 +272,102 @@ func (t *ReservedIPTool) unassignIP(ctx context.Context, req mcp.CallToolRequest
 	return mcp.NewToolResultText(jsonData), nil
 }
 
+// listIPActions lists the actions taken on a reserved IPv4 with pagination
+func (t *ReservedIPTool) listIPActions(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	ip, ok := req.GetArguments()["IP"].(string)
+	if !ok || ip == "" {
+		return mcp.NewToolResultError("IP is required"), nil
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return mcp.NewToolResultError("invalid IP address format"), nil
+	}
+	if !addr.Is4() {
+		return mcp.NewToolResultError("listing actions is only supported for reserved IPv4 addresses"), nil
+	}
+	page := 1
+	perPage := 20
+	if v, ok := req.GetArguments()["Page"].(float64); ok && v > 0 {
+		page = int(v)
+	}
+	if v, ok := req.GetArguments()["PerPage"].(float64); ok && v > 0 {
+		perPage = int(v)
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	actions, _, err := client.ReservedIPActions.List(ctx, ip, &godo.ListOptions{Page: page, PerPage: perPage})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	jsonData, err := response.CompactJSON(actions)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// reservedIPProjectMove is the result of reserved-ip-move-to-project.
+type reservedIPProjectMove struct {
+	ProjectID   string                 `json:"project_id"`
+	ProjectName string                 `json:"project_name"`
+	Resources   []godo.ProjectResource `json:"resources"`
+}
+
+// moveIPToProject assigns a reserved IPv4, and by default the droplet it is assigned to, to a project.
+func (t *ReservedIPTool) moveIPToProject(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	ip := args.RequireString("IP")
+	projectID := args.RequireString("ProjectID")
+	includeDroplet := args.OptionalBool("IncludeDroplet", true)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if addr, err := netip.ParseAddr(ip); err != nil || !addr.Is4() {
+		return mcp.NewToolResultError(fmt.Sprintf("argument 'IP' must be a reserved IPv4 address, got %s", ip)), nil
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	project, _, err := client.Projects.Get(ctx, projectID)
+	if err != nil {
+		return common.APIErrorResult(err, "project", projectID), nil
+	}
+	reservedIP, _, err := client.ReservedIPs.Get(ctx, ip)
+	if err != nil {
+		return common.APIErrorResult(err, "reserved IP", ip), nil
+	}
+
+	ipURN, err := common.BuildURN("reservedip", reservedIP.IP)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	urns := []any{ipURN}
+	if includeDroplet && reservedIP.Droplet != nil {
+		dropletURN, err := common.BuildURN("droplet", strconv.Itoa(reservedIP.Droplet.ID))
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		urns = append(urns, dropletURN)
+	}
+	resources, _, err := client.Projects.AssignResources(ctx, project.ID, urns...)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+
+	jsonData, err := response.CompactJSON(reservedIPProjectMove{ProjectID: project.ID, ProjectName: project.Name, Resources: resources})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
 // Tools returns a list of tools for managing reserved IPs
 func (t *ReservedIPTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
@@ -276,10 +415,29 @@ func (t *ReservedIPTool) Tools() []server.ServerTool {
 		{
 			Handler: t.unassignIP,
 			Tool: mcp.NewTool("reserved-ip-unassign",
-				mcp.WithDescription("Unassign a reserved IP from a droplet"),
+				mcp.WithDescription("Unassign a reserved IP from the droplet it is assigned to. Fails if the IP is not assigned."),
 				mcp.WithString("IP", mcp.Required(), mcp.Description("The reserved IP to unassign")),
 				mcp.WithString("Type", mcp.Required(), mcp.Description("Type of IP to unassign ('ipv4' or 'ipv6')")),
 			),
 		},
+		{
+			Handler: t.moveIPToProject,
+			Tool: mcp.NewTool("reserved-ip-move-to-project",
+				mcp.WithDescription("Move a reserved IPv4 to another project, along with the droplet it is assigned to unless IncludeDroplet is false. Returns the project and its resulting assignments."),
+				mcp.WithString("IP", mcp.Required(), mcp.Description("The reserved IPv4 to move")),
+				mcp.WithString("ProjectID", mcp.Required(), mcp.Description("ID of the project to move the IP to")),
+				mcp.WithBoolean("IncludeDroplet", mcp.DefaultBool(true), mcp.Description("Whether to also move the droplet the IP is assigned to")),
+			),
+		},
+		{
+			Handler: t.listIPActions,
+			Tool: mcp.NewTool("reserved-ip-list-actions",
+				mcp.WithDescription("List the actions taken on a reserved IPv4, such as assignments, with pagination"),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("IP", mcp.Required(), mcp.Description("The reserved IPv4 address")),
+				mcp.WithNumber("Page", mcp.DefaultNumber(1), mcp.Description("Page number (default: 1)")),
+				mcp.WithNumber("PerPage", mcp.DefaultNumber(20), mcp.Description("Items per page (default: 20)")),
+			),
+		},
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"net/netip"
 	"testing"
 
@@ -423,15 +424,36 @@ func TestReservedIPTool_assignIP_unassignIP(t *testing.T) {
 		require.True(t, resp.IsError)
 	})
 
+	t.Run("Assign droplet not found", func(t *testing.T) {
+		mockIPv4Actions := NewMockReservedIPActionsService(ctrl)
+		mockIPv6Actions := NewMockReservedIPV6ActionsService(ctrl)
+		mockIPv4Actions.EXPECT().
+			Assign(gomock.Any(), "192.0.2.1", 404).
+			Return(nil, nil, &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "not found"}).
+			Times(1)
+		tool := setupReservedIPToolWithMocks(nil, nil, mockIPv4Actions, mockIPv6Actions)
+		args := map[string]any{"IP": "192.0.2.1", "DropletID": float64(404), "Type": "ipv4"}
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+		resp, err := tool.assignIP(context.Background(), req)
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+		require.Equal(t, "reserved IP 192.0.2.1 or droplet 404 not found", resp.Content[0].(mcp.TextContent).Text)
+	})
+
 	// unassignIP
 	t.Run("Unassign IPv4 success", func(t *testing.T) {
+		mockIPv4 := NewMockReservedIPsService(ctrl)
 		mockIPv4Actions := NewMockReservedIPActionsService(ctrl)
 		mockIPv6Actions := NewMockReservedIPV6ActionsService(ctrl)
+		mockIPv4.EXPECT().
+			Get(gomock.Any(), "192.0.2.1").
+			Return(&godo.ReservedIP{IP: "192.0.2.1", Droplet: &godo.Droplet{ID: 42}}, nil, nil).
+			Times(1)
 		mockIPv4Actions.EXPECT().
 			Unassign(gomock.Any(), "192.0.2.1").
 			Return(testAction, nil, nil).
 			Times(1)
-		tool := setupReservedIPToolWithMocks(nil, nil, mockIPv4Actions, mockIPv6Actions)
+		tool := setupReservedIPToolWithMocks(mockIPv4, nil, mockIPv4Actions, mockIPv6Actions)
 		args := map[string]any{"IP": "192.0.2.1", "Type": "ipv4"}
 		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 		resp, err := tool.unassignIP(context.Background(), req)
@@ -443,14 +465,36 @@ func TestReservedIPTool_assignIP_unassignIP(t *testing.T) {
 		require.Equal(t, testAction.ID, outAction.ID)
 	})
 
+	t.Run("Unassign IPv4 not assigned", func(t *testing.T) {
+		mockIPv4 := NewMockReservedIPsService(ctrl)
+		mockIPv4Actions := NewMockReservedIPActionsService(ctrl)
+		mockIPv6Actions := NewMockReservedIPV6ActionsService(ctrl)
+		mockIPv4.EXPECT().
+			Get(gomock.Any(), "192.0.2.1").
+			Return(&godo.ReservedIP{IP: "192.0.2.1"}, nil, nil).
+			Times(1)
+		tool := setupReservedIPToolWithMocks(mockIPv4, nil, mockIPv4Actions, mockIPv6Actions)
+		args := map[string]any{"IP": "192.0.2.1", "Type": "ipv4"}
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+		resp, err := tool.unassignIP(context.Background(), req)
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+		require.Equal(t, "reserved IP 192.0.2.1 is not assigned to a droplet", resp.Content[0].(mcp.TextContent).Text)
+	})
+
 	t.Run("Unassign IPv6 error", func(t *testing.T) {
+		mockIPv6 := NewMockReservedIPV6sService(ctrl)
 		mockIPv4Actions := NewMockReservedIPActionsService(ctrl)
 		mockIPv6Actions := NewMockReservedIPV6ActionsService(ctrl)
+		mockIPv6.EXPECT().
+			Get(gomock.Any(), "2001:db8::1").
+			Return(&godo.ReservedIPV6{IP: "2001:db8::1", Droplet: &godo.Droplet{ID: 99}}, nil, nil).
+			Times(1)
 		mockIPv6Actions.EXPECT().
 			Unassign(gomock.Any(), "2001:db8::1").
 			Return(nil, nil, errors.New("api error")).
 			Times(1)
-		tool := setupReservedIPToolWithMocks(nil, nil, mockIPv4Actions, mockIPv6Actions)
+		tool := setupReservedIPToolWithMocks(nil, mockIPv6, mockIPv4Actions, mockIPv6Actions)
 		args := map[string]any{"IP": "2001:db8::1", "Type": "ipv6"}
 		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
 		resp, err := tool.unassignIP(context.Background(), req)
@@ -459,3 +503,168 @@ func TestReservedIPTool_assignIP_unassignIP(t *testing.T) {
 		require.True(t, resp.IsError)
 	})
 }
+
+func TestReservedIPTool_listIPActions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testActions := []godo.Action{{ID: 1, Type: "assign_ip", Status: "completed"}, {ID: 2, Type: "unassign_ip", Status: "completed"}}
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockReservedIPActionsService)
+		expectError bool
+		expectText  string
+	}{
+		{
+			name: "Successful list",
+			args: map[string]any{"IP": "192.0.2.1", "Page": float64(2), "PerPage": float64(10)},
+			mockSetup: func(m *MockReservedIPActionsService) {
+				m.EXPECT().
+					List(gomock.Any(), "192.0.2.1", &godo.ListOptions{Page: 2, PerPage: 10}).
+					Return(testActions, nil, nil).
+					Times(1)
+			},
+		},
+		{
+			name:        "IPv6 not supported",
+			args:        map[string]any{"IP": "2001:db8::1"},
+			expectError: true,
+			expectText:  "listing actions is only supported for reserved IPv4 addresses",
+		},
+		{
+			name:        "Invalid IP",
+			args:        map[string]any{"IP": "not-an-ip"},
+			expectError: true,
+			expectText:  "invalid IP address format",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"IP": "192.0.2.1"},
+			mockSetup: func(m *MockReservedIPActionsService) {
+				m.EXPECT().
+					List(gomock.Any(), "192.0.2.1", &godo.ListOptions{Page: 1, PerPage: 20}).
+					Return(nil, nil, errors.New("api error")).
+					Times(1)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockIPv4Actions := NewMockReservedIPActionsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockIPv4Actions)
+			}
+			tool := setupReservedIPToolWithMocks(nil, nil, mockIPv4Actions, nil)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.listIPActions(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectText)
+				return
+			}
+			require.False(t, resp.IsError)
+			var outActions []godo.Action
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &outActions))
+			require.Equal(t, testActions, outActions)
+		})
+	}
+}
+
+func TestReservedIPTool_moveIPToProject(t *testing.T) {
+	droplet := &godo.Droplet{ID: 42}
+	assigned := &godo.ReservedIP{IP: "203.0.113.1", Droplet: droplet}
+	project := &godo.Project{ID: "proj-1", Name: "staging"}
+	notFound := &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockProjectsService, *MockReservedIPsService)
+		expectError string
+	}{
+		{
+			name: "Moves the IP and its droplet",
+			args: map[string]any{"IP": "203.0.113.1", "ProjectID": "proj-1"},
+			mockSetup: func(p *MockProjectsService, ips *MockReservedIPsService) {
+				p.EXPECT().Get(gomock.Any(), "proj-1").Return(project, nil, nil)
+				ips.EXPECT().Get(gomock.Any(), "203.0.113.1").Return(assigned, nil, nil)
+				p.EXPECT().AssignResources(gomock.Any(), "proj-1", assigned.URN(), droplet.URN()).
+					Return([]godo.ProjectResource{{URN: assigned.URN()}, {URN: droplet.URN()}}, nil, nil)
+			},
+		},
+		{
+			name: "Moves only the IP",
+			args: map[string]any{"IP": "203.0.113.1", "ProjectID": "proj-1", "IncludeDroplet": false},
+			mockSetup: func(p *MockProjectsService, ips *MockReservedIPsService) {
+				p.EXPECT().Get(gomock.Any(), "proj-1").Return(project, nil, nil)
+				ips.EXPECT().Get(gomock.Any(), "203.0.113.1").Return(assigned, nil, nil)
+				p.EXPECT().AssignResources(gomock.Any(), "proj-1", assigned.URN()).
+					Return([]godo.ProjectResource{{URN: assigned.URN()}}, nil, nil)
+			},
+		},
+		{
+			name: "Project not found",
+			args: map[string]any{"IP": "203.0.113.1", "ProjectID": "proj-1"},
+			mockSetup: func(p *MockProjectsService, ips *MockReservedIPsService) {
+				p.EXPECT().Get(gomock.Any(), "proj-1").Return(nil, nil, notFound)
+			},
+			expectError: `"resource_type":"project"`,
+		},
+		{
+			name: "Reserved IP not found",
+			args: map[string]any{"IP": "203.0.113.1", "ProjectID": "proj-1"},
+			mockSetup: func(p *MockProjectsService, ips *MockReservedIPsService) {
+				p.EXPECT().Get(gomock.Any(), "proj-1").Return(project, nil, nil)
+				ips.EXPECT().Get(gomock.Any(), "203.0.113.1").Return(nil, nil, notFound)
+			},
+			expectError: `"resource_type":"reserved IP"`,
+		},
+		{
+			name:        "IPv6 address",
+			args:        map[string]any{"IP": "2001:db8::1", "ProjectID": "proj-1"},
+			expectError: "argument 'IP' must be a reserved IPv4 address",
+		},
+		{
+			name:        "Missing project",
+			args:        map[string]any{"IP": "203.0.113.1"},
+			expectError: "argument 'ProjectID' is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockProjects := NewMockProjectsService(ctrl)
+			mockIPs := NewMockReservedIPsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockProjects, mockIPs)
+			}
+			tool := NewReservedIPTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{Projects: mockProjects, ReservedIPs: mockIPs}, nil
+			})
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.moveIPToProject(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			text := resp.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out reservedIPProjectMove
+			require.NoError(t, json.Unmarshal([]byte(text), &out))
+			require.Equal(t, "proj-1", out.ProjectID)
+			require.Equal(t, "staging", out.ProjectName)
+			require.NotEmpty(t, out.Resources)
+			require.Equal(t, assigned.URN(), out.Resources[0].URN)
+		})
+	}
+}
@@ -3,13 +3,21 @@ package networking
 import (
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+const (
+	defaultPeeringWaitTimeout      = 300 * time.Second
+	maxPeeringWaitTimeout          = 1800 * time.Second
+	defaultPeeringWaitPollInterval = 5 * time.Second
+)
+
 // VPCPeeringTool represents a tool for managing VPC peering connections.
 type VPCPeeringTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
@@ -99,10 +107,77 @@ func (t *VPCPeeringTool) createPeering(ctx context.Context, req mcp.CallToolRequ
 	return mcp.NewToolResultText(jsonData), nil
 }
 
+// waitForPeering polls a VPC peering until it is ACTIVE or ERRORED, the timeout elapses, or the context is cancelled.
+func (t *VPCPeeringTool) waitForPeering(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	id, ok := args["ID"].(string)
+	if !ok || id == "" {
+		return mcp.NewToolResultError("VPC Peering ID is required"), nil
+	}
+	timeout := defaultPeeringWaitTimeout
+	if v, ok := args["TimeoutSeconds"].(float64); ok && v > 0 {
+		timeout = time.Duration(v * float64(time.Second))
+	}
+	if timeout > maxPeeringWaitTimeout {
+		return mcp.NewToolResultError(fmt.Sprintf("TimeoutSeconds must not exceed %d", int(maxPeeringWaitTimeout.Seconds()))), nil
+	}
+	pollArgs := common.NewArgs(req)
+	interval := pollArgs.PollInterval(defaultPeeringWaitPollInterval)
+	if err := pollArgs.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := t.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	var peering *godo.VPCPeering
+	err = common.Poll(ctx, interval, func() (bool, error) {
+		latest, _, err := client.VPCs.GetVPCPeering(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		peering = latest
+		return peering.Status == "ACTIVE" || peering.Status == "ERRORED", nil
+	})
+	if err != nil {
+		if ctx.Err() == nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		msg := fmt.Sprintf("stopped waiting for VPC peering %s: %v", id, ctx.Err())
+		if peering != nil {
+			jsonData, err := response.CompactJSON(peering)
+			if err != nil {
+				return nil, fmt.Errorf("marshal error: %w", err)
+			}
+			msg += ", last status: " + jsonData
+		}
+		return mcp.NewToolResultError(msg), nil
+	}
+	jsonData, err := response.CompactJSON(peering)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	if peering.Status == "ERRORED" {
+		return mcp.NewToolResultError(fmt.Sprintf("VPC peering %s errored: %s", id, jsonData)), nil
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
 func (t *VPCPeeringTool) deletePeering(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 
-	peeringID := args["ID"].(string)
+	peeringID, ok := args["ID"].(string)
+	if !ok || peeringID == "" {
+		return mcp.NewToolResultError("VPC Peering ID is required"), nil
+	}
+	if confirm, _ := args["Confirm"].(bool); !confirm {
+		return mcp.NewToolResultError("Confirm must be true to delete a VPC Peering connection"), nil
+	}
 
 	client, err := t.client(ctx)
 	if err != nil {
@@ -123,8 +198,18 @@ func (t *VPCPeeringTool) Tools() []server.ServerTool {
 		{
 			Handler: t.getVPCPeering,
 			Tool: mcp.NewTool("vpc-peering-get",
-				mcp.WithDescription("Get VPC Peering information by ID"),
+				mcp.WithDescription("Get VPC Peering information by ID, including its status (PROVISIONING, ACTIVE, DELETING or ERRORED)"),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the VPC Peering connection")),
+			),
+		},
+		{
+			Handler: t.waitForPeering,
+			Tool: mcp.NewTool("vpc-peering-wait",
+				mcp.WithDescription("Wait for a VPC Peering connection to be provisioned by polling it until its status is ACTIVE or ERRORED. Returns the final peering."),
+				mcp.WithReadOnlyHintAnnotation(true),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the VPC Peering connection")),
+				mcp.WithNumber("TimeoutSeconds", mcp.DefaultNumber(defaultPeeringWaitTimeout.Seconds()), mcp.Max(maxPeeringWaitTimeout.Seconds()), mcp.Description("Maximum time to wait in seconds")),
+				common.WithPollInterval(defaultPeeringWaitPollInterval),
 			),
 		},
 		{
@@ -149,6 +234,8 @@ func (t *VPCPeeringTool) Tools() []server.ServerTool {
 			Tool: mcp.NewTool("vpc-peering-delete",
 				mcp.WithDescription("Delete a VPC Peering connection"),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the VPC Peering connection to delete")),
+				mcp.WithBoolean("Confirm", mcp.Required(), mcp.Description("Must be true to confirm the deletion")),
+				mcp.WithDestructiveHintAnnotation(true),
 			),
 		},
 	}
@@ -262,7 +262,7 @@ func TestVPCPeeringTool_deletePeering(t *testing.T) {
 	}{
 		{
 			name: "Successful delete",
-			args: map[string]any{"ID": "peer-123"},
+			args: map[string]any{"ID": "peer-123", "Confirm": true},
 			mockSetup: func(m *MockVPCsService) {
 				m.EXPECT().
 					DeleteVPCPeering(gomock.Any(), "peer-123").
@@ -273,7 +273,7 @@ func TestVPCPeeringTool_deletePeering(t *testing.T) {
 		},
 		{
 			name: "API error",
-			args: map[string]any{"ID": "peer-456"},
+			args: map[string]any{"ID": "peer-456", "Confirm": true},
 			mockSetup: func(m *MockVPCsService) {
 				m.EXPECT().
 					DeleteVPCPeering(gomock.Any(), "peer-456").
@@ -282,6 +282,16 @@ func TestVPCPeeringTool_deletePeering(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name:        "Without confirm",
+			args:        map[string]any{"ID": "peer-123"},
+			expectError: true,
+		},
+		{
+			name:        "Confirm false",
+			args:        map[string]any{"ID": "peer-123", "Confirm": false},
+			expectError: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -305,3 +315,97 @@ func TestVPCPeeringTool_deletePeering(t *testing.T) {
 		})
 	}
 }
+
+func TestVPCPeeringTool_waitForPeering(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockVPCsService)
+		expectError string
+	}{
+		{
+			name: "Active after polling",
+			args: map[string]any{"ID": "peer-123", "PollIntervalSeconds": float64(1)},
+			mockSetup: func(m *MockVPCsService) {
+				gomock.InOrder(
+					m.EXPECT().GetVPCPeering(gomock.Any(), "peer-123").Return(&godo.VPCPeering{ID: "peer-123", Status: "PROVISIONING"}, nil, nil).Times(1),
+					m.EXPECT().GetVPCPeering(gomock.Any(), "peer-123").Return(&godo.VPCPeering{ID: "peer-123", Status: "ACTIVE"}, nil, nil).Times(1),
+				)
+			},
+		},
+		{
+			name: "Errored peering",
+			args: map[string]any{"ID": "peer-123", "PollIntervalSeconds": float64(1)},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().GetVPCPeering(gomock.Any(), "peer-123").Return(&godo.VPCPeering{ID: "peer-123", Status: "ERRORED"}, nil, nil).Times(1)
+			},
+			expectError: "errored",
+		},
+		{
+			name: "Timeout",
+			args: map[string]any{"ID": "peer-123", "TimeoutSeconds": 0.05, "PollIntervalSeconds": float64(1)},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().GetVPCPeering(gomock.Any(), "peer-123").Return(&godo.VPCPeering{ID: "peer-123", Status: "PROVISIONING"}, nil, nil).MinTimes(1)
+			},
+			expectError: "stopped waiting",
+		},
+		{
+			name:        "Poll interval below a second",
+			args:        map[string]any{"ID": "peer-123", "PollIntervalSeconds": float64(0)},
+			expectError: "argument 'PollIntervalSeconds' must be at least 1",
+		},
+		{
+			name:        "Timeout too large",
+			args:        map[string]any{"ID": "peer-123", "TimeoutSeconds": float64(7200)},
+			expectError: "TimeoutSeconds",
+		},
+		{
+			name:        "Missing ID",
+			args:        map[string]any{},
+			expectError: "VPC Peering ID is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockVPC := NewMockVPCsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockVPC)
+			}
+			tool := setupVPCPeeringToolWithMock(mockVPC)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.waitForPeering(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, `"status":"ACTIVE"`)
+		})
+	}
+}
+
+func TestVPCPeeringTool_waitForPeeringCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockVPC := NewMockVPCsService(ctrl)
+	mockVPC.EXPECT().GetVPCPeering(gomock.Any(), "peer-123").DoAndReturn(func(context.Context, string) (*godo.VPCPeering, *godo.Response, error) {
+		cancel()
+		return &godo.VPCPeering{ID: "peer-123", Status: "PROVISIONING"}, nil, nil
+	}).Times(1)
+	tool := setupVPCPeeringToolWithMock(mockVPC)
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"ID": "peer-123"}}}
+	resp, err := tool.waitForPeering(ctx, req)
+	require.NoError(t, err)
+	require.True(t, resp.IsError)
+	require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "context canceled")
+}
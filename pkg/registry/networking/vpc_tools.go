@@ -3,7 +3,9 @@ package networking
 import (
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -132,18 +134,99 @@ func (v *VPCTool) listVPCMembers(ctx context.Context, req mcp.CallToolRequest) (
 	return mcp.NewToolResultText(jsonMembers), nil
 }
 
-// deleteVPC deletes a VPC
+// getDefaultVPC returns the default VPC of a region, the one resources are placed in when no VPC is
+// given.
+func (v *VPCTool) getDefaultVPC(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	region := args.RequireString("Region")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := v.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		vpcs, resp, err := client.VPCs.List(ctx, opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		for _, vpc := range vpcs {
+			if vpc.Default && vpc.RegionSlug == region {
+				jsonVPC, err := response.CompactJSON(vpc)
+				if err != nil {
+					return nil, fmt.Errorf("marshal error: %w", err)
+				}
+				return mcp.NewToolResultText(jsonVPC), nil
+			}
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+	return mcp.NewToolResultError(fmt.Sprintf("region %s has no default VPC", region)), nil
+}
+
+// listAllVPCMembers returns every member of a VPC, following every page.
+func listAllVPCMembers(ctx context.Context, client *godo.Client, vpcID string) ([]*godo.VPCMember, error) {
+	var all []*godo.VPCMember
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		members, resp, err := client.VPCs.ListMembers(ctx, vpcID, nil, opt)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, members...)
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			return all, nil
+		}
+		opt.Page++
+	}
+}
+
+// deleteVPC deletes a VPC. It refuses to delete the default VPC of a region or a VPC that still has
+// members unless Force is set.
 func (v *VPCTool) deleteVPC(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	vpcID := req.GetArguments()["ID"].(string)
+	args := common.NewArgs(req)
+	vpcID := args.RequireString("ID")
+	force := args.OptionalBool("Force", false)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	client, err := v.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	if !force {
+		vpc, _, err := client.VPCs.Get(ctx, vpcID)
+		if err != nil {
+			return common.APIErrorResult(err, "vpc", vpcID), nil
+		}
+		if vpc.Default {
+			return mcp.NewToolResultError(fmt.Sprintf("VPC %s (%s) is the default VPC of region %s: resources created there without a VPC are placed in it. Set Force to true to delete it anyway", vpc.ID, vpc.Name, vpc.RegionSlug)), nil
+		}
+		members, err := listAllVPCMembers(ctx, client, vpcID)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+		if len(members) > 0 {
+			blocking := make([]string, len(members))
+			for i, m := range members {
+				blocking[i] = fmt.Sprintf("%s (%s)", m.Name, m.URN)
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("VPC %s still has %d members: %s. Move or delete them first, or set Force to true", vpcID, len(members), strings.Join(blocking, ", "))), nil
+		}
+	}
+
 	_, err = client.VPCs.Delete(ctx, vpcID)
 	if err != nil {
-		return mcp.NewToolResultErrorFromErr("api error", err), nil
+		return common.APIErrorResult(err, "vpc", vpcID), nil
 	}
 
 	return mcp.NewToolResultText("VPC deleted successfully"), nil
@@ -187,8 +270,16 @@ func (v *VPCTool) Tools() []server.ServerTool {
 		{
 			Handler: v.deleteVPC,
 			Tool: mcp.NewTool("vpc-delete",
-				mcp.WithDescription("Delete a VPC"),
+				mcp.WithDescription("Delete a VPC. Refuses to delete the default VPC of a region or a VPC that still has members, listing them, unless Force is set."),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the VPC to delete")),
+				mcp.WithBoolean("Force", mcp.DefaultBool(false), mcp.Description("Skip the default VPC and members checks")),
+			),
+		},
+		{
+			Handler: v.getDefaultVPC,
+			Tool: mcp.NewTool("vpc-get-default",
+				mcp.WithDescription("Get the default VPC of a region, the one resources are placed in when no VPC is given"),
+				mcp.WithString("Region", mcp.Required(), mcp.Description("Region slug (e.g., nyc3)")),
 			),
 		},
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/digitalocean/godo"
@@ -403,6 +404,13 @@ func TestVPCTool_deleteVPC(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
+	lastPage := &godo.Response{Links: &godo.Links{}}
+	emptyVPC := func(m *MockVPCsService, id string) {
+		m.EXPECT().Get(gomock.Any(), id).Return(&godo.VPC{ID: id, Name: "staging", RegionSlug: "nyc3"}, nil, nil)
+		m.EXPECT().ListMembers(gomock.Any(), id, nil, gomock.Any()).Return(nil, lastPage, nil)
+	}
+	notFound := &godo.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "not found"}
+
 	tests := []struct {
 		name        string
 		args        map[string]any
@@ -414,6 +422,7 @@ func TestVPCTool_deleteVPC(t *testing.T) {
 			name: "Successful delete",
 			args: map[string]any{"ID": "vpc-123"},
 			mockSetup: func(m *MockVPCsService) {
+				emptyVPC(m, "vpc-123")
 				m.EXPECT().
 					Delete(gomock.Any(), "vpc-123").
 					Return(&godo.Response{}, nil).
@@ -425,6 +434,7 @@ func TestVPCTool_deleteVPC(t *testing.T) {
 			name: "API error",
 			args: map[string]any{"ID": "vpc-456"},
 			mockSetup: func(m *MockVPCsService) {
+				emptyVPC(m, "vpc-456")
 				m.EXPECT().
 					Delete(gomock.Any(), "vpc-456").
 					Return(nil, errors.New("api error")).
@@ -432,6 +442,54 @@ func TestVPCTool_deleteVPC(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "Default VPC refused",
+			args: map[string]any{"ID": "vpc-default"},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().Get(gomock.Any(), "vpc-default").
+					Return(&godo.VPC{ID: "vpc-default", Name: "default-nyc3", RegionSlug: "nyc3", Default: true}, nil, nil)
+			},
+			expectError: true,
+			expectText:  "is the default VPC of region nyc3",
+		},
+		{
+			name: "VPC with members refused",
+			args: map[string]any{"ID": "vpc-789"},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().Get(gomock.Any(), "vpc-789").Return(&godo.VPC{ID: "vpc-789"}, nil, nil)
+				gomock.InOrder(
+					m.EXPECT().ListMembers(gomock.Any(), "vpc-789", nil, &godo.ListOptions{Page: 1, PerPage: 200}).
+						Return([]*godo.VPCMember{{Name: "web-1", URN: "do:droplet:1"}}, &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+					m.EXPECT().ListMembers(gomock.Any(), "vpc-789", nil, &godo.ListOptions{Page: 2, PerPage: 200}).
+						Return([]*godo.VPCMember{{Name: "db", URN: "do:dbaas:abc"}}, lastPage, nil),
+				)
+			},
+			expectError: true,
+			expectText:  "VPC vpc-789 still has 2 members: web-1 (do:droplet:1), db (do:dbaas:abc)",
+		},
+		{
+			name: "Forced delete skips the checks",
+			args: map[string]any{"ID": "vpc-default", "Force": true},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().Delete(gomock.Any(), "vpc-default").Return(&godo.Response{}, nil)
+			},
+			expectText: "VPC deleted successfully",
+		},
+		{
+			name: "VPC not found",
+			args: map[string]any{"ID": "vpc-missing"},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().Get(gomock.Any(), "vpc-missing").Return(nil, &godo.Response{Response: notFound.Response}, notFound)
+			},
+			expectError: true,
+			expectText:  `"resource_type":"vpc"`,
+		},
+		{
+			name:        "Missing ID",
+			args:        map[string]any{},
+			expectError: true,
+			expectText:  "argument 'ID' is required",
+		},
 	}
 
 	for _, tc := range tests {
@@ -446,6 +504,7 @@ func TestVPCTool_deleteVPC(t *testing.T) {
 			if tc.expectError {
 				require.NotNil(t, resp)
 				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectText)
 				return
 			}
 			require.NoError(t, err)
@@ -455,3 +514,78 @@ func TestVPCTool_deleteVPC(t *testing.T) {
 		})
 	}
 }
+
+func TestVPCTool_getDefaultVPC(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockVPCsService)
+		expectID    string
+		expectError string
+	}{
+		{
+			name: "Default VPC on a later page",
+			args: map[string]any{"Region": "ams3"},
+			mockSetup: func(m *MockVPCsService) {
+				gomock.InOrder(
+					m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 1, PerPage: 200}).
+						Return([]*godo.VPC{
+							{ID: "vpc-nyc3", RegionSlug: "nyc3", Default: true},
+							{ID: "vpc-ams3-custom", RegionSlug: "ams3"},
+						}, &godo.Response{Links: &godo.Links{Pages: &godo.Pages{Next: "page=2", Last: "page=2"}}}, nil),
+					m.EXPECT().List(gomock.Any(), &godo.ListOptions{Page: 2, PerPage: 200}).
+						Return([]*godo.VPC{{ID: "vpc-ams3", RegionSlug: "ams3", Default: true}}, &godo.Response{Links: &godo.Links{}}, nil),
+				)
+			},
+			expectID: "vpc-ams3",
+		},
+		{
+			name: "No default VPC",
+			args: map[string]any{"Region": "sfo3"},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).
+					Return([]*godo.VPC{{ID: "vpc-nyc3", RegionSlug: "nyc3", Default: true}}, &godo.Response{Links: &godo.Links{}}, nil)
+			},
+			expectError: "region sfo3 has no default VPC",
+		},
+		{
+			name: "API error",
+			args: map[string]any{"Region": "nyc3"},
+			mockSetup: func(m *MockVPCsService) {
+				m.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("boom"))
+			},
+			expectError: "boom",
+		},
+		{
+			name:        "Missing region",
+			args:        map[string]any{},
+			expectError: "argument 'Region' is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockVPCs := NewMockVPCsService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockVPCs)
+			}
+			tool := setupVPCToolWithMock(mockVPCs)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.getDefaultVPC(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var vpc godo.VPC
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &vpc))
+			require.Equal(t, tc.expectID, vpc.ID)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package networking
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/digitalocean/godo"
+	"github.com/miekg/dns"
+)
+
+// zoneEntry is a resource record read from a zone file, either converted to a record create request
+// or carrying the reason it was skipped or rejected.
+type zoneEntry struct {
+	Name    string
+	Type    string
+	Request *godo.DomainRecordEditRequest
+	Skip    string
+	Err     error
+}
+
+// parseZoneFile reads the resource records of a BIND zone file for domain. The file is parsed with
+// the zone parser of github.com/miekg/dns, which handles the directives, multi-line records, quoting
+// and escapes; $INCLUDE is rejected. The A, AAAA, CAA, CNAME, MX, NS, SRV and TXT types are
+// converted. The SOA record and the NS records of the apex are skipped, as DigitalOcean manages
+// them. A record that can't be converted is returned with its error; a malformed file fails as a
+// whole.
+func parseZoneFile(domain, zoneFile string) ([]zoneEntry, error) {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	zp := dns.NewZoneParser(strings.NewReader(zoneFile), domain, "")
+	// Records without a TTL get 0, which DigitalOcean replaces with its default.
+	zp.SetDefaultTTL(0)
+
+	var entries []zoneEntry
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		header := rr.Header()
+		if header.Name == "" {
+			// The parser leaves the owner empty when the first record omits it.
+			return nil, fmt.Errorf("record without an owner name")
+		}
+		owner := strings.TrimSuffix(strings.ToLower(header.Name), ".")
+		entry := zoneEntry{Name: owner, Type: dns.Type(header.Rrtype).String()}
+		name, err := relativeName(owner, domain)
+		if err != nil {
+			entry.Err = err
+			entries = append(entries, entry)
+			continue
+		}
+		entry.Name = name
+		switch {
+		case header.Rrtype == dns.TypeSOA:
+			entry.Skip = "SOA records are managed by DigitalOcean"
+		case header.Rrtype == dns.TypeNS && name == "@":
+			entry.Skip = "NS records of the apex are managed by DigitalOcean"
+		default:
+			entry.Request, entry.Err = zoneRecordRequest(rr)
+			if entry.Request != nil {
+				entry.Request.Name = name
+				entry.Request.TTL = int(header.Ttl)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// zoneRecordRequest converts a parsed record to a record create request.
+func zoneRecordRequest(rr dns.RR) (*godo.DomainRecordEditRequest, error) {
+	req := &godo.DomainRecordEditRequest{Type: dns.Type(rr.Header().Rrtype).String()}
+	switch rr := rr.(type) {
+	case *dns.A:
+		req.Data = rr.A.String()
+	case *dns.AAAA:
+		req.Data = rr.AAAA.String()
+	case *dns.CNAME:
+		req.Data = strings.ToLower(rr.Target)
+	case *dns.NS:
+		req.Data = strings.ToLower(rr.Ns)
+	case *dns.MX:
+		req.Priority = int(rr.Preference)
+		req.Data = strings.ToLower(rr.Mx)
+	case *dns.SRV:
+		req.Priority, req.Weight, req.Port = int(rr.Priority), int(rr.Weight), int(rr.Port)
+		req.Data = strings.ToLower(rr.Target)
+	case *dns.TXT:
+		// Character strings of a record are concatenated, as DigitalOcean stores TXT data as one value.
+		req.Data = strings.Join(rr.Txt, "")
+	case *dns.CAA:
+		req.Flags = int(rr.Flag)
+		req.Tag = rr.Tag
+		req.Data = rr.Value
+	default:
+		return nil, fmt.Errorf("unsupported record type %s", req.Type)
+	}
+	return req, nil
+}
+
+// relativeName returns the record name DigitalOcean expects for the absolute name in domain, "@"
+// for the apex.
+func relativeName(name, domain string) (string, error) {
+	if name == domain {
+		return "@", nil
+	}
+	if relative, ok := strings.CutSuffix(name, "."+domain); ok {
+		return relative, nil
+	}
+	return "", fmt.Errorf("name %s is outside of domain %s", name, domain)
+}
@@ -0,0 +1,86 @@
+package networking
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/require"
+)
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.digitalocean.com. hostmaster.example.com. (
+		2024010101 ; serial
+		3600 600 604800 1800 )
+@		IN	NS	ns1.digitalocean.com.
+@	300	IN	A	192.0.2.1
+		IN	AAAA	2001:db8::1
+www		IN	CNAME	@
+mail.example.com.	IN	MX	10 mx1.mail.net.
+_sip._tcp	600	IN	SRV	10 60 5060 sip
+@		IN	TXT	"v=spf1 include:_spf.example.net" " ~all" ; spf
+@		IN	CAA	0 issue "letsencrypt.org"
+sub		IN	NS	ns1.other.net.
+other.org.	IN	A	192.0.2.2
+host		IN	HINFO	"x86" "linux"
+`
+
+func TestParseZoneFile(t *testing.T) {
+	entries, err := parseZoneFile("example.com", testZoneFile)
+	require.NoError(t, err)
+
+	type result struct {
+		name    string
+		skipped bool
+		err     string
+		request *godo.DomainRecordEditRequest
+	}
+	expected := []result{
+		{name: "@", skipped: true},
+		{name: "@", skipped: true},
+		{name: "@", request: &godo.DomainRecordEditRequest{Type: "A", Name: "@", Data: "192.0.2.1", TTL: 300}},
+		{name: "@", request: &godo.DomainRecordEditRequest{Type: "AAAA", Name: "@", Data: "2001:db8::1", TTL: 3600}},
+		{name: "www", request: &godo.DomainRecordEditRequest{Type: "CNAME", Name: "www", Data: "example.com.", TTL: 3600}},
+		{name: "mail", request: &godo.DomainRecordEditRequest{Type: "MX", Name: "mail", Data: "mx1.mail.net.", Priority: 10, TTL: 3600}},
+		{name: "_sip._tcp", request: &godo.DomainRecordEditRequest{Type: "SRV", Name: "_sip._tcp", Data: "sip.example.com.", Priority: 10, Weight: 60, Port: 5060, TTL: 600}},
+		{name: "@", request: &godo.DomainRecordEditRequest{Type: "TXT", Name: "@", Data: "v=spf1 include:_spf.example.net ~all", TTL: 3600}},
+		{name: "@", request: &godo.DomainRecordEditRequest{Type: "CAA", Name: "@", Data: "letsencrypt.org", Tag: "issue", TTL: 3600}},
+		{name: "sub", request: &godo.DomainRecordEditRequest{Type: "NS", Name: "sub", Data: "ns1.other.net.", TTL: 3600}},
+		{name: "other.org", err: "name other.org is outside of domain example.com"},
+		{name: "host", err: "unsupported record type HINFO"},
+	}
+	require.Len(t, entries, len(expected))
+	for i, want := range expected {
+		got := entries[i]
+		require.Equal(t, want.name, got.Name, "entry %d", i)
+		require.Equal(t, want.skipped, got.Skip != "", "entry %d", i)
+		if want.err != "" {
+			require.EqualError(t, got.Err, want.err, "entry %d", i)
+			continue
+		}
+		require.NoError(t, got.Err, "entry %d", i)
+		require.Equal(t, want.request, got.Request, "entry %d", i)
+	}
+}
+
+func TestParseZoneFile_malformed(t *testing.T) {
+	tests := []struct {
+		name     string
+		zoneFile string
+		err      string
+	}{
+		{name: "Unbalanced parenthesis", zoneFile: "@ IN A 192.0.2.1 (\n", err: "unbalanced brace"},
+		{name: "Extra parenthesis", zoneFile: "@ IN A 192.0.2.1 )\n", err: "extra closing brace"},
+		{name: "Unterminated string", zoneFile: "@ IN TXT \"abc\n", err: "at line: 1"},
+		{name: "No owner", zoneFile: "\tIN A 192.0.2.1\n", err: "record without an owner name"},
+		{name: "Include", zoneFile: "$INCLUDE other.zone\n", err: "$INCLUDE directive not allowed"},
+		{name: "Invalid TTL", zoneFile: "$TTL 1x\n", err: "at line: 1"},
+		{name: "Invalid address", zoneFile: "@ IN A 2001:db8::2\n", err: "bad A A"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseZoneFile("example.com", tc.zoneFile)
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package registry
+
+import (
+	"log/slog"
+	"slices"
+
+	"mcp-digitalocean/pkg/metrics"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Option configures how tools are registered with the MCP server.
+type Option func(*options)
+
+// options holds the registration settings built from the Option values passed to RegisterWithOptions.
+type options struct {
+	decorators    []toolDecorator
+	dryRun        bool
+	strictConfirm bool
+	callLogging   bool
+	callLogLevel  slog.Level
+	bestEffort    bool
+	metrics       *metrics.Registry
+	shutdown      *Shutdown
+	authContexts  *common.AuthContexts
+	regionSizes   *common.RegionSizeCache
+	// defaultCategories maps a service to the only category registered for it by default.
+	defaultCategories map[string]string
+}
+
+// WithDefaultCategories overrides, per service, which tools a service loads when it is activated: only
+// those of the given category, e.g. {"networking": "dns"}, instead of all its categories. Categories
+// selected explicitly as "service:category" are still registered. Services without an override are
+// unaffected.
+func WithDefaultCategories(defaults map[string]string) Option {
+	return func(o *options) {
+		o.defaultCategories = defaults
+	}
+}
+
+// WithBestEffort keeps registering the remaining services when one fails to register. Each failure
+// is logged and RegisterWithOptions returns a *RegistrationError listing the failed services.
+func WithBestEffort() Option {
+	return func(o *options) {
+		o.bestEffort = true
+	}
+}
+
+// WithRegionSizeCache makes region-list and size-list serve the regions and sizes held by cache,
+// unless called with fresh. The cache is refreshed by its Run method, which the caller starts.
+func WithRegionSizeCache(cache *common.RegionSizeCache) Option {
+	return func(o *options) {
+		o.regionSizes = cache
+	}
+}
+
+// toolDecorator wraps a tool before it is added to the server, typically replacing its handler
+// and optionally extending its input schema.
+type toolDecorator func(server.ServerTool) server.ServerTool
+
+// toolAdder is the subset of *server.MCPServer used by the register functions.
+type toolAdder interface {
+	AddTools(tools ...server.ServerTool)
+}
+
+// registrar adds tools to the server on behalf of the register functions. It applies the configured
+// decorators to every tool and records the service and category each tool was registered under.
+type registrar struct {
+	s          toolAdder
+	logger     *slog.Logger
+	decorators []toolDecorator
+	service    string
+	catalog    []common.ToolInfo
+	// selected holds the "service:category" pairs selected explicitly, which enables opt-in categories.
+	selected map[string]struct{}
+	// defaultCategories restricts a service to one category, besides those selected explicitly.
+	defaultCategories map[string]string
+	// authContexts holds the switchable auth contexts, nil when none are configured.
+	authContexts *common.AuthContexts
+	// regionSizes holds the cached regions and sizes, nil when caching is disabled.
+	regionSizes *common.RegionSizeCache
+	// shutdown tracks the work tools defer past their call, nil when the server has no coordinator.
+	shutdown *Shutdown
+}
+
+// addTools decorates and registers the given tools under a category of the current service.
+// Decorators are applied in order, so the first decorator is the innermost wrapper around the handler.
+// Tools of an opt-in category, or of a category other than the service's default when it has one, are
+// skipped unless the category was selected explicitly. A default category is loaded even if opt-in.
+func (r *registrar) addTools(category string, tools ...server.ServerTool) {
+	_, selected := r.selected[r.service+":"+category]
+	if def := r.defaultCategories[r.service]; !selected && category != def {
+		if def != "" || slices.Contains(optInCategories[r.service], category) {
+			return
+		}
+	}
+	for i := range tools {
+		r.catalog = append(r.catalog, common.ToolInfo{
+			Name:        tools[i].Tool.Name,
+			Description: tools[i].Tool.Description,
+			Service:     r.service,
+			Category:    category,
+		})
+		for _, decorate := range r.decorators {
+			tools[i] = decorate(tools[i])
+		}
+	}
+	r.s.AddTools(tools...)
+}
+
+// enabledTools returns the tools registered so far.
+func (r *registrar) enabledTools() []common.ToolInfo {
+	return slices.Clone(r.catalog)
+}
+
+// addProperty advertises an extra argument in the tool's input schema. Tools built with a raw JSON
+// schema are left untouched, the argument is still honored by the decorated handler.
+func addProperty(tool *mcp.Tool, name string, schema map[string]any) {
+	if tool.RawInputSchema != nil {
+		return
+	}
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]any{}
+	}
+	tool.InputSchema.Properties[name] = schema
+}
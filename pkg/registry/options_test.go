@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingServer collects the tools added to it.
+type recordingServer struct {
+	tools []server.ServerTool
+}
+
+func (r *recordingServer) AddTools(tools ...server.ServerTool) {
+	r.tools = append(r.tools, tools...)
+}
+
+func noopTool(name string) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool(name, mcp.WithDescription(name+" description")),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(name), nil
+		},
+	}
+}
+
+func TestRegistrar_addTools(t *testing.T) {
+	var order []string
+	decorator := func(label string) toolDecorator {
+		return func(tool server.ServerTool) server.ServerTool {
+			next := tool.Handler
+			tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				order = append(order, label)
+				return next(ctx, req)
+			}
+			return tool
+		}
+	}
+
+	s := &recordingServer{}
+	r := &registrar{s: s, decorators: []toolDecorator{decorator("inner"), decorator("outer")}}
+	r.service = "droplets"
+	r.addTools("basic", noopTool("droplet-create"))
+	r.addTools("actions", noopTool("droplet-reboot"), noopTool("droplet-shutdown"))
+	r.service = "common"
+	r.addTools("regions", noopTool("region-list"))
+
+	require.Equal(t, []common.ToolInfo{
+		{Name: "droplet-create", Description: "droplet-create description", Service: "droplets", Category: "basic"},
+		{Name: "droplet-reboot", Description: "droplet-reboot description", Service: "droplets", Category: "actions"},
+		{Name: "droplet-shutdown", Description: "droplet-shutdown description", Service: "droplets", Category: "actions"},
+		{Name: "region-list", Description: "region-list description", Service: "common", Category: "regions"},
+	}, r.enabledTools())
+
+	require.Len(t, s.tools, 4)
+	res := callTool(context.Background(), t, s.tools[0], nil)
+	require.Equal(t, "droplet-create", res.Content[0].(mcp.TextContent).Text)
+	require.Equal(t, []string{"outer", "inner"}, order)
+}
+
+func TestRegistrar_addTools_optInCategories(t *testing.T) {
+	services, selected := parseServiceFilters([]string{"apps", " droplets", "apps:alerts", ""})
+	require.Equal(t, []string{"apps", "droplets"}, services)
+
+	s := &recordingServer{}
+	r := &registrar{s: s, selected: selected, service: "apps"}
+	r.addTools("apps", noopTool("apps-list"))
+	r.addTools("alerts", noopTool("apps-list-alerts"))
+	require.Len(t, s.tools, 2)
+
+	s = &recordingServer{}
+	_, selected = parseServiceFilters([]string{"apps"})
+	r = &registrar{s: s, selected: selected, service: "apps"}
+	r.addTools("apps", noopTool("apps-list"))
+	r.addTools("alerts", noopTool("apps-list-alerts"))
+	require.Len(t, s.tools, 1)
+	require.Equal(t, []common.ToolInfo{
+		{Name: "apps-list", Description: "apps-list description", Service: "apps", Category: "apps"},
+	}, r.enabledTools())
+}
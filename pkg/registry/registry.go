@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"mcp-digitalocean/pkg/registry/account"
 	"mcp-digitalocean/pkg/registry/apps"
@@ -16,6 +19,7 @@ import (
 	"mcp-digitalocean/pkg/registry/marketplace"
 	"mcp-digitalocean/pkg/registry/networking"
 	"mcp-digitalocean/pkg/registry/spaces"
+	"mcp-digitalocean/pkg/registry/volumes"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/server"
@@ -23,114 +27,162 @@ import (
 
 type getClientFn func(ctx context.Context) (*godo.Client, error)
 
-// supportedServices is a set of services that we support in this MCP server.
-var supportedServices = map[string]struct{}{
-	"apps":        {},
-	"networking":  {},
-	"droplets":    {},
-	"accounts":    {},
-	"spaces":      {},
-	"databases":   {},
-	"marketplace": {},
-	"insights":    {},
-	"doks":        {},
+// supportedServices maps each service we support in this MCP server to the function registering its tools.
+var supportedServices = map[string]func(*registrar, getClientFn) error{
+	"apps":        registerAppTools,
+	"networking":  registerNetworkingTools,
+	"droplets":    registerDropletTools,
+	"accounts":    registerAccountTools,
+	"spaces":      registerSpacesTools,
+	"databases":   registerDatabasesTools,
+	"marketplace": registerMarketplaceTools,
+	"insights":    registerInsightsTools,
+	"doks":        registerDOKSTools,
+	"volumes":     registerVolumeTools,
+}
+
+// optInCategories lists, per service, the categories that are only registered when selected
+// explicitly as "service:category", e.g. "apps:alerts".
+var optInCategories = map[string][]string{
+	"apps":      {"alerts"},
+	"databases": {"query"},
 }
 
 // registerAppTools registers the app platform tools with the MCP server.
-func registerAppTools(s *server.MCPServer, getClient getClientFn) error {
+func registerAppTools(r *registrar, getClient getClientFn) error {
 	appTools, err := apps.NewAppPlatformTool(getClient)
 	if err != nil {
 		return fmt.Errorf("failed to create apps tool: %w", err)
 	}
 
-	s.AddTools(appTools.Tools()...)
+	r.addTools("apps", appTools.Tools()...)
+	r.addTools("env", appTools.EnvTools()...)
+	r.addTools("domains", appTools.DomainTools()...)
+	r.addTools("alerts", apps.NewAppAlertsTool(getClient).Tools()...)
 
 	return nil
 }
 
 // registerCommonTools registers the common tools with the MCP server.
-func registerCommonTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(common.NewRegionTools(getClient).Tools()...)
+func registerCommonTools(r *registrar, getClient getClientFn) error {
+	r.addTools("regions", common.NewRegionTools(getClient, r.regionSizes).Tools()...)
+	r.addTools("search", common.NewSearchTool(getClient).Tools()...)
+	r.addTools("cost", common.NewCostTool(getClient).Tools()...)
+	r.addTools("export", common.NewExportTool(getClient).Tools()...)
+	r.addTools("urn", common.NewURNTool().Tools()...)
+	r.addTools("health", common.NewHealthTool(getClient).Tools()...)
+	r.addTools("catalog", common.NewCatalogTool(r.enabledTools).Tools()...)
 
 	return nil
 }
 
 // registerDropletTools registers the droplet tools with the MCP server.
-func registerDropletTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(droplet.NewDropletTool(getClient).Tools()...)
-	s.AddTools(droplet.NewDropletActionsTool(getClient).Tools()...)
-	s.AddTools(droplet.NewImageTool(getClient).Tools()...)
-	s.AddTools(droplet.NewImageActionsTool(getClient).Tools()...)
-	s.AddTools(droplet.NewSizesTool(getClient).Tools()...)
+func registerDropletTools(r *registrar, getClient getClientFn) error {
+	r.addTools("basic", droplet.NewDropletTool(getClient).Tools()...)
+	r.addTools("actions", droplet.NewDropletActionsTool(getClient).Tools()...)
+	r.addTools("images", droplet.NewImageTool(getClient).Tools()...)
+	r.addTools("images", droplet.NewImageActionsTool(getClient).Tools()...)
+	r.addTools("sizes", droplet.NewSizesTool(getClient, r.regionSizes).Tools()...)
 	return nil
 }
 
 // registerNetworkingTools registers the networking tools with the MCP server.
-func registerNetworkingTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(networking.NewCertificateTool(getClient).Tools()...)
-	s.AddTools(networking.NewDomainsTool(getClient).Tools()...)
-	s.AddTools(networking.NewFirewallTool(getClient).Tools()...)
-	s.AddTools(networking.NewLoadBalancersTool(getClient).Tools()...)
-	s.AddTools(networking.NewReservedIPTool(getClient).Tools()...)
-	s.AddTools(networking.NewBYOIPPrefixTool(getClient).Tools()...)
+func registerNetworkingTools(r *registrar, getClient getClientFn) error {
+	r.addTools("certificates", networking.NewCertificateTool(getClient).Tools()...)
+	r.addTools("dns", networking.NewDomainsTool(getClient).Tools()...)
+	r.addTools("firewall", networking.NewFirewallTool(getClient).Tools()...)
+	r.addTools("load-balancers", networking.NewLoadBalancersTool(getClient).Tools()...)
+	r.addTools("ip", networking.NewReservedIPTool(getClient).Tools()...)
+	r.addTools("byoip-prefixes", networking.NewBYOIPPrefixTool(getClient).Tools()...)
 	// Partner attachments doesn't have much users so this has been disabled
-	// s.AddTools(networking.NewPartnerAttachmentTool(c).Tools()...)
-	s.AddTools(networking.NewVPCTool(getClient).Tools()...)
-	s.AddTools(networking.NewVPCPeeringTool(getClient).Tools()...)
+	// r.addTools("partner-attachments", networking.NewPartnerAttachmentTool(c).Tools()...)
+	r.addTools("vpc", networking.NewVPCTool(getClient).Tools()...)
+	r.addTools("vpc", networking.NewVPCPeeringTool(getClient).Tools()...)
 	return nil
 }
 
 // registerAccountTools registers the account tools with the MCP server.
-func registerAccountTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(account.NewAccountTools(getClient).Tools()...)
-	s.AddTools(account.NewActionTools(getClient).Tools()...)
-	s.AddTools(account.NewBalanceTools(getClient).Tools()...)
-	s.AddTools(account.NewBillingTools(getClient).Tools()...)
-	s.AddTools(account.NewInvoiceTools(getClient).Tools()...)
-	s.AddTools(account.NewKeysTool(getClient).Tools()...)
+func registerAccountTools(r *registrar, getClient getClientFn) error {
+	r.addTools("account", account.NewAccountTools(getClient).Tools()...)
+	if r.authContexts != nil {
+		r.addTools("contexts", account.NewContextTools(getClient, r.authContexts).Tools()...)
+	}
+	r.addTools("actions", account.NewActionTools(getClient).Tools()...)
+	r.addTools("balance", account.NewBalanceTools(getClient).Tools()...)
+	invoiceTools := account.NewInvoiceTools(getClient)
+	r.addTools("billing", account.NewBillingTools(getClient).Tools()...)
+	r.addTools("billing", invoiceTools.SummaryTools()...)
+	r.addTools("billing", invoiceTools.Tools()...)
+	r.addTools("keys", account.NewKeysTool(getClient).Tools()...)
 
 	return nil
 }
 
 // registerSpacesTools registers the spaces tools and resources with the MCP server.
-func registerSpacesTools(s *server.MCPServer, getClient getClientFn) error {
+func registerSpacesTools(r *registrar, getClient getClientFn) error {
 	// Register the tools for spaces keys
-	s.AddTools(spaces.NewSpacesKeysTool(getClient).Tools()...)
-	s.AddTools(spaces.NewCDNTool(getClient).Tools()...)
+	var afterFunc func(time.Duration, func())
+	if r.shutdown != nil {
+		afterFunc = r.shutdown.AfterFunc
+	}
+	r.addTools("keys", spaces.NewSpacesKeysTool(getClient, afterFunc, r.logger).Tools()...)
+	r.addTools("cdn", spaces.NewCDNTool(getClient).Tools()...)
+	// Buckets are managed through the S3-compatible API, signed with the Spaces access keys
+	r.addTools("buckets", spaces.NewBucketsTool(spaces.CredentialsFromEnv()).Tools()...)
 
 	return nil
 }
 
 // registerMarketplaceTools registers the marketplace tools with the MCP server.
-func registerMarketplaceTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(marketplace.NewOneClickTool(getClient).Tools()...)
+func registerMarketplaceTools(r *registrar, getClient getClientFn) error {
+	oneClickTool := marketplace.NewOneClickTool(getClient)
+	r.addTools("oneclick", oneClickTool.Tools()...)
+	r.addTools("install", oneClickTool.InstallTools()...)
 
 	return nil
 }
 
-func registerInsightsTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(insights.NewUptimeTool(getClient).Tools()...)
-	s.AddTools(insights.NewUptimeCheckAlertTool(getClient).Tools()...)
-	s.AddTools(insights.NewAlertPolicyTool(getClient).Tools()...)
+func registerInsightsTools(r *registrar, getClient getClientFn) error {
+	r.addTools("uptime", insights.NewUptimeTool(getClient).Tools()...)
+	r.addTools("uptime", insights.NewUptimeCheckAlertTool(getClient).Tools()...)
+	alertPolicyTool := insights.NewAlertPolicyTool(getClient)
+	r.addTools("alerts", alertPolicyTool.Tools()...)
+	r.addTools("alerts", insights.NewAlertDestinationTool(getClient).Tools()...)
+	r.addTools("alerts", alertPolicyTool.SimulationTools()...)
+	r.addTools("sizing", insights.NewSizeRecommendationTool(getClient).Tools()...)
 	return nil
 }
 
-func registerDOKSTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(doks.NewDoksTool(getClient).Tools()...)
+func registerDOKSTools(r *registrar, getClient getClientFn) error {
+	doksTool := doks.NewDoksTool(getClient)
+	r.addTools("clusters", doksTool.Tools()...)
+	r.addTools("registry", doksTool.RegistryTools()...)
+	r.addTools("maintenance", doksTool.MaintenanceTools()...)
 
 	return nil
 }
 
-func registerDatabasesTools(s *server.MCPServer, getClient getClientFn) error {
-	s.AddTools(dbaas.NewClusterTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewFirewallTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewKafkaTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewMongoTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewMysqlTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewOpenSearchTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewPostgreSQLTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewRedisTool(getClient).Tools()...)
-	s.AddTools(dbaas.NewUserTool(getClient).Tools()...)
+// registerVolumeTools registers the block storage volume tools with the MCP server.
+func registerVolumeTools(r *registrar, getClient getClientFn) error {
+	r.addTools("snapshots", volumes.NewSnapshotTool(getClient).Tools()...)
+
+	return nil
+}
+
+func registerDatabasesTools(r *registrar, getClient getClientFn) error {
+	clusterTool := dbaas.NewClusterTool(getClient)
+	r.addTools("clusters", clusterTool.Tools()...)
+	r.addTools("backups", clusterTool.BackupTools()...)
+	r.addTools("firewall", dbaas.NewFirewallTool(getClient).Tools()...)
+	r.addTools("kafka", dbaas.NewKafkaTool(getClient).Tools()...)
+	r.addTools("mongodb", dbaas.NewMongoTool(getClient).Tools()...)
+	r.addTools("mysql", dbaas.NewMysqlTool(getClient).Tools()...)
+	r.addTools("opensearch", dbaas.NewOpenSearchTool(getClient).Tools()...)
+	r.addTools("postgresql", dbaas.NewPostgreSQLTool(getClient).Tools()...)
+	r.addTools("redis", dbaas.NewRedisTool(getClient).Tools()...)
+	r.addTools("replicas", dbaas.NewReplicaTool(getClient).Tools()...)
+	r.addTools("users", dbaas.NewUserTool(getClient).Tools()...)
+	r.addTools("query", dbaas.NewQueryTool(getClient).Tools()...)
 
 	return nil
 }
@@ -138,65 +190,129 @@ func registerDatabasesTools(s *server.MCPServer, getClient getClientFn) error {
 // Register registers the set of tools for the specified services with the MCP server.
 // We either register a subset of tools of the services are specified, or we register all tools if no services are specified.
 func Register(logger *slog.Logger, s *server.MCPServer, getClient getClientFn, servicesToActivate ...string) error {
+	return RegisterWithOptions(logger, s, getClient, servicesToActivate)
+}
+
+// RegisterWithOptions is like Register but applies the given options, such as WithTimeout, to every registered tool.
+func RegisterWithOptions(logger *slog.Logger, mcpServer *server.MCPServer, getClient getClientFn, servicesToActivate []string, opts ...Option) error {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.callLogging {
+		// Added last so the logged duration covers the other decorators, such as the timeout.
+		o.decorators = append(o.decorators, loggingDecorator(logger, o.callLogLevel))
+	}
+	if o.metrics != nil {
+		o.decorators = append(o.decorators, metricsDecorator(o.metrics))
+	}
+	if o.shutdown != nil {
+		// Outermost, so that a drain waits for the whole call and a rejected call goes no further.
+		o.decorators = append(o.decorators, shutdownDecorator(o.shutdown))
+	}
+	if o.strictConfirm && !o.dryRun {
+		return fmt.Errorf("strict confirmation requires dry run, which returns the confirmation tokens")
+	}
+	servicesToActivate, selected := parseServiceFilters(servicesToActivate)
+	r := &registrar{s: mcpServer, logger: logger, decorators: o.decorators, selected: selected, defaultCategories: o.defaultCategories, authContexts: o.authContexts, regionSizes: o.regionSizes, shutdown: o.shutdown}
+	// Every tool shares the client built for the caller's token rather than building one per call.
+	getClient = common.MemoizeClient(getClient)
+	if o.authContexts != nil {
+		getClient = authContextClient(getClient, o.authContexts)
+	}
+	if o.dryRun {
+		getClient = dryRunClient(getClient)
+	}
+
 	if len(servicesToActivate) == 0 {
 		logger.Warn("no services specified, loading all supported services")
 		for k := range supportedServices {
 			servicesToActivate = append(servicesToActivate, k)
 		}
 	}
+	failed := map[string]error{}
+	// fail aborts the registration, or in best-effort mode logs the failure and lets it carry on.
+	fail := func(svc string, err error) error {
+		if !o.bestEffort {
+			return err
+		}
+		logger.Error("failed to register service", "service", svc, "error", err)
+		failed[svc] = err
+		return nil
+	}
 	for _, svc := range servicesToActivate {
 		logger.Debug(fmt.Sprintf("Registering tool and resources for service: %s", svc))
-		switch svc {
-		case "apps":
-			if err := registerAppTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register app tools: %w", err)
-			}
-		case "networking":
-			if err := registerNetworkingTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register networking tools: %w", err)
-			}
-		case "droplets":
-			if err := registerDropletTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register droplets tool: %w", err)
+		r.service = svc
+		register, ok := supportedServices[svc]
+		if !ok {
+			if err := fail(svc, fmt.Errorf("unsupported service: %s, supported service are: %v", svc, setToString(supportedServices))); err != nil {
+				return err
 			}
-		case "accounts":
-			if err := registerAccountTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register account tools: %w", err)
-			}
-		case "spaces":
-			if err := registerSpacesTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register spaces tools: %w", err)
-			}
-		case "databases":
-			if err := registerDatabasesTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register databases tools: %w", err)
-			}
-		case "marketplace":
-			if err := registerMarketplaceTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register marketplace tools: %w", err)
-			}
-		case "insights":
-			if err := registerInsightsTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register insights tools: %w", err)
-			}
-		case "doks":
-			if err := registerDOKSTools(s, getClient); err != nil {
-				return fmt.Errorf("failed to register DOKS tools: %w", err)
+			continue
+		}
+		if err := register(r, getClient); err != nil {
+			if err := fail(svc, fmt.Errorf("failed to register %s tools: %w", svc, err)); err != nil {
+				return err
 			}
-		default:
-			return fmt.Errorf("unsupported service: %s, supported service are: %v", svc, setToString(supportedServices))
 		}
 	}
 
 	// Common tools are always registered because they provide common functionality for all services such as region resources
-	if err := registerCommonTools(s, getClient); err != nil {
-		return fmt.Errorf("failed to register common tools: %w", err)
+	r.service = "common"
+	if err := registerCommonTools(r, getClient); err != nil {
+		if err := fail("common", fmt.Errorf("failed to register common tools: %w", err)); err != nil {
+			return err
+		}
 	}
 
+	if len(failed) > 0 {
+		return &RegistrationError{Services: failed}
+	}
 	return nil
 }
 
-func setToString(set map[string]struct{}) string {
+// RegistrationError is returned by RegisterWithOptions in best-effort mode when some services failed
+// to register. The tools of the other services were registered.
+type RegistrationError struct {
+	// Services maps each service that failed to register to its error.
+	Services map[string]error
+}
+
+func (e *RegistrationError) Error() string {
+	names := slices.Sorted(maps.Keys(e.Services))
+	msgs := make([]string, len(names))
+	for i, name := range names {
+		msgs[i] = e.Services[name].Error()
+	}
+	return fmt.Sprintf("failed to register services %s: %s", strings.Join(names, ", "), strings.Join(msgs, "; "))
+}
+
+func (e *RegistrationError) Unwrap() []error {
+	return slices.Collect(maps.Values(e.Services))
+}
+
+// parseServiceFilters splits service filters such as "apps" or "apps:alerts" into the services to
+// activate, in order and without duplicates, and the set of explicitly selected "service:category" pairs.
+func parseServiceFilters(filters []string) ([]string, map[string]struct{}) {
+	var services []string
+	selected := map[string]struct{}{}
+	for _, filter := range filters {
+		filter = strings.TrimSpace(filter)
+		if filter == "" {
+			continue
+		}
+		svc, category, hasCategory := strings.Cut(filter, ":")
+		if hasCategory {
+			selected[svc+":"+category] = struct{}{}
+		}
+		if !slices.Contains(services, svc) {
+			services = append(services, svc)
+		}
+	}
+	return services, selected
+}
+
+func setToString[V any](set map[string]V) string {
 	var result []string
 	for key := range set {
 		result = append(result, key)
@@ -0,0 +1,150 @@
+package registry
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	middleware "mcp-digitalocean/internal"
+	"mcp-digitalocean/pkg/registry/common"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+// failAppsRegistration makes the apps service fail to register for the duration of the test.
+func failAppsRegistration(t *testing.T) error {
+	errApps := errors.New("apps constructor failed")
+	original := supportedServices["apps"]
+	supportedServices["apps"] = func(*registrar, getClientFn) error { return errApps }
+	t.Cleanup(func() { supportedServices["apps"] = original })
+	return errApps
+}
+
+func TestRegisterWithOptions_bestEffort(t *testing.T) {
+	getClient := func(ctx context.Context) (*godo.Client, error) { return godo.NewFromToken("token"), nil }
+
+	t.Run("stops at the first failure by default", func(t *testing.T) {
+		errApps := failAppsRegistration(t)
+		s := server.NewMCPServer("test", "0.0.0")
+		err := RegisterWithOptions(slog.New(slog.DiscardHandler), s, getClient, []string{"apps", "droplets"})
+		require.ErrorIs(t, err, errApps)
+		require.NotContains(t, s.ListTools(), "droplet-create")
+	})
+
+	t.Run("registers the other services and aggregates the failures", func(t *testing.T) {
+		errApps := failAppsRegistration(t)
+		var logs bytes.Buffer
+		s := server.NewMCPServer("test", "0.0.0")
+		err := RegisterWithOptions(slog.New(slog.NewTextHandler(&logs, nil)), s, getClient, []string{"apps", "bogus", "droplets"}, WithBestEffort())
+
+		var regErr *RegistrationError
+		require.ErrorAs(t, err, &regErr)
+		require.ErrorIs(t, err, errApps)
+		require.Len(t, regErr.Services, 2)
+		require.Contains(t, regErr.Services, "apps")
+		require.Contains(t, regErr.Services, "bogus")
+		require.Contains(t, err.Error(), "failed to register services apps, bogus")
+
+		tools := s.ListTools()
+		require.Contains(t, tools, "droplet-create")
+		require.Contains(t, tools, "region-list")
+		require.Contains(t, logs.String(), "service=apps")
+		require.Contains(t, logs.String(), "service=bogus")
+	})
+
+	t.Run("returns nil when every service registers", func(t *testing.T) {
+		s := server.NewMCPServer("test", "0.0.0")
+		require.NoError(t, RegisterWithOptions(slog.New(slog.DiscardHandler), s, getClient, []string{"droplets"}, WithBestEffort()))
+	})
+}
+
+func TestRegisterWithOptions_defaultCategories(t *testing.T) {
+	getClient := func(ctx context.Context) (*godo.Client, error) { return godo.NewFromToken("token"), nil }
+	register := func(t *testing.T, services []string, opts ...Option) map[string]*server.ServerTool {
+		t.Helper()
+		s := server.NewMCPServer("test", "0.0.0")
+		require.NoError(t, RegisterWithOptions(slog.New(slog.DiscardHandler), s, getClient, services, opts...))
+		return s.ListTools()
+	}
+	defaults := WithDefaultCategories(map[string]string{"networking": "dns"})
+
+	t.Run("loads every category without an override", func(t *testing.T) {
+		tools := register(t, []string{"networking"})
+		require.Contains(t, tools, "domain-list")
+		require.Contains(t, tools, "lb-create")
+	})
+
+	t.Run("loads only the default category of an overridden service", func(t *testing.T) {
+		tools := register(t, []string{"networking", "droplets"}, defaults)
+		require.Contains(t, tools, "domain-list")
+		require.NotContains(t, tools, "lb-create")
+		require.NotContains(t, tools, "firewall-list")
+		require.Contains(t, tools, "droplet-create")
+		require.Contains(t, tools, "droplet-reboot")
+	})
+
+	t.Run("keeps explicitly selected categories", func(t *testing.T) {
+		tools := register(t, []string{"networking", "networking:load-balancers"}, defaults)
+		require.Contains(t, tools, "domain-list")
+		require.Contains(t, tools, "lb-create")
+		require.NotContains(t, tools, "firewall-list")
+	})
+
+	t.Run("groups uptime checks and their alerts in one category", func(t *testing.T) {
+		tools := register(t, []string{"insights"}, WithDefaultCategories(map[string]string{"insights": "uptime"}))
+		require.Contains(t, tools, "uptime-check-list")
+		require.Contains(t, tools, "uptime-alert-create")
+		require.NotContains(t, tools, "alert-policy-create")
+	})
+
+	t.Run("loads an opt-in default category", func(t *testing.T) {
+		tools := register(t, []string{"apps"}, WithDefaultCategories(map[string]string{"apps": "alerts"}))
+		require.Contains(t, tools, "apps-list-alerts")
+		require.NotContains(t, tools, "apps-list")
+	})
+}
+
+func TestRegisterWithOptions_authContexts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	var auths []string
+	getClient := func(ctx context.Context) (*godo.Client, error) {
+		auth, _ := ctx.Value(middleware.AuthKey{}).(string)
+		auths = append(auths, auth)
+		client := godo.NewClient(srv.Client())
+		client.BaseURL, _ = url.Parse(srv.URL + "/")
+		return client, nil
+	}
+	contexts, err := common.NewAuthContexts(map[string]string{common.DefaultAuthContext: "main", "staging": "staging-token"}, common.DefaultAuthContext)
+	require.NoError(t, err)
+
+	s := server.NewMCPServer("test", "0.0.0")
+	require.NoError(t, RegisterWithOptions(slog.New(slog.DiscardHandler), s, getClient, []string{"accounts"}))
+	require.NotContains(t, s.ListTools(), "account-switch-context")
+
+	s = server.NewMCPServer("test", "0.0.0")
+	require.NoError(t, RegisterWithOptions(slog.New(slog.DiscardHandler), s, getClient, []string{"accounts"}, WithAuthContexts(contexts)))
+	tools := s.ListTools()
+	require.Contains(t, tools, "account-list-contexts")
+	require.Contains(t, tools, "account-switch-context")
+
+	// The tools build their client for the token of the context in use.
+	call := func() {
+		_, err := tools["balance-get"].Handler(context.Background(), mcp.CallToolRequest{})
+		require.NoError(t, err)
+	}
+	call()
+	require.NoError(t, contexts.Use("staging"))
+	call()
+	require.Equal(t, []string{"Bearer main", "Bearer staging-token"}, auths)
+}
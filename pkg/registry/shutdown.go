@@ -0,0 +1,146 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// DefaultShutdownGracePeriod is how long in-flight tool calls are given to finish on shutdown. It
+// leaves room within the 30 seconds Kubernetes waits by default before killing a terminating pod.
+const DefaultShutdownGracePeriod = 25 * time.Second
+
+// Shutdown coordinates a graceful shutdown of the server. It tracks the tool calls in flight and,
+// once draining, turns new calls away while waiting for those in flight to finish, so that a call
+// is not cut off between the API requests it makes. Work a tool defers past its call, such as the
+// delayed deletion of a rotated key, is scheduled with AfterFunc so it is accounted for too.
+type Shutdown struct {
+	mu       sync.Mutex
+	draining bool
+	inFlight int
+	calls    sync.WaitGroup
+	// timers holds the tasks scheduled with AfterFunc that have not started yet.
+	timers map[*time.Timer]struct{}
+	// dropped counts the scheduled tasks that were not run because the server drained first.
+	dropped int
+}
+
+// NewShutdown returns a shutdown coordinator accepting tool calls.
+func NewShutdown() *Shutdown {
+	return &Shutdown{timers: map[*time.Timer]struct{}{}}
+}
+
+// AfterFunc runs f once d has elapsed, tracked like a tool call so that Drain waits for it to finish.
+// A task that has not started when the server drains is dropped rather than run early, and counted
+// in Dropped.
+func (s *Shutdown) AfterFunc(d time.Duration, f func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.draining {
+		s.dropped++
+		return
+	}
+	var timer *time.Timer
+	// The task takes s.mu before removing its timer, so it can't do so before the timer is added.
+	timer = time.AfterFunc(d, func() {
+		s.mu.Lock()
+		delete(s.timers, timer)
+		s.mu.Unlock()
+		if !s.begin() {
+			s.mu.Lock()
+			s.dropped++
+			s.mu.Unlock()
+			return
+		}
+		defer s.end()
+		f()
+	})
+	s.timers[timer] = struct{}{}
+}
+
+// Dropped returns the number of tasks scheduled with AfterFunc that were dropped by Drain.
+func (s *Shutdown) Dropped() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// begin records the start of a tool call, reporting false when the server is draining.
+func (s *Shutdown) begin() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.draining {
+		return false
+	}
+	s.inFlight++
+	s.calls.Add(1)
+	return true
+}
+
+// end records the end of a tool call started with begin.
+func (s *Shutdown) end() {
+	s.mu.Lock()
+	s.inFlight--
+	s.mu.Unlock()
+	s.calls.Done()
+}
+
+// InFlight returns the number of tool calls running.
+func (s *Shutdown) InFlight() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inFlight
+}
+
+// Drain stops accepting tool calls, drops the tasks scheduled with AfterFunc that have not started,
+// and waits for the calls and tasks in flight to finish. When ctx is done first, it returns an error
+// telling how many are still running.
+func (s *Shutdown) Drain(ctx context.Context) error {
+	s.mu.Lock()
+	s.draining = true
+	for timer := range s.timers {
+		if timer.Stop() {
+			s.dropped++
+		}
+	}
+	clear(s.timers)
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.calls.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d tool calls still in flight: %w", s.InFlight(), ctx.Err())
+	}
+}
+
+// WithShutdown tracks every tool call in s, so that s.Drain waits for the calls in flight and
+// rejects new ones.
+func WithShutdown(s *Shutdown) Option {
+	return func(o *options) {
+		o.shutdown = s
+	}
+}
+
+func shutdownDecorator(s *Shutdown) toolDecorator {
+	return func(tool server.ServerTool) server.ServerTool {
+		next := tool.Handler
+		tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !s.begin() {
+				return mcp.NewToolResultError("the server is shutting down, retry the call once it is back"), nil
+			}
+			defer s.end()
+			return next(ctx, req)
+		}
+		return tool
+	}
+}
@@ -0,0 +1,140 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+// drainBlockingTool returns a tool tracked by s whose calls signal started, then block until release is
+// closed.
+func drainBlockingTool(s *Shutdown, started chan<- struct{}, release <-chan struct{}) server.ServerTool {
+	return shutdownDecorator(s)(server.ServerTool{
+		Tool: mcp.NewTool("droplet-create"),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			started <- struct{}{}
+			<-release
+			return mcp.NewToolResultText("created"), nil
+		},
+	})
+}
+
+func TestShutdown_drainsInFlightCalls(t *testing.T) {
+	s := NewShutdown()
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	tool := drainBlockingTool(s, started, release)
+
+	results := make(chan *mcp.CallToolResult, 1)
+	go func() {
+		result, _ := tool.Handler(context.Background(), mcp.CallToolRequest{})
+		results <- result
+	}()
+	<-started
+	require.Equal(t, 1, s.InFlight())
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- s.Drain(context.Background())
+	}()
+
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.draining
+	}, time.Second, time.Millisecond)
+
+	// Once draining, new calls are turned away while the one in flight keeps running.
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	select {
+	case err := <-drained:
+		t.Fatalf("drain returned with a call in flight: %v", err)
+	default:
+	}
+
+	close(release)
+	require.NoError(t, <-drained)
+	result = <-results
+	require.False(t, result.IsError)
+	require.Equal(t, "created", result.Content[0].(mcp.TextContent).Text)
+	require.Equal(t, 0, s.InFlight())
+}
+
+func TestShutdown_gracePeriodElapses(t *testing.T) {
+	s := NewShutdown()
+	started, release := make(chan struct{}, 1), make(chan struct{})
+	defer close(release)
+	tool := drainBlockingTool(s, started, release)
+
+	go func() {
+		_, _ = tool.Handler(context.Background(), mcp.CallToolRequest{})
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := s.Drain(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "1 tool calls still in flight")
+}
+
+func TestShutdown_rejectsCallsOnceDrained(t *testing.T) {
+	s := NewShutdown()
+	require.NoError(t, s.Drain(context.Background()))
+
+	called := false
+	tool := shutdownDecorator(s)(server.ServerTool{
+		Tool: mcp.NewTool("droplet-get"),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			called = true
+			return mcp.NewToolResultText("ok"), nil
+		},
+	})
+	result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	require.Contains(t, result.Content[0].(mcp.TextContent).Text, "the server is shutting down")
+	require.False(t, called)
+}
+
+func TestShutdown_afterFunc(t *testing.T) {
+	t.Run("drain waits for a running task", func(t *testing.T) {
+		s := NewShutdown()
+		started, release := make(chan struct{}), make(chan struct{})
+		s.AfterFunc(time.Millisecond, func() {
+			close(started)
+			<-release
+		})
+		<-started
+		require.Equal(t, 1, s.InFlight())
+
+		drained := make(chan error, 1)
+		go func() {
+			drained <- s.Drain(context.Background())
+		}()
+		select {
+		case err := <-drained:
+			t.Fatalf("drain returned with a task running: %v", err)
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(release)
+		require.NoError(t, <-drained)
+		require.Equal(t, 0, s.Dropped())
+	})
+
+	t.Run("drain drops pending tasks", func(t *testing.T) {
+		s := NewShutdown()
+		ran := false
+		s.AfterFunc(time.Hour, func() { ran = true })
+		require.NoError(t, s.Drain(context.Background()))
+		s.AfterFunc(time.Millisecond, func() { ran = true })
+		time.Sleep(10 * time.Millisecond)
+		require.False(t, ran)
+		require.Equal(t, 2, s.Dropped())
+	})
+}
@@ -0,0 +1,538 @@
+package spaces
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	defaultBucketRegion = "nyc3"
+
+	// defaultPresignExpiry is how long a URL from spaces-presign-url stays valid by default.
+	defaultPresignExpiry = time.Hour
+	// maxPresignExpiry is the longest validity Signature Version 4 allows for a presigned URL.
+	maxPresignExpiry = 7 * 24 * time.Hour
+)
+
+// presignMethods are the methods spaces-presign-url can sign a URL for.
+var presignMethods = []string{http.MethodGet, http.MethodPut}
+
+// bucketRegions are the regions in which Spaces buckets can be created.
+var bucketRegions = []string{"nyc3", "ams3", "sgp1", "sfo3", "fra1"}
+
+var bucketNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// Bucket is a Spaces bucket as returned by the S3-compatible API.
+type Bucket struct {
+	Name         string    `json:"name"`
+	CreationDate time.Time `json:"creation_date"`
+}
+
+// Object is an object stored in a Spaces bucket.
+type Object struct {
+	Key          string    `json:"key"`
+	LastModified time.Time `json:"last_modified"`
+	ETag         string    `json:"etag"`
+	Size         int64     `json:"size"`
+	StorageClass string    `json:"storage_class,omitempty"`
+}
+
+// corsMethods are the HTTP methods a CORS rule may allow.
+var corsMethods = []string{"GET", "PUT", "POST", "DELETE", "HEAD"}
+
+// ListObjectsResult is a page of objects in a bucket.
+type ListObjectsResult struct {
+	Name                  string   `json:"bucket"`
+	Prefix                string   `json:"prefix,omitempty"`
+	KeyCount              int      `json:"key_count"`
+	IsTruncated           bool     `json:"is_truncated"`
+	NextContinuationToken string   `json:"next_continuation_token,omitempty"`
+	Contents              []Object `json:"objects"`
+}
+
+// BucketsTool provides Spaces bucket management tools using the S3-compatible API.
+type BucketsTool struct {
+	credentials Credentials
+	httpClient  *http.Client
+	endpoint    func(region string) string
+}
+
+// NewBucketsTool creates a new buckets tool signing requests with the given Spaces access key pair.
+func NewBucketsTool(credentials Credentials) *BucketsTool {
+	return &BucketsTool{
+		credentials: credentials,
+		httpClient:  http.DefaultClient,
+		endpoint:    endpointForRegion,
+	}
+}
+
+func (b *BucketsTool) s3(ctx context.Context, region string) (*s3.Client, error) {
+	return newS3Client(ctx, b.httpClient, b.credentials, b.endpoint(region), region)
+}
+
+// validateBucketName checks a bucket name against the S3 bucket naming rules.
+func validateBucketName(name string) error {
+	if len(name) < 3 || len(name) > 63 {
+		return fmt.Errorf("bucket name must be between 3 and 63 characters long")
+	}
+	if !bucketNamePattern.MatchString(name) {
+		return fmt.Errorf("bucket name may only contain lowercase letters, numbers, dots and hyphens, and must begin and end with a letter or number")
+	}
+	if strings.Contains(name, "..") || strings.Contains(name, ".-") || strings.Contains(name, "-.") {
+		return fmt.Errorf("bucket name must not contain adjacent periods or a period next to a hyphen")
+	}
+	if net.ParseIP(name) != nil {
+		return fmt.Errorf("bucket name must not be formatted as an IP address")
+	}
+	if strings.HasPrefix(name, "xn--") || strings.HasSuffix(name, "-s3alias") {
+		return fmt.Errorf("bucket name must not start with xn-- or end with -s3alias")
+	}
+	return nil
+}
+
+// regionArg reads the Region argument, defaulting to nyc3, and checks that Spaces is available there.
+func regionArg(args map[string]any) (string, error) {
+	region, _ := args["Region"].(string)
+	if region == "" {
+		return defaultBucketRegion, nil
+	}
+	for _, r := range bucketRegions {
+		if r == region {
+			return region, nil
+		}
+	}
+	return "", fmt.Errorf("region must be one of %s", strings.Join(bucketRegions, ", "))
+}
+
+func (b *BucketsTool) listBuckets(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	region, err := regionArg(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := b.s3(ctx, region)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket list", err), nil
+	}
+	out, err := client.ListBuckets(ctx, &s3.ListBucketsInput{})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket list", err), nil
+	}
+	buckets := make([]Bucket, len(out.Buckets))
+	for i, bucket := range out.Buckets {
+		buckets[i] = Bucket{Name: aws.ToString(bucket.Name), CreationDate: aws.ToTime(bucket.CreationDate)}
+	}
+
+	jsonBuckets, err := response.CompactJSON(buckets)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonBuckets), nil
+}
+
+func (b *BucketsTool) createBucket(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	name, _ := args["Name"].(string)
+	if err := validateBucketName(name); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	region, err := regionArg(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := b.s3(ctx, region)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket create", err), nil
+	}
+	if _, err := client.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(name)}); err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket create", err), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Bucket %s created successfully in %s", name, region)), nil
+}
+
+func (b *BucketsTool) deleteBucket(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	name, _ := args["Name"].(string)
+	if name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+	region, err := regionArg(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := b.s3(ctx, region)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket delete", err), nil
+	}
+	if _, err := client.DeleteBucket(ctx, &s3.DeleteBucketInput{Bucket: aws.String(name)}); err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket delete", err), nil
+	}
+	return mcp.NewToolResultText("Bucket deleted successfully"), nil
+}
+
+func (b *BucketsTool) listObjects(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	name, _ := args["Name"].(string)
+	if name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+	region, err := regionArg(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	input := &s3.ListObjectsV2Input{Bucket: aws.String(name)}
+	if prefix, ok := args["Prefix"].(string); ok && prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+	if token, ok := args["ContinuationToken"].(string); ok && token != "" {
+		input.ContinuationToken = aws.String(token)
+	}
+	if maxKeys, ok := args["MaxKeys"].(float64); ok && maxKeys > 0 {
+		input.MaxKeys = aws.Int32(int32(maxKeys))
+	}
+
+	client, err := b.s3(ctx, region)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket list objects", err), nil
+	}
+	out, err := client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket list objects", err), nil
+	}
+	result := ListObjectsResult{
+		Name:                  aws.ToString(out.Name),
+		Prefix:                aws.ToString(out.Prefix),
+		KeyCount:              int(aws.ToInt32(out.KeyCount)),
+		IsTruncated:           aws.ToBool(out.IsTruncated),
+		NextContinuationToken: aws.ToString(out.NextContinuationToken),
+		Contents:              make([]Object, len(out.Contents)),
+	}
+	for i, object := range out.Contents {
+		result.Contents[i] = Object{
+			Key:          aws.ToString(object.Key),
+			LastModified: aws.ToTime(object.LastModified),
+			ETag:         aws.ToString(object.ETag),
+			Size:         aws.ToInt64(object.Size),
+			StorageClass: string(object.StorageClass),
+		}
+	}
+
+	jsonObjects, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonObjects), nil
+}
+
+// stringsArg reads a list of strings from a rule definition.
+func stringsArg(rule map[string]any, name string) []string {
+	values, _ := rule[name].([]any)
+	var out []string
+	for _, v := range values {
+		if s, ok := v.(string); ok && s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// rulesArg reads the Rules argument, which must contain at least one rule definition.
+func rulesArg(args map[string]any) ([]map[string]any, error) {
+	values, _ := args["Rules"].([]any)
+	if len(values) == 0 {
+		return nil, fmt.Errorf("at least one rule is required")
+	}
+	rules := make([]map[string]any, len(values))
+	for i, v := range values {
+		rule, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("rule %d must be an object", i+1)
+		}
+		rules[i] = rule
+	}
+	return rules, nil
+}
+
+// parseCORSRules builds the CORS configuration from the rule definitions.
+func parseCORSRules(args map[string]any) (*types.CORSConfiguration, error) {
+	rules, err := rulesArg(args)
+	if err != nil {
+		return nil, err
+	}
+	config := &types.CORSConfiguration{}
+	for i, rule := range rules {
+		r := types.CORSRule{
+			AllowedOrigins: stringsArg(rule, "AllowedOrigins"),
+			AllowedMethods: stringsArg(rule, "AllowedMethods"),
+			AllowedHeaders: stringsArg(rule, "AllowedHeaders"),
+		}
+		if len(r.AllowedOrigins) == 0 {
+			return nil, fmt.Errorf("rule %d: AllowedOrigins is required", i+1)
+		}
+		if len(r.AllowedMethods) == 0 {
+			return nil, fmt.Errorf("rule %d: AllowedMethods is required", i+1)
+		}
+		for j, method := range r.AllowedMethods {
+			r.AllowedMethods[j] = strings.ToUpper(method)
+			if !slices.Contains(corsMethods, r.AllowedMethods[j]) {
+				return nil, fmt.Errorf("rule %d: invalid method %q, must be one of %s", i+1, method, strings.Join(corsMethods, ", "))
+			}
+		}
+		if maxAge, ok := rule["MaxAgeSeconds"].(float64); ok {
+			if maxAge < 0 {
+				return nil, fmt.Errorf("rule %d: MaxAgeSeconds must not be negative", i+1)
+			}
+			r.MaxAgeSeconds = aws.Int32(int32(maxAge))
+		}
+		config.CORSRules = append(config.CORSRules, r)
+	}
+	return config, nil
+}
+
+// parseLifecycleRules builds the lifecycle configuration from the rule definitions.
+func parseLifecycleRules(args map[string]any) (*types.BucketLifecycleConfiguration, error) {
+	rules, err := rulesArg(args)
+	if err != nil {
+		return nil, err
+	}
+	config := &types.BucketLifecycleConfiguration{}
+	for i, rule := range rules {
+		days, _ := rule["ExpirationDays"].(float64)
+		if days < 1 || days != float64(int(days)) {
+			return nil, fmt.Errorf("rule %d: ExpirationDays must be a positive whole number of days", i+1)
+		}
+		id, _ := rule["ID"].(string)
+		prefix, _ := rule["Prefix"].(string)
+		r := types.LifecycleRule{
+			Status:     types.ExpirationStatusEnabled,
+			Filter:     &types.LifecycleRuleFilter{Prefix: aws.String(prefix)},
+			Expiration: &types.LifecycleExpiration{Days: aws.Int32(int32(days))},
+		}
+		if id != "" {
+			r.ID = aws.String(id)
+		}
+		config.Rules = append(config.Rules, r)
+	}
+	return config, nil
+}
+
+func (b *BucketsTool) setCORS(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	name, _ := args["Name"].(string)
+	if name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+	region, err := regionArg(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	config, err := parseCORSRules(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := b.s3(ctx, region)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket set cors", err), nil
+	}
+	if _, err := client.PutBucketCors(ctx, &s3.PutBucketCorsInput{Bucket: aws.String(name), CORSConfiguration: config}); err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket set cors", err), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Applied %d CORS rule(s) to bucket %s", len(config.CORSRules), name)), nil
+}
+
+func (b *BucketsTool) setLifecycle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	name, _ := args["Name"].(string)
+	if name == "" {
+		return mcp.NewToolResultError("Name is required"), nil
+	}
+	region, err := regionArg(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	config, err := parseLifecycleRules(args)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := b.s3(ctx, region)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket set lifecycle", err), nil
+	}
+	input := &s3.PutBucketLifecycleConfigurationInput{Bucket: aws.String(name), LifecycleConfiguration: config}
+	if _, err := client.PutBucketLifecycleConfiguration(ctx, input); err != nil {
+		return mcp.NewToolResultErrorFromErr("bucket set lifecycle", err), nil
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Applied %d lifecycle rule(s) to bucket %s", len(config.Rules), name)), nil
+}
+
+// presignedURL is the result of spaces-presign-url.
+type presignedURL struct {
+	URL       string    `json:"url"`
+	Method    string    `json:"method"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func (b *BucketsTool) presignURL(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	name := args.RequireString("Name")
+	key := args.RequireString("Key")
+	method := args.OptionalEnum("Method", http.MethodGet, presignMethods...)
+	expirySeconds := args.OptionalInt("ExpirySeconds", int(defaultPresignExpiry.Seconds()))
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := validateBucketName(name); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	key = strings.TrimPrefix(key, "/")
+	if key == "" {
+		return mcp.NewToolResultError("Key must name an object"), nil
+	}
+	region, err := regionArg(req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	expiry := time.Duration(expirySeconds) * time.Second
+	if expiry <= 0 || expiry > maxPresignExpiry {
+		return mcp.NewToolResultError(fmt.Sprintf("ExpirySeconds must be between 1 and %d", int(maxPresignExpiry.Seconds()))), nil
+	}
+
+	client, err := b.s3(ctx, region)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("presign url", err), nil
+	}
+	presigner := s3.NewPresignClient(client, s3.WithPresignExpires(expiry))
+	var signed *v4.PresignedHTTPRequest
+	if method == http.MethodPut {
+		signed, err = presigner.PresignPutObject(ctx, &s3.PutObjectInput{Bucket: aws.String(name), Key: aws.String(key)})
+	} else {
+		signed, err = presigner.PresignGetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(name), Key: aws.String(key)})
+	}
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("presign url", err), nil
+	}
+	// The URL is valid for expiry from the time it was signed at.
+	signedURL, err := url.Parse(signed.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid presigned url: %w", err)
+	}
+	signedAt, err := time.Parse("20060102T150405Z", signedURL.Query().Get("X-Amz-Date"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid presigned url date: %w", err)
+	}
+
+	jsonData, err := response.CompactJSON(presignedURL{URL: signed.URL, Method: method, ExpiresAt: signedAt.Add(expiry)})
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// Tools returns a list of tool functions
+func (b *BucketsTool) Tools() []server.ServerTool {
+	return []server.ServerTool{
+		{
+			Handler: b.listBuckets,
+			Tool: mcp.NewTool("spaces-bucket-list",
+				mcp.WithDescription("List all Spaces buckets"),
+				mcp.WithString("Region", mcp.DefaultString(defaultBucketRegion), mcp.Enum(bucketRegions...), mcp.Description("Spaces region endpoint to query")),
+			),
+		},
+		{
+			Handler: b.createBucket,
+			Tool: mcp.NewTool("spaces-bucket-create",
+				mcp.WithDescription("Create a new Spaces bucket. The name must follow S3 bucket naming rules."),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the bucket (3-63 lowercase letters, numbers, dots and hyphens)")),
+				mcp.WithString("Region", mcp.Required(), mcp.Enum(bucketRegions...), mcp.Description("Region to create the bucket in")),
+			),
+		},
+		{
+			Handler: b.deleteBucket,
+			Tool: mcp.NewTool("spaces-bucket-delete",
+				mcp.WithDescription("Delete an empty Spaces bucket"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the bucket to delete")),
+				mcp.WithString("Region", mcp.Required(), mcp.Enum(bucketRegions...), mcp.Description("Region of the bucket")),
+			),
+		},
+		{
+			Handler: b.listObjects,
+			Tool: mcp.NewTool("spaces-bucket-list-objects",
+				mcp.WithDescription("List objects in a Spaces bucket, optionally filtered by prefix"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the bucket")),
+				mcp.WithString("Region", mcp.Required(), mcp.Enum(bucketRegions...), mcp.Description("Region of the bucket")),
+				mcp.WithString("Prefix", mcp.Description("Only list objects whose key starts with this prefix")),
+				mcp.WithNumber("MaxKeys", mcp.DefaultNumber(100), mcp.Max(1000), mcp.Description("Maximum number of objects to return")),
+				mcp.WithString("ContinuationToken", mcp.Description("Token from a previous truncated listing to fetch the next page")),
+			),
+		},
+		{
+			Handler: b.setCORS,
+			Tool: mcp.NewTool("spaces-set-cors",
+				mcp.WithDescription("Replace the CORS configuration of a Spaces bucket with the given rules"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the bucket")),
+				mcp.WithString("Region", mcp.Required(), mcp.Enum(bucketRegions...), mcp.Description("Region of the bucket")),
+				mcp.WithArray("Rules", mcp.Required(), mcp.Description("CORS rules to apply"), mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"AllowedOrigins": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Origins allowed to make requests, e.g. https://example.com or *"},
+						"AllowedMethods": map[string]any{"type": "array", "items": map[string]any{"type": "string", "enum": corsMethods}, "description": "HTTP methods allowed from these origins"},
+						"AllowedHeaders": map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Request headers allowed in preflight requests"},
+						"MaxAgeSeconds":  map[string]any{"type": "number", "description": "How long browsers may cache the preflight response"},
+					},
+					"required": []string{"AllowedOrigins", "AllowedMethods"},
+				})),
+			),
+		},
+		{
+			Handler: b.setLifecycle,
+			Tool: mcp.NewTool("spaces-set-lifecycle",
+				mcp.WithDescription("Replace the lifecycle configuration of a Spaces bucket with rules expiring objects after a number of days"),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the bucket")),
+				mcp.WithString("Region", mcp.Required(), mcp.Enum(bucketRegions...), mcp.Description("Region of the bucket")),
+				mcp.WithArray("Rules", mcp.Required(), mcp.Description("Lifecycle rules to apply"), mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"ID":             map[string]any{"type": "string", "description": "Optional rule identifier"},
+						"Prefix":         map[string]any{"type": "string", "description": "Only expire objects whose key starts with this prefix. Applies to all objects if empty."},
+						"ExpirationDays": map[string]any{"type": "number", "description": "Delete objects this many days after their creation"},
+					},
+					"required": []string{"ExpirationDays"},
+				})),
+			),
+		},
+		{
+			Handler: b.presignURL,
+			Tool: mcp.NewTool("spaces-presign-url",
+				mcp.WithDescription("Generate a time-limited presigned URL to download (GET) or upload (PUT) an object of a Spaces bucket, which can be shared without the Spaces credentials"),
+				// Signing happens locally, nothing is sent to Spaces.
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the bucket")),
+				mcp.WithString("Region", mcp.Required(), mcp.Enum(bucketRegions...), mcp.Description("Region of the bucket")),
+				mcp.WithString("Key", mcp.Required(), mcp.Description("Key of the object, e.g. reports/2025/q1.pdf")),
+				mcp.WithString("Method", mcp.DefaultString(http.MethodGet), mcp.Enum(presignMethods...), mcp.Description("GET to download the object, PUT to upload it")),
+				mcp.WithNumber("ExpirySeconds", mcp.DefaultNumber(defaultPresignExpiry.Seconds()), mcp.Min(1), mcp.Max(maxPresignExpiry.Seconds()), mcp.Description("How long the URL stays valid, at most 7 days")),
+			),
+		},
+	}
+}
@@ -0,0 +1,328 @@
+package spaces
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBucketsToolWithServer(t *testing.T, handler http.HandlerFunc) *BucketsTool {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	tool := NewBucketsTool(Credentials{AccessKey: "DO00EXAMPLE", SecretKey: "secret"})
+	tool.httpClient = srv.Client()
+	tool.endpoint = func(region string) string { return srv.URL }
+	return tool
+}
+
+func TestBucketsTool_listBuckets(t *testing.T) {
+	tool := setupBucketsToolWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "/", r.URL.Path)
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=DO00EXAMPLE/"))
+		require.Contains(t, r.Header.Get("Authorization"), "/sfo3/s3/aws4_request")
+		_, _ = w.Write([]byte(`<ListAllMyBucketsResult><Buckets><Bucket><Name>assets</Name><CreationDate>2025-01-02T03:04:05.000Z</CreationDate></Bucket></Buckets></ListAllMyBucketsResult>`))
+	})
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Region": "sfo3"}}}
+	res, err := tool.listBuckets(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+	require.Contains(t, res.Content[0].(mcp.TextContent).Text, `"name":"assets"`)
+}
+
+func TestBucketsTool_createBucket(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectError string
+	}{
+		{
+			name: "Successful create",
+			args: map[string]any{"Name": "my-bucket", "Region": "ams3"},
+		},
+		{
+			name:        "Uppercase name",
+			args:        map[string]any{"Name": "MyBucket", "Region": "ams3"},
+			expectError: "lowercase letters",
+		},
+		{
+			name:        "Too short",
+			args:        map[string]any{"Name": "ab", "Region": "ams3"},
+			expectError: "between 3 and 63",
+		},
+		{
+			name:        "IP address",
+			args:        map[string]any{"Name": "192.168.1.1", "Region": "ams3"},
+			expectError: "IP address",
+		},
+		{
+			name:        "Adjacent periods",
+			args:        map[string]any{"Name": "my..bucket", "Region": "ams3"},
+			expectError: "adjacent periods",
+		},
+		{
+			name:        "Unsupported region",
+			args:        map[string]any{"Name": "my-bucket", "Region": "tor1"},
+			expectError: "region must be one of",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			tool := setupBucketsToolWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				require.Equal(t, http.MethodPut, r.Method)
+				require.Equal(t, "/my-bucket", r.URL.Path)
+			})
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			res, err := tool.createBucket(context.Background(), req)
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expectError)
+				require.False(t, called)
+				return
+			}
+			require.False(t, res.IsError)
+			require.True(t, called)
+		})
+	}
+}
+
+func TestBucketsTool_deleteBucket(t *testing.T) {
+	tool := setupBucketsToolWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`<Error><Code>BucketNotEmpty</Code><Message>The bucket you tried to delete is not empty.</Message></Error>`))
+	})
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Name": "my-bucket", "Region": "nyc3"}}}
+	res, err := tool.deleteBucket(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, res.IsError)
+	require.Contains(t, res.Content[0].(mcp.TextContent).Text, "BucketNotEmpty")
+}
+
+func TestBucketsTool_listObjects(t *testing.T) {
+	tool := setupBucketsToolWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/my-bucket", r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("list-type"))
+		require.Equal(t, "logs/2025 01", r.URL.Query().Get("prefix"))
+		require.Contains(t, r.URL.RawQuery, "prefix=logs%2F2025%2001")
+		_, _ = w.Write([]byte(`<ListBucketResult><Name>my-bucket</Name><KeyCount>1</KeyCount><IsTruncated>true</IsTruncated><NextContinuationToken>next</NextContinuationToken><Contents><Key>logs/2025 01/a.log</Key><Size>42</Size></Contents></ListBucketResult>`))
+	})
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Name": "my-bucket", "Region": "nyc3", "Prefix": "logs/2025 01"}}}
+	res, err := tool.listObjects(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+	text := res.Content[0].(mcp.TextContent).Text
+	require.Contains(t, text, `"key":"logs/2025 01/a.log"`)
+	require.Contains(t, text, `"next_continuation_token":"next"`)
+}
+
+func TestBucketsTool_setCORS(t *testing.T) {
+	var body string
+	tool := setupBucketsToolWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPut, r.Method)
+		require.Equal(t, "/my-bucket", r.URL.Path)
+		require.Equal(t, "cors=", r.URL.RawQuery)
+		require.NotEmpty(t, r.Header.Get("X-Amz-Checksum-Crc32"))
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	})
+
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"Name":   "my-bucket",
+		"Region": "nyc3",
+		"Rules": []any{map[string]any{
+			"AllowedOrigins": []any{"https://example.com"},
+			"AllowedMethods": []any{"get", "PUT"},
+			"AllowedHeaders": []any{"*"},
+			"MaxAgeSeconds":  float64(3000),
+		}},
+	}}}
+	res, err := tool.setCORS(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, res.IsError)
+	require.Equal(t, `<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><CORSRule><AllowedHeader>*</AllowedHeader><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod><AllowedOrigin>https://example.com</AllowedOrigin><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`, body)
+}
+
+func TestBucketsTool_setCORS_invalidRules(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       []any
+		expectError string
+	}{
+		{name: "No rules", rules: []any{}, expectError: "at least one rule"},
+		{name: "Missing origins", rules: []any{map[string]any{"AllowedMethods": []any{"GET"}}}, expectError: "AllowedOrigins is required"},
+		{name: "Invalid method", rules: []any{map[string]any{"AllowedOrigins": []any{"*"}, "AllowedMethods": []any{"PATCH"}}}, expectError: "invalid method"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			called := false
+			tool := setupBucketsToolWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Name": "my-bucket", "Region": "nyc3", "Rules": tc.rules}}}
+			res, err := tool.setCORS(context.Background(), req)
+			require.NoError(t, err)
+			require.True(t, res.IsError)
+			require.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expectError)
+			require.False(t, called)
+		})
+	}
+}
+
+func TestBucketsTool_setLifecycle(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       []any
+		expectBody  string
+		expectError string
+	}{
+		{
+			name:       "Successful set",
+			rules:      []any{map[string]any{"ID": "expire-logs", "Prefix": "logs/", "ExpirationDays": float64(30)}},
+			expectBody: `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><Expiration><Days>30</Days></Expiration><Filter><Prefix>logs/</Prefix></Filter><ID>expire-logs</ID><Status>Enabled</Status></Rule></LifecycleConfiguration>`,
+		},
+		{
+			name:        "Zero days",
+			rules:       []any{map[string]any{"Prefix": "tmp/", "ExpirationDays": float64(0)}},
+			expectError: "ExpirationDays must be a positive",
+		},
+		{
+			name:        "Negative days",
+			rules:       []any{map[string]any{"ExpirationDays": float64(-7)}},
+			expectError: "ExpirationDays must be a positive",
+		},
+		{
+			name:        "Missing days",
+			rules:       []any{map[string]any{"Prefix": "tmp/"}},
+			expectError: "ExpirationDays must be a positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var body string
+			tool := setupBucketsToolWithServer(t, func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPut, r.Method)
+				require.Equal(t, "lifecycle=", r.URL.RawQuery)
+				data, _ := io.ReadAll(r.Body)
+				body = string(data)
+			})
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Name": "my-bucket", "Region": "nyc3", "Rules": tc.rules}}}
+			res, err := tool.setLifecycle(context.Background(), req)
+			require.NoError(t, err)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, res.Content[0].(mcp.TextContent).Text, tc.expectError)
+				require.Empty(t, body)
+				return
+			}
+			require.False(t, res.IsError)
+			require.Equal(t, tc.expectBody, body)
+		})
+	}
+}
+
+func TestBucketsTool_missingCredentials(t *testing.T) {
+	tool := NewBucketsTool(Credentials{})
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{}}}
+	res, err := tool.listBuckets(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, res.IsError)
+	require.Contains(t, res.Content[0].(mcp.TextContent).Text, SpacesAccessKeyEnv)
+}
+
+func TestBucketsTool_presignURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         map[string]any
+		expectError  string
+		expectURL    []string
+		expectExpiry time.Duration
+	}{
+		{
+			name: "Download URL",
+			args: map[string]any{"Name": "assets", "Region": "fra1", "Key": "/reports/q1 2025.pdf"},
+			expectURL: []string{
+				"https://fra1.digitaloceanspaces.com/assets/reports/q1%202025.pdf?",
+				"X-Amz-Algorithm=AWS4-HMAC-SHA256",
+				"X-Amz-Credential=DO00EXAMPLE%2F",
+				"%2Ffra1%2Fs3%2Faws4_request",
+				"X-Amz-Expires=3600",
+				"X-Amz-SignedHeaders=host",
+				"X-Amz-Signature=",
+			},
+			expectExpiry: time.Hour,
+		},
+		{
+			name:         "Upload URL",
+			args:         map[string]any{"Name": "assets", "Region": "fra1", "Key": "upload.bin", "Method": "PUT", "ExpirySeconds": float64(60)},
+			expectURL:    []string{"/assets/upload.bin?", "X-Amz-Expires=60"},
+			expectExpiry: time.Minute,
+		},
+		{
+			name:        "Invalid method",
+			args:        map[string]any{"Name": "assets", "Region": "fra1", "Key": "a.txt", "Method": "DELETE"},
+			expectError: "Method",
+		},
+		{
+			name:        "Expiry too long",
+			args:        map[string]any{"Name": "assets", "Region": "fra1", "Key": "a.txt", "ExpirySeconds": float64(8 * 24 * 3600)},
+			expectError: "ExpirySeconds must be between 1 and 604800",
+		},
+		{
+			name:        "Missing key",
+			args:        map[string]any{"Name": "assets", "Region": "fra1", "Key": "/"},
+			expectError: "Key must name an object",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := NewBucketsTool(Credentials{AccessKey: "DO00EXAMPLE", SecretKey: "secret"})
+
+			res, err := tool.presignURL(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}})
+			require.NoError(t, err)
+			text := res.Content[0].(mcp.TextContent).Text
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, res.IsError, text)
+
+			var got presignedURL
+			require.NoError(t, json.Unmarshal([]byte(text), &got))
+			for _, part := range tc.expectURL {
+				require.Contains(t, got.URL, part)
+			}
+			require.WithinDuration(t, time.Now().Add(tc.expectExpiry), got.ExpiresAt, 5*time.Second)
+		})
+	}
+}
+
+func TestBucketsTool_presignURL_missingCredentials(t *testing.T) {
+	tool := NewBucketsTool(Credentials{})
+	res, err := tool.presignURL(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"Name": "assets", "Region": "fra1", "Key": "a.txt"}}})
+	require.NoError(t, err)
+	require.True(t, res.IsError)
+	require.Contains(t, res.Content[0].(mcp.TextContent).Text, SpacesAccessKeyEnv)
+}
@@ -3,13 +3,21 @@ package spaces
 import (
 	"context"
 	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"strings"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// maxPurgeFiles is the maximum number of file paths accepted by a single cache purge request.
+const maxPurgeFiles = 50
+
+// cdnTTLs are the cache TTLs, in seconds, supported by the CDN API.
+var cdnTTLs = []uint32{60, 600, 3600, 86400, 604800}
+
 // CDNTool provides CDN management tools
 type CDNTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
@@ -119,20 +127,31 @@ func (c *CDNTool) deleteCDN(ctx context.Context, req mcp.CallToolRequest) (*mcp.
 	return mcp.NewToolResultText("CDN deleted successfully"), nil
 }
 
-// flushCDNCache flushes the cache of a CDN
-func (c *CDNTool) flushCDNCache(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	cdnID := req.GetArguments()["ID"].(string)
-	files := req.GetArguments()["Files"].([]any)
+// purgeCDNCache purges cached files from a CDN. An empty file list is rejected unless PurgeAll is set,
+// in which case the whole cache is purged using the "*" wildcard.
+func (c *CDNTool) purgeCDNCache(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	cdnID, ok := args["ID"].(string)
+	if !ok || cdnID == "" {
+		return mcp.NewToolResultError("CDN ID is required"), nil
+	}
+	purgeAll, _ := args["PurgeAll"].(bool)
 
-	filesStr := make([]string, len(files))
-	for i, file := range files {
-		if fileStr, ok := file.(string); ok {
-			filesStr[i] = fileStr
+	files, _ := args["Files"].([]any)
+	filesStr := make([]string, 0, len(files))
+	for _, file := range files {
+		if fileStr, ok := file.(string); ok && fileStr != "" {
+			filesStr = append(filesStr, fileStr)
 		}
 	}
 
-	flushRequest := &godo.CDNFlushCacheRequest{
-		Files: filesStr,
+	switch {
+	case len(filesStr) == 0 && !purgeAll:
+		return mcp.NewToolResultError("Files must contain at least one path; set PurgeAll to purge the entire cache"), nil
+	case len(filesStr) == 0:
+		filesStr = []string{"*"}
+	case len(filesStr) > maxPurgeFiles:
+		return mcp.NewToolResultError(fmt.Sprintf("at most %d files can be purged per request", maxPurgeFiles)), nil
 	}
 
 	client, err := c.client(ctx)
@@ -140,12 +159,165 @@ func (c *CDNTool) flushCDNCache(ctx context.Context, req mcp.CallToolRequest) (*
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
-	_, err = client.CDNs.FlushCache(ctx, cdnID, flushRequest)
+	_, err = client.CDNs.FlushCache(ctx, cdnID, &godo.CDNFlushCacheRequest{Files: filesStr})
 	if err != nil {
 		return mcp.NewToolResultErrorFromErr("api error", err), nil
 	}
 
-	return mcp.NewToolResultText("CDN cache flushed successfully"), nil
+	return mcp.NewToolResultText("CDN cache purged successfully"), nil
+}
+
+// updateCDN updates the TTL and/or custom domain of a CDN
+func (c *CDNTool) updateCDN(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	cdnID, ok := args["ID"].(string)
+	if !ok || cdnID == "" {
+		return mcp.NewToolResultError("CDN ID is required"), nil
+	}
+	ttl, hasTTL := args["TTL"].(float64)
+	customDomain, hasDomain := args["CustomDomain"].(string)
+	certificateID, _ := args["CertificateID"].(string)
+	if !hasTTL && !hasDomain {
+		return mcp.NewToolResultError("at least one of TTL or CustomDomain is required"), nil
+	}
+	if hasTTL && !validCDNTTL(uint32(ttl)) {
+		return mcp.NewToolResultError("TTL must be one of 60, 600, 3600, 86400 or 604800 seconds"), nil
+	}
+	if hasDomain && customDomain != "" && certificateID == "" {
+		return mcp.NewToolResultError("CertificateID is required when setting a CustomDomain"), nil
+	}
+
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	var cdn *godo.CDN
+	if hasTTL {
+		cdn, _, err = client.CDNs.UpdateTTL(ctx, cdnID, &godo.CDNUpdateTTLRequest{TTL: uint32(ttl)})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+	}
+	if hasDomain {
+		// An empty CustomDomain removes the custom domain from the CDN.
+		cdn, _, err = client.CDNs.UpdateCustomDomain(ctx, cdnID, &godo.CDNUpdateCustomDomainRequest{
+			CustomDomain:  customDomain,
+			CertificateID: certificateID,
+		})
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("api error", err), nil
+		}
+	}
+
+	jsonCDN, err := response.CompactJSON(cdn)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+
+	return mcp.NewToolResultText(jsonCDN), nil
+}
+
+// certificateRotation is the result of cdn-rotate-certificate.
+type certificateRotation struct {
+	CDN                   *godo.CDN `json:"cdn"`
+	OldCertificateID      string    `json:"old_certificate_id,omitempty"`
+	OldCertificateDeleted bool      `json:"old_certificate_deleted"`
+	Warning               string    `json:"warning,omitempty"`
+}
+
+// certificateCovers reports whether a certificate for dnsNames is valid for domain. A wildcard name
+// covers a single label, e.g. *.example.com covers cdn.example.com but not example.com.
+func certificateCovers(dnsNames []string, domain string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	for _, name := range dnsNames {
+		name = strings.ToLower(strings.TrimSuffix(name, "."))
+		if name == domain {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(name, "*."); ok {
+			if label, ok := strings.CutSuffix(domain, "."+suffix); ok && label != "" && !strings.Contains(label, ".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rotateCertificate switches the custom domain of a CDN to a new certificate, e.g. after a renewal,
+// keeping the domain. Once the CDN reports the new certificate, the old one is deleted if asked.
+func (c *CDNTool) rotateCertificate(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	cdnID := args.RequireString("ID")
+	certificateID := args.RequireString("CertificateID")
+	deleteOld := args.OptionalBool("DeleteOldCertificate", false)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	cdn, _, err := client.CDNs.Get(ctx, cdnID)
+	if err != nil {
+		return common.APIErrorResult(err, "cdn", cdnID), nil
+	}
+	if cdn.CustomDomain == "" {
+		return mcp.NewToolResultError(fmt.Sprintf("CDN %s has no custom domain; use cdn-update to set one", cdnID)), nil
+	}
+	if cdn.CertificateID == certificateID {
+		return mcp.NewToolResultError(fmt.Sprintf("CDN %s already uses certificate %s", cdnID, certificateID)), nil
+	}
+
+	certificate, _, err := client.Certificates.Get(ctx, certificateID)
+	if err != nil {
+		return common.APIErrorResult(err, "certificate", certificateID), nil
+	}
+	if certificate.State != "" && certificate.State != "verified" {
+		return mcp.NewToolResultError(fmt.Sprintf("certificate %s is %s, only a verified certificate can be used", certificateID, certificate.State)), nil
+	}
+	if !certificateCovers(certificate.DNSNames, cdn.CustomDomain) {
+		return mcp.NewToolResultError(fmt.Sprintf("certificate %s covers %s but not the custom domain %s of CDN %s", certificateID, strings.Join(certificate.DNSNames, ", "), cdn.CustomDomain, cdnID)), nil
+	}
+
+	updated, _, err := client.CDNs.UpdateCustomDomain(ctx, cdnID, &godo.CDNUpdateCustomDomainRequest{
+		CustomDomain:  cdn.CustomDomain,
+		CertificateID: certificateID,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("api error", err), nil
+	}
+	if updated == nil || updated.CertificateID != certificateID {
+		return mcp.NewToolResultError(fmt.Sprintf("CDN %s did not switch to certificate %s; the old certificate %s was kept", cdnID, certificateID, cdn.CertificateID)), nil
+	}
+
+	result := certificateRotation{CDN: updated, OldCertificateID: cdn.CertificateID}
+	if deleteOld && cdn.CertificateID != "" {
+		if _, err := client.Certificates.Delete(ctx, cdn.CertificateID); err != nil {
+			// The rotation succeeded, so report it along with the failed cleanup.
+			result.Warning = fmt.Sprintf("the CDN now uses certificate %s, but deleting the old certificate %s failed: %v", certificateID, cdn.CertificateID, err)
+		} else {
+			result.OldCertificateDeleted = true
+		}
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// validCDNTTL reports whether ttl is one of the cache TTLs supported by the CDN API.
+func validCDNTTL(ttl uint32) bool {
+	for _, v := range cdnTTLs {
+		if v == ttl {
+			return true
+		}
+	}
+	return false
 }
 
 // Tools returns a list of tool functions
@@ -183,14 +355,34 @@ func (c *CDNTool) Tools() []server.ServerTool {
 		},
 
 		{
-			Handler: c.flushCDNCache,
-			Tool: mcp.NewTool("spaces-cdn-flush-cache",
-				mcp.WithDescription("Flush the cache of a CDN"),
+			Handler: c.purgeCDNCache,
+			Tool: mcp.NewTool("cdn-purge-cache",
+				mcp.WithDescription("Purge cached files from a CDN. Use \"*\" as a path to purge everything, or set PurgeAll."),
 				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the CDN")),
-				mcp.WithArray("Files", mcp.Required(), mcp.Description("file names to flush from the cache (max 50 per request)"), mcp.Items(map[string]any{
+				mcp.WithArray("Files", mcp.Description("file paths to purge from the cache, wildcards such as \"assets/*\" or \"*\" are supported (max 50 per request)"), mcp.Items(map[string]any{
 					"type":        "string",
-					"description": "name of file",
+					"description": "path of file",
 				})),
+				mcp.WithBoolean("PurgeAll", mcp.DefaultBool(false), mcp.Description("Purge the entire cache when Files is empty")),
+			),
+		},
+		{
+			Handler: c.updateCDN,
+			Tool: mcp.NewTool("cdn-update",
+				mcp.WithDescription("Update the cache TTL and/or custom domain of a CDN"),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the CDN to update")),
+				mcp.WithNumber("TTL", mcp.Description("Time-to-live for the CDN cache in seconds (60, 600, 3600, 86400 or 604800)")),
+				mcp.WithString("CustomDomain", mcp.Description("Fully qualified custom domain for the CDN, or an empty string to remove it")),
+				mcp.WithString("CertificateID", mcp.Description("ID of the certificate for the custom domain (required when setting CustomDomain)")),
+			),
+		},
+		{
+			Handler: c.rotateCertificate,
+			Tool: mcp.NewTool("cdn-rotate-certificate",
+				mcp.WithDescription("Switch the custom domain of a CDN to a new certificate, e.g. after a renewal, without dropping the domain. The certificate must be verified and cover the custom domain. Returns the updated CDN."),
+				mcp.WithString("ID", mcp.Required(), mcp.Description("ID of the CDN")),
+				mcp.WithString("CertificateID", mcp.Required(), mcp.Description("ID of the new certificate")),
+				mcp.WithBoolean("DeleteOldCertificate", mcp.DefaultBool(false), mcp.Description("Delete the previous certificate once the CDN uses the new one")),
 			),
 		},
 	}
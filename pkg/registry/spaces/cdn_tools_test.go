@@ -308,7 +308,7 @@ func TestCDNTool_deleteCDN(t *testing.T) {
 	}
 }
 
-func TestCDNTool_flushCDNCache(t *testing.T) {
+func TestCDNTool_purgeCDNCache(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -320,7 +320,7 @@ func TestCDNTool_flushCDNCache(t *testing.T) {
 		expectText  string
 	}{
 		{
-			name: "Successful flush",
+			name: "Successful purge",
 			args: map[string]any{
 				"ID":    "cdn-123",
 				"Files": []any{"/index.html", "/logo.png"},
@@ -333,7 +333,32 @@ func TestCDNTool_flushCDNCache(t *testing.T) {
 					Return(&godo.Response{}, nil).
 					Times(1)
 			},
-			expectText: "CDN cache flushed successfully",
+			expectText: "CDN cache purged successfully",
+		},
+		{
+			name: "Purge all",
+			args: map[string]any{
+				"ID":       "cdn-123",
+				"Files":    []any{},
+				"PurgeAll": true,
+			},
+			mockSetup: func(m *MockCDNService) {
+				m.EXPECT().
+					FlushCache(gomock.Any(), "cdn-123", &godo.CDNFlushCacheRequest{
+						Files: []string{"*"},
+					}).
+					Return(&godo.Response{}, nil).
+					Times(1)
+			},
+			expectText: "CDN cache purged successfully",
+		},
+		{
+			name: "Empty files without PurgeAll",
+			args: map[string]any{
+				"ID":    "cdn-123",
+				"Files": []any{},
+			},
+			expectError: true,
 		},
 		{
 			name: "API error",
@@ -361,7 +386,7 @@ func TestCDNTool_flushCDNCache(t *testing.T) {
 			}
 			tool := setupCDNToolWithMock(mockCDN)
 			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
-			resp, err := tool.flushCDNCache(context.Background(), req)
+			resp, err := tool.purgeCDNCache(context.Background(), req)
 			if tc.expectError {
 				require.NotNil(t, resp)
 				require.True(t, resp.IsError)
@@ -374,3 +399,217 @@ func TestCDNTool_flushCDNCache(t *testing.T) {
 		})
 	}
 }
+
+func TestCDNTool_updateCDN(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		mockSetup   func(*MockCDNService)
+		expectError bool
+		expectText  string
+	}{
+		{
+			name: "Update TTL",
+			args: map[string]any{
+				"ID":  "cdn-123",
+				"TTL": float64(600),
+			},
+			mockSetup: func(m *MockCDNService) {
+				m.EXPECT().
+					UpdateTTL(gomock.Any(), "cdn-123", &godo.CDNUpdateTTLRequest{TTL: 600}).
+					Return(&godo.CDN{ID: "cdn-123", TTL: 600}, nil, nil).
+					Times(1)
+			},
+			expectText: `"ttl":600`,
+		},
+		{
+			name: "Update custom domain",
+			args: map[string]any{
+				"ID":            "cdn-123",
+				"CustomDomain":  "static.example.com",
+				"CertificateID": "cert-1",
+			},
+			mockSetup: func(m *MockCDNService) {
+				m.EXPECT().
+					UpdateCustomDomain(gomock.Any(), "cdn-123", &godo.CDNUpdateCustomDomainRequest{
+						CustomDomain:  "static.example.com",
+						CertificateID: "cert-1",
+					}).
+					Return(&godo.CDN{ID: "cdn-123", CustomDomain: "static.example.com"}, nil, nil).
+					Times(1)
+			},
+			expectText: "static.example.com",
+		},
+		{
+			name:        "Unsupported TTL",
+			args:        map[string]any{"ID": "cdn-123", "TTL": float64(42)},
+			expectError: true,
+		},
+		{
+			name:        "Custom domain without certificate",
+			args:        map[string]any{"ID": "cdn-123", "CustomDomain": "static.example.com"},
+			expectError: true,
+		},
+		{
+			name:        "Nothing to update",
+			args:        map[string]any{"ID": "cdn-123"},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCDN := NewMockCDNService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockCDN)
+			}
+			tool := setupCDNToolWithMock(mockCDN)
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.updateCDN(context.Background(), req)
+			if tc.expectError {
+				require.NotNil(t, resp)
+				require.True(t, resp.IsError)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			require.False(t, resp.IsError)
+			require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectText)
+		})
+	}
+}
+
+func TestCertificateCovers(t *testing.T) {
+	require.True(t, certificateCovers([]string{"cdn.example.com"}, "cdn.example.com"))
+	require.True(t, certificateCovers([]string{"example.com", "*.example.com"}, "CDN.example.com."))
+	require.False(t, certificateCovers([]string{"*.example.com"}, "example.com"))
+	require.False(t, certificateCovers([]string{"*.example.com"}, "a.cdn.example.com"))
+	require.False(t, certificateCovers([]string{"www.example.com"}, "cdn.example.com"))
+}
+
+func TestCDNTool_rotateCertificate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	current := &godo.CDN{ID: "cdn-123", CustomDomain: "static.example.com", CertificateID: "cert-old"}
+	rotated := &godo.CDN{ID: "cdn-123", CustomDomain: "static.example.com", CertificateID: "cert-new"}
+	newCert := &godo.Certificate{ID: "cert-new", DNSNames: []string{"*.example.com"}, State: "verified"}
+	updateReq := &godo.CDNUpdateCustomDomainRequest{CustomDomain: "static.example.com", CertificateID: "cert-new"}
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		mockSetup     func(*MockCDNService, *MockCertificatesService)
+		expectError   string
+		expectDeleted bool
+		expectWarning string
+	}{
+		{
+			name: "Rotate and delete the old certificate",
+			args: map[string]any{"ID": "cdn-123", "CertificateID": "cert-new", "DeleteOldCertificate": true},
+			mockSetup: func(cdn *MockCDNService, certs *MockCertificatesService) {
+				cdn.EXPECT().Get(gomock.Any(), "cdn-123").Return(current, nil, nil)
+				certs.EXPECT().Get(gomock.Any(), "cert-new").Return(newCert, nil, nil)
+				gomock.InOrder(
+					cdn.EXPECT().UpdateCustomDomain(gomock.Any(), "cdn-123", updateReq).Return(rotated, nil, nil),
+					certs.EXPECT().Delete(gomock.Any(), "cert-old").Return(nil, nil),
+				)
+			},
+			expectDeleted: true,
+		},
+		{
+			name: "Rotate keeping the old certificate",
+			args: map[string]any{"ID": "cdn-123", "CertificateID": "cert-new"},
+			mockSetup: func(cdn *MockCDNService, certs *MockCertificatesService) {
+				cdn.EXPECT().Get(gomock.Any(), "cdn-123").Return(current, nil, nil)
+				certs.EXPECT().Get(gomock.Any(), "cert-new").Return(newCert, nil, nil)
+				cdn.EXPECT().UpdateCustomDomain(gomock.Any(), "cdn-123", updateReq).Return(rotated, nil, nil)
+			},
+		},
+		{
+			name: "Old certificate deletion fails",
+			args: map[string]any{"ID": "cdn-123", "CertificateID": "cert-new", "DeleteOldCertificate": true},
+			mockSetup: func(cdn *MockCDNService, certs *MockCertificatesService) {
+				cdn.EXPECT().Get(gomock.Any(), "cdn-123").Return(current, nil, nil)
+				certs.EXPECT().Get(gomock.Any(), "cert-new").Return(newCert, nil, nil)
+				cdn.EXPECT().UpdateCustomDomain(gomock.Any(), "cdn-123", updateReq).Return(rotated, nil, nil)
+				certs.EXPECT().Delete(gomock.Any(), "cert-old").Return(nil, errors.New("certificate in use"))
+			},
+			expectWarning: "the CDN now uses certificate cert-new, but deleting the old certificate cert-old failed: certificate in use",
+		},
+		{
+			name: "Certificate does not cover the domain",
+			args: map[string]any{"ID": "cdn-123", "CertificateID": "cert-other"},
+			mockSetup: func(cdn *MockCDNService, certs *MockCertificatesService) {
+				cdn.EXPECT().Get(gomock.Any(), "cdn-123").Return(current, nil, nil)
+				certs.EXPECT().Get(gomock.Any(), "cert-other").Return(&godo.Certificate{ID: "cert-other", DNSNames: []string{"www.example.org"}, State: "verified"}, nil, nil)
+			},
+			expectError: "covers www.example.org but not the custom domain static.example.com",
+		},
+		{
+			name: "Certificate not verified",
+			args: map[string]any{"ID": "cdn-123", "CertificateID": "cert-pending"},
+			mockSetup: func(cdn *MockCDNService, certs *MockCertificatesService) {
+				cdn.EXPECT().Get(gomock.Any(), "cdn-123").Return(current, nil, nil)
+				certs.EXPECT().Get(gomock.Any(), "cert-pending").Return(&godo.Certificate{ID: "cert-pending", DNSNames: []string{"static.example.com"}, State: "pending"}, nil, nil)
+			},
+			expectError: "certificate cert-pending is pending",
+		},
+		{
+			name: "CDN without a custom domain",
+			args: map[string]any{"ID": "cdn-123", "CertificateID": "cert-new"},
+			mockSetup: func(cdn *MockCDNService, certs *MockCertificatesService) {
+				cdn.EXPECT().Get(gomock.Any(), "cdn-123").Return(&godo.CDN{ID: "cdn-123"}, nil, nil)
+			},
+			expectError: "has no custom domain",
+		},
+		{
+			name: "Update not applied",
+			args: map[string]any{"ID": "cdn-123", "CertificateID": "cert-new", "DeleteOldCertificate": true},
+			mockSetup: func(cdn *MockCDNService, certs *MockCertificatesService) {
+				cdn.EXPECT().Get(gomock.Any(), "cdn-123").Return(current, nil, nil)
+				certs.EXPECT().Get(gomock.Any(), "cert-new").Return(newCert, nil, nil)
+				cdn.EXPECT().UpdateCustomDomain(gomock.Any(), "cdn-123", updateReq).Return(current, nil, nil)
+			},
+			expectError: "did not switch to certificate cert-new",
+		},
+		{
+			name:        "Missing certificate",
+			args:        map[string]any{"ID": "cdn-123"},
+			expectError: "argument 'CertificateID' is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCDN := NewMockCDNService(ctrl)
+			mockCerts := NewMockCertificatesService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockCDN, mockCerts)
+			}
+			tool := NewCDNTool(func(ctx context.Context) (*godo.Client, error) {
+				return &godo.Client{CDNs: mockCDN, Certificates: mockCerts}, nil
+			})
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.rotateCertificate(context.Background(), req)
+			require.NoError(t, err)
+			require.NotNil(t, resp)
+			if tc.expectError != "" {
+				require.True(t, resp.IsError)
+				require.Contains(t, resp.Content[0].(mcp.TextContent).Text, tc.expectError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out certificateRotation
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, "cert-new", out.CDN.CertificateID)
+			require.Equal(t, "static.example.com", out.CDN.CustomDomain)
+			require.Equal(t, "cert-old", out.OldCertificateID)
+			require.Equal(t, tc.expectDeleted, out.OldCertificateDeleted)
+			require.Equal(t, tc.expectWarning, out.Warning)
+		})
+	}
+}
@@ -3,23 +3,101 @@ package spaces
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"mcp-digitalocean/pkg/registry/common"
 	"mcp-digitalocean/pkg/response"
+	"slices"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// maxRotationGrace bounds the grace period after which spaces-key-rotate deletes the old key.
+const maxRotationGrace = 24 * time.Hour
+
+// grantPermissions are the permissions a Spaces key grant can give.
+var grantPermissions = []godo.SpacesKeyPermission{godo.SpacesKeyRead, godo.SpacesKeyReadWrite, godo.SpacesKeyFullAccess}
+
 type KeysTool struct {
 	client func(ctx context.Context) (*godo.Client, error)
+	// afterFunc runs f once d has elapsed, tracked by the server's shutdown coordinator. It is nil
+	// when the server has none, and old keys can then only be deleted right away.
+	afterFunc func(d time.Duration, f func())
+	logger    *slog.Logger
 }
 
-func NewSpacesKeysTool(client func(ctx context.Context) (*godo.Client, error)) *KeysTool {
+// NewSpacesKeysTool returns the Spaces key tools. afterFunc schedules the deletion of a rotated key
+// once its grace period has elapsed, and logger reports the outcome of that deletion.
+func NewSpacesKeysTool(client func(ctx context.Context) (*godo.Client, error), afterFunc func(d time.Duration, f func()), logger *slog.Logger) *KeysTool {
 	return &KeysTool{
-		client: client,
+		client:    client,
+		afterFunc: afterFunc,
+		logger:    logger,
 	}
 }
 
+// grantsArg reads an array of grants, each an object with a Permission and, unless the permission is
+// fullaccess, the Bucket it applies to. A fullaccess grant covers every bucket and can't be combined
+// with bucket grants. It returns nil when the argument is not set.
+func grantsArg(args *common.Args, name string) ([]*godo.Grant, error) {
+	items := args.OptionalArray(name)
+	if err := args.Err(); err != nil {
+		return nil, err
+	}
+	if items == nil {
+		return nil, nil
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("argument '%s' must contain at least one grant", name)
+	}
+
+	grants := make([]*godo.Grant, 0, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("grant %d must be an object with Bucket and Permission", i+1)
+		}
+		value, _ := obj["Permission"].(string)
+		permission := godo.SpacesKeyPermission(value)
+		bucket, _ := obj["Bucket"].(string)
+		if !slices.Contains(grantPermissions, permission) {
+			return nil, fmt.Errorf("grant %d: Permission must be one of: %s, %s, %s", i+1, godo.SpacesKeyRead, godo.SpacesKeyReadWrite, godo.SpacesKeyFullAccess)
+		}
+		if permission == godo.SpacesKeyFullAccess {
+			if bucket != "" {
+				return nil, fmt.Errorf("grant %d: fullaccess applies to every bucket and must not name a Bucket", i+1)
+			}
+		} else {
+			if bucket == "" {
+				return nil, fmt.Errorf("grant %d: Bucket is required for %s permission", i+1, permission)
+			}
+			if err := validateBucketName(bucket); err != nil {
+				return nil, fmt.Errorf("grant %d: %w", i+1, err)
+			}
+		}
+		if slices.ContainsFunc(grants, func(g *godo.Grant) bool { return g.Bucket == bucket }) {
+			return nil, fmt.Errorf("grant %d: more than one grant for bucket %q", i+1, bucket)
+		}
+		grants = append(grants, &godo.Grant{Bucket: bucket, Permission: permission})
+	}
+	if len(grants) > 1 && slices.ContainsFunc(grants, func(g *godo.Grant) bool { return g.Permission == godo.SpacesKeyFullAccess }) {
+		return nil, fmt.Errorf("a fullaccess grant can't be combined with bucket grants")
+	}
+	return grants, nil
+}
+
+// grantsSchema describes the items of a Grants argument.
+var grantsSchema = map[string]any{
+	"type": "object",
+	"properties": map[string]any{
+		"Bucket":     map[string]any{"type": "string", "description": "Bucket the grant applies to, empty for fullaccess"},
+		"Permission": map[string]any{"type": "string", "enum": grantPermissions},
+	},
+	"required": []string{"Permission"},
+}
+
 func (s *KeysTool) createSpacesKey(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args := req.GetArguments()
 	nameArg, ok := args["Name"]
@@ -36,14 +114,22 @@ func (s *KeysTool) createSpacesKey(ctx context.Context, req mcp.CallToolRequest)
 		return mcp.NewToolResultError("Name cannot be empty"), nil
 	}
 
-	createRequest := &godo.SpacesKeyCreateRequest{
-		Name: name,
-		Grants: []*godo.Grant{
+	grants, err := grantsArg(common.NewArgs(req), "Grants")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if grants == nil {
+		grants = []*godo.Grant{
 			{
 				Bucket:     "",
 				Permission: godo.SpacesKeyFullAccess,
 			},
-		},
+		}
+	}
+
+	createRequest := &godo.SpacesKeyCreateRequest{
+		Name:   name,
+		Grants: grants,
 	}
 
 	client, err := s.client(ctx)
@@ -81,29 +167,44 @@ func (s *KeysTool) updateSpacesKey(ctx context.Context, req mcp.CallToolRequest)
 		return mcp.NewToolResultError("AccessKey cannot be empty"), nil
 	}
 
+	grants, err := grantsArg(common.NewArgs(req), "Grants")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	nameArg, ok := args["Name"]
-	if !ok {
+	if !ok && grants == nil {
 		return mcp.NewToolResultError("Name parameter is required"), nil
 	}
 
-	name, ok := nameArg.(string)
-	if !ok {
+	name, isString := nameArg.(string)
+	if ok && !isString {
 		return mcp.NewToolResultError("Name must be a string"), nil
 	}
 
-	if name == "" {
+	if ok && name == "" {
 		return mcp.NewToolResultError("Name cannot be empty"), nil
 	}
 
-	updateRequest := &godo.SpacesKeyUpdateRequest{
-		Name: name,
-	}
-
 	client, err := s.client(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
 	}
 
+	// The API expects the name with every update, keep the current one when only the grants change.
+	if name == "" {
+		current, _, err := client.SpacesKeys.Get(ctx, accessKey)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		name = current.Name
+	}
+
+	updateRequest := &godo.SpacesKeyUpdateRequest{
+		Name:   name,
+		Grants: grants,
+	}
+
 	key, _, err := client.SpacesKeys.Update(ctx, accessKey, updateRequest)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
@@ -236,6 +337,85 @@ func (s *KeysTool) getSpacesKey(ctx context.Context, req mcp.CallToolRequest) (*
 	return mcp.NewToolResultText(jsonKey), nil
 }
 
+// keyRotation is the result of spaces-key-rotate.
+type keyRotation struct {
+	NewKey          *godo.SpacesKey `json:"new_key"`
+	OldAccessKey    string          `json:"old_access_key"`
+	OldKeyStatus    string          `json:"old_key_status"`
+	OldKeyDeletesAt *time.Time      `json:"old_key_deletes_at,omitempty"`
+	Warning         string          `json:"warning"`
+}
+
+// rotateSpacesKey creates a key with the name and grants of an existing one and, when asked, deletes
+// the old key right away or once a grace period has elapsed.
+func (s *KeysTool) rotateSpacesKey(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	accessKey := args.RequireString("AccessKey")
+	deleteOld := args.OptionalBool("DeleteOldKey", false)
+	graceMinutes := args.OptionalInt("GracePeriodMinutes", 0)
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	grace := time.Duration(graceMinutes) * time.Minute
+	if grace < 0 || grace > maxRotationGrace {
+		return mcp.NewToolResultError(fmt.Sprintf("GracePeriodMinutes must be between 0 and %d", int(maxRotationGrace.Minutes()))), nil
+	}
+	if grace > 0 && !deleteOld {
+		return mcp.NewToolResultError("GracePeriodMinutes requires DeleteOldKey"), nil
+	}
+	if grace > 0 && s.afterFunc == nil {
+		return mcp.NewToolResultError("this server can't schedule a deletion, rotate without GracePeriodMinutes and delete the old key later with spaces-key-delete"), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	old, _, err := client.SpacesKeys.Get(ctx, accessKey)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	newKey, _, err := client.SpacesKeys.Create(ctx, &godo.SpacesKeyCreateRequest{Name: old.Name, Grants: old.Grants})
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := keyRotation{NewKey: newKey, OldAccessKey: accessKey}
+	switch {
+	case !deleteOld:
+		result.OldKeyStatus = "active"
+		result.Warning = fmt.Sprintf("The old key %s still works. Update every client to the new credentials, then delete it with spaces-key-delete.", accessKey)
+	case grace == 0:
+		if _, err := client.SpacesKeys.Delete(ctx, accessKey); err != nil {
+			result.OldKeyStatus = "active"
+			result.Warning = fmt.Sprintf("The new key was created but deleting the old key %s failed: %s. Delete it with spaces-key-delete.", accessKey, err)
+			break
+		}
+		result.OldKeyStatus = "deleted"
+		result.Warning = fmt.Sprintf("The old key %s was deleted and no longer works. Clients must use the new credentials now.", accessKey)
+	default:
+		deletesAt := time.Now().Add(grace).UTC()
+		s.afterFunc(grace, func() {
+			// The call that scheduled the deletion is long over, so it must not use its context.
+			if _, err := client.SpacesKeys.Delete(context.Background(), accessKey); err != nil {
+				s.logger.Error("failed to delete the rotated Spaces key after its grace period", "access_key", accessKey, "error", err)
+				return
+			}
+			s.logger.Info("deleted the rotated Spaces key after its grace period", "access_key", accessKey)
+		})
+		result.OldKeyStatus = "scheduled_for_deletion"
+		result.OldKeyDeletesAt = &deletesAt
+		result.Warning = fmt.Sprintf("The old key %s STOPS WORKING at %s. Update every client to the new credentials before then. The deletion is dropped if the server shuts down first, check with spaces-key-get.", accessKey, deletesAt.Format(time.RFC3339))
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
 func (s *KeysTool) Tools() []server.ServerTool {
 	return []server.ServerTool{
 		{
@@ -258,14 +438,25 @@ func (s *KeysTool) Tools() []server.ServerTool {
 			Tool: mcp.NewTool("spaces-key-create",
 				mcp.WithDescription("Create a new Spaces key. SECURITY WARNING: The returned secret key should NEVER be added to files or committed to source control. Always store the secret key in environment variables (e.g., DO_SPACES_SECRET_KEY) and access it securely at runtime. The secret key should be treated as highly sensitive credential information and should not be displayed in logs or output when possible."),
 				mcp.WithString("Name", mcp.Required(), mcp.Description("Name for the Spaces key")),
+				mcp.WithArray("Grants", mcp.Items(grantsSchema), mcp.Description("Permissions of the key: read or readwrite on named buckets, or a single fullaccess grant without a bucket. Defaults to fullaccess.")),
 			),
 		},
 		{
 			Handler: s.updateSpacesKey,
 			Tool: mcp.NewTool("spaces-key-update",
-				mcp.WithDescription("Update an existing Spaces key"),
+				mcp.WithDescription("Update the name or the grants of an existing Spaces key. At least one of Name and Grants is required."),
 				mcp.WithString("AccessKey", mcp.Required(), mcp.Description("Access Key of the Spaces key to update")),
-				mcp.WithString("Name", mcp.Required(), mcp.Description("New name for the Spaces key")),
+				mcp.WithString("Name", mcp.Description("New name for the Spaces key")),
+				mcp.WithArray("Grants", mcp.Items(grantsSchema), mcp.Description("Permissions replacing the current ones: read or readwrite on named buckets, or a single fullaccess grant without a bucket")),
+			),
+		},
+		{
+			Handler: s.rotateSpacesKey,
+			Tool: mcp.NewTool("spaces-key-rotate",
+				mcp.WithDescription("Rotate a Spaces key: create a new key with the same name and grants, and optionally delete the old key now or after a grace period. Returns the new credentials and when the old key stops working. SECURITY WARNING: never write the returned secret key to files or source control."),
+				mcp.WithString("AccessKey", mcp.Required(), mcp.Description("Access Key of the Spaces key to rotate")),
+				mcp.WithBoolean("DeleteOldKey", mcp.DefaultBool(false), mcp.Description("Delete the old key, which then stops working")),
+				mcp.WithNumber("GracePeriodMinutes", mcp.DefaultNumber(0), mcp.Min(0), mcp.Max(maxRotationGrace.Minutes()), mcp.Description("Minutes to wait before deleting the old key, 0 deletes it immediately")),
 			),
 		},
 		{
@@ -1,10 +1,14 @@
 package spaces
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"log/slog"
+	"mcp-digitalocean/pkg/registry/common"
 	"testing"
+	"time"
 
 	"github.com/digitalocean/godo"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -17,7 +21,7 @@ func setupSpacesKeysToolWithMock(spacesKeys *MockSpacesKeysService) *KeysTool {
 		return &godo.Client{SpacesKeys: spacesKeys}, nil
 	}
 
-	return NewSpacesKeysTool(client)
+	return NewSpacesKeysTool(client, nil, slog.New(slog.DiscardHandler))
 }
 
 func TestSpacesKeysTool_createSpacesKey(t *testing.T) {
@@ -523,3 +527,189 @@ func TestSpacesKeysTool_getSpacesKey(t *testing.T) {
 		})
 	}
 }
+
+func TestGrantsArg(t *testing.T) {
+	tests := []struct {
+		name     string
+		grants   any
+		expected []*godo.Grant
+		errMsg   string
+	}{
+		{name: "Not set", grants: nil},
+		{
+			name:   "Bucket grants",
+			grants: []any{map[string]any{"Bucket": "assets", "Permission": "read"}, map[string]any{"Bucket": "uploads", "Permission": "readwrite"}},
+			expected: []*godo.Grant{
+				{Bucket: "assets", Permission: godo.SpacesKeyRead},
+				{Bucket: "uploads", Permission: godo.SpacesKeyReadWrite},
+			},
+		},
+		{name: "Full access", grants: []any{map[string]any{"Permission": "fullaccess"}}, expected: []*godo.Grant{{Permission: godo.SpacesKeyFullAccess}}},
+		{name: "Empty", grants: []any{}, errMsg: "at least one grant"},
+		{name: "Not an object", grants: []any{"assets:read"}, errMsg: "must be an object"},
+		{name: "Unknown permission", grants: []any{map[string]any{"Bucket": "assets", "Permission": "write"}}, errMsg: "Permission must be one of"},
+		{name: "Missing bucket", grants: []any{map[string]any{"Permission": "read"}}, errMsg: "Bucket is required"},
+		{name: "Invalid bucket", grants: []any{map[string]any{"Bucket": "Assets_Bucket", "Permission": "read"}}, errMsg: "bucket name may only contain"},
+		{name: "Full access with bucket", grants: []any{map[string]any{"Bucket": "assets", "Permission": "fullaccess"}}, errMsg: "must not name a Bucket"},
+		{name: "Duplicate bucket", grants: []any{map[string]any{"Bucket": "assets", "Permission": "read"}, map[string]any{"Bucket": "assets", "Permission": "readwrite"}}, errMsg: "more than one grant"},
+		{name: "Full access combined", grants: []any{map[string]any{"Permission": "fullaccess"}, map[string]any{"Bucket": "assets", "Permission": "read"}}, errMsg: "can't be combined"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args := map[string]any{}
+			if tc.grants != nil {
+				args["Grants"] = tc.grants
+			}
+			grants, err := grantsArg(common.NewArgs(mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}), "Grants")
+			if tc.errMsg != "" {
+				require.ErrorContains(t, err, tc.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, grants)
+		})
+	}
+}
+
+func TestSpacesKeysTool_updateSpacesKeyGrants(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSpacesKeys := NewMockSpacesKeysService(ctrl)
+	grants := []*godo.Grant{{Bucket: "assets", Permission: godo.SpacesKeyRead}}
+	mockSpacesKeys.EXPECT().Get(gomock.Any(), "AKIA123456789").Return(&godo.SpacesKey{Name: "ci", AccessKey: "AKIA123456789"}, nil, nil)
+	mockSpacesKeys.EXPECT().
+		Update(gomock.Any(), "AKIA123456789", &godo.SpacesKeyUpdateRequest{Name: "ci", Grants: grants}).
+		Return(&godo.SpacesKey{Name: "ci", AccessKey: "AKIA123456789", Grants: grants}, nil, nil)
+
+	tool := setupSpacesKeysToolWithMock(mockSpacesKeys)
+	req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+		"AccessKey": "AKIA123456789",
+		"Grants":    []any{map[string]any{"Bucket": "assets", "Permission": "read"}},
+	}}}
+	resp, err := tool.updateSpacesKey(context.Background(), req)
+	require.NoError(t, err)
+	require.False(t, resp.IsError)
+}
+
+func TestSpacesKeysTool_rotateSpacesKey(t *testing.T) {
+	oldKey := &godo.SpacesKey{Name: "ci", AccessKey: "OLDKEY", Grants: []*godo.Grant{{Bucket: "assets", Permission: godo.SpacesKeyRead}}}
+	newKey := &godo.SpacesKey{Name: "ci", AccessKey: "NEWKEY", SecretKey: "new-secret", Grants: oldKey.Grants}
+
+	tests := []struct {
+		name          string
+		args          map[string]any
+		mockSetup     func(*MockSpacesKeysService)
+		expectError   bool
+		status        string
+		expectedDelay time.Duration
+		expectLog     string
+	}{
+		{
+			name: "Keep old key",
+			args: map[string]any{"AccessKey": "OLDKEY"},
+			mockSetup: func(m *MockSpacesKeysService) {
+				m.EXPECT().Get(gomock.Any(), "OLDKEY").Return(oldKey, nil, nil)
+				m.EXPECT().Create(gomock.Any(), &godo.SpacesKeyCreateRequest{Name: "ci", Grants: oldKey.Grants}).Return(newKey, nil, nil)
+			},
+			status: "active",
+		},
+		{
+			name: "Delete old key now",
+			args: map[string]any{"AccessKey": "OLDKEY", "DeleteOldKey": true},
+			mockSetup: func(m *MockSpacesKeysService) {
+				m.EXPECT().Get(gomock.Any(), "OLDKEY").Return(oldKey, nil, nil)
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(newKey, nil, nil)
+				m.EXPECT().Delete(gomock.Any(), "OLDKEY").Return(nil, nil)
+			},
+			status: "deleted",
+		},
+		{
+			name: "Delete old key after grace period",
+			args: map[string]any{"AccessKey": "OLDKEY", "DeleteOldKey": true, "GracePeriodMinutes": float64(30)},
+			mockSetup: func(m *MockSpacesKeysService) {
+				m.EXPECT().Get(gomock.Any(), "OLDKEY").Return(oldKey, nil, nil)
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(newKey, nil, nil)
+				m.EXPECT().Delete(gomock.Any(), "OLDKEY").Return(nil, nil)
+			},
+			status:        "scheduled_for_deletion",
+			expectedDelay: 30 * time.Minute,
+			expectLog:     "deleted the rotated Spaces key after its grace period",
+		},
+		{
+			name: "Failed deletion after grace period is logged",
+			args: map[string]any{"AccessKey": "OLDKEY", "DeleteOldKey": true, "GracePeriodMinutes": float64(30)},
+			mockSetup: func(m *MockSpacesKeysService) {
+				m.EXPECT().Get(gomock.Any(), "OLDKEY").Return(oldKey, nil, nil)
+				m.EXPECT().Create(gomock.Any(), gomock.Any()).Return(newKey, nil, nil)
+				m.EXPECT().Delete(gomock.Any(), "OLDKEY").Return(nil, errors.New("key in use"))
+			},
+			status:        "scheduled_for_deletion",
+			expectedDelay: 30 * time.Minute,
+			expectLog:     "failed to delete the rotated Spaces key after its grace period",
+		},
+		{
+			name:        "Grace period without deletion",
+			args:        map[string]any{"AccessKey": "OLDKEY", "GracePeriodMinutes": float64(30)},
+			expectError: true,
+		},
+		{
+			name:        "Grace period too long",
+			args:        map[string]any{"AccessKey": "OLDKEY", "DeleteOldKey": true, "GracePeriodMinutes": float64(2000)},
+			expectError: true,
+		},
+		{
+			name: "Unknown key",
+			args: map[string]any{"AccessKey": "OLDKEY"},
+			mockSetup: func(m *MockSpacesKeysService) {
+				m.EXPECT().Get(gomock.Any(), "OLDKEY").Return(nil, nil, errors.New("not found"))
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockSpacesKeys := NewMockSpacesKeysService(ctrl)
+			if tc.mockSetup != nil {
+				tc.mockSetup(mockSpacesKeys)
+			}
+			tool := setupSpacesKeysToolWithMock(mockSpacesKeys)
+			var delay time.Duration
+			tool.afterFunc = func(d time.Duration, f func()) {
+				delay = d
+				f()
+			}
+			var logs bytes.Buffer
+			tool.logger = slog.New(slog.NewTextHandler(&logs, nil))
+
+			req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: tc.args}}
+			resp, err := tool.rotateSpacesKey(context.Background(), req)
+			require.NoError(t, err)
+			if tc.expectError {
+				require.True(t, resp.IsError)
+				return
+			}
+			require.False(t, resp.IsError)
+			var out keyRotation
+			require.NoError(t, json.Unmarshal([]byte(resp.Content[0].(mcp.TextContent).Text), &out))
+			require.Equal(t, "NEWKEY", out.NewKey.AccessKey)
+			require.Equal(t, "new-secret", out.NewKey.SecretKey)
+			require.Equal(t, "OLDKEY", out.OldAccessKey)
+			require.Equal(t, tc.status, out.OldKeyStatus)
+			require.Contains(t, out.Warning, "OLDKEY")
+			require.Equal(t, tc.expectedDelay, delay)
+			require.Equal(t, tc.expectedDelay > 0, out.OldKeyDeletesAt != nil)
+			require.Contains(t, logs.String(), tc.expectLog)
+		})
+	}
+
+	t.Run("Grace period without a scheduler", func(t *testing.T) {
+		tool := setupSpacesKeysToolWithMock(NewMockSpacesKeysService(gomock.NewController(t)))
+		req := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"AccessKey": "OLDKEY", "DeleteOldKey": true, "GracePeriodMinutes": float64(30)}}}
+		resp, err := tool.rotateSpacesKey(context.Background(), req)
+		require.NoError(t, err)
+		require.True(t, resp.IsError)
+		require.Contains(t, resp.Content[0].(mcp.TextContent).Text, "spaces-key-delete")
+	})
+}
@@ -0,0 +1,70 @@
+package spaces
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"mcp-digitalocean/pkg/dryrun"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+const (
+	// SpacesAccessKeyEnv and SpacesSecretKeyEnv hold the Spaces access key pair used to sign S3 requests.
+	SpacesAccessKeyEnv = "SPACES_ACCESS_KEY_ID"
+	SpacesSecretKeyEnv = "SPACES_SECRET_ACCESS_KEY"
+)
+
+// Credentials is a Spaces access key pair.
+type Credentials struct {
+	AccessKey string
+	SecretKey string
+}
+
+// CredentialsFromEnv reads the Spaces access key pair from SPACES_ACCESS_KEY_ID and SPACES_SECRET_ACCESS_KEY.
+func CredentialsFromEnv() Credentials {
+	return Credentials{
+		AccessKey: os.Getenv(SpacesAccessKeyEnv),
+		SecretKey: os.Getenv(SpacesSecretKeyEnv),
+	}
+}
+
+// Retrieve implements aws.CredentialsProvider.
+func (c Credentials) Retrieve(context.Context) (aws.Credentials, error) {
+	return aws.Credentials{AccessKeyID: c.AccessKey, SecretAccessKey: c.SecretKey, Source: "Spaces access key"}, nil
+}
+
+// endpointForRegion returns the S3-compatible endpoint for a Spaces region.
+func endpointForRegion(region string) string {
+	return fmt.Sprintf("https://%s.digitaloceanspaces.com", region)
+}
+
+// newS3Client returns an S3 client for the Spaces endpoint of region, signing its requests with
+// credentials. In a dry run, mutating requests are recorded instead of sent, and are not retried.
+func newS3Client(ctx context.Context, httpClient *http.Client, credentials Credentials, endpoint, region string) (*s3.Client, error) {
+	if credentials.AccessKey == "" || credentials.SecretKey == "" {
+		return nil, fmt.Errorf("spaces credentials are not configured, set %s and %s", SpacesAccessKeyEnv, SpacesSecretKeyEnv)
+	}
+	rec := dryrun.FromContext(ctx)
+	if rec != nil {
+		httpClient = rec.Client(httpClient)
+	}
+	return s3.New(s3.Options{
+		Region:       region,
+		BaseEndpoint: aws.String(endpoint),
+		Credentials:  credentials,
+		HTTPClient:   httpClient,
+		// Buckets are addressed by path, as Spaces serves them from the regional endpoint.
+		UsePathStyle: true,
+		// Only send the checksums an operation requires, Spaces rejects those the SDK adds by default.
+		RequestChecksumCalculation: aws.RequestChecksumCalculationWhenRequired,
+		ResponseChecksumValidation: aws.ResponseChecksumValidationWhenRequired,
+	}, func(o *s3.Options) {
+		if rec != nil {
+			o.Retryer = aws.NopRetryer{}
+		}
+	}), nil
+}
@@ -0,0 +1,65 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// DefaultToolTimeout is the per-call timeout applied to tool handlers when WithTimeout is given a zero duration.
+	DefaultToolTimeout = 30 * time.Second
+
+	// timeoutArg is the optional tool argument overriding the default timeout for a single call.
+	timeoutArg = "timeout_seconds"
+
+	// ownTimeoutArg marks tools, such as action-wait, that manage their own deadline.
+	ownTimeoutArg = "TimeoutSeconds"
+)
+
+// WithTimeout bounds every tool call with a context deadline of d, or DefaultToolTimeout when d is zero.
+// Callers can override the deadline for a single call with the timeout_seconds argument. Tools that
+// take their own TimeoutSeconds argument are only bounded when timeout_seconds is set explicitly.
+func WithTimeout(d time.Duration) Option {
+	if d <= 0 {
+		d = DefaultToolTimeout
+	}
+	return func(o *options) {
+		o.decorators = append(o.decorators, timeoutDecorator(d))
+	}
+}
+
+func timeoutDecorator(defaultTimeout time.Duration) toolDecorator {
+	return func(tool server.ServerTool) server.ServerTool {
+		_, ownTimeout := tool.Tool.InputSchema.Properties[ownTimeoutArg]
+		addProperty(&tool.Tool, timeoutArg, map[string]any{
+			"type":        "number",
+			"description": fmt.Sprintf("Maximum time in seconds to wait for this call (default %d)", int(defaultTimeout.Seconds())),
+		})
+
+		next := tool.Handler
+		tool.Handler = func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			timeout := defaultTimeout
+			if v, ok := req.GetArguments()[timeoutArg].(float64); ok && v > 0 {
+				timeout = time.Duration(v * float64(time.Second))
+			} else if ownTimeout {
+				return next(ctx, req)
+			}
+
+			callCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			result, err := next(callCtx, req)
+			// Only report a timeout when our deadline fired, not when the caller cancelled the request.
+			if ctx.Err() == nil && errors.Is(callCtx.Err(), context.DeadlineExceeded) {
+				return mcp.NewToolResultError(fmt.Sprintf("operation timed out after %s", timeout)), nil
+			}
+			return result, err
+		}
+		return tool
+	}
+}
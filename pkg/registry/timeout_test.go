@@ -0,0 +1,86 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingTool returns a tool whose handler waits for its context to be done and returns the context error.
+func blockingTool(opts ...mcp.ToolOption) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("blocking", opts...),
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+}
+
+func callTool(ctx context.Context, t *testing.T, tool server.ServerTool, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	res, err := tool.Handler(ctx, mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	return res
+}
+
+func TestTimeoutDecorator(t *testing.T) {
+	t.Run("default timeout", func(t *testing.T) {
+		tool := timeoutDecorator(20 * time.Millisecond)(blockingTool())
+		res := callTool(context.Background(), t, tool, map[string]any{})
+		require.True(t, res.IsError)
+		require.Contains(t, res.Content[0].(mcp.TextContent).Text, "operation timed out after 20ms")
+	})
+
+	t.Run("per call override", func(t *testing.T) {
+		tool := timeoutDecorator(time.Hour)(blockingTool())
+		res := callTool(context.Background(), t, tool, map[string]any{"timeout_seconds": 0.02})
+		require.True(t, res.IsError)
+		require.Contains(t, res.Content[0].(mcp.TextContent).Text, "operation timed out after 20ms")
+	})
+
+	t.Run("fast handler is unaffected", func(t *testing.T) {
+		tool := timeoutDecorator(time.Second)(server.ServerTool{
+			Tool: mcp.NewTool("fast"),
+			Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				_, ok := ctx.Deadline()
+				require.True(t, ok)
+				return mcp.NewToolResultText("done"), nil
+			},
+		})
+		res := callTool(context.Background(), t, tool, nil)
+		require.False(t, res.IsError)
+		require.Equal(t, "done", res.Content[0].(mcp.TextContent).Text)
+	})
+
+	t.Run("caller cancellation is not reported as a timeout", func(t *testing.T) {
+		tool := timeoutDecorator(time.Hour)(blockingTool())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := tool.Handler(ctx, mcp.CallToolRequest{})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("tools with their own timeout are not bounded by default", func(t *testing.T) {
+		tool := timeoutDecorator(time.Millisecond)(server.ServerTool{
+			Tool: mcp.NewTool("waiter", mcp.WithNumber("TimeoutSeconds")),
+			Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				_, ok := ctx.Deadline()
+				require.False(t, ok)
+				return mcp.NewToolResultText("done"), nil
+			},
+		})
+		res := callTool(context.Background(), t, tool, map[string]any{})
+		require.False(t, res.IsError)
+	})
+
+	t.Run("schema advertises timeout_seconds", func(t *testing.T) {
+		tool := timeoutDecorator(time.Second)(blockingTool())
+		require.Contains(t, tool.Tool.InputSchema.Properties, "timeout_seconds")
+	})
+}
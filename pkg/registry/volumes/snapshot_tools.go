@@ -0,0 +1,316 @@
+package volumes
+
+import (
+	"context"
+	"fmt"
+	"mcp-digitalocean/pkg/registry/common"
+	"mcp-digitalocean/pkg/response"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// snapshotName matches the names given to volume snapshots: letters, digits, dots, underscores and
+// dashes, starting with a letter or digit.
+var snapshotName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,254}$`)
+
+// invalidNameChars matches the runs of characters snapshot names can't hold.
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// SnapshotTool snapshots block storage volumes and restores volumes from their snapshots.
+type SnapshotTool struct {
+	client func(ctx context.Context) (*godo.Client, error)
+	now    func() time.Time
+}
+
+// NewSnapshotTool creates a new SnapshotTool instance.
+func NewSnapshotTool(client func(ctx context.Context) (*godo.Client, error)) *SnapshotTool {
+	return &SnapshotTool{client: client, now: time.Now}
+}
+
+// validateSnapshotName checks name can be given to a snapshot.
+func validateSnapshotName(name string) error {
+	if !snapshotName.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name %q: use up to 255 letters, digits, dots, underscores and dashes, starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// volumeSnapshotNames returns the names of the existing snapshots of a volume.
+func volumeSnapshotNames(ctx context.Context, client *godo.Client, volumeID string) ([]string, error) {
+	var names []string
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		snapshots, resp, err := client.Storage.ListSnapshots(ctx, volumeID, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshot := range snapshots {
+			names = append(names, snapshot.Name)
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+	return names, nil
+}
+
+// createSnapshot snapshots a volume after checking none of its snapshots already has the name, as
+// DigitalOcean would accept the duplicate and leave two snapshots that can't be told apart by name.
+func createSnapshot(ctx context.Context, client *godo.Client, volumeID, name, description string, tags []string) (*godo.Snapshot, error) {
+	names, err := volumeSnapshotNames(ctx, client, volumeID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(names, name) {
+		return nil, fmt.Errorf("volume %s already has a snapshot named %s", volumeID, name)
+	}
+	snapshot, _, err := client.Storage.CreateSnapshot(ctx, &godo.SnapshotCreateRequest{
+		VolumeID:    volumeID,
+		Name:        name,
+		Description: description,
+		Tags:        tags,
+	})
+	return snapshot, err
+}
+
+// snapshotVolume takes a snapshot of a volume.
+func (s *SnapshotTool) snapshotVolume(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	volumeID := args.RequireString("VolumeID")
+	name := args.RequireString("Name")
+	description := args.OptionalString("Description", "")
+	tags := args.OptionalStrings("Tags")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err := validateSnapshotName(name); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	if _, _, err := client.Storage.GetVolume(ctx, volumeID); err != nil {
+		return common.APIErrorResult(err, "volume", volumeID), nil
+	}
+	snapshot, err := createSnapshot(ctx, client, volumeID, name, description, tags)
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to snapshot volume", err), nil
+	}
+
+	jsonData, err := response.CompactJSON(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// createVolumeFromSnapshot creates a volume holding the data of a volume snapshot. The volume is
+// created in the snapshot's region and as large as the snapshot's minimum disk size unless given.
+func (s *SnapshotTool) createVolumeFromSnapshot(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	snapshotID := args.RequireString("SnapshotID")
+	name := args.RequireString("Name")
+	region := args.OptionalString("Region", "")
+	sizeGB := args.OptionalInt("SizeGigaBytes", 0)
+	description := args.OptionalString("Description", "")
+	tags := args.OptionalStrings("Tags")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if sizeGB < 0 {
+		return mcp.NewToolResultError("SizeGigaBytes must be positive"), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	snapshot, _, err := client.Snapshots.Get(ctx, snapshotID)
+	if err != nil {
+		return common.APIErrorResult(err, "snapshot", snapshotID), nil
+	}
+	if snapshot.ResourceType != "volume" {
+		return mcp.NewToolResultError(fmt.Sprintf("snapshot %s is a %s snapshot, only volume snapshots can be restored to a volume", snapshotID, snapshot.ResourceType)), nil
+	}
+	switch {
+	case region == "" && len(snapshot.Regions) > 0:
+		region = snapshot.Regions[0]
+	case !slices.Contains(snapshot.Regions, region):
+		return mcp.NewToolResultError(fmt.Sprintf("snapshot %s is only available in %v, a volume can't be created from it in %s", snapshotID, snapshot.Regions, region)), nil
+	}
+	if sizeGB == 0 {
+		sizeGB = snapshot.MinDiskSize
+	}
+	if sizeGB < snapshot.MinDiskSize {
+		return mcp.NewToolResultError(fmt.Sprintf("SizeGigaBytes must be at least %d, the size of the snapshotted volume", snapshot.MinDiskSize)), nil
+	}
+
+	volume, _, err := client.Storage.CreateVolume(ctx, &godo.VolumeCreateRequest{
+		Region:        region,
+		Name:          name,
+		Description:   description,
+		SizeGigaBytes: int64(sizeGB),
+		SnapshotID:    snapshotID,
+		Tags:          tags,
+	})
+	if err != nil {
+		return mcp.NewToolResultErrorFromErr("failed to create volume", err), nil
+	}
+
+	jsonData, err := response.CompactJSON(volume)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// volumeSnapshotResult is the outcome of snapshotting one volume in volume-snapshot-all-by-tag.
+type volumeSnapshotResult struct {
+	VolumeID     string `json:"volume_id"`
+	VolumeName   string `json:"volume_name,omitempty"`
+	DropletIDs   []int  `json:"droplet_ids"`
+	SnapshotID   string `json:"snapshot_id,omitempty"`
+	SnapshotName string `json:"snapshot_name,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// bulkVolumeSnapshot is the result of volume-snapshot-all-by-tag.
+type bulkVolumeSnapshot struct {
+	Tag       string                 `json:"tag"`
+	Droplets  int                    `json:"droplets"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	Volumes   []volumeSnapshotResult `json:"volumes"`
+}
+
+// snapshotVolumesByTag snapshots every volume attached to the droplets carrying a tag. Each snapshot
+// is named after its volume and the time of the run, and a volume that fails is reported without
+// stopping the others.
+func (s *SnapshotTool) snapshotVolumesByTag(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := common.NewArgs(req)
+	tag := args.RequireString("Tag")
+	prefix := args.OptionalString("NamePrefix", "")
+	if err := args.Err(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if prefix == "" {
+		// Tags may hold colons, which snapshot names can't.
+		prefix = strings.Trim(invalidNameChars.ReplaceAllString(tag, "-"), "-._")
+	}
+	if err := validateSnapshotName(prefix); err != nil {
+		return mcp.NewToolResultError("invalid NamePrefix: " + err.Error()), nil
+	}
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DigitalOcean client: %w", err)
+	}
+
+	result := bulkVolumeSnapshot{Tag: tag, Volumes: []volumeSnapshotResult{}}
+	volumes := map[string]*volumeSnapshotResult{}
+	var order []string
+	opt := &godo.ListOptions{Page: 1, PerPage: 200}
+	for {
+		droplets, resp, err := client.Droplets.ListByTag(ctx, tag, opt)
+		if err != nil {
+			return mcp.NewToolResultErrorFromErr("failed to list droplets", err), nil
+		}
+		for _, droplet := range droplets {
+			result.Droplets++
+			for _, volumeID := range droplet.VolumeIDs {
+				if volumes[volumeID] == nil {
+					volumes[volumeID] = &volumeSnapshotResult{VolumeID: volumeID}
+					order = append(order, volumeID)
+				}
+				volumes[volumeID].DropletIDs = append(volumes[volumeID].DropletIDs, droplet.ID)
+			}
+		}
+		if resp == nil || resp.Links == nil || resp.Links.IsLastPage() {
+			break
+		}
+		opt.Page++
+	}
+
+	stamp := s.now().UTC().Format("20060102-150405")
+	description := fmt.Sprintf("snapshot of the volumes of droplets tagged %s", tag)
+	for _, volumeID := range order {
+		v := volumes[volumeID]
+		if err := s.snapshotTaggedVolume(ctx, client, v, prefix, stamp, description); err != nil {
+			v.Error = err.Error()
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+		result.Volumes = append(result.Volumes, *v)
+	}
+
+	jsonData, err := response.CompactJSON(result)
+	if err != nil {
+		return nil, fmt.Errorf("marshal error: %w", err)
+	}
+	return mcp.NewToolResultText(jsonData), nil
+}
+
+// snapshotTaggedVolume snapshots one volume of volume-snapshot-all-by-tag, recording it in v.
+func (s *SnapshotTool) snapshotTaggedVolume(ctx context.Context, client *godo.Client, v *volumeSnapshotResult, prefix, stamp, description string) error {
+	volume, _, err := client.Storage.GetVolume(ctx, v.VolumeID)
+	if err != nil {
+		return err
+	}
+	v.VolumeName = volume.Name
+	v.SnapshotName = fmt.Sprintf("%s-%s-%s", prefix, volume.Name, stamp)
+	snapshot, err := createSnapshot(ctx, client, v.VolumeID, v.SnapshotName, description, nil)
+	if err != nil {
+		return err
+	}
+	v.SnapshotID = snapshot.ID
+	return nil
+}
+
+// Tools returns the volume snapshot tools.
+func (s *SnapshotTool) Tools() []server.ServerTool {
+	tags := mcp.WithArray("Tags", mcp.Items(map[string]any{"type": "string"}), mcp.Description("Tags to apply"))
+	return []server.ServerTool{
+		{
+			Handler: s.snapshotVolume,
+			Tool: mcp.NewTool("volume-snapshot",
+				mcp.WithDescription("Take a snapshot of a block storage volume. Fails when the volume already has a snapshot with the same name. Returns the snapshot."),
+				mcp.WithString("VolumeID", mcp.Required(), mcp.Description("ID of the volume")),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the snapshot: letters, digits, dots, underscores and dashes")),
+				mcp.WithString("Description", mcp.Description("Description of the snapshot")),
+				tags,
+			),
+		},
+		{
+			Handler: s.createVolumeFromSnapshot,
+			Tool: mcp.NewTool("volume-create-from-snapshot",
+				mcp.WithDescription("Create a block storage volume holding the data of a volume snapshot. Returns the volume."),
+				mcp.WithString("SnapshotID", mcp.Required(), mcp.Description("ID of the volume snapshot")),
+				mcp.WithString("Name", mcp.Required(), mcp.Description("Name of the new volume")),
+				mcp.WithString("Region", mcp.Description("Region of the new volume, one the snapshot is available in. Defaults to the snapshot's region")),
+				mcp.WithNumber("SizeGigaBytes", mcp.Description("Size of the new volume in GiB, at least the size of the snapshotted volume. Defaults to that size")),
+				mcp.WithString("Description", mcp.Description("Description of the new volume")),
+				tags,
+			),
+		},
+		{
+			Handler: s.snapshotVolumesByTag,
+			Tool: mcp.NewTool("volume-snapshot-all-by-tag",
+				mcp.WithDescription("Snapshot every block storage volume attached to the droplets carrying a tag. Each snapshot is named <NamePrefix>-<volume name>-<UTC time>. A volume that fails is reported without stopping the others. Returns the outcome for each volume."),
+				mcp.WithString("Tag", mcp.Required(), mcp.Description("Tag of the droplets whose volumes to snapshot")),
+				mcp.WithString("NamePrefix", mcp.Description("Prefix of the snapshot names. Defaults to the tag, with characters snapshot names can't hold replaced by dashes")),
+			),
+		},
+	}
+}
@@ -0,0 +1,191 @@
+package volumes
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/digitalocean/godo"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSnapshotTool returns a SnapshotTool backed by a real godo client pointed at a test server
+// answering with handler, frozen at 2025-03-01 12:00:00 UTC.
+func setupSnapshotTool(t *testing.T, handler http.HandlerFunc) *SnapshotTool {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	baseURL, err := url.Parse(srv.URL + "/")
+	require.NoError(t, err)
+	client := godo.NewClient(srv.Client())
+	client.BaseURL = baseURL
+
+	tool := NewSnapshotTool(func(ctx context.Context) (*godo.Client, error) {
+		return client, nil
+	})
+	tool.now = func() time.Time { return time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return tool
+}
+
+func callTool(t *testing.T, handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), args map[string]any) (*mcp.CallToolResult, string) {
+	t.Helper()
+	res, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}})
+	require.NoError(t, err)
+	return res, res.Content[0].(mcp.TextContent).Text
+}
+
+func writeJSON(w http.ResponseWriter, body string) {
+	_, _ = w.Write([]byte(body))
+}
+
+func notFound(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNotFound)
+	writeJSON(w, `{"id":"not_found","message":"The resource you were accessing could not be found."}`)
+}
+
+func TestSnapshotTool_snapshotVolume(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/volumes/vol-1" && r.Method == http.MethodGet:
+			writeJSON(w, `{"volume":{"id":"vol-1","name":"data"}}`)
+		case r.URL.Path == "/v2/volumes/vol-1/snapshots" && r.Method == http.MethodGet:
+			writeJSON(w, `{"snapshots":[{"id":"snap-0","name":"nightly"}]}`)
+		case r.URL.Path == "/v2/volumes/vol-1/snapshots" && r.Method == http.MethodPost:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			writeJSON(w, `{"snapshot":{"id":"snap-1","name":"`+body["name"].(string)+`","resource_id":"vol-1","resource_type":"volume"}}`)
+		default:
+			notFound(w)
+		}
+	}
+
+	tests := []struct {
+		name        string
+		args        map[string]any
+		expectName  string
+		expectError string
+	}{
+		{name: "Snapshot", args: map[string]any{"VolumeID": "vol-1", "Name": "before-migration"}, expectName: "before-migration"},
+		{name: "Name already used", args: map[string]any{"VolumeID": "vol-1", "Name": "nightly"}, expectError: "already has a snapshot named nightly"},
+		{name: "Invalid name", args: map[string]any{"VolumeID": "vol-1", "Name": "my snapshot"}, expectError: "invalid snapshot name"},
+		{name: "Unknown volume", args: map[string]any{"VolumeID": "vol-9", "Name": "x"}, expectError: "resource not found"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tool := setupSnapshotTool(t, handler)
+			res, text := callTool(t, tool.snapshotVolume, tc.args)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, text, tc.expectError)
+				return
+			}
+			require.False(t, res.IsError)
+			var snapshot godo.Snapshot
+			require.NoError(t, json.Unmarshal([]byte(text), &snapshot))
+			require.Equal(t, "snap-1", snapshot.ID)
+			require.Equal(t, tc.expectName, snapshot.Name)
+		})
+	}
+}
+
+func TestSnapshotTool_createVolumeFromSnapshot(t *testing.T) {
+	var created map[string]any
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/snapshots/snap-1":
+			writeJSON(w, `{"snapshot":{"id":"snap-1","name":"data-snap","resource_type":"volume","regions":["nyc1"],"min_disk_size":100}}`)
+		case "/v2/snapshots/snap-droplet":
+			writeJSON(w, `{"snapshot":{"id":"snap-droplet","resource_type":"droplet","regions":["nyc1"],"min_disk_size":25}}`)
+		case "/v2/volumes":
+			body, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(body, &created)
+			writeJSON(w, `{"volume":{"id":"vol-2","name":"restored","size_gigabytes":100}}`)
+		default:
+			notFound(w)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		args         map[string]any
+		expectRegion string
+		expectSize   float64
+		expectError  string
+	}{
+		{name: "Defaults to the snapshot's region and size", args: map[string]any{"SnapshotID": "snap-1", "Name": "restored"}, expectRegion: "nyc1", expectSize: 100},
+		{name: "Larger volume", args: map[string]any{"SnapshotID": "snap-1", "Name": "restored", "SizeGigaBytes": float64(200)}, expectRegion: "nyc1", expectSize: 200},
+		{name: "Too small", args: map[string]any{"SnapshotID": "snap-1", "Name": "restored", "SizeGigaBytes": float64(50)}, expectError: "SizeGigaBytes must be at least 100"},
+		{name: "Region without the snapshot", args: map[string]any{"SnapshotID": "snap-1", "Name": "restored", "Region": "ams3"}, expectError: "only available in [nyc1]"},
+		{name: "Droplet snapshot", args: map[string]any{"SnapshotID": "snap-droplet", "Name": "restored"}, expectError: "is a droplet snapshot"},
+		{name: "Unknown snapshot", args: map[string]any{"SnapshotID": "snap-9", "Name": "restored"}, expectError: "resource not found"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			created = nil
+			tool := setupSnapshotTool(t, handler)
+			res, text := callTool(t, tool.createVolumeFromSnapshot, tc.args)
+			if tc.expectError != "" {
+				require.True(t, res.IsError)
+				require.Contains(t, text, tc.expectError)
+				require.Nil(t, created)
+				return
+			}
+			require.False(t, res.IsError)
+			require.Equal(t, tc.expectRegion, created["region"])
+			require.Equal(t, tc.expectSize, created["size_gigabytes"])
+			require.Equal(t, "snap-1", created["snapshot_id"])
+		})
+	}
+}
+
+func TestSnapshotTool_snapshotVolumesByTag(t *testing.T) {
+	tool := setupSnapshotTool(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/droplets" && r.URL.Query().Get("tag_name") == "env:prod":
+			writeJSON(w, `{"droplets":[{"id":1,"volume_ids":["vol-a","vol-b"]},{"id":2,"volume_ids":["vol-a"]},{"id":3}]}`)
+		case r.URL.Path == "/v2/volumes/vol-a":
+			writeJSON(w, `{"volume":{"id":"vol-a","name":"shared"}}`)
+		case r.URL.Path == "/v2/volumes/vol-b":
+			writeJSON(w, `{"volume":{"id":"vol-b","name":"logs"}}`)
+		case strings.HasSuffix(r.URL.Path, "/snapshots") && r.Method == http.MethodGet:
+			writeJSON(w, `{"snapshots":[]}`)
+		case r.URL.Path == "/v2/volumes/vol-a/snapshots":
+			writeJSON(w, `{"snapshot":{"id":"snap-a","name":"env-prod-shared-20250301-120000"}}`)
+		case r.URL.Path == "/v2/volumes/vol-b/snapshots":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			writeJSON(w, `{"id":"unprocessable_entity","message":"snapshot limit reached"}`)
+		default:
+			notFound(w)
+		}
+	})
+
+	res, text := callTool(t, tool.snapshotVolumesByTag, map[string]any{"Tag": "env:prod"})
+	require.False(t, res.IsError)
+	var out bulkVolumeSnapshot
+	require.NoError(t, json.Unmarshal([]byte(text), &out))
+	require.Equal(t, 3, out.Droplets)
+	require.Equal(t, 1, out.Succeeded)
+	require.Equal(t, 1, out.Failed)
+	require.Len(t, out.Volumes, 2)
+
+	require.Equal(t, volumeSnapshotResult{
+		VolumeID:     "vol-a",
+		VolumeName:   "shared",
+		DropletIDs:   []int{1, 2},
+		SnapshotID:   "snap-a",
+		SnapshotName: "env-prod-shared-20250301-120000",
+	}, out.Volumes[0])
+	require.Equal(t, "vol-b", out.Volumes[1].VolumeID)
+	require.Equal(t, "env-prod-logs-20250301-120000", out.Volumes[1].SnapshotName)
+	require.Contains(t, out.Volumes[1].Error, "snapshot limit reached")
+}
@@ -13,3 +13,21 @@ func CompactJSON(v interface{}) (string, error) {
 	}
 	return string(data), nil
 }
+
+// PrettyJSON returns JSON indented with two spaces.
+// It is easier to read when debugging, at the cost of a larger response.
+func PrettyJSON(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FormatJSON returns PrettyJSON when pretty is true and CompactJSON otherwise.
+func FormatJSON(v interface{}, pretty bool) (string, error) {
+	if pretty {
+		return PrettyJSON(v)
+	}
+	return CompactJSON(v)
+}
@@ -87,3 +87,39 @@ func TestCompactJSON_ReducesSize(t *testing.T) {
 	assert.NoError(t, json.Unmarshal(indented, &indentedData))
 	assert.Equal(t, compactData, indentedData, "Data should be identical")
 }
+
+func TestPrettyJSON(t *testing.T) {
+	result, err := PrettyJSON(map[string]interface{}{"id": 123, "tags": []string{"web"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"id\": 123,\n  \"tags\": [\n    \"web\"\n  ]\n}", result)
+
+	_, err = PrettyJSON(make(chan int))
+	assert.Error(t, err)
+}
+
+func TestPrettyJSON_SameDataAsCompact(t *testing.T) {
+	data := map[string]interface{}{
+		"id":     12345,
+		"name":   "test-droplet",
+		"status": "active",
+		"region": map[string]interface{}{
+			"name": "New York 3",
+			"slug": "nyc3",
+		},
+		"tags": []string{"web", "production"},
+	}
+
+	compact, err := FormatJSON(data, false)
+	assert.NoError(t, err)
+	pretty, err := FormatJSON(data, true)
+	assert.NoError(t, err)
+
+	// Verify pretty version is larger
+	assert.Greater(t, len(pretty), len(compact), "Pretty JSON should be larger than compact")
+
+	// Verify they contain the same data when unmarshaled
+	var compactData, prettyData map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(compact), &compactData))
+	assert.NoError(t, json.Unmarshal([]byte(pretty), &prettyData))
+	assert.Equal(t, compactData, prettyData, "Data should be identical")
+}
@@ -0,0 +1,62 @@
+package response
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/digitalocean/godo"
+)
+
+// ListResult is the response of a list tool: a page of items with the signals telling whether more
+// pages exist, so that a partial list is not mistaken for the whole.
+type ListResult struct {
+	Items any `json:"items"`
+	// Total is the number of items across all pages, omitted when the API does not report it.
+	Total    *int `json:"total,omitempty"`
+	HasMore  bool `json:"has_more"`
+	NextPage int  `json:"next_page,omitempty"`
+}
+
+// List wraps a page of items with the total from meta and the next page from links. Either may be
+// nil, in which case the total is omitted and the page is assumed to be the last one.
+func List(items any, meta *godo.Meta, links *godo.Links) ListResult {
+	if v := reflect.ValueOf(items); v.Kind() == reflect.Slice && v.IsNil() {
+		items = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+	result := ListResult{Items: items}
+	if meta != nil {
+		total := meta.Total
+		result.Total = &total
+	}
+	if links != nil && !links.IsLastPage() {
+		result.HasMore = true
+		result.NextPage = nextPage(links)
+	}
+	return result
+}
+
+// ListFromResponse wraps a page of items with the pagination details of resp, which may be nil.
+func ListFromResponse(items any, resp *godo.Response) ListResult {
+	if resp == nil {
+		return List(items, nil, nil)
+	}
+	return List(items, resp.Meta, resp.Links)
+}
+
+// nextPage returns the number of the page after the current one, read from the next link when the
+// API gives one.
+func nextPage(links *godo.Links) int {
+	if links.Pages != nil && links.Pages.Next != "" {
+		if u, err := url.Parse(links.Pages.Next); err == nil {
+			if page, err := strconv.Atoi(u.Query().Get("page")); err == nil {
+				return page
+			}
+		}
+	}
+	current, err := links.CurrentPage()
+	if err != nil || current < 1 {
+		return 0
+	}
+	return current + 1
+}
@@ -0,0 +1,63 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/digitalocean/godo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	tests := []struct {
+		name     string
+		items    any
+		meta     *godo.Meta
+		links    *godo.Links
+		expected string
+	}{
+		{
+			name:     "middle page",
+			items:    []int{21, 22},
+			meta:     &godo.Meta{Total: 120},
+			links:    &godo.Links{Pages: &godo.Pages{Prev: "https://api.digitalocean.com/v2/droplets?page=1&per_page=20", Next: "https://api.digitalocean.com/v2/droplets?page=3&per_page=20", Last: "https://api.digitalocean.com/v2/droplets?page=6&per_page=20"}},
+			expected: `{"items":[21,22],"total":120,"has_more":true,"next_page":3}`,
+		},
+		{
+			name:     "next page derived from the previous link when the next link has no page",
+			items:    []int{41},
+			meta:     &godo.Meta{Total: 120},
+			links:    &godo.Links{Pages: &godo.Pages{Prev: "https://api.digitalocean.com/v2/droplets?page=2&per_page=20", Next: "https://api.digitalocean.com/v2/droplets?per_page=20", Last: "https://api.digitalocean.com/v2/droplets?page=6&per_page=20"}},
+			expected: `{"items":[41],"total":120,"has_more":true,"next_page":4}`,
+		},
+		{
+			name:     "last page",
+			items:    []string{"a"},
+			meta:     &godo.Meta{Total: 41},
+			links:    &godo.Links{Pages: &godo.Pages{Prev: "https://api.digitalocean.com/v2/droplets?page=2&per_page=20", First: "https://api.digitalocean.com/v2/droplets?page=1&per_page=20"}},
+			expected: `{"items":["a"],"total":41,"has_more":false}`,
+		},
+		{
+			name:     "empty without pagination details",
+			items:    []string(nil),
+			expected: `{"items":[],"has_more":false}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CompactJSON(List(tt.items, tt.meta, tt.links))
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expected, result)
+		})
+	}
+}
+
+func TestListFromResponse(t *testing.T) {
+	result, err := CompactJSON(ListFromResponse([]int{1}, nil))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"items":[1],"has_more":false}`, result)
+
+	resp := &godo.Response{Meta: &godo.Meta{Total: 2}, Links: &godo.Links{Pages: &godo.Pages{Next: "https://api.digitalocean.com/v2/databases?page=2", Last: "https://api.digitalocean.com/v2/databases?page=2"}}}
+	result, err = CompactJSON(ListFromResponse([]int{1}, resp))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"items":[1],"total":2,"has_more":true,"next_page":2}`, result)
+}
@@ -0,0 +1,282 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+)
+
+// Output formats of Render.
+const (
+	FormatNameJSON   = "json"
+	FormatNamePretty = "pretty"
+)
+
+// Formats lists the output formats Render accepts.
+var Formats = []string{FormatNameJSON, FormatNamePretty}
+
+var (
+	// ErrTooLarge is returned when a response can't be cut down to the size a Capper allows.
+	ErrTooLarge = errors.New("response too large")
+	// ErrUnknownField is returned when a Projector selects a field no object has.
+	ErrUnknownField = errors.New("unknown field")
+)
+
+// Transformer is a stage of a Pipeline. It rewrites data decoded from JSON, made of maps, slices and
+// JSON values, and returns the result.
+type Transformer interface {
+	Transform(data any) (any, error)
+}
+
+// Pipeline chains transformers, each stage receiving the output of the previous one.
+type Pipeline []Transformer
+
+// Run marshals v to JSON, decodes it back into generic data, numbers keeping their exact text, and
+// passes it through the stages in order.
+func (p Pipeline) Run(v any) (any, error) {
+	data, err := decodeGeneric(v)
+	if err != nil {
+		return nil, err
+	}
+	for _, stage := range p {
+		if data, err = stage.Transform(data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// decodeGeneric returns v as the maps, slices and JSON values it marshals to.
+func decodeGeneric(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Projector keeps only Fields of objects, each a key or a dotted path to a nested key such as
+// region.slug. Arrays are projected item by item, and so are the items of a list result, whose
+// pagination fields are kept.
+type Projector struct {
+	Fields []string
+}
+
+func (p Projector) Transform(data any) (any, error) {
+	found := map[string]bool{}
+	var out any
+	if list, ok := data.(map[string]any); ok && isListResult(list) && !p.selects("items") {
+		projected := maps.Clone(list)
+		projected["items"] = p.project(list["items"], found)
+		out = projected
+	} else {
+		out = p.project(data, found)
+	}
+	for _, field := range p.Fields {
+		if !found[field] {
+			return nil, fmt.Errorf("%w %q", ErrUnknownField, field)
+		}
+	}
+	return out, nil
+}
+
+// selects reports whether field, or a path under it, is one of the projected fields.
+func (p Projector) selects(field string) bool {
+	for _, f := range p.Fields {
+		if f == field || strings.HasPrefix(f, field+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// project projects an object or the objects of an array, recording the fields it found.
+func (p Projector) project(data any, found map[string]bool) any {
+	switch data := data.(type) {
+	case []any:
+		out := make([]any, len(data))
+		for i, item := range data {
+			out[i] = p.project(item, found)
+		}
+		return out
+	case map[string]any:
+		out := map[string]any{}
+		for _, field := range p.Fields {
+			if value, ok := lookupPath(data, field); ok {
+				setPath(out, field, value)
+				found[field] = true
+			}
+		}
+		return out
+	}
+	return data
+}
+
+// lookupPath returns the value at a dotted path of an object.
+func lookupPath(data map[string]any, path string) (any, bool) {
+	key, rest, nested := strings.Cut(path, ".")
+	value, ok := data[key]
+	if !ok || !nested {
+		return value, ok
+	}
+	child, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return lookupPath(child, rest)
+}
+
+// setPath sets the value at a dotted path of an object, creating the intermediate objects.
+func setPath(data map[string]any, path string, value any) {
+	key, rest, nested := strings.Cut(path, ".")
+	if !nested {
+		data[key] = value
+		return
+	}
+	child, ok := data[key].(map[string]any)
+	if !ok {
+		child = map[string]any{}
+		data[key] = child
+	}
+	setPath(child, rest, value)
+}
+
+// TimezoneConverter expresses the TimestampFields of data in Location, as ConvertTimestamps does.
+type TimezoneConverter struct {
+	Location *time.Location
+}
+
+func (c TimezoneConverter) Transform(data any) (any, error) {
+	if c.Location == nil {
+		return data, nil
+	}
+	return convertTimestamps(data, c.Location), nil
+}
+
+// Capper keeps responses within MaxBytes once encoded with Encode. An array, or the items of a list
+// result, is cut to the longest prefix that fits and marked truncated, with the number of items
+// returned; an array also reports its total. Any other response over MaxBytes fails with ErrTooLarge.
+type Capper struct {
+	MaxBytes int
+	Encode   func(v any) (string, error)
+}
+
+func (c Capper) Transform(data any) (any, error) {
+	if c.MaxBytes <= 0 {
+		return data, nil
+	}
+	size, err := c.size(data)
+	if err != nil || size <= c.MaxBytes {
+		return data, err
+	}
+
+	var items []any
+	var truncate func(n int) map[string]any
+	switch data := data.(type) {
+	case []any:
+		items = data
+		truncate = func(n int) map[string]any {
+			return map[string]any{"items": items[:n], "truncated": true, "returned": n, "total": len(items)}
+		}
+	case map[string]any:
+		if isListResult(data) {
+			items = data["items"].([]any)
+			truncate = func(n int) map[string]any {
+				out := maps.Clone(data)
+				out["items"], out["truncated"], out["returned"] = items[:n], true, n
+				return out
+			}
+		}
+	}
+	if truncate == nil {
+		return nil, fmt.Errorf("%w: %d bytes, over max_bytes %d; select fewer fields", ErrTooLarge, size, c.MaxBytes)
+	}
+
+	// The encoded size grows with the number of items kept, so search the largest count that fits.
+	lo, hi := 0, len(items)-1
+	if size, err := c.size(truncate(0)); err != nil || size > c.MaxBytes {
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: not even an empty page fits in max_bytes %d", ErrTooLarge, c.MaxBytes)
+	}
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		size, err := c.size(truncate(mid))
+		if err != nil {
+			return nil, err
+		}
+		if size <= c.MaxBytes {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return truncate(lo), nil
+}
+
+func (c Capper) size(data any) (int, error) {
+	encode := c.Encode
+	if encode == nil {
+		encode = CompactJSON
+	}
+	s, err := encode(data)
+	return len(s), err
+}
+
+// isListResult reports whether data is a list result, an object holding its page in an items array.
+func isListResult(data map[string]any) bool {
+	_, ok := data["items"].([]any)
+	return ok
+}
+
+// RenderOptions are the output options common to tools, read from their standard arguments.
+type RenderOptions struct {
+	// Format is one of Formats, FormatNameJSON when empty.
+	Format string
+	// Fields, when set, are the only fields returned, as selected by a Projector.
+	Fields []string
+	// Location, when set, is the time zone timestamps are expressed in.
+	Location *time.Location
+	// MaxBytes, when positive, caps the size of the response as a Capper does.
+	MaxBytes int
+}
+
+// Render encodes v as the options ask, projecting, converting timestamps and capping it in that
+// order. Without projection, time zone or cap, v is encoded directly.
+func Render(v any, opts RenderOptions) (string, error) {
+	encode := CompactJSON
+	if opts.Format == FormatNamePretty {
+		encode = PrettyJSON
+	}
+
+	var pipeline Pipeline
+	if len(opts.Fields) > 0 {
+		pipeline = append(pipeline, Projector{Fields: opts.Fields})
+	}
+	if opts.Location != nil {
+		pipeline = append(pipeline, TimezoneConverter{Location: opts.Location})
+	}
+	if opts.MaxBytes > 0 {
+		pipeline = append(pipeline, Capper{MaxBytes: opts.MaxBytes, Encode: encode})
+	}
+	if len(pipeline) == 0 {
+		return encode(v)
+	}
+
+	data, err := pipeline.Run(v)
+	if err != nil {
+		return "", err
+	}
+	return encode(data)
+}
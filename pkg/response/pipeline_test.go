@@ -0,0 +1,132 @@
+package response
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type pipelineDroplet struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	CreatedAt string         `json:"created_at"`
+	Region    pipelineRegion `json:"region"`
+}
+
+type pipelineRegion struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+func TestRender(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+
+	droplets := []pipelineDroplet{
+		{ID: 9007199254740993, Name: "web-1", CreatedAt: "2024-07-01T12:00:00Z", Region: pipelineRegion{Slug: "fra1", Name: "Frankfurt 1"}},
+		{ID: 2, Name: "web-2", CreatedAt: "2024-07-02T12:00:00Z", Region: pipelineRegion{Slug: "ams3", Name: "Amsterdam 3"}},
+		{ID: 3, Name: "web-3", CreatedAt: "2024-07-03T12:00:00Z", Region: pipelineRegion{Slug: "nyc1", Name: "New York 1"}},
+	}
+	total := 30
+	list := ListResult{Items: droplets, Total: &total, HasMore: true, NextPage: 2}
+
+	tests := []struct {
+		name        string
+		v           any
+		opts        RenderOptions
+		expected    string
+		expectError error
+	}{
+		{
+			name:     "No options encodes directly",
+			v:        droplets[:1],
+			expected: `[{"id":9007199254740993,"name":"web-1","created_at":"2024-07-01T12:00:00Z","region":{"slug":"fra1","name":"Frankfurt 1"}}]`,
+		},
+		{
+			name:     "Fields and dotted paths",
+			v:        droplets[:2],
+			opts:     RenderOptions{Fields: []string{"id", "region.slug"}},
+			expected: `[{"id":9007199254740993,"region":{"slug":"fra1"}},{"id":2,"region":{"slug":"ams3"}}]`,
+		},
+		{
+			name:     "Fields and time zone",
+			v:        droplets[:1],
+			opts:     RenderOptions{Fields: []string{"name", "created_at"}, Location: paris},
+			expected: `[{"created_at":"2024-07-01T14:00:00+02:00","name":"web-1"}]`,
+		},
+		{
+			name:     "Fields apply to list items and keep pagination",
+			v:        list,
+			opts:     RenderOptions{Fields: []string{"name"}},
+			expected: `{"has_more":true,"items":[{"name":"web-1"},{"name":"web-2"},{"name":"web-3"}],"next_page":2,"total":30}`,
+		},
+		{
+			name:     "Fields, time zone and cap on a list",
+			v:        list,
+			opts:     RenderOptions{Fields: []string{"id", "created_at"}, Location: paris, MaxBytes: 200},
+			expected: `{"has_more":true,"items":[{"created_at":"2024-07-01T14:00:00+02:00","id":9007199254740993},{"created_at":"2024-07-02T14:00:00+02:00","id":2}],"next_page":2,"returned":2,"total":30,"truncated":true}`,
+		},
+		{
+			name:     "Cap on an array",
+			v:        droplets,
+			opts:     RenderOptions{Fields: []string{"name", "created_at"}, MaxBytes: 158},
+			expected: `{"items":[{"created_at":"2024-07-01T12:00:00Z","name":"web-1"},{"created_at":"2024-07-02T12:00:00Z","name":"web-2"}],"returned":2,"total":3,"truncated":true}`,
+		},
+		{
+			name:     "Cap counts the pretty encoding",
+			v:        droplets,
+			opts:     RenderOptions{Format: FormatNamePretty, Fields: []string{"id", "name"}, MaxBytes: 140},
+			expected: "{\n  \"items\": [\n    {\n      \"id\": 9007199254740993,\n      \"name\": \"web-1\"\n    }\n  ],\n  \"returned\": 1,\n  \"total\": 3,\n  \"truncated\": true\n}",
+		},
+		{
+			name:     "Under the cap is left whole",
+			v:        droplets[:1],
+			opts:     RenderOptions{Fields: []string{"id"}, MaxBytes: 1000},
+			expected: `[{"id":9007199254740993}]`,
+		},
+		{
+			name:        "Object over the cap",
+			v:           droplets[0],
+			opts:        RenderOptions{MaxBytes: 20},
+			expectError: ErrTooLarge,
+		},
+		{
+			name:        "Unknown field",
+			v:           droplets,
+			opts:        RenderOptions{Fields: []string{"id", "size"}},
+			expectError: ErrUnknownField,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := Render(tc.v, tc.opts)
+			if tc.expectError != nil {
+				assert.ErrorIs(t, err, tc.expectError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, out)
+			if tc.opts.MaxBytes > 0 {
+				assert.LessOrEqual(t, len(out), tc.opts.MaxBytes)
+			}
+		})
+	}
+}
+
+func TestPipeline_Run(t *testing.T) {
+	wrap := transformerFunc(func(data any) (any, error) {
+		return map[string]any{"wrapped": data}, nil
+	})
+	out, err := Pipeline{Projector{Fields: []string{"a"}}, wrap}.Run(map[string]int{"a": 1, "b": 2})
+	assert.NoError(t, err)
+	s, err := CompactJSON(out)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"wrapped":{"a":1}}`, s)
+}
+
+type transformerFunc func(data any) (any, error)
+
+func (f transformerFunc) Transform(data any) (any, error) {
+	return f(data)
+}
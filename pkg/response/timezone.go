@@ -0,0 +1,52 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// TimestampFields are the JSON fields ConvertTimestamps rewrites, at any depth.
+var TimestampFields = []string{"created_at", "updated_at", "started_at", "completed_at", "last_updated_at"}
+
+// ConvertTimestamps returns data with the RFC 3339 values of TimestampFields expressed in loc, e.g.
+// 2024-05-01T12:00:00Z becomes 2024-05-01T14:00:00+02:00 in Europe/Paris. data is marshalled to JSON
+// and decoded back, so the result is made of maps, slices and JSON values; numbers keep their exact
+// text. Values that aren't timestamps are left as they are. A nil loc returns data unchanged.
+func ConvertTimestamps(data any, loc *time.Location) (any, error) {
+	if loc == nil {
+		return data, nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var out any
+	if err := dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return convertTimestamps(out, loc), nil
+}
+
+func convertTimestamps(v any, loc *time.Location) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if s, ok := value.(string); ok && slices.Contains(TimestampFields, key) {
+				if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+					v[key] = t.In(loc).Format(time.RFC3339Nano)
+				}
+				continue
+			}
+			v[key] = convertTimestamps(value, loc)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = convertTimestamps(item, loc)
+		}
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package response
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type timestamped struct {
+	ID        int64     `json:"id"`
+	CreatedAt string    `json:"created_at"`
+	StartedAt time.Time `json:"started_at"`
+	Name      string    `json:"name"`
+}
+
+func TestConvertTimestamps(t *testing.T) {
+	started := time.Date(2024, 1, 15, 23, 30, 0, 0, time.UTC)
+	data := map[string]any{
+		"items": []timestamped{{ID: 9007199254740993, CreatedAt: "2024-07-01T12:00:00Z", StartedAt: started, Name: "2024-07-01T12:00:00Z"}},
+		"nested": map[string]any{
+			"completed_at": "2024-07-01T12:00:00.5Z",
+			"updated_at":   "not a timestamp",
+		},
+	}
+
+	tests := []struct {
+		zone      string
+		created   string
+		started   string
+		completed string
+	}{
+		{zone: "Europe/Paris", created: "2024-07-01T14:00:00+02:00", started: "2024-01-16T00:30:00+01:00", completed: "2024-07-01T14:00:00.5+02:00"},
+		{zone: "America/New_York", created: "2024-07-01T08:00:00-04:00", started: "2024-01-15T18:30:00-05:00", completed: "2024-07-01T08:00:00.5-04:00"},
+		{zone: "Asia/Kolkata", created: "2024-07-01T17:30:00+05:30", started: "2024-01-16T05:00:00+05:30", completed: "2024-07-01T17:30:00.5+05:30"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.zone, func(t *testing.T) {
+			loc, err := time.LoadLocation(tc.zone)
+			assert.NoError(t, err)
+
+			out, err := ConvertTimestamps(data, loc)
+			assert.NoError(t, err)
+			got, err := CompactJSON(out)
+			assert.NoError(t, err)
+
+			want, err := CompactJSON(map[string]any{
+				"items": []map[string]any{{"id": 9007199254740993, "created_at": tc.created, "started_at": tc.started, "name": "2024-07-01T12:00:00Z"}},
+				"nested": map[string]any{
+					"completed_at": tc.completed,
+					"updated_at":   "not a timestamp",
+				},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestConvertTimestamps_NilLocation(t *testing.T) {
+	data := []string{"a"}
+	out, err := ConvertTimestamps(data, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, data, out)
+}
+
+func TestConvertTimestamps_MarshalError(t *testing.T) {
+	_, err := ConvertTimestamps(make(chan int), time.UTC)
+	assert.Error(t, err)
+}
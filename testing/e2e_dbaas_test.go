@@ -79,7 +79,7 @@ func TestDbaasKafkaLifecycle(t *testing.T) {
 	// Create a topic
 	resp, err := c.CallTool(ctx, mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
-			Name: "db-cluster-create-topic",
+			Name: "db-kafka-topic-create",
 			Arguments: map[string]interface{}{
 				"id":   cluster.ID,
 				"name": topicName,
@@ -102,7 +102,7 @@ func TestDbaasKafkaLifecycle(t *testing.T) {
 	// List topics
 	resp, err = c.CallTool(ctx, mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
-			Name: "db-cluster-list-topics",
+			Name: "db-kafka-topic-list",
 			Arguments: map[string]interface{}{
 				"id":       cluster.ID,
 				"page":     "1",
@@ -136,7 +136,7 @@ func TestDbaasKafkaLifecycle(t *testing.T) {
 	// Delete the topic
 	resp, err = c.CallTool(ctx, mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
-			Name: "db-cluster-delete-topic",
+			Name: "db-kafka-topic-delete",
 			Arguments: map[string]interface{}{
 				"id":   cluster.ID,
 				"name": topicName,
@@ -173,7 +173,7 @@ func TestDbaasUserLifecycle(t *testing.T) {
 	// Create a user
 	resp, err := c.CallTool(ctx, mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
-			Name: "db-cluster-create-user",
+			Name: "db-user-create",
 			Arguments: map[string]interface{}{
 				"id":   cluster.ID,
 				"name": userName,
@@ -16,7 +16,7 @@ func TestDropletReboot(t *testing.T) {
 
 	d := CreateTestDroplet(t, "mcp-e2e-reboot")
 
-	triggerActionAndWait(t, "reboot-droplet", map[string]interface{}{"ID": d.ID}, d.ID)
+	triggerActionAndWait(t, "droplet-reboot", map[string]interface{}{"ID": d.ID}, d.ID)
 }
 
 func TestDropletPowerCycle(t *testing.T) {
@@ -24,7 +24,7 @@ func TestDropletPowerCycle(t *testing.T) {
 
 	d := CreateTestDroplet(t, "mcp-e2e-powercycle")
 
-	triggerActionAndWait(t, "power-cycle-droplet", map[string]interface{}{"ID": d.ID}, d.ID)
+	triggerActionAndWait(t, "droplet-power-cycle", map[string]interface{}{"ID": d.ID}, d.ID)
 }
 
 func TestDropletSnapshotAction(t *testing.T) {
@@ -81,7 +81,7 @@ func TestDropletEnableBackups(t *testing.T) {
 
 	d := CreateTestDroplet(t, "mcp-e2e-backups")
 
-	triggerActionAndWait(t, "enable-backups-droplet", map[string]interface{}{"ID": d.ID}, d.ID)
+	triggerActionAndWait(t, "droplet-enable-backups", map[string]interface{}{"ID": d.ID}, d.ID)
 
 	// Verify Backups Enabled
 	refreshed, err := WaitForDropletCondition(t, d.ID, func(droplet *godo.Droplet) bool {
@@ -106,7 +106,7 @@ func TestDropletActionTool(t *testing.T) {
 
 	d := CreateTestDroplet(t, "mcp-e2e-action-tool")
 
-	cycleAction := callTool[godo.Action](t, "power-cycle-droplet", map[string]interface{}{"ID": d.ID})
+	cycleAction := callTool[godo.Action](t, "droplet-power-cycle", map[string]interface{}{"ID": d.ID})
 	require.NotZero(t, cycleAction.ID)
 
 	fetchedAction := callTool[godo.Action](t, "droplet-action", map[string]interface{}{
@@ -76,9 +76,10 @@ func TestDropletRebuildBySlug(t *testing.T) {
 
 	droplet := CreateTestDroplet(t, "mcp-e2e-rebuild")
 
-	action := callTool[godo.Action](t, "rebuild-droplet-by-slug", map[string]interface{}{
+	action := callTool[godo.Action](t, "droplet-rebuild", map[string]interface{}{
 		"ID":        droplet.ID,
 		"ImageSlug": imageSlug,
+		"Confirm":   true,
 	})
 
 	LogActionStatus(t, "Rebuild", action)
@@ -32,17 +32,16 @@ const (
 	resourcePollInterval = 3 * time.Second
 
 	// Timeouts
-	defaultActionTimeout     = 5 * time.Minute
-	dropletActiveTimeout     = 10 * time.Minute
-	dropletDeleteTimeout     = 2 * time.Minute
-	imageAvailableTimeout    = 5 * time.Minute
-	snapshotDiscoveryTimeout = 2 * time.Minute
-	renameVerifyTimeout      = 30 * time.Second
-	ipv6AssignTimeout        = 1 * time.Minute
-	backupsEnableTimeout     = 1 * time.Minute
-	imageDeleteTimeout       = 1 * time.Minute
-	restoreActionTimeout     = 2 * time.Minute
-	rebuildActionTimeout     = 5 * time.Minute
+	defaultActionTimeout  = 5 * time.Minute
+	dropletActiveTimeout  = 10 * time.Minute
+	dropletDeleteTimeout  = 2 * time.Minute
+	imageAvailableTimeout = 5 * time.Minute
+	renameVerifyTimeout   = 30 * time.Second
+	ipv6AssignTimeout     = 1 * time.Minute
+	backupsEnableTimeout  = 1 * time.Minute
+	imageDeleteTimeout    = 1 * time.Minute
+	restoreActionTimeout  = 2 * time.Minute
+	rebuildActionTimeout  = 5 * time.Minute
 
 	// Pagination
 	defaultPerPage   = 50
@@ -198,20 +197,16 @@ func CreateDropletSnapshot(t *testing.T, dropletID int, snapshotName string) int
 
 	t.Logf("Creating snapshot %s from droplet %d...", snapshotName, dropletID)
 
-	triggerActionAndWait(t, "snapshot-droplet", map[string]any{
+	snapshot := callTool[struct {
+		SnapshotID int `json:"snapshot_id"`
+	}](t, "droplet-snapshot", map[string]any{
 		"ID":   float64(dropletID),
 		"Name": snapshotName,
-	}, dropletID)
-
-	updatedDroplet, err := WaitForDropletCondition(t, dropletID, func(d *godo.Droplet) bool {
-		return len(d.SnapshotIDs) > 0
-	}, resourcePollInterval, snapshotDiscoveryTimeout)
-	require.NoError(t, err, "Failed to find created snapshot on droplet")
-	require.NotEmpty(t, updatedDroplet.SnapshotIDs, "Droplet should have at least one snapshot")
-
-	imageID := updatedDroplet.SnapshotIDs[len(updatedDroplet.SnapshotIDs)-1]
+		"wait": true,
+	})
+	require.NotZero(t, snapshot.SnapshotID, "droplet-snapshot should return the snapshot id")
 
-	img := WaitForImageAvailable(t, imageID, imageAvailableTimeout)
+	img := WaitForImageAvailable(t, snapshot.SnapshotID, imageAvailableTimeout)
 
 	RegisterResourceCleanup(t, "image", float64(img.ID))
 
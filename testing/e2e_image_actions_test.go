@@ -26,7 +26,7 @@ func TestImageTransfer(t *testing.T) {
 
 	t.Logf("Transferring image %d from %s to %s...", image.ID, currentRegion, targetRegion)
 
-	triggerImageActionAndWait(t, "image-action-transfer", map[string]any{
+	triggerImageActionAndWait(t, "image-transfer", map[string]any{
 		"ID":     float64(image.ID),
 		"Region": targetRegion,
 	}, image.ID)
@@ -17,7 +17,7 @@ func deleteUptimeCheck(t *testing.T, tc testContext, checkID string) {
 	t.Logf("deleting uptime check %s...", checkID)
 	resp, err := tc.client.CallTool(tc.ctx, mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
-			Name:      "uptimecheck-delete",
+			Name:      "uptime-check-delete",
 			Arguments: map[string]interface{}{"ID": checkID},
 		},
 	})
@@ -26,7 +26,7 @@ func deleteUptimeCheck(t *testing.T, tc testContext, checkID string) {
 		return
 	}
 	if resp.IsError {
-		t.Logf("uptimecheck-delete returned error: %v", resp.Content)
+		t.Logf("uptime-check-delete returned error: %v", resp.Content)
 		return
 	}
 	t.Logf("deleted uptime check %s", checkID)
@@ -37,7 +37,7 @@ func deleteUptimeCheckAlert(t *testing.T, tc testContext, checkID, alertID strin
 	t.Logf("deleting uptime check alert %s...", alertID)
 	_, _ = tc.client.CallTool(tc.ctx, mcp.CallToolRequest{
 		Params: mcp.CallToolParams{
-			Name: "uptimecheck-alert-delete",
+			Name: "uptime-alert-delete",
 			Arguments: map[string]interface{}{
 				"CheckID": checkID,
 				"AlertID": alertID,
@@ -76,7 +76,7 @@ func TestUptimeCheckLifecycle(t *testing.T) {
 
 	// create uptime check
 	t.Log("creating uptime check...")
-	uptimeCheck := callTool[godo.UptimeCheck](t, "uptimecheck-create", map[string]interface{}{
+	uptimeCheck := callTool[godo.UptimeCheck](t, "uptime-check-create", map[string]interface{}{
 		"Name":    checkName,
 		"Type":    "https",
 		"Target":  target,
@@ -90,7 +90,7 @@ func TestUptimeCheckLifecycle(t *testing.T) {
 
 	// get uptime check
 	t.Log("getting uptime check...")
-	fetchedCheck := callTool[godo.UptimeCheck](t, "uptimecheck-get", map[string]interface{}{
+	fetchedCheck := callTool[godo.UptimeCheck](t, "uptime-check-get", map[string]interface{}{
 		"ID": uptimeCheck.ID,
 	})
 	require.Equal(t, uptimeCheck.ID, fetchedCheck.ID)
@@ -99,7 +99,7 @@ func TestUptimeCheckLifecycle(t *testing.T) {
 
 	// list uptime checks
 	t.Log("listing uptime checks...")
-	checks := callTool[[]godo.UptimeCheck](t, "uptimecheck-list", map[string]interface{}{
+	checks := callTool[[]godo.UptimeCheck](t, "uptime-check-list", map[string]interface{}{
 		"Page":    1,
 		"PerPage": 50,
 	})
@@ -108,7 +108,7 @@ func TestUptimeCheckLifecycle(t *testing.T) {
 
 	// get uptime check state
 	t.Log("getting uptime check state...")
-	checkState := callTool[godo.UptimeCheckState](t, "uptimecheck-get-state", map[string]interface{}{
+	checkState := callTool[godo.UptimeCheckState](t, "uptime-check-get-state", map[string]interface{}{
 		"ID": uptimeCheck.ID,
 	})
 	t.Logf("uptime check state: %+v", checkState)
@@ -116,7 +116,9 @@ func TestUptimeCheckLifecycle(t *testing.T) {
 	// update uptime check
 	updatedName := checkName + "-updated"
 	t.Log("updating uptime check...")
-	updatedCheck := callTool[godo.UptimeCheck](t, "uptimecheck-update", map[string]interface{}{
+	updated := callTool[struct {
+		Check godo.UptimeCheck `json:"check"`
+	}](t, "uptime-check-update", map[string]interface{}{
 		"ID":      uptimeCheck.ID,
 		"Name":    updatedName,
 		"Type":    "https",
@@ -124,8 +126,8 @@ func TestUptimeCheckLifecycle(t *testing.T) {
 		"Regions": []string{"us_east", "eu_west"},
 		"Enabled": true,
 	})
-	require.Equal(t, updatedName, updatedCheck.Name)
-	t.Logf("updated uptime check name to: %s", updatedCheck.Name)
+	require.Equal(t, updatedName, updated.Check.Name)
+	t.Logf("updated uptime check name to: %s", updated.Check.Name)
 }
 
 // TestUptimeCheckAlertLifecycle tests the full lifecycle of an uptime check alert:
@@ -144,7 +146,7 @@ func TestUptimeCheckAlertLifecycle(t *testing.T) {
 
 	// create uptime check first (required for alerts)
 	t.Log("creating uptime check for alert testing...")
-	uptimeCheck := callTool[godo.UptimeCheck](t, "uptimecheck-create", map[string]interface{}{
+	uptimeCheck := callTool[godo.UptimeCheck](t, "uptime-check-create", map[string]interface{}{
 		"Name":    checkName,
 		"Type":    "https",
 		"Target":  target,
@@ -159,7 +161,7 @@ func TestUptimeCheckAlertLifecycle(t *testing.T) {
 	// Note: The DigitalOcean API requires at least one notification (email or slack)
 	alertName := fmt.Sprintf("test-alert-%d", time.Now().Unix())
 	t.Log("creating uptime check alert...")
-	alert := callTool[godo.UptimeAlert](t, "uptimecheck-alert-create", map[string]interface{}{
+	alert := callTool[godo.UptimeAlert](t, "uptime-alert-create", map[string]interface{}{
 		"CheckID":      uptimeCheck.ID,
 		"Name":         alertName,
 		"Type":         "down",
@@ -175,7 +177,7 @@ func TestUptimeCheckAlertLifecycle(t *testing.T) {
 
 	// get uptime check alert
 	t.Log("getting uptime check alert...")
-	fetchedAlert := callTool[godo.UptimeAlert](t, "uptimecheck-alert-get", map[string]interface{}{
+	fetchedAlert := callTool[godo.UptimeAlert](t, "uptime-alert-get", map[string]interface{}{
 		"CheckID": uptimeCheck.ID,
 		"AlertID": alert.ID,
 	})
@@ -184,7 +186,7 @@ func TestUptimeCheckAlertLifecycle(t *testing.T) {
 
 	// list uptime check alerts
 	t.Log("listing uptime check alerts...")
-	alerts := callTool[[]godo.UptimeAlert](t, "uptimecheck-alert-list", map[string]interface{}{
+	alerts := callTool[[]godo.UptimeAlert](t, "uptime-alert-list", map[string]interface{}{
 		"CheckID": uptimeCheck.ID,
 		"Page":    1,
 		"PerPage": 50,
@@ -195,7 +197,7 @@ func TestUptimeCheckAlertLifecycle(t *testing.T) {
 	// update uptime check alert
 	updatedAlertName := alertName + "-updated"
 	t.Log("updating uptime check alert...")
-	updatedAlert := callTool[godo.UptimeAlert](t, "uptimecheck-alert-update", map[string]interface{}{
+	updatedAlert := callTool[godo.UptimeAlert](t, "uptime-alert-update", map[string]interface{}{
 		"CheckID":      uptimeCheck.ID,
 		"AlertID":      alert.ID,
 		"Name":         updatedAlertName,
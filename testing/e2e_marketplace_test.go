@@ -172,7 +172,7 @@ func TestInstallKubernetesApps(t *testing.T) {
 
 		installResp, err := c.CallTool(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{
-				Name: "1-click-kubernetes-app-install",
+				Name: "oneclick-install-kubernetes",
 				Arguments: map[string]interface{}{
 					"ClusterUUID": cluster.ID,
 					"AppSlugs":    []string{appSlug},
@@ -208,7 +208,7 @@ func TestInstallKubernetesApps(t *testing.T) {
 
 		installResp, err := c.CallTool(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{
-				Name: "1-click-kubernetes-app-install",
+				Name: "oneclick-install-kubernetes",
 				Arguments: map[string]interface{}{
 					"ClusterUUID": cluster.ID,
 					"AppSlugs":    appSlugs,
@@ -238,7 +238,7 @@ func TestInstallKubernetesApps_InvalidInputs(t *testing.T) {
 	t.Run("missing cluster UUID", func(t *testing.T) {
 		resp, err := c.CallTool(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{
-				Name: "1-click-kubernetes-app-install",
+				Name: "oneclick-install-kubernetes",
 				Arguments: map[string]interface{}{
 					"AppSlugs": []string{"monitoring"},
 				},
@@ -257,7 +257,7 @@ func TestInstallKubernetesApps_InvalidInputs(t *testing.T) {
 	t.Run("missing app slugs", func(t *testing.T) {
 		resp, err := c.CallTool(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{
-				Name: "1-click-kubernetes-app-install",
+				Name: "oneclick-install-kubernetes",
 				Arguments: map[string]interface{}{
 					"ClusterUUID": "test-cluster-uuid",
 				},
@@ -276,7 +276,7 @@ func TestInstallKubernetesApps_InvalidInputs(t *testing.T) {
 	t.Run("empty cluster UUID", func(t *testing.T) {
 		resp, err := c.CallTool(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{
-				Name: "1-click-kubernetes-app-install",
+				Name: "oneclick-install-kubernetes",
 				Arguments: map[string]interface{}{
 					"ClusterUUID": "",
 					"AppSlugs":    []string{"monitoring"},
@@ -296,7 +296,7 @@ func TestInstallKubernetesApps_InvalidInputs(t *testing.T) {
 	t.Run("empty app slugs array", func(t *testing.T) {
 		resp, err := c.CallTool(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{
-				Name: "1-click-kubernetes-app-install",
+				Name: "oneclick-install-kubernetes",
 				Arguments: map[string]interface{}{
 					"ClusterUUID": "test-cluster-uuid",
 					"AppSlugs":    []string{},
@@ -316,7 +316,7 @@ func TestInstallKubernetesApps_InvalidInputs(t *testing.T) {
 	t.Run("invalid cluster UUID", func(t *testing.T) {
 		resp, err := c.CallTool(ctx, mcp.CallToolRequest{
 			Params: mcp.CallToolParams{
-				Name: "1-click-kubernetes-app-install",
+				Name: "oneclick-install-kubernetes",
 				Arguments: map[string]interface{}{
 					"ClusterUUID": "invalid-cluster-uuid-that-does-not-exist",
 					"AppSlugs":    []string{"monitoring"},